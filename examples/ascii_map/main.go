@@ -0,0 +1,44 @@
+// Command ascii_map generates a small room and prints it with RenderASCII
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+	"github.com/fadedpez/dnd5e-roomgen/internal/services"
+)
+
+func main() {
+	service, err := services.NewRoomService()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	room, err := service.GenerateRoom(services.RoomConfig{Width: 10, Height: 6, LightLevel: entities.LightLevelDim, UseGrid: true})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := services.AddWallRegion(room, entities.Position{X: 5, Y: 0}, entities.Position{X: 5, Y: 5}); err != nil {
+		log.Fatal(err)
+	}
+	if err := services.ClearWallRegion(room, entities.Position{X: 5, Y: 3}, entities.Position{X: 5, Y: 3}); err != nil {
+		log.Fatal(err)
+	}
+
+	door := entities.Position{X: 5, Y: 3}
+	err = service.AddPlaceablesToRoom(room, []services.PlaceableConfig{
+		services.PlayerConfig{Name: "Fighter", Level: 3, RandomPlace: false, Position: &entities.Position{X: 1, Y: 1}},
+		services.PlayerConfig{Name: "Wizard", Level: 3, RandomPlace: false, Position: &entities.Position{X: 1, Y: 3}},
+		services.MonsterConfig{Name: "Goblin", Key: "goblin", CR: 0.25, Count: 1, RandomPlace: true},
+		services.ObstacleConfig{Name: "Pillar", Key: "pillar", Blocking: true, Count: 1, RandomPlace: true},
+		services.DoorConfig{Name: "Oak Door", Key: "oak-door", Locked: true, Position: &door},
+		services.TrapConfig{Name: "Pit Trap", RandomPlace: true},
+		services.ItemConfig{Name: "Gold", Key: "gold", Count: 1, RandomPlace: true},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Print(services.RenderASCII(room))
+}
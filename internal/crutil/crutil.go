@@ -0,0 +1,79 @@
+// Package crutil provides helpers for working with D&D 5e challenge ratings
+package crutil
+
+import "sort"
+
+// crXPTable maps each official challenge rating to its experience point value (DMG p. 275)
+var crXPTable = map[float64]int{
+	0:     10,
+	0.125: 25,
+	0.25:  50,
+	0.5:   100,
+	1:     200,
+	2:     450,
+	3:     700,
+	4:     1100,
+	5:     1800,
+	6:     2300,
+	7:     2900,
+	8:     3900,
+	9:     5000,
+	10:    5900,
+	11:    7200,
+	12:    8400,
+	13:    10000,
+	14:    11500,
+	15:    13000,
+	16:    15000,
+	17:    18000,
+	18:    20000,
+	19:    22000,
+	20:    25000,
+	21:    33000,
+	22:    41000,
+	23:    50000,
+	24:    62000,
+	25:    75000,
+	26:    90000,
+	27:    105000,
+	28:    120000,
+	29:    135000,
+	30:    155000,
+}
+
+// sortedCRs holds the official challenge ratings in ascending order
+var sortedCRs = func() []float64 {
+	crs := make([]float64, 0, len(crXPTable))
+	for cr := range crXPTable {
+		crs = append(crs, cr)
+	}
+	sort.Float64s(crs)
+	return crs
+}()
+
+// CRToXP returns the experience point value for a challenge rating
+// Ratings that are not in the official table use the highest official rating below them
+// Negative ratings return 0 and ratings above 30 use the CR 30 value
+func CRToXP(cr float64) int {
+	if cr < 0 {
+		return 0
+	}
+	if xp, ok := crXPTable[cr]; ok {
+		return xp
+	}
+
+	// Find the first official rating greater than cr and step back one
+	i := sort.SearchFloat64s(sortedCRs, cr)
+	return crXPTable[sortedCRs[i-1]]
+}
+
+// IsOfficialCR reports whether cr is one of the challenge ratings in the official table
+func IsOfficialCR(cr float64) bool {
+	_, ok := crXPTable[cr]
+	return ok
+}
+
+// OfficialCRs returns all official challenge ratings in ascending order
+func OfficialCRs() []float64 {
+	return append([]float64(nil), sortedCRs...)
+}
@@ -0,0 +1,49 @@
+package crutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCRToXP(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cr       float64
+		expected int
+	}{
+		{"CR 0", 0, 10},
+		{"CR 1/8", 0.125, 25},
+		{"CR 1/4", 0.25, 50},
+		{"CR 1/2", 0.5, 100},
+		{"CR 1", 1, 200},
+		{"CR 5", 5, 1800},
+		{"CR 17", 17, 18000},
+		{"CR 24", 24, 62000},
+		{"CR 30", 30, 155000},
+		{"Between official ratings", 2.5, 450},
+		{"Between fractional ratings", 0.3, 50},
+		{"Above table", 35, 155000},
+		{"Negative", -1, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, CRToXP(tc.cr))
+		})
+	}
+}
+
+func TestOfficialCRs(t *testing.T) {
+	crs := OfficialCRs()
+	assert.Len(t, crs, 34)
+	assert.Equal(t, 0.0, crs[0])
+	assert.Equal(t, 30.0, crs[len(crs)-1])
+
+	assert.True(t, IsOfficialCR(0.125))
+	assert.False(t, IsOfficialCR(0.3))
+
+	// Returned slice is a copy
+	crs[0] = 99
+	assert.Equal(t, 0.0, OfficialCRs()[0])
+}
@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Action types that can be spent during a creature's turn
+const (
+	ActionTypeAction      = "action"
+	ActionTypeBonusAction = "bonus_action"
+	ActionTypeReaction    = "reaction"
+	ActionTypeMovement    = "movement"
+	ActionTypeFree        = "free"
+)
+
+// MovementStepFt is the width of one standard grid square in feet, which is also the cost of moving into it
+const MovementStepFt = 5
+
+// Error constants for action economy operations
+var (
+	ErrActionAlreadyUsed = errors.New("action has already been used this turn")
+	ErrUnknownActionType = errors.New("unknown action type")
+)
+
+// ActionEconomy tracks what a creature has spent during the current turn
+type ActionEconomy struct {
+	ActionUsed      bool // Whether the action has been used
+	BonusActionUsed bool // Whether the bonus action has been used
+	ReactionUsed    bool // Whether the reaction has been used
+	MovementUsed    int  // Feet of movement used this turn
+}
+
+// Use spends the given action type
+// Actions, bonus actions, and reactions can only be used once per turn
+// Movement spends one grid square and free actions are always available
+func (a *ActionEconomy) Use(actionType string) error {
+	switch actionType {
+	case ActionTypeAction:
+		if a.ActionUsed {
+			return fmt.Errorf("%s: %w", actionType, ErrActionAlreadyUsed)
+		}
+		a.ActionUsed = true
+	case ActionTypeBonusAction:
+		if a.BonusActionUsed {
+			return fmt.Errorf("%s: %w", actionType, ErrActionAlreadyUsed)
+		}
+		a.BonusActionUsed = true
+	case ActionTypeReaction:
+		if a.ReactionUsed {
+			return fmt.Errorf("%s: %w", actionType, ErrActionAlreadyUsed)
+		}
+		a.ReactionUsed = true
+	case ActionTypeMovement:
+		a.MovementUsed += MovementStepFt
+	case ActionTypeFree:
+		// Free actions do not consume anything
+	default:
+		return fmt.Errorf("%s: %w", actionType, ErrUnknownActionType)
+	}
+
+	return nil
+}
+
+// Reset restores all actions and movement for a new turn
+func (a *ActionEconomy) Reset() {
+	*a = ActionEconomy{}
+}
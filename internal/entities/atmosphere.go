@@ -0,0 +1,46 @@
+package entities
+
+// Temperatures allowed in Atmosphere.Temperature
+const (
+	TemperatureFreezing = "freezing"
+	TemperatureCold     = "cold"
+	TemperatureCool     = "cool"
+	TemperatureWarm     = "warm"
+	TemperatureHot      = "hot"
+)
+
+// Air qualities allowed in Atmosphere.AirQuality
+const (
+	AirQualityFresh   = "fresh"
+	AirQualityStale   = "stale"
+	AirQualitySmoky   = "smoky"
+	AirQualityToxic   = "toxic"
+	AirQualityMagical = "magical"
+)
+
+// Atmosphere holds the non-mechanical sensory details of a room
+type Atmosphere struct {
+	Sounds             []string // Things the party can hear, e.g. "dripping water"
+	Smells             []string // Things the party can smell, e.g. "wet fur"
+	Temperature        string   // One of the Temperature constants, or empty if unremarkable
+	AirQuality         string   // One of the AirQuality constants, or empty if unremarkable
+	VisibilityModifier int      // Bonus or penalty applied to perception DCs in the room
+}
+
+// IsValidTemperature reports whether the temperature is one of the Temperature constants
+func IsValidTemperature(temperature string) bool {
+	switch temperature {
+	case TemperatureFreezing, TemperatureCold, TemperatureCool, TemperatureWarm, TemperatureHot:
+		return true
+	}
+	return false
+}
+
+// IsValidAirQuality reports whether the air quality is one of the AirQuality constants
+func IsValidAirQuality(airQuality string) bool {
+	switch airQuality {
+	case AirQualityFresh, AirQualityStale, AirQualitySmoky, AirQualityToxic, AirQualityMagical:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package entities
+
+// BattleEventType identifies the kind of entry in a room's battle log
+type BattleEventType string
+
+const (
+	// BattleEventDamage records damage dealt by the actor to the target
+	BattleEventDamage BattleEventType = "damage"
+
+	// BattleEventHealing records hit points restored by the actor to the target
+	BattleEventHealing BattleEventType = "healing"
+
+	// BattleEventConditionApplied records a condition the actor applied to the target
+	BattleEventConditionApplied BattleEventType = "condition_applied"
+
+	// BattleEventEntityRemoved records the actor defeating the target and removing it from the room
+	BattleEventEntityRemoved BattleEventType = "entity_removed"
+)
+
+// BattleLogEntry is a single event that happened during combat in a room
+type BattleLogEntry struct {
+	Turn       int             // Turn the event happened on, counting from 1
+	Type       BattleEventType // Kind of event
+	ActorID    string          // Entity that caused the event
+	ActorKey   string          // Reference key of the actor, e.g. the monster's API key
+	ActorType  CellType        // Kind of entity that caused the event
+	TargetID   string          // Entity the event happened to
+	TargetType CellType        // Kind of entity the event happened to
+	Amount     int             // Damage or healing for those events, XP awarded for removed entities
+	Condition  Condition       // Condition applied, for BattleEventConditionApplied
+}
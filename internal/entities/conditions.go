@@ -0,0 +1,57 @@
+package entities
+
+// Condition is a D&D 5e condition affecting a creature
+type Condition string
+
+const (
+	// ConditionHidden marks a creature that has successfully hidden and cannot be seen
+	ConditionHidden Condition = "hidden"
+
+	ConditionBlinded       Condition = "blinded"
+	ConditionCharmed       Condition = "charmed"
+	ConditionDeafened      Condition = "deafened"
+	ConditionExhaustion    Condition = "exhaustion"
+	ConditionFrightened    Condition = "frightened"
+	ConditionGrappled      Condition = "grappled"
+	ConditionIncapacitated Condition = "incapacitated"
+	ConditionInvisible     Condition = "invisible"
+	ConditionParalyzed     Condition = "paralyzed"
+	ConditionPetrified     Condition = "petrified"
+	ConditionPoisoned      Condition = "poisoned"
+	ConditionProne         Condition = "prone"
+	ConditionRestrained    Condition = "restrained"
+	ConditionStunned       Condition = "stunned"
+	ConditionUnconscious   Condition = "unconscious"
+)
+
+// ApplyCondition adds the condition to the entity's conditions
+// Applying a condition the entity already has does nothing
+func ApplyCondition(entity Conditionable, c Condition) {
+	if HasCondition(entity, c) {
+		return
+	}
+	entity.SetConditions(append(entity.GetConditions(), c))
+}
+
+// RemoveCondition removes the condition from the entity's conditions, if it has it
+func RemoveCondition(entity Conditionable, c Condition) {
+	kept := []Condition{}
+	for _, existing := range entity.GetConditions() {
+		if existing != c {
+			kept = append(kept, existing)
+		}
+	}
+	if len(kept) != len(entity.GetConditions()) {
+		entity.SetConditions(kept)
+	}
+}
+
+// HasCondition reports whether the entity is affected by the condition
+func HasCondition(entity Conditionable, c Condition) bool {
+	for _, existing := range entity.GetConditions() {
+		if existing == c {
+			return true
+		}
+	}
+	return false
+}
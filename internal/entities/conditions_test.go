@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditionHelpers(t *testing.T) {
+	for _, entity := range []Conditionable{&Monster{}, &Player{}, &NPC{}} {
+		ApplyCondition(entity, ConditionPoisoned)
+		ApplyCondition(entity, ConditionProne)
+		ApplyCondition(entity, ConditionPoisoned)
+		assert.Equal(t, []Condition{ConditionPoisoned, ConditionProne}, entity.GetConditions(), "%T conditions are not duplicated", entity)
+		assert.True(t, HasCondition(entity, ConditionProne))
+		assert.False(t, HasCondition(entity, ConditionStunned))
+
+		RemoveCondition(entity, ConditionPoisoned)
+		RemoveCondition(entity, ConditionStunned)
+		assert.Equal(t, []Condition{ConditionProne}, entity.GetConditions())
+		assert.False(t, HasCondition(entity, ConditionPoisoned))
+	}
+}
@@ -0,0 +1,8 @@
+package entities
+
+// RoomConnection is a door between a cell of this room and a cell of another room
+type RoomConnection struct {
+	Position   Position // Door cell in this room
+	ToRoomID   string   // ID of the room on the other side of the door
+	ToPosition Position // Door cell in the connected room
+}
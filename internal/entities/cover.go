@@ -0,0 +1,36 @@
+package entities
+
+// CoverLevel is how much cover a position has from a threat, ordered from least to most
+type CoverLevel int
+
+const (
+	CoverNone          CoverLevel = iota // Nothing between the threat and the position
+	CoverHalf                            // +2 to AC and Dexterity saving throws
+	CoverThreeQuarters                   // +5 to AC and Dexterity saving throws
+	CoverFull                            // The position cannot be targeted directly
+)
+
+// String returns the name of the cover level
+func (c CoverLevel) String() string {
+	switch c {
+	case CoverHalf:
+		return "half"
+	case CoverThreeQuarters:
+		return "three-quarters"
+	case CoverFull:
+		return "full"
+	}
+	return "none"
+}
+
+// ACBonus returns the bonus the cover adds to AC and Dexterity saving throws
+// Full cover returns 0 because the position cannot be targeted at all
+func (c CoverLevel) ACBonus() int {
+	switch c {
+	case CoverHalf:
+		return 2
+	case CoverThreeQuarters:
+		return 5
+	}
+	return 0
+}
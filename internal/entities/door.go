@@ -0,0 +1,39 @@
+package entities
+
+// Door represents a door inside a room that can be opened, closed, locked, or barred
+type Door struct {
+	ID            string   // Unique identifier
+	Name          string   // Descriptive name of the door
+	Key           string   // Key for identifying the door type
+	Position      Position // Position in the room
+	Locked        bool     // Whether the door is locked
+	Barred        bool     // Whether the door is barred shut
+	RequiredKeyID string   // ID of the key that unlocks the door, or empty if any key will do
+	Open          bool     // Whether the door is open
+}
+
+// IsBlocking reports whether the door blocks movement and sight
+// Only closed doors that are locked or barred block; closed doors that can simply be opened do not
+func (d *Door) IsBlocking() bool {
+	return !d.Open && (d.Locked || d.Barred)
+}
+
+// GetID implements Placeable for Door
+func (d *Door) GetID() string {
+	return d.ID
+}
+
+// GetPosition implements Placeable for Door
+func (d *Door) GetPosition() Position {
+	return d.Position
+}
+
+// SetPosition implements Placeable for Door
+func (d *Door) SetPosition(pos Position) {
+	d.Position = pos
+}
+
+// GetCellType implements Placeable for Door
+func (d *Door) GetCellType() CellType {
+	return CellDoor
+}
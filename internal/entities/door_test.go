@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoorIsBlocking(t *testing.T) {
+	testCases := []struct {
+		name     string
+		door     Door
+		expected bool
+	}{
+		{"closed", Door{}, false},
+		{"open", Door{Open: true}, false},
+		{"locked", Door{Locked: true}, true},
+		{"barred", Door{Barred: true}, true},
+		{"locked but open", Door{Locked: true, Open: true}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.door.IsBlocking())
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrRoomNotFound is returned when a dungeon or repository has no room with the requested ID
+var ErrRoomNotFound = errors.New("room not found")
+
+// DungeonConnection links a door in one room of a dungeon to a door in another
+// It is named apart from RoomConnection, which links grid cells and is stored on the room itself
+type DungeonConnection struct {
+	FromRoomID string // ID of the room the connection starts in
+	ToRoomID   string // ID of the room the connection leads to
+	FromDoor   string // ID of the door in the first room (optional)
+	ToDoor     string // ID of the door in the second room (optional)
+	Direction  string // Direction of travel from the first room, such as "north" (optional)
+}
+
+// DungeonMap is a collection of rooms joined by connections
+// Rooms are keyed by their IDs
+type DungeonMap struct {
+	Rooms       map[string]*Room
+	Connections []DungeonConnection
+}
+
+// NewDungeonMap creates an empty dungeon
+func NewDungeonMap() *DungeonMap {
+	return &DungeonMap{
+		Rooms:       make(map[string]*Room),
+		Connections: make([]DungeonConnection, 0),
+	}
+}
+
+// AddRoom adds a room to the dungeon, replacing any room with the same ID
+// Nil rooms are ignored
+func (d *DungeonMap) AddRoom(room *Room) {
+	if room == nil {
+		return
+	}
+	if d.Rooms == nil {
+		d.Rooms = make(map[string]*Room)
+	}
+	d.Rooms[room.ID] = room
+}
+
+// GetRoom returns the room with the given ID, or ErrRoomNotFound if the dungeon has none
+func (d *DungeonMap) GetRoom(id string) (*Room, error) {
+	room, ok := d.Rooms[id]
+	if !ok {
+		return nil, fmt.Errorf("room %s: %w", id, ErrRoomNotFound)
+	}
+	return room, nil
+}
+
+// ConnectRooms records a connection between two rooms of the dungeon
+// Door IDs may be empty; when set, the door must exist in its room. Returns an error if either room is
+// missing or a room is connected to itself
+func (d *DungeonMap) ConnectRooms(fromID, toID, fromDoorID, toDoorID, direction string) error {
+	if fromID == toID {
+		return fmt.Errorf("cannot connect room %s to itself", fromID)
+	}
+
+	from, err := d.GetRoom(fromID)
+	if err != nil {
+		return err
+	}
+	to, err := d.GetRoom(toID)
+	if err != nil {
+		return err
+	}
+	if fromDoorID != "" && !hasDoor(from, fromDoorID) {
+		return fmt.Errorf("door %s not found in room %s", fromDoorID, fromID)
+	}
+	if toDoorID != "" && !hasDoor(to, toDoorID) {
+		return fmt.Errorf("door %s not found in room %s", toDoorID, toID)
+	}
+
+	d.Connections = append(d.Connections, DungeonConnection{
+		FromRoomID: fromID,
+		ToRoomID:   toID,
+		FromDoor:   fromDoorID,
+		ToDoor:     toDoorID,
+		Direction:  direction,
+	})
+	return nil
+}
+
+// GetNeighbors returns the rooms connected to the room in either direction, in connection order
+// Each neighbor is listed once. Returns an empty slice if the room has no connections
+func (d *DungeonMap) GetNeighbors(roomID string) []*Room {
+	seen := map[string]bool{}
+	neighbors := []*Room{}
+	for _, conn := range d.Connections {
+		var otherID string
+		switch roomID {
+		case conn.FromRoomID:
+			otherID = conn.ToRoomID
+		case conn.ToRoomID:
+			otherID = conn.FromRoomID
+		default:
+			continue
+		}
+
+		if room, ok := d.Rooms[otherID]; ok && !seen[otherID] {
+			seen[otherID] = true
+			neighbors = append(neighbors, room)
+		}
+	}
+	return neighbors
+}
+
+// hasDoor reports whether the room has a door with the given ID
+func hasDoor(room *Room, doorID string) bool {
+	for _, door := range room.Doors {
+		if door.ID == doorID {
+			return true
+		}
+	}
+	return false
+}
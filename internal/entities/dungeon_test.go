@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestDungeon creates a dungeon of three rooms, each with one door
+func createTestDungeon() *DungeonMap {
+	dungeon := NewDungeonMap()
+	for _, id := range []string{"hall", "crypt", "vault"} {
+		room := NewRoom(5, 5, LightLevelDim)
+		room.ID = id
+		room.Doors = []Door{{ID: id + "-door"}}
+		dungeon.AddRoom(room)
+	}
+	return dungeon
+}
+
+func TestDungeonMap(t *testing.T) {
+	dungeon := createTestDungeon()
+	require.NoError(t, dungeon.ConnectRooms("hall", "crypt", "hall-door", "crypt-door", "north"))
+	require.NoError(t, dungeon.ConnectRooms("crypt", "vault", "", "vault-door", "east"))
+
+	hall, err := dungeon.GetRoom("hall")
+	require.NoError(t, err)
+	crypt, _ := dungeon.GetRoom("crypt")
+	vault, _ := dungeon.GetRoom("vault")
+	assert.Equal(t, "hall", hall.ID)
+
+	assert.Equal(t, []*Room{crypt}, dungeon.GetNeighbors("hall"))
+	assert.Equal(t, []*Room{hall, vault}, dungeon.GetNeighbors("crypt"), "connections work in both directions")
+	assert.Equal(t, []*Room{crypt}, dungeon.GetNeighbors("vault"))
+	assert.Empty(t, dungeon.GetNeighbors("cellar"))
+
+	require.NoError(t, dungeon.ConnectRooms("vault", "crypt", "", "", "west"))
+	assert.Equal(t, []*Room{crypt}, dungeon.GetNeighbors("vault"), "neighbors are listed once")
+	assert.Equal(t, DungeonConnection{FromRoomID: "hall", ToRoomID: "crypt", FromDoor: "hall-door", ToDoor: "crypt-door", Direction: "north"}, dungeon.Connections[0])
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := dungeon.GetRoom("cellar")
+		assert.ErrorIs(t, err, ErrRoomNotFound)
+		assert.ErrorIs(t, dungeon.ConnectRooms("hall", "cellar", "", "", "south"), ErrRoomNotFound)
+		assert.ErrorIs(t, dungeon.ConnectRooms("cellar", "hall", "", "", "north"), ErrRoomNotFound)
+		assert.Error(t, dungeon.ConnectRooms("hall", "hall", "", "", "up"))
+		assert.Error(t, dungeon.ConnectRooms("hall", "vault", "crypt-door", "", "east"))
+		assert.Error(t, dungeon.ConnectRooms("hall", "vault", "", "hall-door", "east"))
+		assert.Len(t, dungeon.Connections, 3)
+	})
+
+	t.Run("AddRoom replaces rooms with the same ID", func(t *testing.T) {
+		replacement := NewRoom(3, 3, LightLevelBright)
+		replacement.ID = "vault"
+		dungeon.AddRoom(replacement)
+		dungeon.AddRoom(nil)
+
+		assert.Len(t, dungeon.Rooms, 3)
+		assert.Equal(t, []*Room{hall, replacement}, dungeon.GetNeighbors("crypt"))
+	})
+
+	t.Run("Zero value", func(t *testing.T) {
+		var empty DungeonMap
+		empty.AddRoom(hall)
+		got, err := empty.GetRoom("hall")
+		require.NoError(t, err)
+		assert.Same(t, hall, got)
+	})
+}
@@ -0,0 +1,7 @@
+package entities
+
+// EncounterResetData preserves a room's state at the start of an encounter so the encounter can be reset
+type EncounterResetData struct {
+	StartSnapshot *Room // Deep copy of the room when the encounter started
+	TimesReset    int   // Number of times the encounter has been reset since it started
+}
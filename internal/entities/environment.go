@@ -0,0 +1,17 @@
+package entities
+
+// Environments a room can be set in, used as hints when choosing random monsters and loot
+const (
+	EnvironmentDungeon    = "dungeon"
+	EnvironmentForest     = "forest"
+	EnvironmentMountain   = "mountain"
+	EnvironmentUnderdark  = "underdark"
+	EnvironmentCoastal    = "coastal"
+	EnvironmentDesert     = "desert"
+	EnvironmentGrassland  = "grassland"
+	EnvironmentHill       = "hill"
+	EnvironmentSwamp      = "swamp"
+	EnvironmentArctic     = "arctic"
+	EnvironmentUrban      = "urban"
+	EnvironmentUnderwater = "underwater"
+)
@@ -0,0 +1,9 @@
+package entities
+
+// InitiativeEntry is a creature's place in the room's combat turn order
+type InitiativeEntry struct {
+	EntityID    string   // ID of the creature
+	CellType    CellType // Type of the creature (CellMonster, CellPlayer, or CellNPC)
+	Roll        int      // Initiative roll including modifiers
+	DexModifier int      // Dexterity modifier, used to break ties
+}
@@ -8,6 +8,12 @@ type Placeable interface {
 	GetCellType() CellType
 }
 
+// Conditionable represents a creature that conditions can be applied to
+type Conditionable interface {
+	GetConditions() []Condition
+	SetConditions(conditions []Condition)
+}
+
 // RoomType defines the behavior of a specific type of room
 // TODO: implement room types
 type RoomType interface {
@@ -9,6 +9,17 @@ var (
 	ErrNilRoom         = errors.New("room is nil")
 	ErrInvalidPosition = errors.New("position is outside room boundaries")
 	ErrCellOccupied    = errors.New("cell is already occupied")
+	ErrNoGrid          = errors.New("room has no grid")
+)
+
+// Item rarities, from most to least common
+const (
+	RarityCommon    = "common"
+	RarityUncommon  = "uncommon"
+	RarityRare      = "rare"
+	RarityVeryRare  = "very rare"
+	RarityLegendary = "legendary"
+	RarityArtifact  = "artifact"
 )
 
 // Item represents a treasure item placed in the room
@@ -27,6 +38,11 @@ type Item struct {
 	DamageType          string   // Type of damage (for weapons)
 	ArmorClass          int      // Base armor class (for armor)
 	StealthDisadvantage bool     // Whether armor gives disadvantage on stealth checks
+	Cursed              bool     // Whether the item resists being taken from its holder
+	CurseDescription    string   // Message shown when the curse takes effect
+	Rarity              string   // One of the Rarity constants (optional, mundane equipment has none)
+	MagicBonus          int      // Enchantment bonus, such as 1 for a +1 weapon (0 if none)
+	RequiresAttunement  bool     // Whether a creature must attune to the item to use its magic
 }
 
 // GetID returns the unique identifier for this item
@@ -54,3 +70,32 @@ type ItemConfig struct {
 	Key      string   // Reference key from the API
 	Position Position // Optional position override
 }
+
+// goldPerUnit converts each currency unit to gold pieces
+var goldPerUnit = map[string]float64{
+	"cp": 0.01,
+	"sp": 0.1,
+	"ep": 0.5,
+	"gp": 1,
+	"pp": 10,
+}
+
+// GoldValue returns the item's value converted to gold pieces
+// Items without a ValueUnit are assumed to be priced in gold, and unknown units are worth nothing
+func (i *Item) GoldValue() float64 {
+	if i.ValueUnit == "" {
+		return float64(i.Value)
+	}
+	return float64(i.Value) * goldPerUnit[i.ValueUnit]
+}
+
+// FilterItemsByRarity returns the items with the given rarity, in their original order
+func FilterItemsByRarity(items []Item, rarity string) []Item {
+	matches := []Item{}
+	for _, item := range items {
+		if item.Rarity == rarity {
+			matches = append(matches, item)
+		}
+	}
+	return matches
+}
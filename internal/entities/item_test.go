@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterItemsByRarity(t *testing.T) {
+	items := []Item{
+		{ID: "rope", Name: "Rope"},
+		{ID: "sword", Name: "Longsword +1", Rarity: RarityUncommon, MagicBonus: 1},
+		{ID: "cloak", Name: "Cloak of Elvenkind", Rarity: RarityUncommon, RequiresAttunement: true},
+		{ID: "staff", Name: "Staff of the Magi", Rarity: RarityLegendary, MagicBonus: 2, RequiresAttunement: true},
+	}
+
+	uncommon := FilterItemsByRarity(items, RarityUncommon)
+	assert.Len(t, uncommon, 2)
+	assert.Equal(t, "sword", uncommon[0].ID)
+	assert.Equal(t, "cloak", uncommon[1].ID)
+
+	assert.Equal(t, []Item{items[3]}, FilterItemsByRarity(items, RarityLegendary))
+	assert.Equal(t, []Item{items[0]}, FilterItemsByRarity(items, ""), "mundane items have no rarity")
+	assert.Empty(t, FilterItemsByRarity(items, RarityArtifact))
+	assert.Empty(t, FilterItemsByRarity(nil, RarityRare))
+
+	room := NewRoom(5, 5, LightLevelBright)
+	room.Items = items
+	room.NPCs = []NPC{{ID: "wizard", Inventory: []Item{{ID: "wand", Rarity: RarityRare}}}}
+	assert.Equal(t, uncommon, room.GetItemsByRarity(RarityUncommon))
+	assert.Empty(t, room.GetItemsByRarity(RarityRare), "NPC inventories are not searched")
+}
@@ -2,12 +2,92 @@ package entities
 
 // Monster represents a monster placed in the room
 type Monster struct {
-	ID       string   // UUID for this monster instance
-	Key      string   // Reference key from the API
-	Name     string   // Name of the monster
-	CR       float64  // Challenge Rating of the monster
-	XP       int      // Experience points awarded when defeated
-	Position Position // Position of the monster in the room (if grid is used)
+	ID        string   // UUID for this monster instance
+	Key       string   // Reference key from the API
+	Name      string   // Name of the monster
+	CR        float64  // Challenge Rating of the monster
+	Type      string   // Creature type, such as "undead" (empty if unknown)
+	XP        int      // Experience points awarded when defeated
+	MaxHP     int      // Maximum hit points (0 if not tracked)
+	CurrentHP int      // Current hit points
+	Position  Position // Position of the monster in the room (if grid is used)
+
+	ActionEconomy ActionEconomy // Actions spent during the current turn
+	Conditions    []Condition   // Conditions currently affecting the monster
+
+	ConditionImmunities []Condition // Conditions that cannot be applied to the monster
+
+	Size       CreatureSize // Size category of the monster (empty is treated as medium)
+	Speed      int          // Walking speed in feet (0 uses the default speed)
+	MeleeReach int          // Melee reach in squares (0 uses the default for the monster's size)
+	Darkvision int          // Darkvision range in feet (0 if the monster has none)
+}
+
+// CreatureSize is a D&D 5e creature size category
+type CreatureSize string
+
+const (
+	SizeTiny       CreatureSize = "tiny"
+	SizeSmall      CreatureSize = "small"
+	SizeMedium     CreatureSize = "medium"
+	SizeLarge      CreatureSize = "large"
+	SizeHuge       CreatureSize = "huge"
+	SizeGargantuan CreatureSize = "gargantuan"
+)
+
+// IsLargeOrBigger reports whether the size is Large, Huge, or Gargantuan
+func (s CreatureSize) IsLargeOrBigger() bool {
+	return s == SizeLarge || s == SizeHuge || s == SizeGargantuan
+}
+
+// DefaultMeleeReach returns the default melee reach in squares for a creature of this size
+// Large and bigger creatures reach 2 squares, everything else 1
+func (s CreatureSize) DefaultMeleeReach() int {
+	if s.IsLargeOrBigger() {
+		return 2
+	}
+	return 1
+}
+
+// IsImmuneTo reports whether the monster is immune to the condition
+func (m *Monster) IsImmuneTo(condition Condition) bool {
+	for _, immunity := range m.ConditionImmunities {
+		if immunity == condition {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyDamage reduces the monster's current hit points, stopping at 0
+// Returns whether the monster is dead afterwards (see IsDead). Negative amounts are ignored, and monsters
+// without hit points tracked are left unchanged
+func (m *Monster) ApplyDamage(amount int) bool {
+	if m.MaxHP <= 0 {
+		return false
+	}
+	m.CurrentHP = max(m.CurrentHP-max(amount, 0), 0)
+	return m.IsDead()
+}
+
+// Heal restores the monster's current hit points, up to MaxHP. Negative amounts are ignored
+func (m *Monster) Heal(amount int) {
+	m.CurrentHP = min(m.CurrentHP+max(amount, 0), m.MaxHP)
+}
+
+// IsDead reports whether the monster has hit points tracked and has none left
+func (m *Monster) IsDead() bool {
+	return m.MaxHP > 0 && m.CurrentHP <= 0
+}
+
+// GetConditions returns the conditions currently affecting this monster
+func (m *Monster) GetConditions() []Condition {
+	return m.Conditions
+}
+
+// SetConditions replaces the conditions affecting this monster
+func (m *Monster) SetConditions(conditions []Condition) {
+	m.Conditions = conditions
 }
 
 // GetID returns the unique identifier for this monster
@@ -0,0 +1,19 @@
+package entities
+
+// MonsterPack groups monsters that act together in a formation
+type MonsterPack struct {
+	PackID          string   // UUID for this pack
+	MonsterIDs      []string // IDs of the monsters in the pack, including the leader
+	LeaderID        string   // ID of the pack leader (optional)
+	FormationCenter Position // Reference point members keep their offsets to when the pack moves
+}
+
+// HasMember reports whether the monster belongs to the pack
+func (p *MonsterPack) HasMember(monsterID string) bool {
+	for _, id := range p.MonsterIDs {
+		if id == monsterID {
+			return true
+		}
+	}
+	return false
+}
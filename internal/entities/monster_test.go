@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonsterHitPoints(t *testing.T) {
+	ogre := &Monster{MaxHP: 59, CurrentHP: 59}
+	assert.False(t, ogre.IsDead())
+
+	assert.False(t, ogre.ApplyDamage(20))
+	assert.Equal(t, 39, ogre.CurrentHP)
+
+	ogre.Heal(100)
+	assert.Equal(t, 59, ogre.CurrentHP, "healing stops at MaxHP")
+
+	assert.False(t, ogre.ApplyDamage(-5), "negative damage is ignored")
+	assert.Equal(t, 59, ogre.CurrentHP)
+
+	assert.True(t, ogre.ApplyDamage(70))
+	assert.Equal(t, 0, ogre.CurrentHP, "hit points stop at 0")
+	assert.True(t, ogre.IsDead())
+
+	ogre.Heal(10)
+	assert.Equal(t, 10, ogre.CurrentHP)
+	assert.False(t, ogre.IsDead())
+}
+
+func TestMonsterIsDeadUntracked(t *testing.T) {
+	assert.False(t, (&Monster{}).IsDead(), "monsters without MaxHP do not track hit points")
+
+	monster := &Monster{CurrentHP: 5}
+	assert.False(t, monster.ApplyDamage(10), "damage does not kill monsters without MaxHP")
+	assert.Equal(t, 5, monster.CurrentHP)
+	assert.False(t, monster.IsDead())
+}
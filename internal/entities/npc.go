@@ -1,12 +1,37 @@
 package entities
 
+// NPC dispositions toward the party, used as hints for dialogue and combat AI
+const (
+	DispositionFriendly = "friendly"
+	DispositionNeutral  = "neutral"
+	DispositionHostile  = "hostile"
+	DispositionFearful  = "fearful"
+)
+
 // NPC represents a non-player character placed in the room
 type NPC struct {
 	ID        string   // UUID for this NPC instance
 	Key       string   // Reference key from the API (if applicable)
 	Name      string   // Name of the NPC
+	Level     int      // Character level of the NPC
+	TeamID    string   // Team the NPC belongs to (optional)
 	Inventory []Item   // Items in the NPC's inventory
 	Position  Position // Position of the NPC in the room (if grid is used)
+
+	Conditions  []Condition // Conditions currently affecting the NPC
+	Darkvision  int         // Darkvision range in feet (0 if the NPC has none)
+	Speed       int         // Walking speed in feet (0 uses the default speed)
+	Disposition string      // Attitude toward the party, one of the Disposition constants (optional)
+}
+
+// GetConditions returns the conditions currently affecting this NPC
+func (n *NPC) GetConditions() []Condition {
+	return n.Conditions
+}
+
+// SetConditions replaces the conditions affecting this NPC
+func (n *NPC) SetConditions(conditions []Condition) {
+	n.Conditions = conditions
 }
 
 // GetID returns the unique identifier for this NPC
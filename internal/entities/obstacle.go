@@ -5,8 +5,32 @@ type Obstacle struct {
 	ID       string   // Unique identifier
 	Name     string   // Descriptive name of the obstacle
 	Key      string   // Key for identifying the obstacle type
-	Position Position // Position in the room
+	Position Position // Position in the room, the top-left cell for obstacles larger than one cell
 	Blocking bool     // Whether the obstacle blocks movement
+	Size     Size     // Cells covered, extending right and down from Position (zero dimensions count as 1)
+}
+
+// Size is a width and height in grid cells
+type Size struct {
+	Width  int
+	Height int
+}
+
+// Footprint returns the obstacle's size with zero or negative dimensions counted as 1
+func (o *Obstacle) Footprint() Size {
+	return Size{Width: max(o.Size.Width, 1), Height: max(o.Size.Height, 1)}
+}
+
+// Cells returns every position covered by the obstacle in row-major order, starting with Position
+func (o *Obstacle) Cells() []Position {
+	size := o.Footprint()
+	cells := make([]Position, 0, size.Width*size.Height)
+	for y := 0; y < size.Height; y++ {
+		for x := 0; x < size.Width; x++ {
+			cells = append(cells, o.Position.Add(Position{X: x, Y: y}))
+		}
+	}
+	return cells
 }
 
 // GetID implements Placeable for Obstacle
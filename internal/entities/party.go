@@ -1,9 +1,16 @@
 package entities
 
+import "sort"
+
 // PartyMember represents a player character in a party
 type PartyMember struct {
+	ID    string // ID of the matching Player, if the member was built from one
 	Name  string
 	Level int
+	Class string
+	Race  string
+
+	InflictedConditions []Condition // Conditions the member's attacks and spells can inflict (optional)
 }
 
 // Party represents a group of player characters
@@ -30,13 +37,31 @@ func (p *Party) Size() int {
 	return len(p.Members)
 }
 
+// UniqueClasses returns the distinct classes of the party's members in alphabetical order
+// Members without a class are skipped
+func (p *Party) UniqueClasses() []string {
+	seen := map[string]bool{}
+	classes := []string{}
+	for _, member := range p.Members {
+		if member.Class != "" && !seen[member.Class] {
+			seen[member.Class] = true
+			classes = append(classes, member.Class)
+		}
+	}
+	sort.Strings(classes)
+	return classes
+}
+
 // PlayersToParty creates a Party from a slice of Player entities
 func PlayersToParty(players []Player) Party {
 	members := make([]PartyMember, len(players))
 	for i, player := range players {
 		members[i] = PartyMember{
+			ID:    player.ID,
 			Name:  player.Name,
 			Level: player.Level,
+			Class: player.Class,
+			Race:  player.Race,
 		}
 	}
 	return Party{Members: members}
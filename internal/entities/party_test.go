@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlayersToPartyCopiesIdentity(t *testing.T) {
+	players := []Player{
+		{ID: "p1", Name: "Vex", Level: 5, Class: "ranger", Race: "half-elf"},
+		{ID: "p2", Name: "Grog", Level: 4, Class: "barbarian", Race: "goliath"},
+	}
+
+	party := PlayersToParty(players)
+	assert.Equal(t, []PartyMember{
+		{ID: "p1", Name: "Vex", Level: 5, Class: "ranger", Race: "half-elf"},
+		{ID: "p2", Name: "Grog", Level: 4, Class: "barbarian", Race: "goliath"},
+	}, party.Members)
+}
+
+func TestPartyUniqueClasses(t *testing.T) {
+	party := Party{Members: []PartyMember{
+		{Class: "wizard"},
+		{Class: "fighter"},
+		{Class: "wizard"},
+		{},
+		{Class: "cleric"},
+	}}
+
+	assert.Equal(t, []string{"cleric", "fighter", "wizard"}, party.UniqueClasses())
+	assert.Empty(t, (&Party{}).UniqueClasses())
+}
@@ -5,7 +5,82 @@ type Player struct {
 	ID       string   // UUID for this player instance
 	Name     string   // Name of the player character
 	Level    int      // Level of the player character
+	Class    string   // Character class, such as "fighter" (optional)
+	Race     string   // Character race, such as "dwarf" (optional)
 	Position Position // Position of the player in the room (if grid is used)
+
+	ActionEconomy ActionEconomy // Actions spent during the current turn
+	Conditions    []Condition   // Conditions currently affecting the player
+	Darkvision    int           // Darkvision range in feet (0 if the player has none)
+	Speed         int           // Walking speed in feet (0 uses the default speed)
+
+	MaxHP      int        // Maximum hit points (0 if not tracked)
+	CurrentHP  int        // Current hit points
+	DeathSaves DeathSaves // Death saving throws made while at 0 hit points
+}
+
+// DeathSaves counts a dying player's death saving throws
+type DeathSaves struct {
+	Successes int
+	Failures  int
+}
+
+// ApplyDamage reduces the player's current hit points, stopping at 0
+// Damage taken at 0 hit points counts as a failed death save. Damage that leaves the player at 0 with at least
+// MaxHP left over, or that reaches MaxHP while the player is at 0, kills outright. Negative amounts, and damage
+// to players without hit points tracked, are ignored
+func (p *Player) ApplyDamage(amount int) {
+	if amount <= 0 || p.MaxHP <= 0 || p.IsDead() {
+		return
+	}
+
+	if p.CurrentHP > 0 {
+		overflow := amount - p.CurrentHP
+		p.CurrentHP = max(p.CurrentHP-amount, 0)
+		if p.CurrentHP == 0 && overflow >= p.MaxHP {
+			p.DeathSaves.Failures = 3
+		}
+		return
+	}
+
+	if amount >= p.MaxHP {
+		p.DeathSaves.Failures = 3
+	} else {
+		p.DeathSaves.Failures++
+	}
+}
+
+// Heal restores the player's current hit points, up to MaxHP, and clears their death saves
+// Dead players cannot be healed. Negative amounts are ignored
+func (p *Player) Heal(amount int) {
+	if amount <= 0 || p.IsDead() {
+		return
+	}
+	p.CurrentHP = min(p.CurrentHP+amount, p.MaxHP)
+	if p.CurrentHP > 0 {
+		p.DeathSaves = DeathSaves{}
+	}
+}
+
+// IsUnconscious reports whether the player has hit points tracked and is at 0
+// Dead players are also unconscious
+func (p *Player) IsUnconscious() bool {
+	return p.MaxHP > 0 && p.CurrentHP == 0
+}
+
+// IsDead reports whether the player has failed three death saves
+func (p *Player) IsDead() bool {
+	return p.DeathSaves.Failures >= 3
+}
+
+// GetConditions returns the conditions currently affecting this player
+func (p *Player) GetConditions() []Condition {
+	return p.Conditions
+}
+
+// SetConditions replaces the conditions affecting this player
+func (p *Player) SetConditions(conditions []Condition) {
+	p.Conditions = conditions
 }
 
 // GetID returns the unique identifier for this player
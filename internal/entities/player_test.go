@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlayerUnconsciousToDead(t *testing.T) {
+	fighter := &Player{MaxHP: 20, CurrentHP: 20}
+
+	fighter.ApplyDamage(15)
+	assert.Equal(t, 5, fighter.CurrentHP)
+	assert.False(t, fighter.IsUnconscious())
+
+	fighter.ApplyDamage(8)
+	assert.Equal(t, 0, fighter.CurrentHP, "hit points stop at 0")
+	assert.True(t, fighter.IsUnconscious())
+	assert.False(t, fighter.IsDead())
+	assert.Equal(t, 0, fighter.DeathSaves.Failures)
+
+	fighter.ApplyDamage(3)
+	assert.Equal(t, 1, fighter.DeathSaves.Failures)
+	fighter.ApplyDamage(3)
+	assert.Equal(t, 2, fighter.DeathSaves.Failures)
+	assert.False(t, fighter.IsDead())
+
+	fighter.ApplyDamage(3)
+	assert.Equal(t, 3, fighter.DeathSaves.Failures)
+	assert.True(t, fighter.IsDead())
+	assert.True(t, fighter.IsUnconscious())
+
+	fighter.Heal(10)
+	assert.Equal(t, 0, fighter.CurrentHP, "the dead cannot be healed")
+}
+
+func TestPlayerHealClearsDeathSaves(t *testing.T) {
+	cleric := &Player{MaxHP: 12, CurrentHP: 0, DeathSaves: DeathSaves{Successes: 1, Failures: 2}}
+
+	cleric.Heal(30)
+	assert.Equal(t, 12, cleric.CurrentHP, "healing stops at MaxHP")
+	assert.Equal(t, DeathSaves{}, cleric.DeathSaves)
+	assert.False(t, cleric.IsUnconscious())
+}
+
+func TestPlayerMassiveDamage(t *testing.T) {
+	testCases := []struct {
+		name      string
+		player    Player
+		damage    int
+		expectDie bool
+	}{
+		{"overflow equal to MaxHP", Player{MaxHP: 10, CurrentHP: 4}, 14, true},
+		{"overflow below MaxHP", Player{MaxHP: 10, CurrentHP: 4}, 13, false},
+		{"damage at 0 reaching MaxHP", Player{MaxHP: 10}, 10, true},
+		{"untracked player", Player{}, 100, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.player.ApplyDamage(tc.damage)
+			assert.Equal(t, tc.expectDie, tc.player.IsDead())
+		})
+	}
+}
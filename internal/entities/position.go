@@ -0,0 +1,109 @@
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Add returns the component-wise sum of two positions
+func (p Position) Add(other Position) Position {
+	return Position{X: p.X + other.X, Y: p.Y + other.Y}
+}
+
+// Sub returns the component-wise difference of two positions
+func (p Position) Sub(other Position) Position {
+	return Position{X: p.X - other.X, Y: p.Y - other.Y}
+}
+
+// Scale multiplies both coordinates by factor
+func (p Position) Scale(factor int) Position {
+	return Position{X: p.X * factor, Y: p.Y * factor}
+}
+
+// Clamp restricts the position to the inclusive rectangle between minPos and maxPos
+func (p Position) Clamp(minPos, maxPos Position) Position {
+	return Position{
+		X: clampInt(p.X, minPos.X, maxPos.X),
+		Y: clampInt(p.Y, minPos.Y, maxPos.Y),
+	}
+}
+
+// IsZero reports whether the position is the origin
+func (p Position) IsZero() bool {
+	return p.X == 0 && p.Y == 0
+}
+
+// Neighbors returns the orthogonally adjacent positions, plus diagonals if requested
+// No bounds checking is performed, so callers must filter positions outside the room
+func (p Position) Neighbors(includeDiagonals bool) []Position {
+	neighbors := []Position{
+		{X: p.X, Y: p.Y - 1},
+		{X: p.X, Y: p.Y + 1},
+		{X: p.X + 1, Y: p.Y},
+		{X: p.X - 1, Y: p.Y},
+	}
+
+	if includeDiagonals {
+		neighbors = append(neighbors,
+			Position{X: p.X + 1, Y: p.Y - 1},
+			Position{X: p.X - 1, Y: p.Y - 1},
+			Position{X: p.X + 1, Y: p.Y + 1},
+			Position{X: p.X - 1, Y: p.Y + 1},
+		)
+	}
+
+	return neighbors
+}
+
+// ManhattanDistance returns the taxicab distance between two positions
+func (p Position) ManhattanDistance(other Position) int {
+	return absInt(p.X-other.X) + absInt(p.Y-other.Y)
+}
+
+// String returns the position formatted as "(x,y)"
+func (p Position) String() string {
+	return fmt.Sprintf("(%d,%d)", p.X, p.Y)
+}
+
+// PositionFromString parses a position in "x,y" format
+// Surrounding whitespace and parentheses are accepted, so the output of String round-trips
+func PositionFromString(s string) (Position, error) {
+	trimmed := strings.TrimSpace(s)
+	if strings.HasPrefix(trimmed, "(") && strings.HasSuffix(trimmed, ")") {
+		trimmed = trimmed[1 : len(trimmed)-1]
+	}
+
+	parts := strings.Split(trimmed, ",")
+	if len(parts) != 2 {
+		return Position{}, fmt.Errorf("invalid position %q: expected \"x,y\"", s)
+	}
+
+	x, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return Position{}, fmt.Errorf("invalid position %q: %w", s, err)
+	}
+	y, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return Position{}, fmt.Errorf("invalid position %q: %w", s, err)
+	}
+
+	return Position{X: x, Y: y}, nil
+}
+
+func clampInt(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
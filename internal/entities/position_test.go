@@ -0,0 +1,93 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPositionArithmetic(t *testing.T) {
+	p := Position{X: 3, Y: -2}
+	q := Position{X: 1, Y: 4}
+
+	assert.Equal(t, Position{X: 4, Y: 2}, p.Add(q))
+	assert.Equal(t, Position{X: 2, Y: -6}, p.Sub(q))
+	assert.Equal(t, Position{X: 9, Y: -6}, p.Scale(3))
+	assert.Equal(t, Position{X: 0, Y: 0}, p.Scale(0))
+	assert.Equal(t, 8, p.ManhattanDistance(q))
+	assert.Equal(t, 8, q.ManhattanDistance(p))
+
+	assert.True(t, Position{}.IsZero())
+	assert.False(t, p.IsZero())
+}
+
+func TestPositionClamp(t *testing.T) {
+	minPos := Position{X: 0, Y: 0}
+	maxPos := Position{X: 9, Y: 4}
+
+	assert.Equal(t, Position{X: 3, Y: 2}, Position{X: 3, Y: 2}.Clamp(minPos, maxPos))
+	assert.Equal(t, Position{X: 0, Y: 4}, Position{X: -5, Y: 10}.Clamp(minPos, maxPos))
+	assert.Equal(t, Position{X: 9, Y: 0}, Position{X: 12, Y: -1}.Clamp(minPos, maxPos))
+}
+
+func TestPositionNeighbors(t *testing.T) {
+	p := Position{X: 5, Y: 5}
+
+	orthogonal := p.Neighbors(false)
+	assert.Len(t, orthogonal, 4)
+	for _, n := range orthogonal {
+		assert.Equal(t, 1, p.ManhattanDistance(n))
+	}
+
+	all := p.Neighbors(true)
+	assert.Len(t, all, 8)
+	seen := map[Position]bool{}
+	for _, n := range all {
+		assert.NotEqual(t, p, n)
+		assert.LessOrEqual(t, absInt(n.X-p.X), 1)
+		assert.LessOrEqual(t, absInt(n.Y-p.Y), 1)
+		seen[n] = true
+	}
+	assert.Len(t, seen, 8)
+}
+
+func TestPositionString(t *testing.T) {
+	assert.Equal(t, "(3,-2)", Position{X: 3, Y: -2}.String())
+}
+
+func TestPositionFromString(t *testing.T) {
+	testCases := []struct {
+		input       string
+		expected    Position
+		expectError bool
+	}{
+		{input: "3,4", expected: Position{X: 3, Y: 4}},
+		{input: " 10 , 0 ", expected: Position{X: 10, Y: 0}},
+		{input: "-3,-7", expected: Position{X: -3, Y: -7}},
+		{input: "(2,-1)", expected: Position{X: 2, Y: -1}},
+		{input: "", expectError: true},
+		{input: "3", expectError: true},
+		{input: "3,4,5", expectError: true},
+		{input: "a,4", expectError: true},
+		{input: "3,b", expectError: true},
+		{input: "3;4", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			pos, err := PositionFromString(tc.input)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, pos)
+		})
+	}
+
+	// String output round-trips
+	original := Position{X: -4, Y: 12}
+	parsed, err := PositionFromString(original.String())
+	assert.NoError(t, err)
+	assert.Equal(t, original, parsed)
+}
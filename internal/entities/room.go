@@ -1,5 +1,12 @@
 package entities
 
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/google/uuid"
+)
+
 // CellType represents what occupies a cell in the room grid
 type CellType int
 
@@ -10,6 +17,10 @@ const (
 	CellPlayer
 	CellNPC
 	CellObstacle
+	CellSpellZone // Never stored in the grid; spell zones overlay cells instead
+	CellTrap
+	CellDoor
+	CellWall // Permanent wall carved into the grid; no entity is stored for it
 )
 
 // Cell represents a single cell in the room grid
@@ -26,19 +37,229 @@ type Position struct {
 
 // Room represents a rectangular room in a dungeon
 type Room struct {
+	ID          string     // UUID identifying this room
 	Width       int        // Width of the room in grid units
 	Height      int        // Height of the room in grid units
 	LightLevel  LightLevel // Light level of the room
 	Description string     // room description
 	RoomType    RoomType   // type of room
+	Environment string     // Setting of the room, one of the Environment constants (optional)
 	Monsters    []Monster  // Monsters in the room
 	Players     []Player   // Players in the room
 	NPCs        []NPC      // NPCs in the room
 	Items       []Item     // Items in the room
 	Obstacles   []Obstacle // Obstacles in the room
+	Traps       []Trap     // Traps in the room
+	Doors       []Door     // Doors in the room
 	Grid        [][]Cell   // Grid of cells in the room (if grid is used)
+
+	DifficultTerrain  map[Position]bool   // Positions that cost double movement to enter
+	PlacementStrategy PlacementStrategy   // Algorithm used to pick random empty cells
+	Rand              *rand.Rand          `json:"-"` // Random source for placement and IDs in seeded rooms (nil uses the shared source)
+	CellSizeFt        int                 // Width of one grid square in feet (0 means the standard 5 ft)
+	MonsterPacks      []MonsterPack       // Groups of monsters that move in formation
+	Atmosphere        Atmosphere          // Sounds, smells, and other sensory details
+	Connections       []RoomConnection    // Doors leading to other rooms
+	EncounterReset    *EncounterResetData // Encounter-start state used to reset the encounter (nil if not marked)
+	BattleLog         []BattleLogEntry    // Events recorded during combat, oldest first
+	SpellZones        []SpellZone         // Spell effects covering parts of the room
+	InitiativeOrder   []InitiativeEntry   // Combat turn order, first to act first once sorted
+	Tags              map[string]string   // Searchable metadata such as "boss" or "explored"
+	VisibilityGrid    [][]VisibilityState // Fog of war revealed to the players, indexed like Grid (if grid is used)
+	EventLog          []RoomEvent         // Entities placed, removed, and moved, oldest first (nil disables logging)
+}
+
+// NewRoom creates an empty gridless room with a freshly generated ID
+func NewRoom(width, height int, lightLevel LightLevel) *Room {
+	return &Room{
+		ID:         uuid.NewString(),
+		Width:      width,
+		Height:     height,
+		LightLevel: lightLevel,
+		Monsters:   make([]Monster, 0),
+		Players:    make([]Player, 0),
+		Items:      make([]Item, 0),
+	}
+}
+
+// IsValidID reports whether the room's ID is a well-formed UUID
+func (r *Room) IsValidID() bool {
+	_, err := uuid.Parse(r.ID)
+	return err == nil
+}
+
+// IsDifficultTerrain reports whether entering the position costs double movement
+func (r *Room) IsDifficultTerrain(pos Position) bool {
+	return r.DifficultTerrain[pos]
+}
+
+// SetDifficultTerrain marks or unmarks a position as difficult terrain
+func (r *Room) SetDifficultTerrain(pos Position, difficult bool) {
+	if !difficult {
+		delete(r.DifficultTerrain, pos)
+		return
+	}
+	if r.DifficultTerrain == nil {
+		r.DifficultTerrain = make(map[Position]bool)
+	}
+	r.DifficultTerrain[pos] = true
+}
+
+// GetItemsByRarity returns copies of the items in the room with the given rarity
+// Items carried by NPCs are not included
+func (r *Room) GetItemsByRarity(rarity string) []Item {
+	return FilterItemsByRarity(r.Items, rarity)
+}
+
+// SetTag sets a tag on the room, replacing any existing value
+func (r *Room) SetTag(key, value string) {
+	if r.Tags == nil {
+		r.Tags = make(map[string]string)
+	}
+	r.Tags[key] = value
+}
+
+// GetTag returns the value of a tag and whether the room has it
+func (r *Room) GetTag(key string) (string, bool) {
+	value, ok := r.Tags[key]
+	return value, ok
+}
+
+// HasTag reports whether the room has a tag, whatever its value
+func (r *Room) HasTag(key string) bool {
+	_, ok := r.Tags[key]
+	return ok
+}
+
+// DeleteTag removes a tag from the room
+func (r *Room) DeleteTag(key string) {
+	delete(r.Tags, key)
+}
+
+// IsPositionValid reports whether position lies within the room's bounds
+// It does not check whether the cell is occupied
+func IsPositionValid(room *Room, position Position) bool {
+	if room == nil {
+		return false
+	}
+	return position.X >= 0 && position.X < room.Width &&
+		position.Y >= 0 && position.Y < room.Height
 }
 
+// IsPositionEmpty reports whether the grid cell at position is unoccupied
+// Returns ErrNoGrid for gridless rooms and ErrInvalidPosition for positions outside the room
+func IsPositionEmpty(room *Room, position Position) (bool, error) {
+	if room == nil {
+		return false, ErrNilRoom
+	}
+	if room.Grid == nil {
+		return false, ErrNoGrid
+	}
+	if !IsPositionValid(room, position) {
+		return false, ErrInvalidPosition
+	}
+	return room.Grid[position.Y][position.X].Type == CellTypeEmpty, nil
+}
+
+// ValidatePosition checks that an entity can be placed at position
+// Returns ErrInvalidPosition if it is outside the room and ErrCellOccupied if the grid cell is taken.
+// Occupation is not checked for gridless rooms
+func ValidatePosition(room *Room, position Position) error {
+	if room == nil {
+		return ErrNilRoom
+	}
+	if !IsPositionValid(room, position) {
+		return ErrInvalidPosition
+	}
+	if room.Grid != nil && room.Grid[position.Y][position.X].Type != CellTypeEmpty {
+		return ErrCellOccupied
+	}
+	return nil
+}
+
+// CountEntities returns the number of entities occupying cells of the given type
+// Counts come from the lengths of the entity slices, so this is O(1). CellTypeEmpty and unknown
+// types count zero
+func (r *Room) CountEntities(cellType CellType) int {
+	switch cellType {
+	case CellMonster:
+		return len(r.Monsters)
+	case CellPlayer:
+		return len(r.Players)
+	case CellItem:
+		return len(r.Items)
+	case CellNPC:
+		return len(r.NPCs)
+	case CellObstacle:
+		return len(r.Obstacles)
+	case CellTrap:
+		return len(r.Traps)
+	case CellDoor:
+		return len(r.Doors)
+	case CellSpellZone:
+		return len(r.SpellZones)
+	}
+	return 0
+}
+
+// TotalEntityCount returns the number of entities of every type in the room in O(1)
+func (r *Room) TotalEntityCount() int {
+	return len(r.Monsters) + len(r.Players) + len(r.Items) + len(r.NPCs) + len(r.Obstacles) + len(r.Traps) + len(r.Doors)
+}
+
+// IsEmpty reports whether the room holds no entities
+func (r *Room) IsEmpty() bool {
+	return r.TotalEntityCount() == 0
+}
+
+// IsFull reports whether no more entities can be placed in the room
+// Gridded rooms are full when no empty cells remain; gridless rooms are full once they hold at least
+// Width x Height entities. Returns an error if the grid does not match the room's dimensions
+func (r *Room) IsFull() (bool, error) {
+	if r.Grid == nil {
+		return r.TotalEntityCount() >= r.Width*r.Height, nil
+	}
+
+	if len(r.Grid) != r.Height {
+		return false, fmt.Errorf("grid has %d rows, expected %d", len(r.Grid), r.Height)
+	}
+	for y, row := range r.Grid {
+		if len(row) != r.Width {
+			return false, fmt.Errorf("grid row %d has %d cells, expected %d", y, len(row), r.Width)
+		}
+		for _, cell := range row {
+			if cell.Type == CellTypeEmpty {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// CapacityUsed returns the number of occupied cells in the room (see GetOccupiedCells)
+func (r *Room) CapacityUsed() int {
+	return len(GetOccupiedCells(r))
+}
+
+// CapacityRemaining returns the number of empty cells in the room (see GetEmptyCells)
+func (r *Room) CapacityRemaining() int {
+	return len(GetEmptyCells(r))
+}
+
+// PlacementStrategy selects the algorithm used to pick a random empty cell
+type PlacementStrategy string
+
+const (
+	// PlacementSequential scans the grid, collects every empty cell, and picks one (default)
+	PlacementSequential PlacementStrategy = "sequential"
+
+	// PlacementFisherYates visits cells in a lazily shuffled order until an empty one is found
+	PlacementFisherYates PlacementStrategy = "fisher_yates"
+
+	// PlacementReservoir uses reservoir sampling to pick an empty cell in one pass without allocating
+	PlacementReservoir PlacementStrategy = "reservoir"
+)
+
 type LightLevel string
 
 const (
@@ -0,0 +1,20 @@
+package entities
+
+import "time"
+
+// Kinds of entry in a room's event log
+const (
+	RoomEventPlaced  = "placed"
+	RoomEventRemoved = "removed"
+	RoomEventMoved   = "moved"
+)
+
+// RoomEvent is a single change to the entities of a room
+type RoomEvent struct {
+	Timestamp   time.Time // When the change happened
+	EventType   string    // One of the RoomEvent constants
+	EntityID    string    // Entity that was placed, removed, or moved
+	OldPosition *Position // Position before the change (nil for placements)
+	NewPosition *Position // Position after the change (nil for removals)
+	Detail      string    // Kind of entity, such as "monster"
+}
@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String returns a one-line summary of the room for debug output
+func (r *Room) String() string {
+	if r == nil {
+		return "Room(nil)"
+	}
+
+	return fmt.Sprintf("Room(%dx%d, light=%s, monsters=%d, players=%d, items=%d, npcs=%d, obstacles=%d, gridded=%t)",
+		r.Width, r.Height, r.LightLevel,
+		len(r.Monsters), len(r.Players), len(r.Items), len(r.NPCs), len(r.Obstacles),
+		r.Grid != nil)
+}
+
+// GoString returns a verbose representation of the room including all entity IDs
+func (r *Room) GoString() string {
+	if r == nil {
+		return "(*entities.Room)(nil)"
+	}
+
+	monsterIDs := make([]string, len(r.Monsters))
+	for i, monster := range r.Monsters {
+		monsterIDs[i] = monster.ID
+	}
+	playerIDs := make([]string, len(r.Players))
+	for i, player := range r.Players {
+		playerIDs[i] = player.ID
+	}
+	itemIDs := make([]string, len(r.Items))
+	for i, item := range r.Items {
+		itemIDs[i] = item.ID
+	}
+	npcIDs := make([]string, len(r.NPCs))
+	for i, npc := range r.NPCs {
+		npcIDs[i] = npc.ID
+	}
+	obstacleIDs := make([]string, len(r.Obstacles))
+	for i, obstacle := range r.Obstacles {
+		obstacleIDs[i] = obstacle.ID
+	}
+
+	return fmt.Sprintf("&entities.Room{Width:%d, Height:%d, LightLevel:%q, Description:%q, Monsters:[%s], Players:[%s], Items:[%s], NPCs:[%s], Obstacles:[%s], Gridded:%t}",
+		r.Width, r.Height, r.LightLevel, r.Description,
+		strings.Join(monsterIDs, " "), strings.Join(playerIDs, " "), strings.Join(itemIDs, " "),
+		strings.Join(npcIDs, " "), strings.Join(obstacleIDs, " "),
+		r.Grid != nil)
+}
+
+// Format implements fmt.Formatter
+// %v prints the String summary, %#v prints the GoString representation,
+// %s prints the room description, and %q prints the quoted description
+func (r *Room) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprint(f, r.GoString())
+			return
+		}
+		fmt.Fprint(f, r.String())
+	case 's':
+		if r == nil {
+			fmt.Fprint(f, r.String())
+			return
+		}
+		fmt.Fprint(f, r.Description)
+	case 'q':
+		if r == nil {
+			fmt.Fprint(f, strconv.Quote(r.String()))
+			return
+		}
+		fmt.Fprint(f, strconv.Quote(r.Description))
+	default:
+		fmt.Fprintf(f, "%%!%c(%s)", verb, r.String())
+	}
+}
@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createFormatTestRoom() *Room {
+	room := &Room{
+		Width:       4,
+		Height:      3,
+		LightLevel:  LightLevelDim,
+		Description: "A damp cellar",
+		Monsters:    []Monster{{ID: "m1"}, {ID: "m2"}},
+		Players:     []Player{{ID: "p1"}},
+		Items:       []Item{{ID: "i1"}},
+		Obstacles:   []Obstacle{{ID: "o1"}},
+	}
+	room.Grid = make([][]Cell, room.Height)
+	for y := range room.Grid {
+		room.Grid[y] = make([]Cell, room.Width)
+	}
+	return room
+}
+
+func TestRoomString(t *testing.T) {
+	room := createFormatTestRoom()
+	assert.Equal(t,
+		"Room(4x3, light=dim, monsters=2, players=1, items=1, npcs=0, obstacles=1, gridded=true)",
+		room.String())
+
+	room.Grid = nil
+	assert.Equal(t,
+		"Room(4x3, light=dim, monsters=2, players=1, items=1, npcs=0, obstacles=1, gridded=false)",
+		room.String())
+
+	var nilRoom *Room
+	assert.Equal(t, "Room(nil)", nilRoom.String())
+}
+
+func TestRoomGoString(t *testing.T) {
+	room := createFormatTestRoom()
+	assert.Equal(t,
+		`&entities.Room{Width:4, Height:3, LightLevel:"dim", Description:"A damp cellar", Monsters:[m1 m2], Players:[p1], Items:[i1], NPCs:[], Obstacles:[o1], Gridded:true}`,
+		room.GoString())
+}
+
+func TestRoomFormat(t *testing.T) {
+	room := createFormatTestRoom()
+
+	assert.Equal(t, room.String(), fmt.Sprintf("%v", room))
+	assert.Equal(t, room.String(), fmt.Sprintf("%+v", room))
+	assert.Equal(t, room.GoString(), fmt.Sprintf("%#v", room))
+	assert.NotEqual(t, fmt.Sprintf("%v", room), fmt.Sprintf("%#v", room))
+	assert.Equal(t, "A damp cellar", fmt.Sprintf("%s", room))
+	assert.Equal(t, `"A damp cellar"`, fmt.Sprintf("%q", room))
+	assert.Equal(t, "%!d("+room.String()+")", fmt.Sprintf("%d", room))
+}
@@ -0,0 +1,105 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// roomTypeJSON is the JSON form of a RoomType, tagged with its Type so it can be rebuilt
+type roomTypeJSON struct {
+	Type string
+	Data json.RawMessage `json:",omitempty"`
+}
+
+// roomAlias has the fields of Room without its JSON methods
+type roomAlias Room
+
+// roomJSON replaces the Room fields encoding/json cannot handle directly
+type roomJSON struct {
+	*roomAlias
+	RoomType         *roomTypeJSON
+	DifficultTerrain []Position
+}
+
+// newRoomType returns an empty room type for a Type identifier, or nil if the identifier is unknown
+func newRoomType(typeID string) RoomType {
+	switch typeID {
+	case "combat":
+		return &CombatRoomType{}
+	case "treasure":
+		return &TreasureRoomType{}
+	case "trap":
+		return &TrapRoomType{}
+	case "puzzle":
+		return &PuzzleRoomType{}
+	case "ambush":
+		return &AmbushRoomType{}
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+// The room type is stored with its Type identifier, and difficult terrain is stored as a list of positions
+func (r Room) MarshalJSON() ([]byte, error) {
+	aux := roomJSON{roomAlias: (*roomAlias)(&r)}
+
+	if r.RoomType != nil {
+		data, err := json.Marshal(r.RoomType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal room type %s: %w", r.RoomType.Type(), err)
+		}
+		aux.RoomType = &roomTypeJSON{Type: r.RoomType.Type(), Data: data}
+	}
+
+	if r.DifficultTerrain != nil {
+		aux.DifficultTerrain = make([]Position, 0, len(r.DifficultTerrain))
+		for pos, difficult := range r.DifficultTerrain {
+			if difficult {
+				aux.DifficultTerrain = append(aux.DifficultTerrain, pos)
+			}
+		}
+		sort.Slice(aux.DifficultTerrain, func(i, j int) bool {
+			a, b := aux.DifficultTerrain[i], aux.DifficultTerrain[j]
+			if a.Y != b.Y {
+				return a.Y < b.Y
+			}
+			return a.X < b.X
+		})
+	}
+
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+// Returns an error if the room type is not one of the room types in this package
+func (r *Room) UnmarshalJSON(data []byte) error {
+	aux := roomJSON{roomAlias: (*roomAlias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	r.RoomType = nil
+	if aux.RoomType != nil {
+		roomType := newRoomType(aux.RoomType.Type)
+		if roomType == nil {
+			return fmt.Errorf("unknown room type: %s", aux.RoomType.Type)
+		}
+		if len(aux.RoomType.Data) > 0 {
+			if err := json.Unmarshal(aux.RoomType.Data, roomType); err != nil {
+				return fmt.Errorf("failed to unmarshal room type %s: %w", aux.RoomType.Type, err)
+			}
+		}
+		r.RoomType = roomType
+	}
+
+	r.DifficultTerrain = nil
+	if aux.DifficultTerrain != nil {
+		r.DifficultTerrain = make(map[Position]bool, len(aux.DifficultTerrain))
+		for _, pos := range aux.DifficultTerrain {
+			r.DifficultTerrain[pos] = true
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// createValidationRoom creates a 4x3 gridded room with a monster at (1,1)
+func createValidationRoom() *Room {
+	room := &Room{Width: 4, Height: 3, Grid: make([][]Cell, 3)}
+	for y := range room.Grid {
+		room.Grid[y] = make([]Cell, 4)
+	}
+	room.Grid[1][1] = Cell{Type: CellMonster, EntityID: "goblin"}
+	return room
+}
+
+func TestIsPositionValid(t *testing.T) {
+	room := createValidationRoom()
+
+	assert.True(t, IsPositionValid(room, Position{X: 0, Y: 0}))
+	assert.True(t, IsPositionValid(room, Position{X: 3, Y: 2}))
+	assert.True(t, IsPositionValid(room, Position{X: 1, Y: 1}), "occupation is not checked")
+	assert.False(t, IsPositionValid(room, Position{X: -1, Y: 0}))
+	assert.False(t, IsPositionValid(room, Position{X: 0, Y: -1}))
+	assert.False(t, IsPositionValid(room, Position{X: 4, Y: 0}))
+	assert.False(t, IsPositionValid(room, Position{X: 0, Y: 3}))
+	assert.False(t, IsPositionValid(nil, Position{}))
+}
+
+func TestIsPositionEmpty(t *testing.T) {
+	room := createValidationRoom()
+
+	empty, err := IsPositionEmpty(room, Position{X: 0, Y: 0})
+	assert.NoError(t, err)
+	assert.True(t, empty)
+
+	empty, err = IsPositionEmpty(room, Position{X: 1, Y: 1})
+	assert.NoError(t, err)
+	assert.False(t, empty)
+
+	_, err = IsPositionEmpty(room, Position{X: 5, Y: 0})
+	assert.ErrorIs(t, err, ErrInvalidPosition)
+	_, err = IsPositionEmpty(&Room{Width: 4, Height: 3}, Position{})
+	assert.ErrorIs(t, err, ErrNoGrid)
+	_, err = IsPositionEmpty(nil, Position{})
+	assert.ErrorIs(t, err, ErrNilRoom)
+}
+
+func TestValidatePosition(t *testing.T) {
+	room := createValidationRoom()
+
+	assert.NoError(t, ValidatePosition(room, Position{X: 2, Y: 2}))
+	assert.ErrorIs(t, ValidatePosition(room, Position{X: 1, Y: 1}), ErrCellOccupied)
+	assert.ErrorIs(t, ValidatePosition(room, Position{X: -1, Y: 1}), ErrInvalidPosition)
+	assert.ErrorIs(t, ValidatePosition(room, Position{X: 1, Y: 3}), ErrInvalidPosition)
+	assert.ErrorIs(t, ValidatePosition(nil, Position{}), ErrNilRoom)
+
+	gridless := &Room{Width: 4, Height: 3}
+	assert.NoError(t, ValidatePosition(gridless, Position{X: 1, Y: 1}))
+	assert.ErrorIs(t, ValidatePosition(gridless, Position{X: 4, Y: 1}), ErrInvalidPosition)
+}
+
+func TestRoomTags(t *testing.T) {
+	room := NewRoom(5, 5, LightLevelBright)
+	assert.False(t, room.HasTag("boss"))
+	_, ok := room.GetTag("boss")
+	assert.False(t, ok)
+	room.DeleteTag("boss")
+
+	room.SetTag("boss", "lich")
+	room.SetTag("explored", "")
+	value, ok := room.GetTag("boss")
+	assert.True(t, ok)
+	assert.Equal(t, "lich", value)
+	assert.True(t, room.HasTag("explored"), "empty values still count as set")
+
+	room.SetTag("boss", "dragon")
+	value, _ = room.GetTag("boss")
+	assert.Equal(t, "dragon", value)
+
+	room.DeleteTag("boss")
+	assert.False(t, room.HasTag("boss"))
+	assert.True(t, room.HasTag("explored"))
+}
@@ -28,3 +28,42 @@ func (r *TreasureRoomType) Description() string {
 func DefaultRoomType() RoomType {
 	return &CombatRoomType{}
 }
+
+// TrapRoomType represents a room guarded by traps near the entrance
+type TrapRoomType struct{}
+
+func (r *TrapRoomType) Type() string {
+	return "trap"
+}
+
+func (r *TrapRoomType) Description() string {
+	return "A room with traps near the entrance and guarded loot at the back"
+}
+
+// PuzzleRoomType represents a non-combat room built around a puzzle
+type PuzzleRoomType struct {
+	Puzzle      string // Description of the puzzle presented to the players
+	SolutionKey string // Key identifying the puzzle's solution
+}
+
+func (r *PuzzleRoomType) Type() string {
+	return "puzzle"
+}
+
+func (r *PuzzleRoomType) Description() string {
+	return "A room with a puzzle guarding its reward"
+}
+
+// AmbushRoomType represents a room where hidden monsters lie in wait
+type AmbushRoomType struct {
+	AmbusherIDs []string // IDs of the monsters taking part in the ambush
+	Triggered   bool     // Whether the ambush has been sprung
+}
+
+func (r *AmbushRoomType) Type() string {
+	return "ambush"
+}
+
+func (r *AmbushRoomType) Description() string {
+	return "A room where monsters lie in wait for unsuspecting adventurers"
+}
@@ -0,0 +1,153 @@
+package entities
+
+// GetAdjacentPositions returns the in-bounds positions around pos, including diagonals, in row-major order
+// Corner and edge positions have fewer than eight neighbors. Gridless rooms use their width and height as bounds
+func GetAdjacentPositions(room *Room, pos Position) []Position {
+	if room == nil {
+		return nil
+	}
+
+	positions := []Position{}
+	for y := pos.Y - 1; y <= pos.Y+1; y++ {
+		for x := pos.X - 1; x <= pos.X+1; x++ {
+			neighbor := Position{X: x, Y: y}
+			if neighbor != pos && IsPositionValid(room, neighbor) {
+				positions = append(positions, neighbor)
+			}
+		}
+	}
+	return positions
+}
+
+// GetAdjacentCells returns the grid cells at the positions returned by GetAdjacentPositions
+// Returns nil for gridless rooms, which have no cells
+func GetAdjacentCells(room *Room, pos Position) []Cell {
+	if room == nil || room.Grid == nil {
+		return nil
+	}
+
+	positions := GetAdjacentPositions(room, pos)
+	cells := make([]Cell, 0, len(positions))
+	for _, neighbor := range positions {
+		cells = append(cells, room.Grid[neighbor.Y][neighbor.X])
+	}
+	return cells
+}
+
+// IsAdjacentTo reports whether the positions are one step apart, diagonals included
+// A position is not adjacent to itself
+func IsAdjacentTo(pos1, pos2 Position) bool {
+	return max(absInt(pos1.X-pos2.X), absInt(pos1.Y-pos2.Y)) == 1
+}
+
+// GetOccupiedCells returns the kind of entity or wall in every occupied cell of the room
+// Gridded rooms report their non-empty grid cells. Gridless rooms derive the cells from the entity slices,
+// counting every cell an obstacle covers and skipping positions outside the room; if entities share a cell,
+// the first in the order monsters, players, items, NPCs, obstacles, traps, doors is reported
+func GetOccupiedCells(room *Room) map[Position]CellType {
+	if room == nil {
+		return nil
+	}
+
+	occupied := map[Position]CellType{}
+	if room.Grid != nil {
+		for y, row := range room.Grid {
+			for x, cell := range row {
+				if cell.Type != CellTypeEmpty {
+					occupied[Position{X: x, Y: y}] = cell.Type
+				}
+			}
+		}
+		return occupied
+	}
+
+	claim := func(pos Position, cellType CellType) {
+		if _, taken := occupied[pos]; !taken && IsPositionValid(room, pos) {
+			occupied[pos] = cellType
+		}
+	}
+	for _, monster := range room.Monsters {
+		claim(monster.Position, CellMonster)
+	}
+	for _, player := range room.Players {
+		claim(player.Position, CellPlayer)
+	}
+	for _, item := range room.Items {
+		claim(item.Position, CellItem)
+	}
+	for _, npc := range room.NPCs {
+		claim(npc.Position, CellNPC)
+	}
+	for _, obstacle := range room.Obstacles {
+		for _, pos := range obstacle.Cells() {
+			claim(pos, CellObstacle)
+		}
+	}
+	for _, trap := range room.Traps {
+		claim(trap.Position, CellTrap)
+	}
+	for _, door := range room.Doors {
+		claim(door.Position, CellDoor)
+	}
+	return occupied
+}
+
+// GetEmptyCells returns every position in the room that GetOccupiedCells does not report, in row-major order
+func GetEmptyCells(room *Room) []Position {
+	if room == nil {
+		return nil
+	}
+
+	empty := []Position{}
+	if room.Grid != nil {
+		for y, row := range room.Grid {
+			for x, cell := range row {
+				if cell.Type == CellTypeEmpty {
+					empty = append(empty, Position{X: x, Y: y})
+				}
+			}
+		}
+		return empty
+	}
+
+	occupied := GetOccupiedCells(room)
+	for y := 0; y < room.Height; y++ {
+		for x := 0; x < room.Width; x++ {
+			if pos := (Position{X: x, Y: y}); occupied[pos] == CellTypeEmpty {
+				empty = append(empty, pos)
+			}
+		}
+	}
+	return empty
+}
+
+// TreasureValue returns the sum of Item.Value over the room's items and every NPC's inventory
+// Values are added as listed regardless of ValueUnit; use TreasureValueGP for a total in gold
+func TreasureValue(room *Room) int {
+	total := 0
+	forEachTreasure(room, func(item *Item) { total += item.Value })
+	return total
+}
+
+// TreasureValueGP returns the gold value of the room's items and every NPC's inventory
+// Each item is converted with Item.GoldValue, so silver and copper count as fractions of a gold piece
+func TreasureValueGP(room *Room) float64 {
+	total := 0.0
+	forEachTreasure(room, func(item *Item) { total += item.GoldValue() })
+	return total
+}
+
+// forEachTreasure calls fn for each of the room's items and then each item carried by an NPC
+func forEachTreasure(room *Room, fn func(*Item)) {
+	if room == nil {
+		return
+	}
+	for i := range room.Items {
+		fn(&room.Items[i])
+	}
+	for i := range room.NPCs {
+		for j := range room.NPCs[i].Inventory {
+			fn(&room.NPCs[i].Inventory[j])
+		}
+	}
+}
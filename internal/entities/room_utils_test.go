@@ -0,0 +1,132 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAdjacentPositions(t *testing.T) {
+	room := createValidationRoom()
+
+	testCases := []struct {
+		name     string
+		pos      Position
+		expected []Position
+	}{
+		{
+			name:     "Corner",
+			pos:      Position{X: 0, Y: 0},
+			expected: []Position{{X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}},
+		},
+		{
+			name:     "Opposite corner",
+			pos:      Position{X: 3, Y: 2},
+			expected: []Position{{X: 2, Y: 1}, {X: 3, Y: 1}, {X: 2, Y: 2}},
+		},
+		{
+			name:     "Edge",
+			pos:      Position{X: 1, Y: 0},
+			expected: []Position{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 2, Y: 1}},
+		},
+		{
+			name: "Interior",
+			pos:  Position{X: 1, Y: 1},
+			expected: []Position{
+				{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0},
+				{X: 0, Y: 1}, {X: 2, Y: 1},
+				{X: 0, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 2},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, GetAdjacentPositions(room, tc.pos))
+		})
+	}
+
+	gridless := &Room{Width: 4, Height: 3}
+	assert.Len(t, GetAdjacentPositions(gridless, Position{X: 0, Y: 0}), 3)
+	assert.Nil(t, GetAdjacentPositions(nil, Position{}))
+}
+
+func TestGetAdjacentCells(t *testing.T) {
+	room := createValidationRoom()
+
+	cells := GetAdjacentCells(room, Position{X: 0, Y: 0})
+	assert.Equal(t, []Cell{{}, {}, {Type: CellMonster, EntityID: "goblin"}}, cells)
+	assert.Len(t, GetAdjacentCells(room, Position{X: 2, Y: 1}), 8)
+
+	assert.Nil(t, GetAdjacentCells(&Room{Width: 4, Height: 3}, Position{X: 1, Y: 1}), "gridless rooms have no cells")
+	assert.Nil(t, GetAdjacentCells(nil, Position{}))
+}
+
+func TestIsAdjacentTo(t *testing.T) {
+	origin := Position{X: 2, Y: 2}
+
+	assert.True(t, IsAdjacentTo(origin, Position{X: 3, Y: 2}))
+	assert.True(t, IsAdjacentTo(origin, Position{X: 1, Y: 1}), "diagonals are adjacent")
+	assert.False(t, IsAdjacentTo(origin, origin))
+	assert.False(t, IsAdjacentTo(origin, Position{X: 4, Y: 2}))
+	assert.False(t, IsAdjacentTo(origin, Position{X: 0, Y: 3}))
+}
+
+func TestGetOccupiedAndEmptyCells(t *testing.T) {
+	t.Run("Grid", func(t *testing.T) {
+		room := createValidationRoom()
+		room.Grid[0][3] = Cell{Type: CellWall}
+
+		assert.Equal(t, map[Position]CellType{{X: 1, Y: 1}: CellMonster, {X: 3, Y: 0}: CellWall}, GetOccupiedCells(room))
+		empty := GetEmptyCells(room)
+		assert.Len(t, empty, 10)
+		assert.Equal(t, Position{X: 0, Y: 0}, empty[0])
+		assert.NotContains(t, empty, Position{X: 1, Y: 1})
+		assert.Equal(t, 2, room.CapacityUsed())
+		assert.Equal(t, 10, room.CapacityRemaining())
+	})
+
+	t.Run("Gridless", func(t *testing.T) {
+		room := &Room{
+			Width:     4,
+			Height:    3,
+			Monsters:  []Monster{{ID: "goblin", Position: Position{X: 1, Y: 1}}},
+			Players:   []Player{{ID: "hero", Position: Position{X: 1, Y: 1}}, {ID: "lost", Position: Position{X: 9, Y: 9}}},
+			Obstacles: []Obstacle{{ID: "table", Position: Position{X: 2, Y: 2}, Size: Size{Width: 2, Height: 1}}},
+		}
+
+		expected := map[Position]CellType{{X: 1, Y: 1}: CellMonster, {X: 2, Y: 2}: CellObstacle, {X: 3, Y: 2}: CellObstacle}
+		assert.Equal(t, expected, GetOccupiedCells(room), "shared and out-of-bounds positions are counted once or not at all")
+		empty := GetEmptyCells(room)
+		assert.Len(t, empty, 9)
+		assert.NotContains(t, empty, Position{X: 3, Y: 2})
+		assert.Equal(t, 3, room.CapacityUsed())
+		assert.Equal(t, 9, room.CapacityRemaining())
+	})
+
+	t.Run("Nil room", func(t *testing.T) {
+		assert.Nil(t, GetOccupiedCells(nil))
+		assert.Nil(t, GetEmptyCells(nil))
+	})
+}
+
+func TestTreasureValue(t *testing.T) {
+	room := NewRoom(5, 5, LightLevelBright)
+	assert.Equal(t, 0, TreasureValue(room))
+	assert.Equal(t, 0.0, TreasureValueGP(room))
+	assert.Equal(t, 0, TreasureValue(nil))
+	assert.Equal(t, 0.0, TreasureValueGP(nil))
+
+	room.Items = []Item{
+		{ID: "longsword", Value: 15, ValueUnit: "gp"},
+		{ID: "torch", Value: 1, ValueUnit: "cp"},
+		{ID: "gem", Value: 50}, // No unit counts as gold
+	}
+	room.NPCs = []NPC{
+		{ID: "merchant", Inventory: []Item{{ID: "rope", Value: 5, ValueUnit: "sp"}, {ID: "crown", Value: 2, ValueUnit: "pp"}}},
+		{ID: "guard"},
+	}
+
+	assert.Equal(t, 73, TreasureValue(room))
+	assert.InDelta(t, 85.51, TreasureValueGP(room), 1e-9)
+}
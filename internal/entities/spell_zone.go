@@ -0,0 +1,59 @@
+package entities
+
+// Spell area of effect shapes, matching the D&D 5e API's area_of_effect types
+const (
+	AreaSphere   = "sphere"
+	AreaCone     = "cone"
+	AreaCube     = "cube"
+	AreaCylinder = "cylinder"
+	AreaLine     = "line"
+)
+
+// SpellZone is the area covered by a spell's effect
+// Zones overlay the grid rather than occupying a cell, so they can cover creatures and obstacles
+type SpellZone struct {
+	ID                string     // UUID for this zone instance
+	SpellKey          string     // Reference key of the spell in the API
+	Name              string     // Name of the spell
+	Shape             string     // One of the Area constants
+	SizeFt            int        // Radius, side, or length of the area in feet, depending on the shape
+	Origin            Position   // Caster position that cones and lines extend from
+	Position          Position   // Point the spell was targeted at
+	AffectedPositions []Position // Cells covered by the spell
+}
+
+// GetID implements Placeable for SpellZone
+func (z *SpellZone) GetID() string {
+	return z.ID
+}
+
+// GetPosition implements Placeable for SpellZone
+func (z *SpellZone) GetPosition() Position {
+	return z.Position
+}
+
+// SetPosition implements Placeable for SpellZone
+// The origin and affected positions move with the target point so the zone keeps its shape
+func (z *SpellZone) SetPosition(pos Position) {
+	delta := pos.Sub(z.Position)
+	z.Origin = z.Origin.Add(delta)
+	for i := range z.AffectedPositions {
+		z.AffectedPositions[i] = z.AffectedPositions[i].Add(delta)
+	}
+	z.Position = pos
+}
+
+// GetCellType implements Placeable for SpellZone
+func (z *SpellZone) GetCellType() CellType {
+	return CellSpellZone
+}
+
+// Covers reports whether the zone affects the position
+func (z *SpellZone) Covers(pos Position) bool {
+	for _, affected := range z.AffectedPositions {
+		if affected == pos {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package entities
+
+// Trap represents a hidden hazard that springs when a creature enters its cell
+type Trap struct {
+	ID          string   // Unique identifier
+	Name        string   // Descriptive name of the trap
+	Key         string   // Key for identifying the trap type
+	Position    Position // Position in the room
+	Triggered   bool     // Whether the trap has already been sprung
+	DamageDice  string   // Damage dealt when triggered, in dice notation (e.g. "2d10")
+	DamageType  string   // Type of damage dealt (e.g. "piercing")
+	SaveDC      int      // Difficulty class of the saving throw to avoid the trap
+	SaveAbility string   // Ability used for the saving throw (e.g. "dex")
+}
+
+// GetID implements Placeable for Trap
+func (t *Trap) GetID() string {
+	return t.ID
+}
+
+// GetPosition implements Placeable for Trap
+func (t *Trap) GetPosition() Position {
+	return t.Position
+}
+
+// SetPosition implements Placeable for Trap
+func (t *Trap) SetPosition(pos Position) {
+	t.Position = pos
+}
+
+// GetCellType implements Placeable for Trap
+func (t *Trap) GetCellType() CellType {
+	return CellTrap
+}
@@ -0,0 +1,13 @@
+package entities
+
+// VisibilityState is how much the players know about a cell of the room
+type VisibilityState int
+
+const (
+	// VisibilityUnseen cells have never been in view
+	VisibilityUnseen VisibilityState = iota
+	// VisibilitySeen cells were in view before but are not now
+	VisibilitySeen
+	// VisibilityVisible cells are currently in view
+	VisibilityVisible
+)
@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// valueRangeQuery identifies a GetItemsByValueRange call
+type valueRangeQuery struct {
+	minGold, maxGold, count int
+}
+
+// cachedItems is a cached lookup result and when it stops being served
+type cachedItems struct {
+	items   []*entities.Item
+	expires time.Time // Zero if the result never expires
+}
+
+// CachedItemRepository wraps an ItemRepository and remembers the items it returns for each query
+// Repeating a query returns the same items instead of a fresh random selection until the entry expires or is
+// invalidated. Lookups that fail are not cached. It is safe for concurrent use
+type CachedItemRepository struct {
+	inner ItemRepository
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu    sync.RWMutex
+	cache map[valueRangeQuery]cachedItems
+}
+
+// NewCachedItemRepository creates a cache in front of inner
+// Cached results are served for ttl after they are fetched, or until invalidated if ttl is zero or negative
+func NewCachedItemRepository(inner ItemRepository, ttl time.Duration) *CachedItemRepository {
+	return &CachedItemRepository{
+		inner: inner,
+		ttl:   ttl,
+		now:   time.Now,
+		cache: make(map[valueRangeQuery]cachedItems),
+	}
+}
+
+// GetItemsByValueRange implements ItemRepository, calling the wrapped repository only on cache misses
+// Results are keyed by the whole query, including count, and every call returns its own copies of the items
+func (r *CachedItemRepository) GetItemsByValueRange(minGold, maxGold int, count int) ([]*entities.Item, error) {
+	query := valueRangeQuery{minGold, maxGold, count}
+
+	r.mu.RLock()
+	entry, ok := r.cache[query]
+	r.mu.RUnlock()
+	if ok && (entry.expires.IsZero() || r.now().Before(entry.expires)) {
+		return copyItems(entry.items), nil
+	}
+
+	items, err := r.inner.GetItemsByValueRange(minGold, maxGold, count)
+	if err != nil {
+		return nil, err
+	}
+
+	entry = cachedItems{items: copyItems(items)}
+	if r.ttl > 0 {
+		entry.expires = r.now().Add(r.ttl)
+	}
+	r.mu.Lock()
+	r.cache[query] = entry
+	r.mu.Unlock()
+
+	return items, nil
+}
+
+// Invalidate drops every cached result that contains the item with the given key
+func (r *CachedItemRepository) Invalidate(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for query, entry := range r.cache {
+		if slices.ContainsFunc(entry.items, func(item *entities.Item) bool { return item.Key == key }) {
+			delete(r.cache, query)
+		}
+	}
+}
+
+// Flush drops every cached result
+func (r *CachedItemRepository) Flush() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cache = make(map[valueRangeQuery]cachedItems)
+}
+
+// copyItems returns copies of the items that share no memory with them
+func copyItems(items []*entities.Item) []*entities.Item {
+	copies := make([]*entities.Item, len(items))
+	for i, item := range items {
+		clone := *item
+		clone.Properties = slices.Clone(item.Properties)
+		copies[i] = &clone
+	}
+	return copies
+}
@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+var _ ItemRepository = (*CachedItemRepository)(nil)
+
+// countingItemRepository wraps a TestItemRepository, counting calls and optionally sleeping to mimic the API
+type countingItemRepository struct {
+	TestItemRepository
+	calls int
+	delay time.Duration
+}
+
+func (r *countingItemRepository) GetItemsByValueRange(minGold, maxGold int, count int) ([]*entities.Item, error) {
+	r.calls++
+	time.Sleep(r.delay)
+	return r.TestItemRepository.GetItemsByValueRange(minGold, maxGold, count)
+}
+
+// createCountingItemRepository creates an inner repository holding a dagger, a rope, and a longsword
+func createCountingItemRepository(delay time.Duration) *countingItemRepository {
+	return &countingItemRepository{delay: delay, TestItemRepository: TestItemRepository{Items: []*entities.Item{
+		{Key: "dagger", Name: "Dagger", Value: 2, ValueUnit: "gp", Properties: []string{"finesse"}},
+		{Key: "rope", Name: "Rope", Value: 1, ValueUnit: "gp"},
+		{Key: "longsword", Name: "Longsword", Value: 15, ValueUnit: "gp"},
+	}}}
+}
+
+func TestCachedItemRepository(t *testing.T) {
+	t.Run("Hits skip the inner repository", func(t *testing.T) {
+		inner := createCountingItemRepository(0)
+		repo := NewCachedItemRepository(inner, 0)
+
+		first, err := repo.GetItemsByValueRange(0, 5, 2)
+		require.NoError(t, err)
+		second, err := repo.GetItemsByValueRange(0, 5, 2)
+		require.NoError(t, err)
+		assert.Equal(t, first, second)
+		assert.Equal(t, 1, inner.calls)
+
+		// Any difference in the query, including the count, is a miss
+		_, _ = repo.GetItemsByValueRange(0, 5, 1)
+		_, _ = repo.GetItemsByValueRange(0, 20, 2)
+		assert.Equal(t, 3, inner.calls)
+	})
+
+	t.Run("Cached items are copies", func(t *testing.T) {
+		repo := NewCachedItemRepository(createCountingItemRepository(0), 0)
+		first, _ := repo.GetItemsByValueRange(0, 5, 1)
+		first[0].Name = "Stolen"
+		first[0].Properties[0] = "heavy"
+
+		second, _ := repo.GetItemsByValueRange(0, 5, 1)
+		assert.Equal(t, "Dagger", second[0].Name)
+		assert.Equal(t, []string{"finesse"}, second[0].Properties)
+	})
+
+	t.Run("Errors are not cached", func(t *testing.T) {
+		inner := createCountingItemRepository(0)
+		repo := NewCachedItemRepository(inner, 0)
+		_, err := repo.GetItemsByValueRange(5, 1, 1)
+		assert.Error(t, err)
+		_, err = repo.GetItemsByValueRange(5, 1, 1)
+		assert.Error(t, err)
+		assert.Equal(t, 2, inner.calls)
+	})
+
+	t.Run("Invalidate and Flush", func(t *testing.T) {
+		inner := createCountingItemRepository(0)
+		repo := NewCachedItemRepository(inner, 0)
+		_, _ = repo.GetItemsByValueRange(0, 1, 5)   // rope
+		_, _ = repo.GetItemsByValueRange(10, 20, 5) // longsword
+
+		repo.Invalidate("rope")
+		_, _ = repo.GetItemsByValueRange(0, 1, 5)
+		_, _ = repo.GetItemsByValueRange(10, 20, 5)
+		assert.Equal(t, 3, inner.calls)
+
+		repo.Flush()
+		_, _ = repo.GetItemsByValueRange(0, 1, 5)
+		_, _ = repo.GetItemsByValueRange(10, 20, 5)
+		assert.Equal(t, 5, inner.calls)
+	})
+
+	t.Run("Entries expire after the TTL", func(t *testing.T) {
+		inner := createCountingItemRepository(0)
+		repo := NewCachedItemRepository(inner, time.Minute)
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		repo.now = func() time.Time { return now }
+
+		_, _ = repo.GetItemsByValueRange(0, 5, 1)
+		now = now.Add(59 * time.Second)
+		_, _ = repo.GetItemsByValueRange(0, 5, 1)
+		assert.Equal(t, 1, inner.calls)
+
+		now = now.Add(time.Second)
+		_, _ = repo.GetItemsByValueRange(0, 5, 1)
+		assert.Equal(t, 2, inner.calls)
+	})
+}
+
+// BenchmarkItemLookup compares repeated lookups against an inner repository that sleeps 100µs per call
+// to stand in for an API round trip, with and without the cache
+func BenchmarkItemLookup(b *testing.B) {
+	b.Run("uncached", func(b *testing.B) {
+		repo := createCountingItemRepository(100 * time.Microsecond)
+		for i := 0; i < b.N; i++ {
+			if _, err := repo.GetItemsByValueRange(0, 20, 3); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		repo := NewCachedItemRepository(createCountingItemRepository(100*time.Microsecond), 0)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := repo.GetItemsByValueRange(0, 20, 3); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"sync"
+	"time"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// cachedXP is a cached XP value and when it stops being served
+type cachedXP struct {
+	xp      int
+	expires time.Time // Zero if the value never expires
+}
+
+// CachedMonsterRepository wraps a MonsterRepository and remembers the XP values it returns
+// Lookups that fail are not cached. Listing is forwarded to the wrapped repository uncached.
+// It is safe for concurrent use
+type CachedMonsterRepository struct {
+	inner MonsterRepository
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu    sync.RWMutex
+	cache map[string]cachedXP
+}
+
+// NewCachedMonsterRepository creates a cache in front of inner
+// Cached values are served for ttl after they are fetched, or until invalidated if ttl is zero or negative
+func NewCachedMonsterRepository(inner MonsterRepository, ttl time.Duration) *CachedMonsterRepository {
+	return &CachedMonsterRepository{
+		inner: inner,
+		ttl:   ttl,
+		now:   time.Now,
+		cache: make(map[string]cachedXP),
+	}
+}
+
+// GetMonsterXP implements MonsterRepository, calling the wrapped repository only on cache misses
+func (r *CachedMonsterRepository) GetMonsterXP(key string) (int, error) {
+	r.mu.RLock()
+	entry, ok := r.cache[key]
+	r.mu.RUnlock()
+	if ok && (entry.expires.IsZero() || r.now().Before(entry.expires)) {
+		return entry.xp, nil
+	}
+
+	xp, err := r.inner.GetMonsterXP(key)
+	if err != nil {
+		return 0, err
+	}
+
+	entry = cachedXP{xp: xp}
+	if r.ttl > 0 {
+		entry.expires = r.now().Add(r.ttl)
+	}
+	r.mu.Lock()
+	r.cache[key] = entry
+	r.mu.Unlock()
+
+	return xp, nil
+}
+
+// Invalidate drops the cached XP value for a monster so the next lookup fetches it again
+func (r *CachedMonsterRepository) Invalidate(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.cache, key)
+}
+
+// InvalidateAll drops every cached XP value
+func (r *CachedMonsterRepository) InvalidateAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cache = make(map[string]cachedXP)
+}
+
+// ListMonstersByCRRange implements MonsterLister by forwarding to the wrapped repository
+// Returns ErrListingNotSupported if the wrapped repository does not implement MonsterLister
+func (r *CachedMonsterRepository) ListMonstersByCRRange(minCR, maxCR float64) ([]*entities.Monster, error) {
+	lister, ok := r.inner.(MonsterLister)
+	if !ok {
+		return nil, ErrListingNotSupported
+	}
+	return lister.ListMonstersByCRRange(minCR, maxCR)
+}
+
+// ListMonstersByFilter implements MonsterFilterLister by forwarding to the wrapped repository
+// Returns ErrListingNotSupported if the wrapped repository does not implement MonsterFilterLister
+func (r *CachedMonsterRepository) ListMonstersByFilter(filter MonsterFilter) ([]*entities.Monster, error) {
+	lister, ok := r.inner.(MonsterFilterLister)
+	if !ok {
+		return nil, ErrListingNotSupported
+	}
+	return lister.ListMonstersByFilter(filter)
+}
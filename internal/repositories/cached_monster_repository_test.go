@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+var (
+	_ MonsterRepository   = (*CachedMonsterRepository)(nil)
+	_ MonsterLister       = (*CachedMonsterRepository)(nil)
+	_ MonsterFilterLister = (*CachedMonsterRepository)(nil)
+)
+
+// countingMonsterRepository serves fixed XP values and counts lookups by key
+type countingMonsterRepository struct {
+	mu    sync.Mutex
+	xp    map[string]int
+	calls map[string]int
+}
+
+func newCountingMonsterRepository(xp map[string]int) *countingMonsterRepository {
+	return &countingMonsterRepository{xp: xp, calls: map[string]int{}}
+}
+
+func (r *countingMonsterRepository) GetMonsterXP(key string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls[key]++
+	xp, ok := r.xp[key]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrMonsterNotFound, key)
+	}
+	return xp, nil
+}
+
+func TestCachedMonsterRepository(t *testing.T) {
+	t.Run("Hits skip the inner repository", func(t *testing.T) {
+		inner := newCountingMonsterRepository(map[string]int{"goblin": 50, "ogre": 450})
+		repo := NewCachedMonsterRepository(inner, 0)
+
+		for i := 0; i < 3; i++ {
+			xp, err := repo.GetMonsterXP("goblin")
+			require.NoError(t, err)
+			assert.Equal(t, 50, xp)
+		}
+		xp, err := repo.GetMonsterXP("ogre")
+		require.NoError(t, err)
+		assert.Equal(t, 450, xp)
+
+		assert.Equal(t, map[string]int{"goblin": 1, "ogre": 1}, inner.calls)
+	})
+
+	t.Run("Errors are not cached", func(t *testing.T) {
+		inner := newCountingMonsterRepository(map[string]int{})
+		repo := NewCachedMonsterRepository(inner, 0)
+
+		_, err := repo.GetMonsterXP("tarrasque")
+		assert.ErrorIs(t, err, ErrMonsterNotFound)
+		inner.xp["tarrasque"] = 155000
+		xp, err := repo.GetMonsterXP("tarrasque")
+		require.NoError(t, err)
+		assert.Equal(t, 155000, xp)
+		assert.Equal(t, 2, inner.calls["tarrasque"])
+	})
+
+	t.Run("Invalidate", func(t *testing.T) {
+		inner := newCountingMonsterRepository(map[string]int{"goblin": 50, "ogre": 450})
+		repo := NewCachedMonsterRepository(inner, 0)
+		_, _ = repo.GetMonsterXP("goblin")
+		_, _ = repo.GetMonsterXP("ogre")
+
+		inner.xp["goblin"] = 100
+		repo.Invalidate("goblin")
+		xp, _ := repo.GetMonsterXP("goblin")
+		assert.Equal(t, 100, xp)
+		_, _ = repo.GetMonsterXP("ogre")
+		assert.Equal(t, map[string]int{"goblin": 2, "ogre": 1}, inner.calls)
+
+		repo.InvalidateAll()
+		_, _ = repo.GetMonsterXP("goblin")
+		_, _ = repo.GetMonsterXP("ogre")
+		assert.Equal(t, map[string]int{"goblin": 3, "ogre": 2}, inner.calls)
+	})
+
+	t.Run("Entries expire after the TTL", func(t *testing.T) {
+		inner := newCountingMonsterRepository(map[string]int{"goblin": 50})
+		repo := NewCachedMonsterRepository(inner, time.Minute)
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		repo.now = func() time.Time { return now }
+
+		_, _ = repo.GetMonsterXP("goblin")
+		now = now.Add(59 * time.Second)
+		_, _ = repo.GetMonsterXP("goblin")
+		assert.Equal(t, 1, inner.calls["goblin"])
+
+		now = now.Add(time.Second)
+		_, _ = repo.GetMonsterXP("goblin")
+		assert.Equal(t, 2, inner.calls["goblin"])
+	})
+
+	t.Run("Concurrent lookups", func(t *testing.T) {
+		inner := newCountingMonsterRepository(map[string]int{"goblin": 50})
+		repo := NewCachedMonsterRepository(inner, 0)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				xp, err := repo.GetMonsterXP("goblin")
+				assert.NoError(t, err)
+				assert.Equal(t, 50, xp)
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestCachedMonsterRepositoryListing(t *testing.T) {
+	t.Run("Listing is forwarded", func(t *testing.T) {
+		inner := NewInMemoryMonsterRepositoryFromSummaries([]MonsterSummary{
+			{Key: "wolf", Name: "Wolf", CR: 0.25, XP: 50, Environments: []string{entities.EnvironmentForest}},
+			{Key: "ogre", Name: "Ogre", CR: 2, XP: 450, Environments: []string{entities.EnvironmentHill}},
+		})
+		repo := NewCachedMonsterRepository(inner, 0)
+
+		monsters, err := repo.ListMonstersByCRRange(0, 1)
+		require.NoError(t, err)
+		require.Len(t, monsters, 1)
+		assert.Equal(t, "wolf", monsters[0].Key)
+
+		monsters, err = repo.ListMonstersByFilter(MonsterFilter{MinCR: 0, MaxCR: 5, Environments: []string{entities.EnvironmentHill}})
+		require.NoError(t, err)
+		require.Len(t, monsters, 1)
+		assert.Equal(t, "ogre", monsters[0].Key)
+	})
+
+	t.Run("Inner repository cannot list", func(t *testing.T) {
+		repo := NewCachedMonsterRepository(newCountingMonsterRepository(map[string]int{}), 0)
+
+		_, err := repo.ListMonstersByCRRange(0, 1)
+		assert.ErrorIs(t, err, ErrListingNotSupported)
+		_, err = repo.ListMonstersByFilter(MonsterFilter{MaxCR: 1})
+		assert.ErrorIs(t, err, ErrListingNotSupported)
+	})
+}
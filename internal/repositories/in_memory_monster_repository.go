@@ -0,0 +1,166 @@
+package repositories
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/crutil"
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// srdXPTable is the SRD monster table, embedded so the repository works without the source tree
+//
+//go:embed data/srd_xp_table.json
+var srdXPTable []byte
+
+// MonsterSummary is the reference data InMemoryMonsterRepository holds for a monster
+type MonsterSummary struct {
+	Key  string  `json:"key"`
+	Name string  `json:"name"`
+	CR   float64 `json:"cr"`
+	XP   int     `json:"xp"`
+
+	Type         string   `json:"type,omitempty"`         // Creature type, such as "undead" (optional)
+	Environments []string `json:"environments,omitempty"` // Environments the monster lives in (optional)
+}
+
+// InMemoryMonsterRepository serves monster reference data from memory without calling the API
+type InMemoryMonsterRepository struct {
+	monsters map[string]MonsterSummary
+}
+
+// NewInMemoryMonsterRepository creates a repository seeded with every monster in the D&D 5e SRD
+// The monsters come from data/srd_xp_table.json, which is embedded in the binary
+func NewInMemoryMonsterRepository() (*InMemoryMonsterRepository, error) {
+	var summaries []MonsterSummary
+	if err := json.Unmarshal(srdXPTable, &summaries); err != nil {
+		return nil, fmt.Errorf("failed to parse the SRD monster table: %w", err)
+	}
+
+	repo := &InMemoryMonsterRepository{monsters: make(map[string]MonsterSummary, len(summaries))}
+	for _, summary := range summaries {
+		repo.monsters[summary.Key] = summary
+	}
+	return repo, nil
+}
+
+// NewInMemoryMonsterRepositoryFromMap creates a repository holding the given XP values by monster key
+// Each monster's CR is the highest official CR worth its XP, and its name is its key
+func NewInMemoryMonsterRepositoryFromMap(xpValues map[string]int) *InMemoryMonsterRepository {
+	repo := &InMemoryMonsterRepository{monsters: make(map[string]MonsterSummary, len(xpValues))}
+	for key, xp := range xpValues {
+		repo.monsters[key] = MonsterSummary{Key: key, Name: key, CR: crForXP(xp), XP: xp}
+	}
+	return repo
+}
+
+// NewInMemoryMonsterRepositoryFromSummaries creates a repository holding the given monsters
+// Later summaries replace earlier ones with the same key
+func NewInMemoryMonsterRepositoryFromSummaries(summaries []MonsterSummary) *InMemoryMonsterRepository {
+	repo := &InMemoryMonsterRepository{monsters: make(map[string]MonsterSummary, len(summaries))}
+	for _, summary := range summaries {
+		repo.monsters[summary.Key] = summary
+	}
+	return repo
+}
+
+// GetMonsterXP implements MonsterRepository, returning ErrMonsterNotFound for unknown keys
+func (r *InMemoryMonsterRepository) GetMonsterXP(key string) (int, error) {
+	summary, ok := r.monsters[key]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrMonsterNotFound, key)
+	}
+	return summary.XP, nil
+}
+
+// ListMonsterSummaries returns the monsters whose CR lies within the inclusive range, sorted by CR and then key
+func (r *InMemoryMonsterRepository) ListMonsterSummaries(minCR, maxCR float64) ([]MonsterSummary, error) {
+	if minCR > maxCR {
+		return nil, fmt.Errorf("minimum CR %g is greater than maximum CR %g", minCR, maxCR)
+	}
+
+	summaries := []MonsterSummary{}
+	for _, summary := range r.monsters {
+		if summary.CR >= minCR && summary.CR <= maxCR {
+			summaries = append(summaries, summary)
+		}
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].CR != summaries[j].CR {
+			return summaries[i].CR < summaries[j].CR
+		}
+		return summaries[i].Key < summaries[j].Key
+	})
+
+	return summaries, nil
+}
+
+// ListMonstersByCRRange implements MonsterLister using ListMonsterSummaries
+func (r *InMemoryMonsterRepository) ListMonstersByCRRange(minCR, maxCR float64) ([]*entities.Monster, error) {
+	summaries, err := r.ListMonsterSummaries(minCR, maxCR)
+	if err != nil {
+		return nil, err
+	}
+
+	return summariesToMonsters(summaries), nil
+}
+
+// ListMonstersByFilter implements MonsterFilterLister using ListMonsterSummaries
+// Monsters without environments are treated as living anywhere, but filtering by environment returns
+// ErrNoEnvironmentData if no monster in the repository has any, as with the SRD table. A type filter only
+// matches monsters whose type is known
+func (r *InMemoryMonsterRepository) ListMonstersByFilter(filter MonsterFilter) ([]*entities.Monster, error) {
+	summaries, err := r.ListMonsterSummaries(filter.MinCR, filter.MaxCR)
+	if err != nil {
+		return nil, err
+	}
+	if len(filter.Environments) > 0 && !r.hasEnvironmentData() {
+		return nil, ErrNoEnvironmentData
+	}
+
+	matches := []MonsterSummary{}
+	for _, summary := range summaries {
+		if len(filter.Types) > 0 && !slices.Contains(filter.Types, summary.Type) {
+			continue
+		}
+		if len(filter.Environments) > 0 && len(summary.Environments) > 0 &&
+			!slices.ContainsFunc(summary.Environments, func(env string) bool { return slices.Contains(filter.Environments, env) }) {
+			continue
+		}
+		matches = append(matches, summary)
+	}
+	return summariesToMonsters(matches), nil
+}
+
+// hasEnvironmentData reports whether any monster in the repository lists an environment
+func (r *InMemoryMonsterRepository) hasEnvironmentData() bool {
+	for _, summary := range r.monsters {
+		if len(summary.Environments) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// summariesToMonsters converts summaries to template monsters with no ID or position
+func summariesToMonsters(summaries []MonsterSummary) []*entities.Monster {
+	monsters := make([]*entities.Monster, len(summaries))
+	for i, summary := range summaries {
+		monsters[i] = &entities.Monster{Key: summary.Key, Name: summary.Name, CR: summary.CR, XP: summary.XP, Type: summary.Type}
+	}
+	return monsters
+}
+
+// crForXP returns the highest official CR whose XP value does not exceed xp
+func crForXP(xp int) float64 {
+	cr := 0.0
+	for _, official := range crutil.OfficialCRs() {
+		if crutil.CRToXP(official) <= xp {
+			cr = official
+		}
+	}
+	return cr
+}
@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/crutil"
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+var _ MonsterRepository = (*InMemoryMonsterRepository)(nil)
+var _ MonsterLister = (*InMemoryMonsterRepository)(nil)
+var _ MonsterFilterLister = (*InMemoryMonsterRepository)(nil)
+
+func TestNewInMemoryMonsterRepository(t *testing.T) {
+	repo, err := NewInMemoryMonsterRepository()
+	require.NoError(t, err)
+	assert.Len(t, repo.monsters, 334)
+
+	testCases := []struct {
+		key string
+		xp  int
+	}{
+		{"ancient-red-dragon", 62000},
+		{"goblin", 50},
+		{"tarrasque", 155000},
+		{"commoner", 10},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			xp, err := repo.GetMonsterXP(tc.key)
+			require.NoError(t, err)
+			assert.Equal(t, tc.xp, xp)
+		})
+	}
+
+	_, err = repo.GetMonsterXP("beholder")
+	assert.ErrorIs(t, err, ErrMonsterNotFound)
+
+	for _, summary := range repo.monsters {
+		assert.Equal(t, crutil.CRToXP(summary.CR), summary.XP, summary.Key)
+		assert.NotEmpty(t, summary.Type, summary.Key)
+	}
+}
+
+func TestListMonstersByFilterSRD(t *testing.T) {
+	repo, err := NewInMemoryMonsterRepository()
+	require.NoError(t, err)
+
+	undead, err := repo.ListMonstersByFilter(MonsterFilter{MinCR: 0, MaxCR: 1, Types: []string{"undead"}})
+	require.NoError(t, err)
+	require.NotEmpty(t, undead)
+	keys := []string{}
+	for _, monster := range undead {
+		assert.Equal(t, "undead", monster.Type, monster.Key)
+		keys = append(keys, monster.Key)
+	}
+	assert.Contains(t, keys, "zombie")
+	assert.NotContains(t, keys, "goblin")
+
+	// The SRD table has no environment data, so an environment filter cannot be honored
+	_, err = repo.ListMonstersByFilter(MonsterFilter{MinCR: 0, MaxCR: 1, Environments: []string{entities.EnvironmentForest}})
+	assert.ErrorIs(t, err, ErrNoEnvironmentData)
+}
+
+func TestListMonsterSummaries(t *testing.T) {
+	repo, err := NewInMemoryMonsterRepository()
+	require.NoError(t, err)
+
+	summaries, err := repo.ListMonsterSummaries(0, 1)
+	require.NoError(t, err)
+	require.NotEmpty(t, summaries)
+
+	seenCRs := map[float64]bool{}
+	for _, summary := range summaries {
+		assert.Contains(t, []float64{0, 0.125, 0.25, 0.5, 1}, summary.CR, summary.Key)
+		seenCRs[summary.CR] = true
+	}
+	assert.Len(t, seenCRs, 5)
+	assert.Equal(t, 0.0, summaries[0].CR, "sorted by CR")
+	assert.Equal(t, 1.0, summaries[len(summaries)-1].CR)
+
+	_, err = repo.ListMonsterSummaries(2, 1)
+	assert.Error(t, err)
+}
+
+func TestNewInMemoryMonsterRepositoryFromMap(t *testing.T) {
+	repo := NewInMemoryMonsterRepositoryFromMap(map[string]int{"goblin": 50, "ogre": 450})
+
+	xp, err := repo.GetMonsterXP("ogre")
+	require.NoError(t, err)
+	assert.Equal(t, 450, xp)
+
+	monsters, err := repo.ListMonstersByCRRange(0, 0.5)
+	require.NoError(t, err)
+	require.Len(t, monsters, 1)
+	assert.Equal(t, "goblin", monsters[0].Key)
+	assert.Equal(t, 0.25, monsters[0].CR)
+}
+
+func TestListMonstersByFilter(t *testing.T) {
+	repo := NewInMemoryMonsterRepositoryFromSummaries([]MonsterSummary{
+		{Key: "wolf", Name: "Wolf", CR: 0.25, XP: 50, Type: "beast", Environments: []string{"forest", "grassland"}},
+		{Key: "goblin", Name: "Goblin", CR: 0.25, XP: 50, Type: "humanoid", Environments: []string{"forest", "dungeon"}},
+		{Key: "drow", Name: "Drow", CR: 0.25, XP: 50, Type: "humanoid", Environments: []string{"underdark"}},
+		{Key: "zombie", Name: "Zombie", CR: 0.25, XP: 50, Type: "undead"},
+		{Key: "ogre", Name: "Ogre", CR: 2, XP: 450, Type: "giant", Environments: []string{"forest"}},
+	})
+	keys := func(monsters []*entities.Monster) []string {
+		result := []string{}
+		for _, monster := range monsters {
+			result = append(result, monster.Key)
+		}
+		return result
+	}
+
+	testCases := []struct {
+		name     string
+		filter   MonsterFilter
+		expected []string
+	}{
+		{"CR only", MonsterFilter{MinCR: 0, MaxCR: 1}, []string{"drow", "goblin", "wolf", "zombie"}},
+		{"Environment", MonsterFilter{MinCR: 0, MaxCR: 5, Environments: []string{"forest"}}, []string{"goblin", "wolf", "zombie", "ogre"}},
+		{"Any of several environments", MonsterFilter{MinCR: 0, MaxCR: 1, Environments: []string{"underdark", "grassland"}}, []string{"drow", "wolf", "zombie"}},
+		{"Type", MonsterFilter{MinCR: 0, MaxCR: 5, Types: []string{"humanoid", "giant"}}, []string{"drow", "goblin", "ogre"}},
+		{"Environment and type", MonsterFilter{MinCR: 0, MaxCR: 1, Environments: []string{"dungeon"}, Types: []string{"humanoid", "undead"}}, []string{"goblin", "zombie"}},
+		{"No matches", MonsterFilter{MinCR: 0, MaxCR: 5, Types: []string{"dragon"}}, []string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			monsters, err := repo.ListMonstersByFilter(tc.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, keys(monsters))
+		})
+	}
+
+	_, err := repo.ListMonstersByFilter(MonsterFilter{MinCR: 2, MaxCR: 1})
+	assert.Error(t, err)
+}
@@ -0,0 +1,224 @@
+package repositories
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// ItemRepository looks up reference data for items
+type ItemRepository interface {
+	// GetItemsByValueRange returns up to count template items whose gold value lies within the inclusive range
+	// Values are normalized to gold using the item's ValueUnit. The returned items have no ID or position
+	GetItemsByValueRange(minGold, maxGold int, count int) ([]*entities.Item, error)
+}
+
+// Canonical D&D 5e API equipment category keys
+const (
+	CategoryWeapon          = "weapon"
+	CategoryArmor           = "armor"
+	CategoryAdventuringGear = "adventuring-gear"
+)
+
+// ItemCategoryRepository looks up random items by kind
+// An item belongs to a category when its Category or Type is the category key
+type ItemCategoryRepository interface {
+	// GetRandomItemsByCategory returns up to count template items in the equipment category
+	GetRandomItemsByCategory(category string, count int) ([]*entities.Item, error)
+	// GetWeapons returns up to count weapons
+	GetWeapons(count int) ([]*entities.Item, error)
+	// GetArmor returns up to count pieces of armor
+	GetArmor(count int) ([]*entities.Item, error)
+	// GetAdventuringGear returns up to count pieces of adventuring gear
+	GetAdventuringGear(count int) ([]*entities.Item, error)
+	// GetMagicItems returns up to count items that have a rarity
+	GetMagicItems(count int) ([]*entities.Item, error)
+}
+
+// EquipmentLister is the part of the D&D 5e API client used by APIItemRepository
+type EquipmentLister interface {
+	// ListEquipment returns every equipment entry with its cost in Value and ValueUnit
+	ListEquipment() ([]*entities.Item, error)
+}
+
+// APIItemRepository is an ItemRepository backed by the D&D 5e API's equipment list
+type APIItemRepository struct {
+	client EquipmentLister
+}
+
+// NewAPIItemRepository creates an APIItemRepository that lists equipment with the given client
+func NewAPIItemRepository(client EquipmentLister) *APIItemRepository {
+	return &APIItemRepository{client: client}
+}
+
+// GetItemsByValueRange implements ItemRepository by choosing up to count matching equipment entries at random
+func (r *APIItemRepository) GetItemsByValueRange(minGold, maxGold int, count int) ([]*entities.Item, error) {
+	if err := validateValueRange(minGold, maxGold, count); err != nil {
+		return nil, err
+	}
+
+	equipment, err := r.client.ListEquipment()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list equipment: %w", err)
+	}
+
+	return pickRandomItems(itemsInValueRange(equipment, minGold, maxGold), count), nil
+}
+
+// GetRandomItemsByCategory implements ItemCategoryRepository by choosing up to count equipment entries in the
+// category at random
+func (r *APIItemRepository) GetRandomItemsByCategory(category string, count int) ([]*entities.Item, error) {
+	if err := validateCount(count); err != nil {
+		return nil, err
+	}
+
+	equipment, err := r.client.ListEquipment()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list equipment: %w", err)
+	}
+
+	return pickRandomItems(itemsInCategory(equipment, category), count), nil
+}
+
+// GetWeapons implements ItemCategoryRepository
+func (r *APIItemRepository) GetWeapons(count int) ([]*entities.Item, error) {
+	return r.GetRandomItemsByCategory(CategoryWeapon, count)
+}
+
+// GetArmor implements ItemCategoryRepository
+func (r *APIItemRepository) GetArmor(count int) ([]*entities.Item, error) {
+	return r.GetRandomItemsByCategory(CategoryArmor, count)
+}
+
+// GetAdventuringGear implements ItemCategoryRepository
+func (r *APIItemRepository) GetAdventuringGear(count int) ([]*entities.Item, error) {
+	return r.GetRandomItemsByCategory(CategoryAdventuringGear, count)
+}
+
+// GetMagicItems implements ItemCategoryRepository by choosing up to count equipment entries with a rarity at random
+// The equipment list is the only source, so magic items the client does not list are never returned
+func (r *APIItemRepository) GetMagicItems(count int) ([]*entities.Item, error) {
+	if err := validateCount(count); err != nil {
+		return nil, err
+	}
+
+	equipment, err := r.client.ListEquipment()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list equipment: %w", err)
+	}
+
+	return pickRandomItems(magicItems(equipment), count), nil
+}
+
+// TestItemRepository is an in-memory ItemRepository for tests
+// Matching items are returned in the order they appear in Items
+type TestItemRepository struct {
+	Items []*entities.Item
+}
+
+// GetItemsByValueRange implements ItemRepository
+func (r *TestItemRepository) GetItemsByValueRange(minGold, maxGold int, count int) ([]*entities.Item, error) {
+	if err := validateValueRange(minGold, maxGold, count); err != nil {
+		return nil, err
+	}
+
+	return firstItems(itemsInValueRange(r.Items, minGold, maxGold), count), nil
+}
+
+// GetRandomItemsByCategory implements ItemCategoryRepository
+func (r *TestItemRepository) GetRandomItemsByCategory(category string, count int) ([]*entities.Item, error) {
+	if err := validateCount(count); err != nil {
+		return nil, err
+	}
+	return firstItems(itemsInCategory(r.Items, category), count), nil
+}
+
+// GetWeapons implements ItemCategoryRepository
+func (r *TestItemRepository) GetWeapons(count int) ([]*entities.Item, error) {
+	return r.GetRandomItemsByCategory(CategoryWeapon, count)
+}
+
+// GetArmor implements ItemCategoryRepository
+func (r *TestItemRepository) GetArmor(count int) ([]*entities.Item, error) {
+	return r.GetRandomItemsByCategory(CategoryArmor, count)
+}
+
+// GetAdventuringGear implements ItemCategoryRepository
+func (r *TestItemRepository) GetAdventuringGear(count int) ([]*entities.Item, error) {
+	return r.GetRandomItemsByCategory(CategoryAdventuringGear, count)
+}
+
+// GetMagicItems implements ItemCategoryRepository
+func (r *TestItemRepository) GetMagicItems(count int) ([]*entities.Item, error) {
+	if err := validateCount(count); err != nil {
+		return nil, err
+	}
+	return firstItems(magicItems(r.Items), count), nil
+}
+
+// validateValueRange checks the arguments shared by GetItemsByValueRange implementations
+func validateValueRange(minGold, maxGold int, count int) error {
+	if minGold < 0 || minGold > maxGold {
+		return fmt.Errorf("invalid value range %d-%d", minGold, maxGold)
+	}
+	return validateCount(count)
+}
+
+// validateCount checks that a requested number of items is positive
+func validateCount(count int) error {
+	if count <= 0 {
+		return fmt.Errorf("count must be positive, got %d", count)
+	}
+	return nil
+}
+
+// itemsInValueRange returns the items whose gold value lies within the inclusive range
+func itemsInValueRange(items []*entities.Item, minGold, maxGold int) []*entities.Item {
+	matches := []*entities.Item{}
+	for _, item := range items {
+		gold := item.GoldValue()
+		if gold >= float64(minGold) && gold <= float64(maxGold) {
+			matches = append(matches, item)
+		}
+	}
+	return matches
+}
+
+// itemsInCategory returns the items whose Category or Type is the category key
+func itemsInCategory(items []*entities.Item, category string) []*entities.Item {
+	matches := []*entities.Item{}
+	for _, item := range items {
+		if item.Category == category || item.Type == category {
+			matches = append(matches, item)
+		}
+	}
+	return matches
+}
+
+// magicItems returns the items that have a rarity
+func magicItems(items []*entities.Item) []*entities.Item {
+	matches := []*entities.Item{}
+	for _, item := range items {
+		if item.Rarity != "" {
+			matches = append(matches, item)
+		}
+	}
+	return matches
+}
+
+// pickRandomItems shuffles the items in place and returns up to count of them
+func pickRandomItems(items []*entities.Item, count int) []*entities.Item {
+	rand.Shuffle(len(items), func(i, j int) {
+		items[i], items[j] = items[j], items[i]
+	})
+	return firstItems(items, count)
+}
+
+// firstItems returns up to the first count items
+func firstItems(items []*entities.Item, count int) []*entities.Item {
+	if len(items) > count {
+		return items[:count]
+	}
+	return items
+}
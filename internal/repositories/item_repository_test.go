@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+var (
+	_ ItemCategoryRepository = (*APIItemRepository)(nil)
+	_ ItemCategoryRepository = (*TestItemRepository)(nil)
+)
+
+// stubEquipmentLister returns a fixed equipment list, or err if it is set
+type stubEquipmentLister struct {
+	items []*entities.Item
+	err   error
+}
+
+func (l *stubEquipmentLister) ListEquipment() ([]*entities.Item, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+	return append([]*entities.Item{}, l.items...), nil
+}
+
+// createTestEquipment creates two weapons, a suit of armor, a rope, and a magic ring
+func createTestEquipment() []*entities.Item {
+	return []*entities.Item{
+		{Key: "dagger", Type: "weapon", Category: "weapon"},
+		{Key: "longsword", Type: "weapon", Category: "weapon"},
+		{Key: "chain-mail", Type: "armor", Category: "armor"},
+		{Key: "rope", Category: "adventuring-gear"},
+		{Key: "ring-of-protection", Category: "ring", Rarity: entities.RarityRare},
+	}
+}
+
+// itemKeys returns the keys of the items in order
+func itemKeys(items []*entities.Item) []string {
+	keys := make([]string, len(items))
+	for i, item := range items {
+		keys[i] = item.Key
+	}
+	return keys
+}
+
+func TestAPIItemRepositoryCategories(t *testing.T) {
+	repo := NewAPIItemRepository(&stubEquipmentLister{items: createTestEquipment()})
+
+	weapons, err := repo.GetWeapons(5)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"dagger", "longsword"}, itemKeys(weapons))
+
+	weapons, err = repo.GetWeapons(1)
+	require.NoError(t, err)
+	assert.Len(t, weapons, 1)
+
+	armor, err := repo.GetArmor(5)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"chain-mail"}, itemKeys(armor))
+
+	gear, err := repo.GetAdventuringGear(5)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rope"}, itemKeys(gear))
+
+	magic, err := repo.GetMagicItems(5)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ring-of-protection"}, itemKeys(magic))
+
+	none, err := repo.GetRandomItemsByCategory("mounts-and-vehicles", 5)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+
+	_, err = repo.GetWeapons(0)
+	assert.Error(t, err)
+
+	failing := NewAPIItemRepository(&stubEquipmentLister{err: errors.New("api down")})
+	_, err = failing.GetArmor(1)
+	assert.Error(t, err)
+	_, err = failing.GetMagicItems(1)
+	assert.Error(t, err)
+}
+
+func TestTestItemRepositoryCategories(t *testing.T) {
+	repo := &TestItemRepository{Items: createTestEquipment()}
+
+	weapons, err := repo.GetWeapons(1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dagger"}, itemKeys(weapons))
+
+	armor, err := repo.GetArmor(5)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"chain-mail"}, itemKeys(armor))
+
+	gear, err := repo.GetAdventuringGear(5)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rope"}, itemKeys(gear))
+
+	magic, err := repo.GetMagicItems(5)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ring-of-protection"}, itemKeys(magic))
+
+	_, err = repo.GetMagicItems(-1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,46 @@
+// Package repositories provides data sources for monsters and other game content
+package repositories
+
+import (
+	"errors"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// Error constants for repository operations
+var (
+	ErrMonsterNotFound = errors.New("monster not found")
+
+	// ErrNoEnvironmentData is returned when monsters are filtered by environment but none have environment data
+	ErrNoEnvironmentData = errors.New("no monster has environment data")
+
+	// ErrListingNotSupported is returned by repository wrappers whose wrapped repository cannot list monsters
+	ErrListingNotSupported = errors.New("monster repository does not support listing")
+)
+
+// MonsterRepository looks up reference data for monsters by key
+type MonsterRepository interface {
+	// GetMonsterXP returns the experience points awarded for defeating the monster
+	GetMonsterXP(key string) (int, error)
+}
+
+// MonsterLister is implemented by monster repositories that can search monsters by challenge rating
+type MonsterLister interface {
+	// ListMonstersByCRRange returns template monsters whose CR lies within the inclusive range
+	// The returned monsters have their Key, Name, CR, XP, and Type (if known) set but no ID or position
+	ListMonstersByCRRange(minCR, maxCR float64) ([]*entities.Monster, error)
+}
+
+// MonsterFilter selects monsters by challenge rating, environment, and type
+type MonsterFilter struct {
+	MinCR        float64  // Lowest CR to include
+	MaxCR        float64  // Highest CR to include
+	Environments []string // Environments the monster must live in at least one of (optional, empty allows any)
+	Types        []string // Creature types the monster must have one of, such as "undead" (optional, empty allows any)
+}
+
+// MonsterFilterLister is implemented by monster repositories that can search monsters by MonsterFilter
+type MonsterFilterLister interface {
+	// ListMonstersByFilter returns template monsters matching the filter, like ListMonstersByCRRange
+	ListMonstersByFilter(filter MonsterFilter) ([]*entities.Monster, error)
+}
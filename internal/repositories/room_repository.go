@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// Error constants for room repository operations
+var (
+	// ErrRoomNotFound is entities.ErrRoomNotFound, so errors.Is matches it from either package
+	ErrRoomNotFound = entities.ErrRoomNotFound
+)
+
+// RoomRepository stores generated rooms so they can be looked up by ID
+type RoomRepository interface {
+	// GetRoom returns the room with the given ID, or ErrRoomNotFound
+	GetRoom(id string) (*entities.Room, error)
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestErrRoomNotFoundMatchesAcrossPackages(t *testing.T) {
+	dungeon := entities.NewDungeonMap()
+	_, err := dungeon.GetRoom("missing")
+	assert.ErrorIs(t, err, ErrRoomNotFound)
+
+	assert.ErrorIs(t, fmt.Errorf("room %s: %w", "missing", ErrRoomNotFound), entities.ErrRoomNotFound)
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// findActionEconomy returns the action economy of a monster or player in the room
+func findActionEconomy(room *entities.Room, entityID string, cellType entities.CellType) (*entities.ActionEconomy, error) {
+	switch cellType {
+	case entities.CellMonster:
+		if monster, _ := FindMonsterByID(room, entityID); monster != nil {
+			return &monster.ActionEconomy, nil
+		}
+		return nil, fmt.Errorf("monster with ID %s not found in room", entityID)
+	case entities.CellPlayer:
+		if player, _ := FindPlayerByID(room, entityID); player != nil {
+			return &player.ActionEconomy, nil
+		}
+		return nil, fmt.Errorf("player with ID %s not found in room", entityID)
+	default:
+		return nil, fmt.Errorf("entity type %d does not track action economy", cellType)
+	}
+}
+
+// UseAction spends an action of the given type for a monster or player
+// Valid action types are "action", "bonus_action", "reaction", "movement", and "free"
+// Returns an error wrapping ErrActionAlreadyUsed if the action was already spent this turn
+func (s *RoomService) UseAction(room *entities.Room, entityID string, cellType entities.CellType, actionType string) error {
+	if room == nil {
+		return entities.ErrNilRoom
+	}
+
+	economy, err := findActionEconomy(room, entityID, cellType)
+	if err != nil {
+		return err
+	}
+
+	return economy.Use(actionType)
+}
+
+// ResetTurnEconomy restores the action economy of a single monster or player at the start of its turn
+func (s *RoomService) ResetTurnEconomy(room *entities.Room, entityID string, cellType entities.CellType) error {
+	if room == nil {
+		return entities.ErrNilRoom
+	}
+
+	economy, err := findActionEconomy(room, entityID, cellType)
+	if err != nil {
+		return err
+	}
+
+	economy.Reset()
+	return nil
+}
+
+// ResetAllTurnEconomy restores the action economy of every monster and player in the room
+// This should be called at the end of each combat round
+func (s *RoomService) ResetAllTurnEconomy(room *entities.Room) error {
+	if room == nil {
+		return entities.ErrNilRoom
+	}
+
+	for i := range room.Monsters {
+		room.Monsters[i].ActionEconomy.Reset()
+	}
+	for i := range room.Players {
+		room.Players[i].ActionEconomy.Reset()
+	}
+
+	return nil
+}
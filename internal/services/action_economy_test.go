@@ -0,0 +1,121 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestUseAction(t *testing.T) {
+	service := &RoomService{}
+
+	setupRoom := func() *entities.Room {
+		room := createTestRoom()
+		monster := createTestMonster("monster1", 1, 1)
+		player := createTestPlayer("player1", 3, 2, 2)
+		assert.NoError(t, PlaceEntity(room, &monster))
+		assert.NoError(t, PlaceEntity(room, &player))
+		return room
+	}
+
+	testCases := []struct {
+		name       string
+		entityID   string
+		cellType   entities.CellType
+		actionType string
+		check      func(t *testing.T, economy entities.ActionEconomy)
+	}{
+		{
+			name:       "Monster action",
+			entityID:   "monster1",
+			cellType:   entities.CellMonster,
+			actionType: entities.ActionTypeAction,
+			check: func(t *testing.T, economy entities.ActionEconomy) {
+				assert.True(t, economy.ActionUsed)
+			},
+		},
+		{
+			name:       "Player bonus action",
+			entityID:   "player1",
+			cellType:   entities.CellPlayer,
+			actionType: entities.ActionTypeBonusAction,
+			check: func(t *testing.T, economy entities.ActionEconomy) {
+				assert.True(t, economy.BonusActionUsed)
+			},
+		},
+		{
+			name:       "Player reaction",
+			entityID:   "player1",
+			cellType:   entities.CellPlayer,
+			actionType: entities.ActionTypeReaction,
+			check: func(t *testing.T, economy entities.ActionEconomy) {
+				assert.True(t, economy.ReactionUsed)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			room := setupRoom()
+
+			err := service.UseAction(room, tc.entityID, tc.cellType, tc.actionType)
+			assert.NoError(t, err)
+
+			economy, err := findActionEconomy(room, tc.entityID, tc.cellType)
+			assert.NoError(t, err)
+			tc.check(t, *economy)
+
+			// Using the same action twice in one turn is an error
+			err = service.UseAction(room, tc.entityID, tc.cellType, tc.actionType)
+			assert.ErrorIs(t, err, entities.ErrActionAlreadyUsed)
+		})
+	}
+
+	t.Run("Movement and free actions can repeat", func(t *testing.T) {
+		room := setupRoom()
+
+		assert.NoError(t, service.UseAction(room, "monster1", entities.CellMonster, entities.ActionTypeMovement))
+		assert.NoError(t, service.UseAction(room, "monster1", entities.CellMonster, entities.ActionTypeMovement))
+		assert.NoError(t, service.UseAction(room, "monster1", entities.CellMonster, entities.ActionTypeFree))
+		assert.NoError(t, service.UseAction(room, "monster1", entities.CellMonster, entities.ActionTypeFree))
+
+		assert.Equal(t, 2*entities.MovementStepFt, room.Monsters[0].ActionEconomy.MovementUsed)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		room := setupRoom()
+
+		assert.ErrorIs(t, service.UseAction(nil, "monster1", entities.CellMonster, entities.ActionTypeAction), entities.ErrNilRoom)
+		assert.ErrorIs(t, service.UseAction(room, "monster1", entities.CellMonster, "lair_action"), entities.ErrUnknownActionType)
+		assert.Error(t, service.UseAction(room, "missing", entities.CellMonster, entities.ActionTypeAction))
+		assert.Error(t, service.UseAction(room, "monster1", entities.CellItem, entities.ActionTypeAction))
+	})
+}
+
+func TestResetTurnEconomy(t *testing.T) {
+	service := &RoomService{}
+	room := createTestRoom()
+	monster := createTestMonster("monster1", 1, 1)
+	player := createTestPlayer("player1", 3, 2, 2)
+	assert.NoError(t, PlaceEntity(room, &monster))
+	assert.NoError(t, PlaceEntity(room, &player))
+
+	assert.NoError(t, service.UseAction(room, "monster1", entities.CellMonster, entities.ActionTypeAction))
+	assert.NoError(t, service.UseAction(room, "player1", entities.CellPlayer, entities.ActionTypeReaction))
+
+	// Resetting one entity leaves the other untouched
+	assert.NoError(t, service.ResetTurnEconomy(room, "monster1", entities.CellMonster))
+	assert.Equal(t, entities.ActionEconomy{}, room.Monsters[0].ActionEconomy)
+	assert.True(t, room.Players[0].ActionEconomy.ReactionUsed)
+	assert.NoError(t, service.UseAction(room, "monster1", entities.CellMonster, entities.ActionTypeAction))
+
+	// Resetting everything clears all entities
+	assert.NoError(t, service.ResetAllTurnEconomy(room))
+	assert.Equal(t, entities.ActionEconomy{}, room.Monsters[0].ActionEconomy)
+	assert.Equal(t, entities.ActionEconomy{}, room.Players[0].ActionEconomy)
+
+	assert.Error(t, service.ResetTurnEconomy(room, "missing", entities.CellPlayer))
+	assert.ErrorIs(t, service.ResetAllTurnEconomy(nil), entities.ErrNilRoom)
+}
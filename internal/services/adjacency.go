@@ -0,0 +1,58 @@
+package services
+
+import (
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// CellInfo describes a single cell together with the entity occupying it
+type CellInfo struct {
+	Position entities.Position  // Position of the cell
+	Cell     entities.Cell      // Grid cell contents (always empty for gridless rooms)
+	Entity   entities.Placeable // Entity occupying the cell, nil if empty
+}
+
+// GetAdjacentCells returns the cells directly adjacent to a position, in the row-major order of
+// entities.GetAdjacentPositions. With includeDiagonals false only the four orthogonal neighbors are considered,
+// otherwise up to eight. Cells outside the room boundaries are excluded rather than wrapped
+// For gridless rooms the neighbor positions are returned with empty cells and nil entities
+func (s *RoomService) GetAdjacentCells(room *entities.Room, position entities.Position, includeDiagonals bool) ([]CellInfo, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+
+	if !IsPositionValid(room, position) {
+		return nil, entities.ErrInvalidPosition
+	}
+
+	// Index entities by ID so occupied cells can be resolved without rescanning the slices
+	var entityByID map[string]entities.Placeable
+	if room.Grid != nil {
+		entityByID = make(map[string]entities.Placeable)
+		for _, p := range collectPlaceables(room) {
+			entityByID[p.GetID()] = p
+		}
+	}
+
+	positions := entities.GetAdjacentPositions(room, position)
+	cells := make([]CellInfo, 0, len(positions))
+	for _, pos := range positions {
+		if !includeDiagonals && pos.X != position.X && pos.Y != position.Y {
+			continue
+		}
+
+		info := CellInfo{
+			Position: pos,
+			Cell:     entities.Cell{Type: entities.CellTypeEmpty},
+		}
+		if room.Grid != nil {
+			info.Cell = room.Grid[pos.Y][pos.X]
+			if info.Cell.EntityID != "" {
+				info.Entity = entityByID[info.Cell.EntityID]
+			}
+		}
+
+		cells = append(cells, info)
+	}
+
+	return cells, nil
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestGetAdjacentCells(t *testing.T) {
+	service := &RoomService{}
+
+	testCases := []struct {
+		name             string
+		position         entities.Position
+		includeDiagonals bool
+		expectedCount    int
+	}{
+		{"Corner without diagonals", entities.Position{X: 0, Y: 0}, false, 2},
+		{"Corner with diagonals", entities.Position{X: 0, Y: 0}, true, 3},
+		{"Opposite corner with diagonals", entities.Position{X: 4, Y: 4}, true, 3},
+		{"Edge without diagonals", entities.Position{X: 2, Y: 0}, false, 3},
+		{"Edge with diagonals", entities.Position{X: 2, Y: 0}, true, 5},
+		{"Center without diagonals", entities.Position{X: 2, Y: 2}, false, 4},
+		{"Center with diagonals", entities.Position{X: 2, Y: 2}, true, 8},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cells, err := service.GetAdjacentCells(createTestRoom(), tc.position, tc.includeDiagonals)
+			assert.NoError(t, err)
+			assert.Len(t, cells, tc.expectedCount)
+
+			for _, cell := range cells {
+				assert.Equal(t, 1.0, CalculateDistance(tc.position, cell.Position))
+			}
+		})
+	}
+
+	t.Run("Entity references are populated", func(t *testing.T) {
+		room := createTestRoom()
+		monster := createTestMonster("monster1", 1, 0)
+		player := createTestPlayer("player1", 3, 1, 1)
+		assert.NoError(t, PlaceEntity(room, &monster))
+		assert.NoError(t, PlaceEntity(room, &player))
+
+		cells, err := service.GetAdjacentCells(room, entities.Position{X: 0, Y: 0}, true)
+		assert.NoError(t, err)
+
+		found := map[string]entities.CellType{}
+		for _, cell := range cells {
+			if cell.Entity == nil {
+				assert.Equal(t, entities.CellTypeEmpty, cell.Cell.Type)
+				continue
+			}
+			assert.Equal(t, cell.Cell.EntityID, cell.Entity.GetID())
+			assert.Equal(t, cell.Position, cell.Entity.GetPosition())
+			found[cell.Entity.GetID()] = cell.Entity.GetCellType()
+		}
+
+		assert.Equal(t, map[string]entities.CellType{
+			"monster1": entities.CellMonster,
+			"player1":  entities.CellPlayer,
+		}, found)
+	})
+
+	t.Run("Gridless room", func(t *testing.T) {
+		room := createTestRoomNoGrid()
+		monster := createTestMonster("monster1", 1, 1)
+		assert.NoError(t, PlaceEntity(room, &monster))
+
+		cells, err := service.GetAdjacentCells(room, entities.Position{X: 2, Y: 2}, true)
+		assert.NoError(t, err)
+		assert.Len(t, cells, 8)
+		for _, cell := range cells {
+			assert.Equal(t, entities.CellTypeEmpty, cell.Cell.Type)
+			assert.Nil(t, cell.Entity)
+		}
+	})
+
+	t.Run("Cells follow entities.GetAdjacentPositions order", func(t *testing.T) {
+		room := createTestRoom()
+		center := entities.Position{X: 2, Y: 2}
+
+		cells, err := service.GetAdjacentCells(room, center, true)
+		assert.NoError(t, err)
+		positions := []entities.Position{}
+		for _, cell := range cells {
+			positions = append(positions, cell.Position)
+		}
+		assert.Equal(t, entities.GetAdjacentPositions(room, center), positions)
+
+		cells, err = service.GetAdjacentCells(room, center, false)
+		assert.NoError(t, err)
+		positions = []entities.Position{}
+		for _, cell := range cells {
+			positions = append(positions, cell.Position)
+		}
+		assert.Equal(t, []entities.Position{{X: 2, Y: 1}, {X: 1, Y: 2}, {X: 3, Y: 2}, {X: 2, Y: 3}}, positions)
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		_, err := service.GetAdjacentCells(nil, entities.Position{}, true)
+		assert.ErrorIs(t, err, entities.ErrNilRoom)
+
+		_, err = service.GetAdjacentCells(createTestRoom(), entities.Position{X: 5, Y: 0}, true)
+		assert.ErrorIs(t, err, entities.ErrInvalidPosition)
+	})
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+var (
+	ErrNotAmbushRoom          = errors.New("room is not an ambush room")
+	ErrAmbushAlreadyTriggered = errors.New("ambush has already been triggered")
+)
+
+// AmbushConfig contains parameters for generating an ambush room
+type AmbushConfig struct {
+	Ambushers            []MonsterConfig // Monsters lying in wait
+	AmbushZone           SpawnZone       // Region where the ambushers hide
+	Victims              []PlayerConfig  // Players walking into the ambush (optional)
+	VictimZone           SpawnZone       // Region where the victims are placed
+	HiddenUntilTriggered bool            // Whether ambushers start with the hidden condition
+}
+
+// GenerateAmbushRoom creates a room with ambushers in AmbushZone and any victims in VictimZone
+// If HiddenUntilTriggered is set, every ambusher starts with entities.ConditionHidden
+// Returns an error if the zones overlap or any entity cannot be placed
+func (s *RoomService) GenerateAmbushRoom(roomConfig RoomConfig, config AmbushConfig) (*entities.Room, error) {
+	if config.AmbushZone.Overlaps(config.VictimZone) {
+		return nil, fmt.Errorf("ambush zone and victim zone must not overlap")
+	}
+
+	room, err := s.GenerateRoom(roomConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := config.AmbushZone.Validate(room); err != nil {
+		return nil, fmt.Errorf("invalid ambush zone: %w", err)
+	}
+	if err := config.VictimZone.Validate(room); err != nil {
+		return nil, fmt.Errorf("invalid victim zone: %w", err)
+	}
+
+	ambush := &entities.AmbushRoomType{}
+	room.RoomType = ambush
+
+	for _, ambusher := range config.Ambushers {
+		for i := 0; i < ambusher.Count; i++ {
+			position, err := FindEmptyPositionInZone(room, config.AmbushZone)
+			if err != nil {
+				return nil, fmt.Errorf("failed to place %s (monster): %w", ambusher.GetName(), err)
+			}
+			if err := s.placeConfigAt(room, ambusher, position); err != nil {
+				return nil, err
+			}
+
+			monster := &room.Monsters[len(room.Monsters)-1]
+			if config.HiddenUntilTriggered {
+				monster.Conditions = append(monster.Conditions, entities.ConditionHidden)
+			}
+			ambush.AmbusherIDs = append(ambush.AmbusherIDs, monster.ID)
+		}
+	}
+
+	for _, victim := range config.Victims {
+		position, err := FindEmptyPositionInZone(room, config.VictimZone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to place %s (player): %w", victim.GetName(), err)
+		}
+		if err := s.placeConfigAt(room, victim, position); err != nil {
+			return nil, err
+		}
+	}
+
+	return room, nil
+}
+
+// TriggerAmbush springs the ambush, removing the hidden condition from every ambusher
+// and publishing an EventAmbushTriggered event. An ambush can only be triggered once
+func (s *RoomService) TriggerAmbush(room *entities.Room) error {
+	if room == nil {
+		return entities.ErrNilRoom
+	}
+
+	ambush, ok := room.RoomType.(*entities.AmbushRoomType)
+	if !ok {
+		return ErrNotAmbushRoom
+	}
+	if ambush.Triggered {
+		return ErrAmbushAlreadyTriggered
+	}
+
+	for _, id := range ambush.AmbusherIDs {
+		monster, _ := FindMonsterByID(room, id)
+		if monster == nil {
+			// Ambushers may have been removed before the ambush was sprung
+			continue
+		}
+		monster.Conditions = withoutCondition(monster.Conditions, entities.ConditionHidden)
+	}
+	ambush.Triggered = true
+
+	s.Events().Publish(Event{
+		Type:      EventAmbushTriggered,
+		EntityIDs: append([]string(nil), ambush.AmbusherIDs...),
+		Detail:    fmt.Sprintf("%d ambushers revealed", len(ambush.AmbusherIDs)),
+	})
+
+	return nil
+}
+
+// withoutCondition returns conditions with every occurrence of condition removed
+func withoutCondition(conditions []entities.Condition, condition entities.Condition) []entities.Condition {
+	kept := conditions[:0]
+	for _, c := range conditions {
+		if c != condition {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
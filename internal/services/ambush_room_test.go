@@ -0,0 +1,106 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// createTestAmbushConfig creates an ambush with three goblins along the top and two victims along the bottom
+func createTestAmbushConfig(hidden bool) AmbushConfig {
+	return AmbushConfig{
+		Ambushers:  []MonsterConfig{createTestMonsterConfig("Goblin", "goblin", 0.25, 3, true, nil)},
+		AmbushZone: SpawnZone{MinX: 0, MinY: 0, MaxX: 9, MaxY: 2},
+		Victims: []PlayerConfig{
+			createTestPlayerConfig("Aria", 3, true, nil),
+			createTestPlayerConfig("Bram", 3, true, nil),
+		},
+		VictimZone:           SpawnZone{MinX: 0, MinY: 6, MaxX: 9, MaxY: 9},
+		HiddenUntilTriggered: hidden,
+	}
+}
+
+func TestGenerateAmbushRoom(t *testing.T) {
+	testCases := []struct {
+		name   string
+		hidden bool
+	}{
+		{name: "Hidden ambushers", hidden: true},
+		{name: "Visible ambushers", hidden: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := &RoomService{}
+			config := createTestAmbushConfig(tc.hidden)
+
+			room, err := service.GenerateAmbushRoom(createTestRoomConfig(10, 10, entities.LightLevelDark, true), config)
+			require.NoError(t, err)
+
+			ambush, ok := room.RoomType.(*entities.AmbushRoomType)
+			require.True(t, ok)
+			assert.False(t, ambush.Triggered)
+			assert.Len(t, ambush.AmbusherIDs, 3)
+
+			require.Len(t, room.Monsters, 3)
+			for _, monster := range room.Monsters {
+				assert.True(t, config.AmbushZone.Contains(monster.Position))
+				if tc.hidden {
+					assert.Equal(t, []entities.Condition{entities.ConditionHidden}, monster.Conditions)
+				} else {
+					assert.Empty(t, monster.Conditions)
+				}
+			}
+
+			require.Len(t, room.Players, 2)
+			for _, player := range room.Players {
+				assert.True(t, config.VictimZone.Contains(player.Position))
+			}
+			assert.Empty(t, ValidateRoomGrid(room))
+		})
+	}
+}
+
+func TestGenerateAmbushRoomErrors(t *testing.T) {
+	service := &RoomService{}
+	roomConfig := createTestRoomConfig(10, 10, entities.LightLevelDark, true)
+
+	overlapping := createTestAmbushConfig(true)
+	overlapping.VictimZone = SpawnZone{MinX: 0, MinY: 2, MaxX: 9, MaxY: 9}
+	_, err := service.GenerateAmbushRoom(roomConfig, overlapping)
+	assert.ErrorContains(t, err, "must not overlap")
+
+	outside := createTestAmbushConfig(true)
+	outside.VictimZone = SpawnZone{MinX: 0, MinY: 6, MaxX: 9, MaxY: 12}
+	_, err = service.GenerateAmbushRoom(roomConfig, outside)
+	assert.ErrorContains(t, err, "invalid victim zone")
+}
+
+func TestTriggerAmbush(t *testing.T) {
+	service := &RoomService{}
+	room, err := service.GenerateAmbushRoom(createTestRoomConfig(10, 10, entities.LightLevelDark, true), createTestAmbushConfig(true))
+	require.NoError(t, err)
+
+	events := []Event{}
+	service.Events().Subscribe(EventAmbushTriggered, func(e Event) {
+		events = append(events, e)
+	})
+
+	require.NoError(t, service.TriggerAmbush(room))
+	for _, monster := range room.Monsters {
+		assert.Empty(t, monster.Conditions)
+	}
+	assert.True(t, room.RoomType.(*entities.AmbushRoomType).Triggered)
+
+	// A second trigger is rejected and does not publish again
+	assert.ErrorIs(t, service.TriggerAmbush(room), ErrAmbushAlreadyTriggered)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventAmbushTriggered, events[0].Type)
+	assert.ElementsMatch(t, room.RoomType.(*entities.AmbushRoomType).AmbusherIDs, events[0].EntityIDs)
+
+	assert.ErrorIs(t, service.TriggerAmbush(createTestRoom()), ErrNotAmbushRoom)
+	assert.ErrorIs(t, service.TriggerAmbush(nil), entities.ErrNilRoom)
+}
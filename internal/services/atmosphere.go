@@ -0,0 +1,81 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// temperatureDescriptions describes how each temperature feels to the party
+var temperatureDescriptions = map[string]string{
+	entities.TemperatureFreezing: "The cold is bitter enough to numb your fingers.",
+	entities.TemperatureCold:     "A chill hangs in the room.",
+	entities.TemperatureCool:     "The room is pleasantly cool.",
+	entities.TemperatureWarm:     "The room is uncomfortably warm.",
+	entities.TemperatureHot:      "Stifling heat presses in from every side.",
+}
+
+// airQualityDescriptions describes how the air feels to breathe
+var airQualityDescriptions = map[string]string{
+	entities.AirQualityFresh:   "The air is fresh.",
+	entities.AirQualityStale:   "The air is stale and close.",
+	entities.AirQualitySmoky:   "Smoke stings your eyes.",
+	entities.AirQualityToxic:   "Every breath burns your throat.",
+	entities.AirQualityMagical: "The air crackles with magic.",
+}
+
+// SetAtmosphere replaces the room's atmosphere
+// Temperature and AirQuality may be empty; otherwise they must be one of the entities constants
+func (s *RoomService) SetAtmosphere(room *entities.Room, atm entities.Atmosphere) error {
+	if room == nil {
+		return entities.ErrNilRoom
+	}
+	if atm.Temperature != "" && !entities.IsValidTemperature(atm.Temperature) {
+		return fmt.Errorf("invalid temperature: %s", atm.Temperature)
+	}
+	if atm.AirQuality != "" && !entities.IsValidAirQuality(atm.AirQuality) {
+		return fmt.Errorf("invalid air quality: %s", atm.AirQuality)
+	}
+
+	room.Atmosphere = atm
+	return nil
+}
+
+// GetSensoryDescription describes the room's sounds, smells, temperature, and air as a paragraph
+// Empty fields are left out, so a room without an atmosphere has an empty description
+func (s *RoomService) GetSensoryDescription(room *entities.Room) (string, error) {
+	if room == nil {
+		return "", entities.ErrNilRoom
+	}
+
+	atm := room.Atmosphere
+	sentences := []string{}
+	if len(atm.Sounds) > 0 {
+		sentences = append(sentences, fmt.Sprintf("You hear %s.", joinWithAnd(atm.Sounds)))
+	}
+	if len(atm.Smells) > 0 {
+		sentences = append(sentences, fmt.Sprintf("The room smells of %s.", joinWithAnd(atm.Smells)))
+	}
+	if description, ok := temperatureDescriptions[atm.Temperature]; ok {
+		sentences = append(sentences, description)
+	}
+	if description, ok := airQualityDescriptions[atm.AirQuality]; ok {
+		sentences = append(sentences, description)
+	}
+
+	return strings.Join(sentences, " "), nil
+}
+
+// joinWithAnd joins words as an English list, e.g. "a, b, and c"
+func joinWithAnd(words []string) string {
+	switch len(words) {
+	case 0:
+		return ""
+	case 1:
+		return words[0]
+	case 2:
+		return words[0] + " and " + words[1]
+	}
+	return strings.Join(words[:len(words)-1], ", ") + ", and " + words[len(words)-1]
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestSetAtmosphere(t *testing.T) {
+	service := &RoomService{}
+
+	testCases := []struct {
+		name      string
+		atm       entities.Atmosphere
+		expectErr bool
+	}{
+		{"empty", entities.Atmosphere{}, false},
+		{"valid", entities.Atmosphere{Temperature: entities.TemperatureCold, AirQuality: entities.AirQualityStale, VisibilityModifier: -2}, false},
+		{"unknown temperature", entities.Atmosphere{Temperature: "lukewarm"}, true},
+		{"unknown air quality", entities.Atmosphere{AirQuality: "minty"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			room := createTestRoom()
+			err := service.SetAtmosphere(room, tc.atm)
+			if tc.expectErr {
+				assert.Error(t, err)
+				assert.Equal(t, entities.Atmosphere{}, room.Atmosphere, "atmosphere should be unchanged")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.atm, room.Atmosphere)
+		})
+	}
+
+	assert.ErrorIs(t, service.SetAtmosphere(nil, entities.Atmosphere{}), entities.ErrNilRoom)
+}
+
+func TestGetSensoryDescription(t *testing.T) {
+	service := &RoomService{}
+	room := createTestRoom()
+
+	description, err := service.GetSensoryDescription(room)
+	require.NoError(t, err)
+	assert.Empty(t, description)
+
+	require.NoError(t, service.SetAtmosphere(room, entities.Atmosphere{
+		Sounds:      []string{"dripping water", "distant chanting", "scurrying rats"},
+		Smells:      []string{"mildew"},
+		Temperature: entities.TemperatureFreezing,
+		AirQuality:  entities.AirQualitySmoky,
+	}))
+	description, err = service.GetSensoryDescription(room)
+	require.NoError(t, err)
+
+	assert.Contains(t, description, "dripping water, distant chanting, and scurrying rats")
+	assert.Contains(t, description, "mildew")
+	assert.Contains(t, description, "cold")
+	assert.Contains(t, description, "Smoke")
+
+	_, err = service.GetSensoryDescription(nil)
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
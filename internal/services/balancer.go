@@ -3,7 +3,9 @@ package services
 import (
 	"fmt"
 	"math"
+	"math/rand"
 
+	"github.com/fadedpez/dnd5e-roomgen/internal/crutil"
 	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
 )
 
@@ -17,16 +19,60 @@ type Balancer interface {
 
 	// CalculateTargetCR calculates the target CR for a party based on difficulty
 	CalculateTargetCR(party entities.Party, difficulty entities.EncounterDifficulty) (float64, error)
+
+	// CalculateEncounterXPBudget returns the party's XP threshold for the difficulty
+	CalculateEncounterXPBudget(party entities.Party, difficulty entities.EncounterDifficulty) (int, error)
+
+	// CalculateAdjustedXP returns the total XP of the monsters scaled by the encounter multiplier
+	CalculateAdjustedXP(monsters []entities.Monster) int
+
+	// ComputeRemainingBudget returns how much XP and CR can still be added before reaching the difficulty's target
+	ComputeRemainingBudget(existing []MonsterConfig, party entities.Party, difficulty entities.EncounterDifficulty) (int, float64, error)
+
+	// CanAddMonster reports whether adding the candidate keeps the encounter within the difficulty band
+	CanAddMonster(existing []MonsterConfig, candidate MonsterConfig, party entities.Party, difficulty entities.EncounterDifficulty) (bool, string, error)
 }
 
 // StandardBalancer implements the Balancer interface using D&D 5e rules
-type StandardBalancer struct{}
+type StandardBalancer struct {
+	config BalancerConfig
+}
+
+// DistributionStrategy controls how CR removed by MaxSameMonsterType is given to other monster types
+type DistributionStrategy string
+
+const (
+	// DistributeEvenly adds monsters to the other types in turn (default)
+	DistributeEvenly DistributionStrategy = "evenly"
+
+	// DistributeToLowest spends the budget on the type with the lowest CR
+	DistributeToLowest DistributionStrategy = "lowest"
+)
+
+// MonsterCountLimits bounds the number of monsters AdjustMonsterSelection may produce
+// A zero value for any limit disables it
+type MonsterCountLimits struct {
+	MinTotalMonsters   int // Minimum number of monsters across all types
+	MaxTotalMonsters   int // Maximum number of monsters across all types
+	MaxSameMonsterType int // Maximum number of monsters of any one type
+}
+
+// BalancerConfig contains optional settings for a StandardBalancer
+type BalancerConfig struct {
+	MonsterCountLimits   MonsterCountLimits   // Limits applied after scaling monster counts
+	DistributionStrategy DistributionStrategy // How excess CR from capped types is redistributed
+}
 
 // NewBalancer creates a new StandardBalancer
 func NewBalancer() *StandardBalancer {
 	return &StandardBalancer{}
 }
 
+// NewBalancerWithConfig creates a new StandardBalancer with the given settings
+func NewBalancerWithConfig(config BalancerConfig) *StandardBalancer {
+	return &StandardBalancer{config: config}
+}
+
 // difficultyMultipliers maps difficulty levels to CR multipliers
 var difficultyMultipliers = map[entities.EncounterDifficulty]float64{
 	entities.EncounterDifficultyEasy:   0.5, // Easy encounter: CR = 0.5 * party level
@@ -75,95 +121,371 @@ func (b *StandardBalancer) CalculateTargetCR(party entities.Party, difficulty en
 	return math.Round(targetCR*4) / 4, nil
 }
 
-// calculateTotalCR calculates the total CR of a set of monsters
-func calculateTotalCR(monsters []entities.Monster) float64 {
-	totalCR := 0.0
-	for _, monster := range monsters {
-		totalCR += monster.CR
+// conditionImmunityXPMultiplier scales the XP of a monster immune to a condition the party relies on
+const conditionImmunityXPMultiplier = 1.25
+
+// standardPartySize is the party size assumed when no party is given, one that needs no multiplier shift
+const standardPartySize = 4
+
+// CalculateEncounterXPBudget returns the party's XP threshold for the difficulty (DMG p. 82)
+// The threshold is the sum of the thresholds of every member for their level, so four level 5 characters
+// have a medium budget of 4 x 500 = 2000 XP. Returns an error for empty parties and levels outside 1-20
+func (b *StandardBalancer) CalculateEncounterXPBudget(party entities.Party, difficulty entities.EncounterDifficulty) (int, error) {
+	if party.Size() == 0 {
+		return 0, fmt.Errorf("party cannot be empty")
+	}
+
+	thresholds, err := partyXPThresholds(party)
+	if err != nil {
+		return 0, err
+	}
+	budget, ok := thresholds[difficulty]
+	if !ok {
+		return 0, fmt.Errorf("invalid difficulty: %s", difficulty)
+	}
+	return budget, nil
+}
+
+// CalculateAdjustedXP returns the total XP of the monsters scaled by the DMG encounter multiplier
+// Monsters use their XP if set and the official CR to XP table otherwise. The multiplier is the one for
+// a party of three to five (x1 for one monster, x1.5 for two, x2 for three to six, and so on)
+func (b *StandardBalancer) CalculateAdjustedXP(monsters []entities.Monster) int {
+	return adjustedMonsterXP(monsters, entities.Party{}, standardPartySize)
+}
+
+// adjustedMonsterXP returns the total XP of the monsters against the party, scaled by the encounter multiplier
+// for the number of monsters and partySize. Monsters immune to any condition a party member can inflict count
+// as conditionImmunityXPMultiplier times their XP, since part of the party's arsenal is wasted on them
+func adjustedMonsterXP(monsters []entities.Monster, party entities.Party, partySize int) int {
+	rawXP := 0.0
+	for i := range monsters {
+		xp := float64(monsters[i].XP)
+		if xp <= 0 {
+			xp = float64(crutil.CRToXP(monsters[i].CR))
+		}
+		if immuneToParty(&monsters[i], party) {
+			xp *= conditionImmunityXPMultiplier
+		}
+		rawXP += xp
 	}
-	return totalCR
+	return int(math.Round(rawXP * encounterMultiplier(len(monsters), partySize)))
+}
+
+// immuneToParty reports whether the monster is immune to any condition a party member can inflict
+func immuneToParty(monster *entities.Monster, party entities.Party) bool {
+	for _, member := range party.Members {
+		for _, condition := range member.InflictedConditions {
+			if monster.IsImmuneTo(condition) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // DetermineEncounterDifficulty determines the difficulty of an encounter based on monsters and party
+// The monsters' adjusted XP for the party's size is compared against the party's XP thresholds (see
+// CalculateEncounterXPBudget), and the highest threshold it meets is returned. Encounters below the easy
+// threshold are still reported as easy. Monsters immune to conditions the party can inflict count for more
 func (b *StandardBalancer) DetermineEncounterDifficulty(monsters []entities.Monster, party entities.Party) (entities.EncounterDifficulty, error) {
 	if party.Size() == 0 {
 		return "", fmt.Errorf("party cannot be empty")
 	}
 
-	// Calculate the total CR of the monsters
-	totalCR := calculateTotalCR(monsters)
-
-	// Calculate the average party level
-	avgLevel := party.AverageLevel()
-
-	// Apply party size adjustment
-	sizeAdjustment := 1.0 // Default for 3-4 players
-	if adj, ok := partySizeAdjustments[party.Size()]; ok {
-		sizeAdjustment = adj
-	} else if party.Size() > 6 {
-		sizeAdjustment = partySizeAdjustments[6] // Use the 6+ player adjustment
+	thresholds, err := partyXPThresholds(party)
+	if err != nil {
+		return "", err
 	}
+	adjustedXP := adjustedMonsterXP(monsters, party, party.Size())
 
-	// Calculate the adjusted CR ratio (totalCR / (avgLevel * sizeAdjustment))
-	crRatio := totalCR / (avgLevel * sizeAdjustment)
-
-	// Determine the difficulty based on the CR ratio
-	if crRatio >= difficultyMultipliers[entities.EncounterDifficultyDeadly] {
-		return entities.EncounterDifficultyDeadly, nil
-	} else if crRatio >= difficultyMultipliers[entities.EncounterDifficultyHard] {
-		return entities.EncounterDifficultyHard, nil
-	} else if crRatio >= difficultyMultipliers[entities.EncounterDifficultyMedium] {
-		return entities.EncounterDifficultyMedium, nil
-	} else if crRatio >= difficultyMultipliers[entities.EncounterDifficultyEasy] {
-		return entities.EncounterDifficultyEasy, nil
+	difficulty := entities.EncounterDifficultyEasy
+	for _, d := range thresholdDifficulties {
+		if adjustedXP >= thresholds[d] {
+			difficulty = d
+		}
 	}
-
-	// If the CR ratio is below the easy threshold, it's trivial (we'll return easy)
-	return entities.EncounterDifficultyEasy, nil
+	return difficulty, nil
 }
 
 // AdjustMonsterSelection adjusts the monster selection based on the party and desired difficulty
+// Counts are scaled by a common factor, keeping at least one of each type, until the monsters' adjusted XP
+// reaches the party's XP threshold for the difficulty (see CalculateEncounterXPBudget). The smallest factor
+// that does so is used, so the result is rated the requested difficulty by DetermineEncounterDifficulty
+// unless a single monster of each type is already above it. Selections already rated the difficulty keep
+// their counts. Count limits are applied after scaling
 func (b *StandardBalancer) AdjustMonsterSelection(monsterConfigs []MonsterConfig, party entities.Party, difficulty entities.EncounterDifficulty) ([]MonsterConfig, error) {
 	if party.Size() == 0 {
 		return nil, fmt.Errorf("party cannot be empty")
 	}
 
-	// Calculate the target CR for the encounter
-	targetCR, err := b.CalculateTargetCR(party, difficulty)
+	limits := b.config.MonsterCountLimits
+	if limits.MinTotalMonsters > 0 && limits.MaxTotalMonsters > 0 && limits.MinTotalMonsters > limits.MaxTotalMonsters {
+		return nil, fmt.Errorf("minimum total monsters (%d) exceeds maximum (%d)", limits.MinTotalMonsters, limits.MaxTotalMonsters)
+	}
+
+	budget, err := b.CalculateEncounterXPBudget(party, difficulty)
 	if err != nil {
 		return nil, err
 	}
 
-	// Calculate the current total CR of the monster configs
-	currentTotalCR := 0.0
-	for _, config := range monsterConfigs {
-		currentTotalCR += config.CR * float64(config.Count)
+	adjustedConfigs := make([]MonsterConfig, len(monsterConfigs))
+	copy(adjustedConfigs, monsterConfigs)
+
+	// Keep the original counts if the encounter is already rated the requested difficulty
+	rated, err := b.DetermineEncounterDifficulty(expandMonsterConfigs(monsterConfigs), party)
+	if err != nil {
+		return nil, err
 	}
+	if rated != difficulty && monsterConfigsAdjustedXP(monsterConfigs, party) > 0 {
+		scaled := func(factor float64) []MonsterConfig {
+			for i := range adjustedConfigs {
+				if count := monsterConfigs[i].Count; count > 0 {
+					adjustedConfigs[i].Count = max(1, int(math.Round(float64(count)*factor)))
+				}
+			}
+			return adjustedConfigs
+		}
 
-	// If we're already close to the target CR (within 10%), return the original configs
-	if math.Abs(currentTotalCR-targetCR)/targetCR < 0.1 {
-		return monsterConfigs, nil
+		// Find a factor that reaches the budget, then the smallest one that still does
+		low, high := 0.0, 1.0
+		for monsterConfigsAdjustedXP(scaled(high), party) < budget {
+			low, high = high, high*2
+		}
+		for i := 0; i < 50; i++ {
+			mid := (low + high) / 2
+			if monsterConfigsAdjustedXP(scaled(mid), party) >= budget {
+				high = mid
+			} else {
+				low = mid
+			}
+		}
+		scaled(high)
 	}
 
-	// Adjust the monster counts to get closer to the target CR
-	adjustedConfigs := make([]MonsterConfig, len(monsterConfigs))
-	copy(adjustedConfigs, monsterConfigs)
+	b.applyCountLimits(adjustedConfigs)
+
+	return adjustedConfigs, nil
+}
 
-	// Calculate the scaling factor
-	scalingFactor := targetCR / currentTotalCR
+// applyCountLimits enforces the configured MonsterCountLimits on the configs in place
+// The per-type cap is applied first, then the total maximum, then the total minimum
+func (b *StandardBalancer) applyCountLimits(configs []MonsterConfig) {
+	limits := b.config.MonsterCountLimits
+
+	if limits.MaxSameMonsterType > 0 {
+		excessCR := 0.0
+		for i := range configs {
+			if configs[i].Count > limits.MaxSameMonsterType {
+				excessCR += float64(configs[i].Count-limits.MaxSameMonsterType) * configs[i].CR
+				configs[i].Count = limits.MaxSameMonsterType
+			}
+		}
+		b.redistributeCR(configs, excessCR)
+	}
 
-	// Apply the scaling factor to each monster count
-	for i := range adjustedConfigs {
-		// Calculate the new count based on the scaling factor
-		newCount := int(math.Round(float64(adjustedConfigs[i].Count) * scalingFactor))
+	if limits.MaxTotalMonsters > 0 {
+		total := totalMonsterCount(configs)
+		if total > limits.MaxTotalMonsters {
+			// Scale down proportionally, keeping at least one of each type
+			for i := range configs {
+				if configs[i].Count > 0 {
+					configs[i].Count = max(1, configs[i].Count*limits.MaxTotalMonsters/total)
+				}
+			}
+			// Trim rounding leftovers from the most numerous types
+			for totalMonsterCount(configs) > limits.MaxTotalMonsters {
+				i := mostNumerousConfig(configs, func(c MonsterConfig) bool { return c.Count > 1 })
+				if i < 0 {
+					break
+				}
+				configs[i].Count--
+			}
+		}
+	}
 
-		// Ensure we have at least one monster if the original count was non-zero
-		if adjustedConfigs[i].Count > 0 && newCount < 1 {
-			newCount = 1
+	if limits.MinTotalMonsters > 0 {
+		for totalMonsterCount(configs) < limits.MinTotalMonsters {
+			i := mostNumerousConfig(configs, func(c MonsterConfig) bool {
+				return c.Count > 0 && (limits.MaxSameMonsterType == 0 || c.Count < limits.MaxSameMonsterType)
+			})
+			if i < 0 {
+				break
+			}
+			configs[i].Count++
 		}
+	}
+}
 
-		adjustedConfigs[i].Count = newCount
+// redistributeCR spends a CR budget on extra monsters of types still under MaxSameMonsterType
+func (b *StandardBalancer) redistributeCR(configs []MonsterConfig, budget float64) {
+	const epsilon = 1e-9
+	maxSame := b.config.MonsterCountLimits.MaxSameMonsterType
+
+	eligible := func(i int) bool {
+		return configs[i].Count > 0 && configs[i].Count < maxSame && configs[i].CR > 0 && configs[i].CR <= budget+epsilon
 	}
 
-	return adjustedConfigs, nil
+	if b.config.DistributionStrategy == DistributeToLowest {
+		for {
+			lowest := -1
+			for i := range configs {
+				if eligible(i) && (lowest < 0 || configs[i].CR < configs[lowest].CR) {
+					lowest = i
+				}
+			}
+			if lowest < 0 {
+				return
+			}
+			configs[lowest].Count++
+			budget -= configs[lowest].CR
+		}
+	}
+
+	for added := true; added; {
+		added = false
+		for i := range configs {
+			if eligible(i) {
+				configs[i].Count++
+				budget -= configs[i].CR
+				added = true
+			}
+		}
+	}
+}
+
+// totalMonsterCount returns the sum of the counts of all configs
+func totalMonsterCount(configs []MonsterConfig) int {
+	total := 0
+	for _, config := range configs {
+		total += config.Count
+	}
+	return total
+}
+
+// mostNumerousConfig returns the index of the config with the highest count among those accepted by canUse
+// Returns -1 if no config qualifies
+func mostNumerousConfig(configs []MonsterConfig, canUse func(MonsterConfig) bool) int {
+	best := -1
+	for i, config := range configs {
+		if canUse(config) && (best < 0 || config.Count > configs[best].Count) {
+			best = i
+		}
+	}
+	return best
+}
+
+// monsterConfigsAdjustedXP returns the adjusted XP of the monsters the configs describe against the party
+// XP is taken from the official CR to XP table, and the result matches what DetermineEncounterDifficulty rates
+func monsterConfigsAdjustedXP(configs []MonsterConfig, party entities.Party) int {
+	return adjustedMonsterXP(expandMonsterConfigs(configs), party, party.Size())
+}
+
+// ComputeRemainingBudget returns how much XP and CR can still be added before reaching the difficulty's target
+// remainingXP is the party's XP threshold for the difficulty minus the adjusted XP of the existing monsters,
+// and is negative when the budget is exceeded. remainingCR is the highest official CR of one more monster
+// that keeps the adjusted XP, with the multiplier for the larger group, within the threshold; it is 0 when
+// no monster fits
+func (b *StandardBalancer) ComputeRemainingBudget(existing []MonsterConfig, party entities.Party, difficulty entities.EncounterDifficulty) (int, float64, error) {
+	budget, err := b.CalculateEncounterXPBudget(party, difficulty)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	remainingCR := 0.0
+	combined := append(append(make([]MonsterConfig, 0, len(existing)+1), existing...), MonsterConfig{Count: 1})
+	for _, cr := range crutil.OfficialCRs() {
+		combined[len(existing)].CR = cr
+		if monsterConfigsAdjustedXP(combined, party) <= budget {
+			remainingCR = cr
+		}
+	}
+
+	return budget - monsterConfigsAdjustedXP(existing, party), remainingCR, nil
+}
+
+// CanAddMonster reports whether adding the candidate keeps the encounter within the difficulty band
+// The band ends at the threshold of the next harder difficulty, so deadly encounters have no upper limit.
+// A candidate with no count is treated as a single monster. When it does not fit, a reason is returned
+func (b *StandardBalancer) CanAddMonster(existing []MonsterConfig, candidate MonsterConfig, party entities.Party, difficulty entities.EncounterDifficulty) (bool, string, error) {
+	if _, err := b.CalculateEncounterXPBudget(party, difficulty); err != nil {
+		return false, "", err
+	}
+	thresholds, err := partyXPThresholds(party)
+	if err != nil {
+		return false, "", err
+	}
+
+	if candidate.Count <= 0 {
+		candidate.Count = 1
+	}
+	combined := append(append(make([]MonsterConfig, 0, len(existing)+1), existing...), candidate)
+	adjustedXP := monsterConfigsAdjustedXP(combined, party)
+
+	for i, d := range thresholdDifficulties {
+		if d != difficulty || i == len(thresholdDifficulties)-1 {
+			continue
+		}
+		next := thresholdDifficulties[i+1]
+		if adjustedXP >= thresholds[next] {
+			return false, fmt.Sprintf("adding %d %s raises adjusted XP to %d, reaching the %s threshold of %d",
+				candidate.Count, candidate.Name, adjustedXP, next, thresholds[next]), nil
+		}
+	}
+
+	return true, "", nil
+}
+
+// EncounterTableEntry is one possible result of rolling an EncounterTable
+type EncounterTableEntry struct {
+	Weight         int             // Relative chance of the entry being rolled; entries with no weight are never rolled
+	MonsterConfigs []MonsterConfig // Monsters the entry produces
+	MinPartyLevel  int             // Lowest average party level the entry applies to (0 for no minimum)
+	MaxPartyLevel  int             // Highest average party level the entry applies to (0 for no maximum)
+}
+
+// EncounterTable is a weighted table of random encounters
+type EncounterTable struct {
+	Entries []EncounterTableEntry
+}
+
+// RollEncounterTable picks an entry from the table at random, weighted by Weight, and returns its monsters
+// Only entries whose level range contains the party's average level, rounded to the nearest level, are
+// considered. A nil rng uses the shared source. Returns an error if no entry can be rolled
+func RollEncounterTable(table *EncounterTable, party entities.Party, rng *rand.Rand) ([]MonsterConfig, error) {
+	if table == nil {
+		return nil, fmt.Errorf("encounter table cannot be nil")
+	}
+	if party.Size() == 0 {
+		return nil, fmt.Errorf("party cannot be empty")
+	}
+
+	level := int(math.Round(party.AverageLevel()))
+	eligible := make([]EncounterTableEntry, 0, len(table.Entries))
+	totalWeight := 0
+	for _, entry := range table.Entries {
+		if entry.Weight <= 0 || len(entry.MonsterConfigs) == 0 {
+			continue
+		}
+		if level < entry.MinPartyLevel || (entry.MaxPartyLevel > 0 && level > entry.MaxPartyLevel) {
+			continue
+		}
+		eligible = append(eligible, entry)
+		totalWeight += entry.Weight
+	}
+	if len(eligible) == 0 {
+		return nil, fmt.Errorf("no encounter table entries apply to party level %d", level)
+	}
+
+	intn := rand.Intn
+	if rng != nil {
+		intn = rng.Intn
+	}
+	roll := intn(totalWeight)
+	for _, entry := range eligible {
+		if roll < entry.Weight {
+			return cloneSlice(entry.MonsterConfigs), nil
+		}
+		roll -= entry.Weight
+	}
+	return nil, fmt.Errorf("encounter table roll %d exceeded total weight %d", roll, totalWeight)
 }
@@ -1,10 +1,12 @@
 package services
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // createTestParty creates a party with the given number of members at the specified level
@@ -135,33 +137,47 @@ func TestDetermineEncounterDifficulty(t *testing.T) {
 			expectError:  false,
 		},
 		{
-			name:         "Medium difficulty monsters",
-			monsters:     createTestMonsters(5, 0, 0), // Total CR 5, avg level 5, 4 players: 5/(5*1.0) = 1.0 = medium threshold
+			name:         "Medium difficulty monster",
+			monsters:     createTestMonsters(6), // 2300 XP against a medium threshold of 4 x 500 = 2000
 			party:        createTestParty(4, 5),
 			expectedDiff: entities.EncounterDifficultyMedium,
 			expectError:  false,
 		},
 		{
-			name:         "Hard difficulty monsters",
-			monsters:     createTestMonsters(7.5, 0, 0), // Total CR 7.5, avg level 5, 4 players: 7.5/(5*1.0) = 1.5 = hard threshold
+			name:         "Hard difficulty monster",
+			monsters:     createTestMonsters(8), // 3900 XP against a hard threshold of 4 x 750 = 3000
 			party:        createTestParty(4, 5),
 			expectedDiff: entities.EncounterDifficultyHard,
 			expectError:  false,
 		},
 		{
-			name:         "Deadly difficulty monsters",
-			monsters:     createTestMonsters(10, 0, 0), // Total CR 10, avg level 5, 4 players: 10/(5*1.0) = 2.0 = deadly threshold
+			name:         "Deadly difficulty monster",
+			monsters:     createTestMonsters(10), // 5900 XP against a deadly threshold of 4 x 1100 = 4400
 			party:        createTestParty(4, 5),
 			expectedDiff: entities.EncounterDifficultyDeadly,
 			expectError:  false,
 		},
+		{
+			name:         "Monster count multiplier",
+			monsters:     createTestMonsters(2, 2, 2), // 3 x 450 = 1350 XP, doubled to 2700 for three monsters
+			party:        createTestParty(4, 5),
+			expectedDiff: entities.EncounterDifficultyMedium,
+			expectError:  false,
+		},
 		{
 			name:         "Solo player adjustment",
-			monsters:     createTestMonsters(5, 0, 0), // Total CR 5, avg level 5, 1 player: 5/(5*0.5) = 2.0 = deadly threshold
+			monsters:     createTestMonsters(3), // 700 XP, x1.5 for a party of fewer than three = 1050 against a hard threshold of 750
 			party:        createTestParty(1, 5),
-			expectedDiff: entities.EncounterDifficultyDeadly, // 5 CR vs level 5 solo player (with 0.5 adjustment) is deadly
+			expectedDiff: entities.EncounterDifficultyHard,
 			expectError:  false,
 		},
+		{
+			name:           "Invalid party level",
+			monsters:       createTestMonsters(1),
+			party:          createTestParty(4, 21),
+			expectError:    true,
+			errorSubstring: "invalid level",
+		},
 		{
 			name:           "Empty party",
 			monsters:       createTestMonsters(1),
@@ -188,6 +204,80 @@ func TestDetermineEncounterDifficulty(t *testing.T) {
 	}
 }
 
+func TestDetermineEncounterDifficultyConditionImmunities(t *testing.T) {
+	balancer := createTestBalancer()
+	wight := entities.Monster{Key: "wight", CR: 5, ConditionImmunities: []entities.Condition{entities.ConditionPoisoned}}
+
+	// 1800 XP against hard and deadly thresholds of 1500 and 2000
+	party := createTestParty(4, 4)
+	difficulty, err := balancer.DetermineEncounterDifficulty([]entities.Monster{wight}, party)
+	require.NoError(t, err)
+	assert.Equal(t, entities.EncounterDifficultyHard, difficulty, "immunities the party cannot exploit do not matter")
+
+	party.Members[0].InflictedConditions = []entities.Condition{entities.ConditionPoisoned}
+	difficulty, err = balancer.DetermineEncounterDifficulty([]entities.Monster{wight}, party)
+	require.NoError(t, err)
+	assert.Equal(t, entities.EncounterDifficultyDeadly, difficulty, "1800 x 1.25 = 2250 XP")
+}
+
+func TestCalculateEncounterXPBudget(t *testing.T) {
+	balancer := createTestBalancer()
+
+	testCases := []struct {
+		name        string
+		party       entities.Party
+		difficulty  entities.EncounterDifficulty
+		expected    int
+		expectError bool
+	}{
+		{"Level 5 easy", createTestParty(1, 5), entities.EncounterDifficultyEasy, 250, false},
+		{"Level 5 medium", createTestParty(1, 5), entities.EncounterDifficultyMedium, 500, false},
+		{"Level 5 hard", createTestParty(1, 5), entities.EncounterDifficultyHard, 750, false},
+		{"Level 5 deadly", createTestParty(1, 5), entities.EncounterDifficultyDeadly, 1100, false},
+		{"Four level 5 characters", createTestParty(4, 5), entities.EncounterDifficultyMedium, 2000, false},
+		{"Mixed levels", entities.Party{Members: []entities.PartyMember{{Level: 1}, {Level: 20}}}, entities.EncounterDifficultyHard, 75 + 8500, false},
+		{"Empty party", entities.Party{}, entities.EncounterDifficultyEasy, 0, true},
+		{"Invalid level", createTestParty(2, 0), entities.EncounterDifficultyEasy, 0, true},
+		{"Invalid difficulty", createTestParty(4, 5), entities.EncounterDifficulty("impossible"), 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			budget, err := balancer.CalculateEncounterXPBudget(tc.party, tc.difficulty)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, budget)
+		})
+	}
+}
+
+func TestCalculateAdjustedXP(t *testing.T) {
+	balancer := createTestBalancer()
+
+	testCases := []struct {
+		name     string
+		monsters []entities.Monster
+		expected int
+	}{
+		{"No monsters", nil, 0},
+		{"One monster", createTestMonsters(1), 200},
+		{"Two monsters", createTestMonsters(1, 1), 600},
+		{"Three monsters", createTestMonsters(1, 1, 1), 1200},
+		{"Six monsters", createTestMonsters(0.25, 0.25, 0.25, 0.25, 0.25, 0.25), 600},
+		{"Seven monsters", createTestMonsters(0.25, 0.25, 0.25, 0.25, 0.25, 0.25, 0.25), 875},
+		{"Explicit XP", []entities.Monster{{CR: 1, XP: 250}}, 250},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, balancer.CalculateAdjustedXP(tc.monsters))
+		})
+	}
+}
+
 func TestAdjustMonsterSelection(t *testing.T) {
 	balancer := createTestBalancer()
 
@@ -211,7 +301,7 @@ func TestAdjustMonsterSelection(t *testing.T) {
 				// Should remain unchanged since it's already balanced
 				assert.Len(t, configs, 1)
 				assert.Equal(t, "Goblin", configs[0].Name)
-				assert.Equal(t, 2, configs[0].Count) // 2 goblins = 0.5 CR, which matches target CR for Easy
+				assert.Equal(t, 2, configs[0].Count) // 2 goblins = 100 XP x 1.5 = 150, which rates easy (100-199)
 			},
 			expectError: false,
 		},
@@ -226,9 +316,8 @@ func TestAdjustMonsterSelection(t *testing.T) {
 			checkFunc: func(t *testing.T, configs []MonsterConfig) {
 				assert.Len(t, configs, 2)
 
-				// Total CR before: 2*0.25 + 1*0.5 = 1
-				// Target CR for deadly: 5*2.0*1.0 = 10
-				// Scaling factor: 10/1 = 10
+				// Adjusted XP before: (2*50 + 100) x 2 = 400
+				// Deadly threshold: 4 x 1100 = 4400
 
 				// Check that counts are scaled up
 				totalCountBefore := 3 // 2 goblins + 1 orc
@@ -251,9 +340,8 @@ func TestAdjustMonsterSelection(t *testing.T) {
 			checkFunc: func(t *testing.T, configs []MonsterConfig) {
 				assert.Len(t, configs, 1)
 
-				// Total CR before: 3*5 = 15
-				// Target CR for easy: 5*0.5*1.0 = 2.5
-				// Scaling factor: 2.5/15 = 0.167
+				// Adjusted XP before: 3*1800 x 2 = 10800
+				// Easy threshold: 4 x 250 = 1000, medium 2000
 
 				// Check that count is scaled down but at least 1
 				assert.Less(t, configs[0].Count, 3)
@@ -289,3 +377,249 @@ func TestAdjustMonsterSelection(t *testing.T) {
 		})
 	}
 }
+
+func TestAdjustMonsterSelectionMatchesRating(t *testing.T) {
+	balancer := createTestBalancer()
+	party := createTestParty(4, 5)
+
+	for _, difficulty := range thresholdDifficulties {
+		t.Run(string(difficulty), func(t *testing.T) {
+			goblins := []MonsterConfig{{Name: "Goblin", Key: "goblin", CR: 0.25, Count: 4, RandomPlace: true}}
+			adjusted, err := balancer.AdjustMonsterSelection(goblins, party, difficulty)
+			require.NoError(t, err)
+
+			rated, err := balancer.DetermineEncounterDifficulty(expandMonsterConfigs(adjusted), party)
+			require.NoError(t, err)
+			assert.Equal(t, difficulty, rated, "%d goblins", adjusted[0].Count)
+		})
+	}
+}
+
+func TestAdjustMonsterSelectionCountLimits(t *testing.T) {
+	// Adjusted XP of 750 x 3 = 2250 already rates deadly for a level 4 party of four,
+	// so only the count limits change the selection
+	createConfigs := func() []MonsterConfig {
+		return []MonsterConfig{
+			{Name: "Goblin", Key: "goblin", CR: 0.25, Count: 10, RandomPlace: true},
+			{Name: "Orc", Key: "orc", CR: 0.5, Count: 2, RandomPlace: true},
+			{Name: "Kobold", Key: "kobold", CR: 0.125, Count: 2, RandomPlace: true},
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		config         BalancerConfig
+		expectedCounts []int
+		errorSubstring string
+	}{
+		{
+			name:           "No limits",
+			expectedCounts: []int{10, 2, 2},
+		},
+		{
+			name:           "Same type cap redistributes evenly",
+			config:         BalancerConfig{MonsterCountLimits: MonsterCountLimits{MaxSameMonsterType: 8}},
+			expectedCounts: []int{8, 3, 2}, // 0.5 CR excess buys one orc
+		},
+		{
+			name: "Same type cap redistributes to lowest CR",
+			config: BalancerConfig{
+				MonsterCountLimits:   MonsterCountLimits{MaxSameMonsterType: 8},
+				DistributionStrategy: DistributeToLowest,
+			},
+			expectedCounts: []int{8, 2, 6}, // 0.5 CR excess buys four kobolds
+		},
+		{
+			name:           "Max total scales down keeping one of each",
+			config:         BalancerConfig{MonsterCountLimits: MonsterCountLimits{MaxTotalMonsters: 6}},
+			expectedCounts: []int{4, 1, 1},
+		},
+		{
+			name:           "Min total grows the most numerous type",
+			config:         BalancerConfig{MonsterCountLimits: MonsterCountLimits{MinTotalMonsters: 20}},
+			expectedCounts: []int{16, 2, 2},
+		},
+		{
+			name:           "Min total respects the same type cap",
+			config:         BalancerConfig{MonsterCountLimits: MonsterCountLimits{MinTotalMonsters: 20, MaxSameMonsterType: 11}},
+			expectedCounts: []int{11, 7, 2},
+		},
+		{
+			name:           "Min above max",
+			config:         BalancerConfig{MonsterCountLimits: MonsterCountLimits{MinTotalMonsters: 10, MaxTotalMonsters: 5}},
+			errorSubstring: "exceeds maximum",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			balancer := NewBalancerWithConfig(tc.config)
+			input := createConfigs()
+
+			adjusted, err := balancer.AdjustMonsterSelection(input, createTestParty(4, 4), entities.EncounterDifficultyDeadly)
+
+			if tc.errorSubstring != "" {
+				assert.ErrorContains(t, err, tc.errorSubstring)
+				return
+			}
+			assert.NoError(t, err)
+
+			counts := make([]int, len(adjusted))
+			for i, config := range adjusted {
+				counts[i] = config.Count
+			}
+			assert.Equal(t, tc.expectedCounts, counts)
+			assert.Equal(t, createConfigs(), input, "input configs must not be modified")
+		})
+	}
+}
+
+func TestAdjustMonsterSelectionLimitsAfterScaling(t *testing.T) {
+	balancer := NewBalancerWithConfig(BalancerConfig{
+		MonsterCountLimits: MonsterCountLimits{MaxTotalMonsters: 5, MaxSameMonsterType: 3},
+	})
+	configs := []MonsterConfig{
+		{Name: "Goblin", Key: "goblin", CR: 0.25, Count: 2, RandomPlace: true},
+		{Name: "Orc", Key: "orc", CR: 0.5, Count: 1, RandomPlace: true},
+		{Name: "Bugbear", Key: "bugbear", CR: 1, Count: 1, RandomPlace: true},
+	}
+
+	// A deadly encounter for level 5 scales every count up well past the limits
+	adjusted, err := balancer.AdjustMonsterSelection(configs, createTestParty(4, 5), entities.EncounterDifficultyDeadly)
+	assert.NoError(t, err)
+
+	total := 0
+	for _, config := range adjusted {
+		assert.LessOrEqual(t, config.Count, 3)
+		assert.GreaterOrEqual(t, config.Count, 1)
+		total += config.Count
+	}
+	assert.LessOrEqual(t, total, 5)
+}
+
+func TestComputeRemainingBudget(t *testing.T) {
+	balancer := createTestBalancer()
+	party := createTestParty(4, 3) // Medium threshold 600 XP
+	goblins := []MonsterConfig{createTestMonsterConfig("Goblin", "goblin", 0.25, 2, true, nil)}
+
+	// Two goblins are 100 XP, times the 1.5 multiplier for a pair. A third monster raises the
+	// multiplier to 2, so a CR 1 monster (200 XP) is the largest that fits: (100 + 200) x 2 = 600
+	remainingXP, remainingCR, err := balancer.ComputeRemainingBudget(goblins, party, entities.EncounterDifficultyMedium)
+	assert.NoError(t, err)
+	assert.Equal(t, 450, remainingXP)
+	assert.Equal(t, 1.0, remainingCR)
+
+	// A lone CR 2 monster is 450 XP, while CR 3 would be 700
+	remainingXP, remainingCR, err = balancer.ComputeRemainingBudget(nil, party, entities.EncounterDifficultyMedium)
+	assert.NoError(t, err)
+	assert.Equal(t, 600, remainingXP)
+	assert.Equal(t, 2.0, remainingCR)
+
+	ogres := []MonsterConfig{createTestMonsterConfig("Ogre", "ogre", 2, 2, true, nil)}
+	remainingXP, remainingCR, err = balancer.ComputeRemainingBudget(ogres, party, entities.EncounterDifficultyMedium)
+	assert.NoError(t, err)
+	assert.Equal(t, -750, remainingXP)
+	assert.Equal(t, 0.0, remainingCR)
+
+	_, _, err = balancer.ComputeRemainingBudget(goblins, entities.Party{}, entities.EncounterDifficultyMedium)
+	assert.Error(t, err)
+	_, _, err = balancer.ComputeRemainingBudget(goblins, party, "impossible")
+	assert.Error(t, err)
+}
+
+func TestCanAddMonster(t *testing.T) {
+	balancer := createTestBalancer()
+	party := createTestParty(4, 3) // Hard threshold 900 XP
+	goblins := []MonsterConfig{createTestMonsterConfig("Goblin", "goblin", 0.25, 2, true, nil)}
+
+	t.Run("Goblin fits", func(t *testing.T) {
+		goblin := MonsterConfig{Name: "Goblin", Key: "goblin", CR: 0.25}
+		ok, reason, err := balancer.CanAddMonster(goblins, goblin, party, entities.EncounterDifficultyMedium)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Empty(t, reason)
+	})
+
+	t.Run("Dragon blows the budget", func(t *testing.T) {
+		dragon := MonsterConfig{Name: "Adult Red Dragon", Key: "adult-red-dragon", CR: 17, Count: 1}
+		ok, reason, err := balancer.CanAddMonster(goblins, dragon, party, entities.EncounterDifficultyMedium)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Contains(t, reason, "Adult Red Dragon")
+		assert.Contains(t, reason, "hard threshold of 900")
+
+		ok, _, err = balancer.CanAddMonster(goblins, dragon, party, entities.EncounterDifficultyDeadly)
+		assert.NoError(t, err)
+		assert.True(t, ok, "deadly encounters have no upper limit")
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, _, err := balancer.CanAddMonster(goblins, goblins[0], entities.Party{}, entities.EncounterDifficultyMedium)
+		assert.Error(t, err)
+		_, _, err = balancer.CanAddMonster(goblins, goblins[0], createTestParty(4, 25), entities.EncounterDifficultyMedium)
+		assert.Error(t, err)
+	})
+}
+
+func TestRollEncounterTable(t *testing.T) {
+	goblins := []MonsterConfig{createTestMonsterConfig("Goblin", "goblin", 0.25, 4, true, nil)}
+	ogre := []MonsterConfig{createTestMonsterConfig("Ogre", "ogre", 2, 1, true, nil)}
+	dragon := []MonsterConfig{createTestMonsterConfig("Young Red Dragon", "young-red-dragon", 10, 1, true, nil)}
+
+	t.Run("Weights affect how often entries are rolled", func(t *testing.T) {
+		table := &EncounterTable{Entries: []EncounterTableEntry{
+			{Weight: 3, MonsterConfigs: goblins},
+			{Weight: 1, MonsterConfigs: ogre},
+		}}
+		rng := rand.New(rand.NewSource(42))
+
+		counts := map[string]int{}
+		for i := 0; i < 1000; i++ {
+			configs, err := RollEncounterTable(table, createTestParty(4, 3), rng)
+			require.NoError(t, err)
+			require.Len(t, configs, 1)
+			counts[configs[0].Key]++
+		}
+		assert.InDelta(t, 750, counts["goblin"], 60)
+		assert.InDelta(t, 250, counts["ogre"], 60)
+	})
+
+	t.Run("Entries outside the party level are excluded", func(t *testing.T) {
+		table := &EncounterTable{Entries: []EncounterTableEntry{
+			{Weight: 1, MonsterConfigs: goblins, MaxPartyLevel: 4},
+			{Weight: 100, MonsterConfigs: dragon, MinPartyLevel: 10},
+			{Weight: 0, MonsterConfigs: ogre},
+		}}
+		rng := rand.New(rand.NewSource(7))
+
+		for i := 0; i < 100; i++ {
+			configs, err := RollEncounterTable(table, createTestParty(4, 3), rng)
+			require.NoError(t, err)
+			assert.Equal(t, "goblin", configs[0].Key)
+		}
+
+		configs, err := RollEncounterTable(table, createTestParty(4, 12), rng)
+		require.NoError(t, err)
+		assert.Equal(t, "young-red-dragon", configs[0].Key)
+
+		_, err = RollEncounterTable(table, createTestParty(4, 6), rng)
+		assert.Error(t, err)
+	})
+
+	t.Run("Rolled configs are copies", func(t *testing.T) {
+		table := &EncounterTable{Entries: []EncounterTableEntry{{Weight: 1, MonsterConfigs: goblins}}}
+		configs, err := RollEncounterTable(table, createTestParty(4, 1), nil)
+		require.NoError(t, err)
+		configs[0].Count = 99
+		assert.Equal(t, 4, table.Entries[0].MonsterConfigs[0].Count)
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		_, err := RollEncounterTable(nil, createTestParty(4, 1), nil)
+		assert.Error(t, err)
+		_, err = RollEncounterTable(&EncounterTable{}, createTestParty(4, 1), nil)
+		assert.Error(t, err)
+		_, err = RollEncounterTable(&EncounterTable{Entries: []EncounterTableEntry{{Weight: 1, MonsterConfigs: goblins}}}, entities.Party{}, nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// DamageEntity deals damage from the actor to the monster or player with the given ID and records it in the
+// room's battle log. actorID may be empty for damage with no source in the room, such as a trap.
+// Returns whether the target is at 0 hit points afterwards. Amounts of 0 or less do nothing
+func (s *RoomService) DamageEntity(room *entities.Room, actorID, targetID string, amount int) (bool, error) {
+	target, actor, err := findBattleEntities(room, actorID, targetID)
+	if err != nil {
+		return false, err
+	}
+
+	var down bool
+	switch t := target.(type) {
+	case *entities.Monster:
+		t.ApplyDamage(amount)
+		down = t.IsDead()
+	case *entities.Player:
+		t.ApplyDamage(amount)
+		down = t.IsUnconscious()
+	default:
+		return false, fmt.Errorf("entity with ID %s has no hit points", targetID)
+	}
+
+	if amount > 0 {
+		entry := newBattleLogEntry(entities.BattleEventDamage, actor, target)
+		entry.Amount = amount
+		recordBattleEvent(room, entry)
+	}
+	return down, nil
+}
+
+// HealEntity restores hit points from the actor to the monster or player with the given ID and records it in the
+// room's battle log. actorID may be empty for healing with no source in the room. Amounts of 0 or less do nothing
+func (s *RoomService) HealEntity(room *entities.Room, actorID, targetID string, amount int) error {
+	target, actor, err := findBattleEntities(room, actorID, targetID)
+	if err != nil {
+		return err
+	}
+
+	switch t := target.(type) {
+	case *entities.Monster:
+		t.Heal(amount)
+	case *entities.Player:
+		t.Heal(amount)
+	default:
+		return fmt.Errorf("entity with ID %s has no hit points", targetID)
+	}
+
+	if amount > 0 {
+		entry := newBattleLogEntry(entities.BattleEventHealing, actor, target)
+		entry.Amount = amount
+		recordBattleEvent(room, entry)
+	}
+	return nil
+}
+
+// findBattleEntities finds the target and, if actorID is not empty, the actor of a battle event
+func findBattleEntities(room *entities.Room, actorID, targetID string) (entities.Placeable, entities.Placeable, error) {
+	target, err := FindEntityByID(room, targetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if actorID == "" {
+		return target, nil, nil
+	}
+	actor, err := FindEntityByID(room, actorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return target, actor, nil
+}
+
+// newBattleLogEntry returns a battle log entry for an event the actor caused to the target
+// actor may be nil for events with no source in the room
+func newBattleLogEntry(eventType entities.BattleEventType, actor, target entities.Placeable) entities.BattleLogEntry {
+	entry := entities.BattleLogEntry{Type: eventType, TargetID: target.GetID(), TargetType: target.GetCellType()}
+	if actor != nil {
+		entry.ActorID, entry.ActorType = actor.GetID(), actor.GetCellType()
+		if monster, ok := actor.(*entities.Monster); ok {
+			entry.ActorKey = monster.Key
+		}
+	}
+	return entry
+}
+
+// recordBattleEvent appends the entry to the room's battle log on the turn of the latest entry, or turn 1
+func recordBattleEvent(room *entities.Room, entry entities.BattleLogEntry) {
+	entry.Turn = 1
+	if n := len(room.BattleLog); n > 0 {
+		entry.Turn = max(room.BattleLog[n-1].Turn, 1)
+	}
+	room.BattleLog = append(room.BattleLog, entry)
+}
+
+// lastDamage returns the latest battle log entry for damage an entity in the room dealt to the target,
+// or nil if there is none
+func lastDamage(room *entities.Room, targetID string) *entities.BattleLogEntry {
+	for i := len(room.BattleLog) - 1; i >= 0; i-- {
+		entry := &room.BattleLog[i]
+		if entry.Type == entities.BattleEventDamage && entry.TargetID == targetID && entry.ActorID != "" {
+			return entry
+		}
+	}
+	return nil
+}
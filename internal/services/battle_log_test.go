@@ -0,0 +1,101 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestBattleLogFromRoomOperations(t *testing.T) {
+	service := &RoomService{}
+	room := createTestRoom()
+	require.NoError(t, PlaceEntity(room, &entities.Player{ID: "fighter", MaxHP: 20, CurrentHP: 20, Position: entities.Position{X: 0, Y: 0}}))
+	require.NoError(t, PlaceEntity(room, &entities.Player{ID: "cleric", MaxHP: 15, CurrentHP: 15, Position: entities.Position{X: 1, Y: 0}}))
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "goblin-1", Key: "goblin", XP: 50, MaxHP: 7, CurrentHP: 7, Position: entities.Position{X: 3, Y: 3}}))
+
+	down, err := service.DamageEntity(room, "goblin-1", "fighter", 5)
+	require.NoError(t, err)
+	assert.False(t, down)
+	assert.Equal(t, 15, room.Players[0].CurrentHP)
+
+	require.NoError(t, service.HealEntity(room, "cleric", "fighter", 3))
+	assert.Equal(t, 18, room.Players[0].CurrentHP)
+
+	require.NoError(t, service.ApplyCondition(room, "goblin-1", entities.ConditionFrightened))
+	require.NoError(t, service.ApplyCondition(room, "goblin-1", entities.ConditionFrightened), "already frightened")
+
+	down, err = service.DamageEntity(room, "fighter", "goblin-1", 9)
+	require.NoError(t, err)
+	assert.True(t, down)
+
+	xp, notRemoved, err := service.CleanupRoom(room, entities.CellMonster, []string{"goblin-1"})
+	require.NoError(t, err)
+	require.Empty(t, notRemoved)
+	assert.Equal(t, 50, xp)
+
+	assert.Equal(t, []entities.BattleLogEntry{
+		{Turn: 1, Type: entities.BattleEventDamage, ActorID: "goblin-1", ActorKey: "goblin", ActorType: entities.CellMonster, TargetID: "fighter", TargetType: entities.CellPlayer, Amount: 5},
+		{Turn: 1, Type: entities.BattleEventHealing, ActorID: "cleric", ActorType: entities.CellPlayer, TargetID: "fighter", TargetType: entities.CellPlayer, Amount: 3},
+		{Turn: 1, Type: entities.BattleEventConditionApplied, TargetID: "goblin-1", TargetType: entities.CellMonster, Condition: entities.ConditionFrightened},
+		{Turn: 1, Type: entities.BattleEventDamage, ActorID: "fighter", ActorType: entities.CellPlayer, TargetID: "goblin-1", TargetType: entities.CellMonster, Amount: 9},
+		{Turn: 1, Type: entities.BattleEventEntityRemoved, ActorID: "fighter", ActorType: entities.CellPlayer, TargetID: "goblin-1", TargetType: entities.CellMonster, Amount: 50},
+	}, room.BattleLog)
+
+	stats, err := service.GetBattleStatistics(room)
+	require.NoError(t, err)
+	assert.Equal(t, BattleStatistics{
+		TotalXPAwarded:          50,
+		MonstersDefeated:        1,
+		TurnsElapsed:            1,
+		DamageDone:              14,
+		HealingDone:             3,
+		ConditionsApplied:       map[string]int{string(entities.ConditionFrightened): 1},
+		MostDangerousMonsterKey: "goblin",
+		PlayerWithMostKills:     "fighter",
+	}, stats)
+}
+
+func TestBattleLogTurns(t *testing.T) {
+	service := &RoomService{}
+	room := createTestRoom()
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "ogre", MaxHP: 59, CurrentHP: 59, Position: entities.Position{X: 2, Y: 2}}))
+
+	// Entries follow the turn of the latest entry
+	room.BattleLog = append(room.BattleLog, entities.BattleLogEntry{Turn: 3, Type: entities.BattleEventHealing, TargetID: "ogre"})
+	_, err := service.DamageEntity(room, "", "ogre", 10)
+	require.NoError(t, err)
+	require.Len(t, room.BattleLog, 2)
+	assert.Equal(t, 3, room.BattleLog[1].Turn)
+	assert.Empty(t, room.BattleLog[1].ActorID)
+	assert.Equal(t, 49, room.Monsters[0].CurrentHP)
+
+	// Damage with no source in the room credits nobody with the removal
+	_, _, err = service.CleanupRoom(room, entities.CellMonster, nil)
+	require.NoError(t, err)
+	require.Len(t, room.BattleLog, 3)
+	assert.Empty(t, room.BattleLog[2].ActorID)
+}
+
+func TestBattleLogErrors(t *testing.T) {
+	service := &RoomService{}
+	room := createTestRoom()
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "goblin", MaxHP: 7, CurrentHP: 7, Position: entities.Position{X: 2, Y: 2}}))
+	require.NoError(t, PlaceEntity(room, &entities.Item{ID: "chest", Position: entities.Position{X: 3, Y: 3}}))
+
+	_, err := service.DamageEntity(room, "", "missing", 5)
+	assert.ErrorIs(t, err, ErrEntityNotFound)
+	_, err = service.DamageEntity(room, "missing", "goblin", 5)
+	assert.ErrorIs(t, err, ErrEntityNotFound)
+	_, err = service.DamageEntity(room, "", "chest", 5)
+	assert.Error(t, err)
+	assert.Error(t, service.HealEntity(room, "", "chest", 5))
+	assert.ErrorIs(t, service.HealEntity(nil, "", "goblin", 5), entities.ErrNilRoom)
+
+	_, err = service.DamageEntity(room, "", "goblin", 0)
+	require.NoError(t, err)
+	assert.Empty(t, room.BattleLog, "no damage, no entry")
+	assert.Equal(t, 7, room.Monsters[0].CurrentHP)
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// BattleStatistics summarizes a room's battle log for a post-combat report
+type BattleStatistics struct {
+	TotalXPAwarded          int            // XP recorded for every removed entity
+	MonstersDefeated        int            // Monsters removed from the room
+	TurnsElapsed            int            // Highest turn number in the log
+	DamageDone              int            // Total damage dealt by everyone
+	HealingDone             int            // Total healing done by everyone
+	ConditionsApplied       map[string]int // Times each condition was applied
+	MostDangerousMonsterKey string         // Key of the monster type that dealt the most damage
+	PlayerWithMostKills     string         // ID of the player who removed the most entities
+}
+
+// GetBattleStatistics computes a post-combat summary from the room's battle log
+// DamageEntity, HealEntity, ApplyCondition, ApplyConditionToEntity, and CleanupRoom write the log, and callers
+// may append entries of their own, for example to move the log on to a new turn.
+// Damage is added up by monster key, so several goblins count together. Ties for the most dangerous monster
+// and the player with the most kills go to the alphabetically first key or ID
+func (s *RoomService) GetBattleStatistics(room *entities.Room) (BattleStatistics, error) {
+	if room == nil {
+		return BattleStatistics{}, entities.ErrNilRoom
+	}
+
+	stats := BattleStatistics{ConditionsApplied: map[string]int{}}
+	damageByMonster := map[string]int{}
+	killsByPlayer := map[string]int{}
+
+	for _, entry := range room.BattleLog {
+		stats.TurnsElapsed = max(stats.TurnsElapsed, entry.Turn)
+
+		switch entry.Type {
+		case entities.BattleEventDamage:
+			stats.DamageDone += entry.Amount
+			if entry.ActorType == entities.CellMonster {
+				damageByMonster[entry.ActorKey] += entry.Amount
+			}
+		case entities.BattleEventHealing:
+			stats.HealingDone += entry.Amount
+		case entities.BattleEventConditionApplied:
+			stats.ConditionsApplied[string(entry.Condition)]++
+		case entities.BattleEventEntityRemoved:
+			stats.TotalXPAwarded += entry.Amount
+			if entry.TargetType == entities.CellMonster {
+				stats.MonstersDefeated++
+			}
+			if entry.ActorType == entities.CellPlayer {
+				killsByPlayer[entry.ActorID]++
+			}
+		}
+	}
+
+	stats.MostDangerousMonsterKey = largestCount(damageByMonster)
+	stats.PlayerWithMostKills = largestCount(killsByPlayer)
+	return stats, nil
+}
+
+// largestCount returns the key with the largest positive count, preferring the alphabetically first on ties
+// Returns "" if no count is positive
+func largestCount(counts map[string]int) string {
+	best, bestCount := "", 0
+	for key, count := range counts {
+		if count > bestCount || (count == bestCount && count > 0 && key < best) {
+			best, bestCount = key, count
+		}
+	}
+	return best
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestGetBattleStatistics(t *testing.T) {
+	service := &RoomService{}
+	room := createTestRoom()
+	room.BattleLog = []entities.BattleLogEntry{
+		{Turn: 1, Type: entities.BattleEventDamage, ActorID: "goblin-1", ActorKey: "goblin", ActorType: entities.CellMonster, TargetID: "fighter", TargetType: entities.CellPlayer, Amount: 5},
+		{Turn: 1, Type: entities.BattleEventDamage, ActorID: "ogre-1", ActorKey: "ogre", ActorType: entities.CellMonster, TargetID: "fighter", TargetType: entities.CellPlayer, Amount: 9},
+		{Turn: 1, Type: entities.BattleEventDamage, ActorID: "fighter", ActorType: entities.CellPlayer, TargetID: "goblin-1", TargetType: entities.CellMonster, Amount: 7},
+		{Turn: 1, Type: entities.BattleEventEntityRemoved, ActorID: "fighter", ActorType: entities.CellPlayer, TargetID: "goblin-1", TargetType: entities.CellMonster, Amount: 50},
+		{Turn: 2, Type: entities.BattleEventDamage, ActorID: "goblin-2", ActorKey: "goblin", ActorType: entities.CellMonster, TargetID: "cleric", TargetType: entities.CellPlayer, Amount: 6},
+		{Turn: 2, Type: entities.BattleEventConditionApplied, ActorID: "cleric", ActorType: entities.CellPlayer, TargetID: "ogre-1", TargetType: entities.CellMonster, Condition: "frightened"},
+		{Turn: 2, Type: entities.BattleEventHealing, ActorID: "cleric", ActorType: entities.CellPlayer, TargetID: "fighter", TargetType: entities.CellPlayer, Amount: 8},
+		{Turn: 3, Type: entities.BattleEventConditionApplied, ActorID: "goblin-2", ActorType: entities.CellMonster, TargetID: "cleric", TargetType: entities.CellPlayer, Condition: "prone"},
+		{Turn: 3, Type: entities.BattleEventConditionApplied, ActorID: "cleric", ActorType: entities.CellPlayer, TargetID: "goblin-2", TargetType: entities.CellMonster, Condition: "frightened"},
+		{Turn: 3, Type: entities.BattleEventEntityRemoved, ActorID: "cleric", ActorType: entities.CellPlayer, TargetID: "goblin-2", TargetType: entities.CellMonster, Amount: 50},
+		{Turn: 4, Type: entities.BattleEventEntityRemoved, ActorID: "fighter", ActorType: entities.CellPlayer, TargetID: "ogre-1", TargetType: entities.CellMonster, Amount: 450},
+		{Turn: 4, Type: entities.BattleEventEntityRemoved, ActorID: "ogre-1", ActorType: entities.CellMonster, TargetID: "barrel", TargetType: entities.CellObstacle},
+	}
+
+	stats, err := service.GetBattleStatistics(room)
+	require.NoError(t, err)
+
+	assert.Equal(t, BattleStatistics{
+		TotalXPAwarded:          550,
+		MonstersDefeated:        3,
+		TurnsElapsed:            4,
+		DamageDone:              27,
+		HealingDone:             8,
+		ConditionsApplied:       map[string]int{"frightened": 2, "prone": 1},
+		MostDangerousMonsterKey: "goblin",
+		PlayerWithMostKills:     "fighter",
+	}, stats)
+}
+
+func TestGetBattleStatisticsEmptyLog(t *testing.T) {
+	service := &RoomService{}
+
+	stats, err := service.GetBattleStatistics(createTestRoom())
+	require.NoError(t, err)
+	assert.Equal(t, BattleStatistics{ConditionsApplied: map[string]int{}}, stats)
+
+	_, err = service.GetBattleStatistics(nil)
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
+
+func TestLargestCountTies(t *testing.T) {
+	assert.Equal(t, "cleric", largestCount(map[string]int{"fighter": 2, "cleric": 2, "rogue": 1}))
+	assert.Empty(t, largestCount(map[string]int{"fighter": 0}))
+	assert.Empty(t, largestCount(nil))
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// ByteMapObstacleKey is the obstacle key used for obstacle cells of hand-drawn maps
+const ByteMapObstacleKey = "wall"
+
+// GenerateRoomFromByteMap creates a gridded room from a hand-drawn map
+// The map's rows set the room height and their length the width; every row must be the same length.
+// Each byte is looked up in legend: CellMonster places a monster using the service's default monster key
+// (see WithDefaultMonsterKey), CellObstacle places a blocking obstacle, and CellTypeEmpty leaves the cell empty
+func (s *RoomService) GenerateRoomFromByteMap(byteMap [][]byte, legend map[byte]entities.CellType) (*entities.Room, error) {
+	if len(byteMap) == 0 || len(byteMap[0]) == 0 {
+		return nil, fmt.Errorf("byte map cannot be empty")
+	}
+
+	width := len(byteMap[0])
+	configs := []PlaceableConfig{}
+	for y, row := range byteMap {
+		if len(row) != width {
+			return nil, fmt.Errorf("row %d has width %d, expected %d", y, len(row), width)
+		}
+
+		for x, b := range row {
+			cellType, ok := legend[b]
+			if !ok {
+				return nil, fmt.Errorf("byte %q at (%d,%d) is not in the legend", b, x, y)
+			}
+
+			pos := &entities.Position{X: x, Y: y}
+			switch cellType {
+			case entities.CellTypeEmpty:
+			case entities.CellMonster:
+				if s.defaultMonsterKey == "" {
+					return nil, fmt.Errorf("map has monster cells but no default monster key is configured")
+				}
+				configs = append(configs, MonsterConfig{Name: s.defaultMonsterKey, Key: s.defaultMonsterKey, Position: pos})
+			case entities.CellObstacle:
+				configs = append(configs, ObstacleConfig{Name: ByteMapObstacleKey, Key: ByteMapObstacleKey, Blocking: true, Position: pos})
+			default:
+				return nil, fmt.Errorf("unsupported cell type %d for byte %q at (%d,%d)", cellType, b, x, y)
+			}
+		}
+	}
+
+	room, err := s.GenerateRoom(RoomConfig{
+		Width:      width,
+		Height:     len(byteMap),
+		LightLevel: entities.LightLevelBright,
+		UseGrid:    true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(configs) > 0 {
+		if err := s.AddPlaceablesToRoom(room, configs); err != nil {
+			return nil, err
+		}
+	}
+
+	return room, nil
+}
+
+// ParseASCIIMap converts ASCII art into a byte map for GenerateRoomFromByteMap
+// Each line is a row, and blank lines before and after the map are ignored. Every character must be
+// ASCII and appear in legend; the bytes of the result are the characters themselves
+func ParseASCIIMap(ascii string, legend map[rune]entities.CellType) ([][]byte, error) {
+	lines := strings.Split(strings.Trim(ascii, "\r\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return nil, fmt.Errorf("ASCII map cannot be empty")
+	}
+
+	byteMap := make([][]byte, len(lines))
+	for y, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		row := make([]byte, 0, len(line))
+		for x, r := range []rune(line) {
+			if r > 127 {
+				return nil, fmt.Errorf("character %q at (%d,%d) is not ASCII", r, x, y)
+			}
+			if _, ok := legend[r]; !ok {
+				return nil, fmt.Errorf("character %q at (%d,%d) is not in the legend", r, x, y)
+			}
+			row = append(row, byte(r))
+		}
+		if y > 0 && len(row) != len(byteMap[0]) {
+			return nil, fmt.Errorf("line %d has width %d, expected %d", y, len(row), len(byteMap[0]))
+		}
+		byteMap[y] = row
+	}
+
+	return byteMap, nil
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// asciiLegend maps '.' to floor, '#' to walls, and 'g' to the default monster
+var asciiLegend = map[rune]entities.CellType{
+	'.': entities.CellTypeEmpty,
+	'#': entities.CellObstacle,
+	'g': entities.CellMonster,
+}
+
+func TestGenerateRoomFromASCIIMap(t *testing.T) {
+	service, err := NewRoomService(WithDefaultMonsterKey("goblin"))
+	require.NoError(t, err)
+
+	byteMap, err := ParseASCIIMap(`
+#.#.
+.g..
+##g.
+`, asciiLegend)
+	require.NoError(t, err)
+	require.Len(t, byteMap, 3)
+
+	legend := map[byte]entities.CellType{}
+	for r, cellType := range asciiLegend {
+		legend[byte(r)] = cellType
+	}
+	room, err := service.GenerateRoomFromByteMap(byteMap, legend)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, room.Width)
+	assert.Equal(t, 3, room.Height)
+	require.Len(t, room.Monsters, 2)
+	require.Len(t, room.Obstacles, 4)
+
+	for y, row := range byteMap {
+		for x, b := range row {
+			assert.Equal(t, legend[b], room.Grid[y][x].Type, "cell (%d,%d)", x, y)
+		}
+	}
+	for _, monster := range room.Monsters {
+		assert.Equal(t, "goblin", monster.Key)
+	}
+	for _, obstacle := range room.Obstacles {
+		assert.True(t, obstacle.Blocking)
+	}
+	assert.Equal(t, entities.Position{X: 1, Y: 1}, room.Monsters[0].Position)
+	assert.Equal(t, entities.Position{X: 2, Y: 2}, room.Monsters[1].Position)
+}
+
+func TestGenerateRoomFromByteMapErrors(t *testing.T) {
+	legend := map[byte]entities.CellType{'.': entities.CellTypeEmpty, 'g': entities.CellMonster, '@': entities.CellPlayer}
+	withKey, err := NewRoomService(WithDefaultMonsterKey("goblin"))
+	require.NoError(t, err)
+	withoutKey, err := NewRoomService()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name           string
+		service        *RoomService
+		byteMap        [][]byte
+		errorSubstring string
+	}{
+		{"Empty map", withKey, nil, "cannot be empty"},
+		{"Ragged rows", withKey, [][]byte{[]byte(".."), []byte(".")}, "row 1 has width 1"},
+		{"Unknown byte", withKey, [][]byte{[]byte(".x")}, "not in the legend"},
+		{"No default monster", withoutKey, [][]byte{[]byte(".g")}, "no default monster key"},
+		{"Unsupported type", withKey, [][]byte{[]byte(".@")}, "unsupported cell type"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.service.GenerateRoomFromByteMap(tc.byteMap, legend)
+			assert.ErrorContains(t, err, tc.errorSubstring)
+		})
+	}
+}
+
+func TestParseASCIIMapErrors(t *testing.T) {
+	_, err := ParseASCIIMap("\n\n", asciiLegend)
+	assert.ErrorContains(t, err, "cannot be empty")
+	_, err = ParseASCIIMap("..\n.", asciiLegend)
+	assert.ErrorContains(t, err, "line 1 has width 1")
+	_, err = ParseASCIIMap(".x", asciiLegend)
+	assert.ErrorContains(t, err, "not in the legend")
+	_, err = ParseASCIIMap(".é", asciiLegend)
+	assert.ErrorContains(t, err, "not ASCII")
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// Checksum computes an FNV-64a hash of the room's observable state
+// The hash covers dimensions, light level, grid usage, wall cells, entity counts per type,
+// every entity's ID and position, and each obstacle's footprint. Entities are sorted before
+// hashing so the result does not depend on the order in which they were added.
+func (s *RoomService) Checksum(room *entities.Room) (uint64, error) {
+	if room == nil {
+		return 0, entities.ErrNilRoom
+	}
+
+	h := fnv.New64a()
+	buf := make([]byte, 8)
+
+	writeInt := func(v int) {
+		binary.LittleEndian.PutUint64(buf, uint64(int64(v)))
+		h.Write(buf)
+	}
+	writeString := func(v string) {
+		writeInt(len(v))
+		h.Write([]byte(v))
+	}
+
+	writeInt(room.Width)
+	writeInt(room.Height)
+	writeString(string(room.LightLevel))
+	if room.Grid != nil {
+		writeInt(1)
+	} else {
+		writeInt(0)
+	}
+
+	// Walls live only in the grid, so hash their positions in row-major order
+	for y, row := range room.Grid {
+		for x, cell := range row {
+			if cell.Type == entities.CellWall {
+				writeInt(x)
+				writeInt(y)
+			}
+		}
+	}
+	writeInt(-1)
+
+	writeInt(len(room.Monsters))
+	writeInt(len(room.Players))
+	writeInt(len(room.Items))
+	writeInt(len(room.NPCs))
+	writeInt(len(room.Obstacles))
+
+	placeables := collectPlaceables(room)
+	sort.Slice(placeables, func(i, j int) bool {
+		if placeables[i].GetCellType() != placeables[j].GetCellType() {
+			return placeables[i].GetCellType() < placeables[j].GetCellType()
+		}
+		return placeables[i].GetID() < placeables[j].GetID()
+	})
+
+	for _, p := range placeables {
+		pos := p.GetPosition()
+		writeInt(int(p.GetCellType()))
+		writeString(p.GetID())
+		writeInt(pos.X)
+		writeInt(pos.Y)
+		if obstacle, ok := p.(*entities.Obstacle); ok {
+			size := obstacle.Footprint()
+			writeInt(size.Width)
+			writeInt(size.Height)
+		}
+	}
+
+	return h.Sum64(), nil
+}
+
+// HasChangedSince reports whether the room's current checksum differs from lastChecksum
+func (s *RoomService) HasChangedSince(room *entities.Room, lastChecksum uint64) (bool, error) {
+	checksum, err := s.Checksum(room)
+	if err != nil {
+		return false, err
+	}
+
+	return checksum != lastChecksum, nil
+}
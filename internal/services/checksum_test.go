@@ -0,0 +1,137 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestChecksum(t *testing.T) {
+	service := &RoomService{}
+
+	// buildRoom creates a gridded room with two monsters and a player, inserting monsters in the given order
+	buildRoom := func(useGrid bool, monsterOrder []string) *entities.Room {
+		room := NewRoom(5, 5, entities.LightLevelBright)
+		if useGrid {
+			InitializeGrid(room)
+		}
+
+		positions := map[string]entities.Position{
+			"monster1": {X: 1, Y: 1},
+			"monster2": {X: 3, Y: 3},
+		}
+		for _, id := range monsterOrder {
+			pos := positions[id]
+			monster := createTestMonster(id, pos.X, pos.Y)
+			assert.NoError(t, PlaceEntity(room, &monster))
+		}
+
+		player := createTestPlayer("player1", 3, 0, 4)
+		assert.NoError(t, PlaceEntity(room, &player))
+		return room
+	}
+
+	t.Run("Identical rooms produce identical checksums", func(t *testing.T) {
+		room1 := buildRoom(true, []string{"monster1", "monster2"})
+		room2 := buildRoom(true, []string{"monster2", "monster1"})
+
+		sum1, err := service.Checksum(room1)
+		assert.NoError(t, err)
+		sum2, err := service.Checksum(room2)
+		assert.NoError(t, err)
+
+		assert.Equal(t, sum1, sum2)
+	})
+
+	t.Run("Adding a monster changes the checksum", func(t *testing.T) {
+		room := buildRoom(true, []string{"monster1", "monster2"})
+		before, err := service.Checksum(room)
+		assert.NoError(t, err)
+
+		monster := createTestMonster("monster3", 2, 2)
+		assert.NoError(t, PlaceEntity(room, &monster))
+
+		after, err := service.Checksum(room)
+		assert.NoError(t, err)
+		assert.NotEqual(t, before, after)
+	})
+
+	t.Run("Moving an entity changes the checksum", func(t *testing.T) {
+		room := buildRoom(true, []string{"monster1", "monster2"})
+		before, err := service.Checksum(room)
+		assert.NoError(t, err)
+
+		assert.NoError(t, MovePlaceable(room, &room.Monsters[0], entities.Position{X: 4, Y: 0}))
+
+		after, err := service.Checksum(room)
+		assert.NoError(t, err)
+		assert.NotEqual(t, before, after)
+	})
+
+	t.Run("Gridless and gridded rooms differ", func(t *testing.T) {
+		gridded := buildRoom(true, []string{"monster1", "monster2"})
+		gridless := buildRoom(false, []string{"monster1", "monster2"})
+
+		griddedSum, err := service.Checksum(gridded)
+		assert.NoError(t, err)
+		gridlessSum, err := service.Checksum(gridless)
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, griddedSum, gridlessSum)
+	})
+
+	t.Run("Adding walls changes the checksum", func(t *testing.T) {
+		room := buildRoom(true, []string{"monster1", "monster2"})
+		before, err := service.Checksum(room)
+		assert.NoError(t, err)
+
+		assert.NoError(t, AddWallRegion(room, entities.Position{X: 0, Y: 2}, entities.Position{X: 1, Y: 2}))
+
+		after, err := service.Checksum(room)
+		assert.NoError(t, err)
+		assert.NotEqual(t, before, after)
+	})
+
+	t.Run("Obstacle footprint changes the checksum", func(t *testing.T) {
+		checksumWithSize := func(size entities.Size) uint64 {
+			room := buildRoom(true, []string{"monster1", "monster2"})
+			obstacle := &entities.Obstacle{ID: "table", Position: entities.Position{X: 0, Y: 2}, Size: size}
+			assert.NoError(t, PlaceEntity(room, obstacle))
+			sum, err := service.Checksum(room)
+			assert.NoError(t, err)
+			return sum
+		}
+
+		assert.NotEqual(t, checksumWithSize(entities.Size{}), checksumWithSize(entities.Size{Width: 2, Height: 1}))
+		assert.Equal(t, checksumWithSize(entities.Size{}), checksumWithSize(entities.Size{Width: 1, Height: 1}))
+	})
+
+	t.Run("Nil room", func(t *testing.T) {
+		_, err := service.Checksum(nil)
+		assert.ErrorIs(t, err, entities.ErrNilRoom)
+	})
+}
+
+func TestHasChangedSince(t *testing.T) {
+	service := &RoomService{}
+	room := createTestRoom()
+
+	checksum, err := service.Checksum(room)
+	assert.NoError(t, err)
+
+	changed, err := service.HasChangedSince(room, checksum)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	monster := createTestMonster("monster1", 2, 2)
+	assert.NoError(t, PlaceEntity(room, &monster))
+
+	changed, err = service.HasChangedSince(room, checksum)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	_, err = service.HasChangedSince(nil, checksum)
+	assert.Error(t, err)
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// ErrConditionImmune is returned when a condition is applied to a monster immune to it
+var ErrConditionImmune = errors.New("monster is immune to the condition")
+
+// ApplyCondition adds the condition to the monster's conditions and records it in the room's battle log
+// Returns ErrConditionImmune without changing the monster if it is immune to the condition.
+// Applying a condition the monster already has does nothing
+func (s *RoomService) ApplyCondition(room *entities.Room, monsterID string, condition entities.Condition) error {
+	if room == nil {
+		return entities.ErrNilRoom
+	}
+
+	monster, _ := FindMonsterByID(room, monsterID)
+	if monster == nil {
+		return fmt.Errorf("monster with ID %s not found in room", monsterID)
+	}
+	return applyCondition(room, monster, condition)
+}
+
+// ApplyConditionToEntity adds the condition to the conditions of the monster, player, or NPC with the given ID
+// and records it in the room's battle log.
+// Returns ErrConditionImmune without changing a monster that is immune to the condition.
+// Applying a condition the entity already has does nothing
+func (s *RoomService) ApplyConditionToEntity(room *entities.Room, entityID string, condition entities.Condition) error {
+	entity, err := FindEntityByID(room, entityID)
+	if err != nil {
+		return err
+	}
+
+	return applyCondition(room, entity, condition)
+}
+
+// applyCondition applies the condition to an entity found in the room, checking monster immunities first
+func applyCondition(room *entities.Room, entity entities.Placeable, condition entities.Condition) error {
+	target, ok := entity.(entities.Conditionable)
+	if !ok {
+		return fmt.Errorf("entity with ID %s cannot have conditions", entity.GetID())
+	}
+	if monster, ok := entity.(*entities.Monster); ok && monster.IsImmuneTo(condition) {
+		return fmt.Errorf("%w: %s is immune to %s", ErrConditionImmune, monster.Name, condition)
+	}
+
+	if entities.HasCondition(target, condition) {
+		return nil
+	}
+	entities.ApplyCondition(target, condition)
+	entry := newBattleLogEntry(entities.BattleEventConditionApplied, nil, entity)
+	entry.Condition = condition
+	recordBattleEvent(room, entry)
+	return nil
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestApplyCondition(t *testing.T) {
+	service := &RoomService{}
+	room := createTestRoom()
+	goblin := createTestMonster("goblin", 1, 1)
+	require.NoError(t, PlaceEntity(room, &goblin))
+
+	require.NoError(t, service.ApplyCondition(room, goblin.ID, entities.ConditionPoisoned))
+	require.NoError(t, service.ApplyCondition(room, goblin.ID, entities.ConditionPoisoned))
+	assert.Equal(t, []entities.Condition{entities.ConditionPoisoned}, room.Monsters[0].Conditions, "conditions are not duplicated")
+}
+
+func TestApplyConditionImmune(t *testing.T) {
+	service := &RoomService{}
+	room := createTestRoom()
+	require.NoError(t, service.AddPlaceablesToRoom(room, []PlaceableConfig{MonsterConfig{
+		Name:                "Zombie",
+		Key:                 "zombie",
+		CR:                  0.25,
+		Count:               1,
+		RandomPlace:         true,
+		ConditionImmunities: []entities.Condition{entities.ConditionPoisoned},
+	}}))
+	zombie := room.Monsters[0]
+	assert.Equal(t, []entities.Condition{entities.ConditionPoisoned}, zombie.ConditionImmunities)
+
+	err := service.ApplyCondition(room, zombie.ID, entities.ConditionPoisoned)
+	assert.ErrorIs(t, err, ErrConditionImmune)
+	assert.Empty(t, room.Monsters[0].Conditions)
+
+	require.NoError(t, service.ApplyCondition(room, zombie.ID, entities.ConditionProne))
+	assert.Equal(t, []entities.Condition{entities.ConditionProne}, room.Monsters[0].Conditions)
+}
+
+func TestApplyConditionErrors(t *testing.T) {
+	service := &RoomService{}
+
+	assert.ErrorIs(t, service.ApplyCondition(nil, "id", entities.ConditionProne), entities.ErrNilRoom)
+	assert.Error(t, service.ApplyCondition(createTestRoom(), "missing", entities.ConditionProne))
+}
+
+func TestApplyConditionToEntity(t *testing.T) {
+	service := &RoomService{}
+	room := createTestRoom()
+	zombie := createTestMonster("zombie", 0, 0)
+	zombie.ConditionImmunities = []entities.Condition{entities.ConditionPoisoned}
+	player := createTestPlayer("fighter", 3, 1, 0)
+	npc := entities.NPC{ID: "merchant", Position: entities.Position{X: 2, Y: 0}}
+	item := entities.Item{ID: "sword", Position: entities.Position{X: 3, Y: 0}}
+	for _, p := range []entities.Placeable{&zombie, &player, &npc, &item} {
+		require.NoError(t, PlaceEntity(room, p))
+	}
+
+	require.NoError(t, service.ApplyConditionToEntity(room, "zombie", entities.ConditionProne))
+	require.NoError(t, service.ApplyConditionToEntity(room, "fighter", entities.ConditionStunned))
+	require.NoError(t, service.ApplyConditionToEntity(room, "merchant", entities.ConditionFrightened))
+	require.NoError(t, service.ApplyConditionToEntity(room, "merchant", entities.ConditionFrightened))
+	assert.Equal(t, []entities.Condition{entities.ConditionProne}, room.Monsters[0].Conditions)
+	assert.Equal(t, []entities.Condition{entities.ConditionStunned}, room.Players[0].Conditions)
+	assert.Equal(t, []entities.Condition{entities.ConditionFrightened}, room.NPCs[0].Conditions)
+
+	assert.ErrorIs(t, service.ApplyConditionToEntity(room, "zombie", entities.ConditionPoisoned), ErrConditionImmune)
+	assert.False(t, entities.HasCondition(&room.Monsters[0], entities.ConditionPoisoned))
+	assert.Error(t, service.ApplyConditionToEntity(room, "sword", entities.ConditionProne), "items cannot have conditions")
+	assert.ErrorIs(t, service.ApplyConditionToEntity(room, "missing", entities.ConditionProne), ErrEntityNotFound)
+	assert.ErrorIs(t, service.ApplyConditionToEntity(nil, "zombie", entities.ConditionProne), entities.ErrNilRoom)
+}
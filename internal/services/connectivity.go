@@ -0,0 +1,221 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// FindIsolatedRegions groups the walkable cells of a room into connected regions
+// A cell is walkable if it is empty or holds a non-blocking obstacle, so walls split regions, and cells connect in all eight directions.
+// Regions are sorted by size with the largest first; positions within a region are in row-major order
+func (s *RoomService) FindIsolatedRegions(room *entities.Room) ([][]entities.Position, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+
+	return walkableRegions(room, walkableCells(room)), nil
+}
+
+// HasUnreachableCells reports whether any walkable cells lie outside the largest region
+// and returns how many such cells there are
+func (s *RoomService) HasUnreachableCells(room *entities.Room) (bool, int, error) {
+	regions, err := s.FindIsolatedRegions(room)
+	if err != nil {
+		return false, 0, err
+	}
+
+	unreachable := 0
+	for _, region := range regions[min(1, len(regions)):] {
+		unreachable += len(region)
+	}
+
+	return unreachable > 0, unreachable, nil
+}
+
+// EnsureConnectivity removes blocking obstacles until every walkable region is connected
+// Regions are joined to the largest one in turn, each time through the fewest blocking obstacles.
+// If more than maxBlockingObstaclesToRemove obstacles would be needed, the room is left unchanged and an error is returned
+func (s *RoomService) EnsureConnectivity(room *entities.Room, maxBlockingObstaclesToRemove int) error {
+	if room == nil {
+		return entities.ErrNilRoom
+	}
+	if maxBlockingObstaclesToRemove < 0 {
+		return fmt.Errorf("maximum obstacles to remove cannot be negative")
+	}
+
+	// Plan every removal before touching the room so a failure leaves it intact
+	walkable := walkableCells(room)
+	obstacleAt := map[entities.Position]*entities.Obstacle{}
+	for i := range room.Obstacles {
+		if room.Obstacles[i].Blocking {
+			for _, pos := range room.Obstacles[i].Cells() {
+				obstacleAt[pos] = &room.Obstacles[i]
+			}
+		}
+	}
+
+	toRemove := []string{}
+	for {
+		regions := walkableRegions(room, walkable)
+		if len(regions) <= 1 {
+			break
+		}
+
+		breach, err := cheapestBreach(room, regions, walkable, obstacleAt)
+		if err != nil {
+			return err
+		}
+		for _, pos := range breach {
+			obstacle := obstacleAt[pos]
+			if slices.Contains(toRemove, obstacle.ID) {
+				continue
+			}
+			// Removing an obstacle frees its whole footprint
+			for _, cell := range obstacle.Cells() {
+				walkable[cell] = true
+			}
+			toRemove = append(toRemove, obstacle.ID)
+		}
+		if len(toRemove) > maxBlockingObstaclesToRemove {
+			return fmt.Errorf("connecting the room requires removing more than %d blocking obstacles", maxBlockingObstaclesToRemove)
+		}
+	}
+
+	for _, id := range toRemove {
+		removeEntity(room, id, entities.CellObstacle)
+	}
+
+	return nil
+}
+
+// walkableCells returns the set of cells that are empty or hold a non-blocking obstacle
+// Wall cells, blocking obstacles and locked doors are excluded (see blockingObstaclePositions)
+func walkableCells(room *entities.Room) map[entities.Position]bool {
+	walkable := map[entities.Position]bool{}
+	for y := 0; y < room.Height; y++ {
+		for x := 0; x < room.Width; x++ {
+			walkable[entities.Position{X: x, Y: y}] = true
+		}
+	}
+
+	for _, p := range collectPlaceables(room) {
+		if obstacle, ok := p.(*entities.Obstacle); ok && !obstacle.Blocking {
+			continue
+		}
+		delete(walkable, p.GetPosition())
+	}
+	for pos := range blockingObstaclePositions(room) {
+		delete(walkable, pos)
+	}
+
+	return walkable
+}
+
+// walkableRegions flood fills the walkable cells into connected regions, largest first
+func walkableRegions(room *entities.Room, walkable map[entities.Position]bool) [][]entities.Position {
+	visited := map[entities.Position]bool{}
+	regions := [][]entities.Position{}
+
+	for y := 0; y < room.Height; y++ {
+		for x := 0; x < room.Width; x++ {
+			start := entities.Position{X: x, Y: y}
+			if !walkable[start] || visited[start] {
+				continue
+			}
+
+			region := []entities.Position{}
+			stack := []entities.Position{start}
+			visited[start] = true
+			for len(stack) > 0 {
+				current := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				region = append(region, current)
+
+				for _, neighbor := range current.Neighbors(true) {
+					if walkable[neighbor] && !visited[neighbor] {
+						visited[neighbor] = true
+						stack = append(stack, neighbor)
+					}
+				}
+			}
+
+			sort.Slice(region, func(i, j int) bool {
+				if region[i].Y != region[j].Y {
+					return region[i].Y < region[j].Y
+				}
+				return region[i].X < region[j].X
+			})
+			regions = append(regions, region)
+		}
+	}
+
+	sort.SliceStable(regions, func(i, j int) bool {
+		return len(regions[i]) > len(regions[j])
+	})
+
+	return regions
+}
+
+// cheapestBreach finds the fewest blocking obstacles that join the largest region to any other region
+// It runs a 0-1 breadth-first search where walkable cells are free and blocking obstacles cost one removal
+func cheapestBreach(room *entities.Room, regions [][]entities.Position, walkable map[entities.Position]bool, obstacleAt map[entities.Position]*entities.Obstacle) ([]entities.Position, error) {
+	inLargest := map[entities.Position]bool{}
+	for _, pos := range regions[0] {
+		inLargest[pos] = true
+	}
+
+	cost := map[entities.Position]int{}
+	cameFrom := map[entities.Position]entities.Position{}
+	deque := []entities.Position{}
+	for _, pos := range regions[0] {
+		cost[pos] = 0
+		deque = append(deque, pos)
+	}
+
+	for len(deque) > 0 {
+		current := deque[0]
+		deque = deque[1:]
+
+		if walkable[current] && !inLargest[current] {
+			breach := []entities.Position{}
+			for !inLargest[current] {
+				if !walkable[current] {
+					breach = append(breach, current)
+				}
+				current = cameFrom[current]
+			}
+			return breach, nil
+		}
+
+		for _, neighbor := range current.Neighbors(true) {
+			if !IsPositionValid(room, neighbor) {
+				continue
+			}
+
+			step := 0
+			if !walkable[neighbor] {
+				if _, ok := obstacleAt[neighbor]; !ok {
+					continue
+				}
+				step = 1
+			}
+
+			newCost := cost[current] + step
+			if known, ok := cost[neighbor]; ok && newCost >= known {
+				continue
+			}
+			cost[neighbor] = newCost
+			cameFrom[neighbor] = current
+			if step == 0 {
+				deque = append([]entities.Position{neighbor}, deque...)
+			} else {
+				deque = append(deque, neighbor)
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("isolated regions cannot be connected by removing blocking obstacles")
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// createPartitionedRoom creates a 6x5 room split by a wall of blocking obstacles in column 2
+// leaving a 2x5 region on the left and a 3x5 region on the right
+func createPartitionedRoom(t *testing.T) *entities.Room {
+	room := NewRoom(6, 5, entities.LightLevelBright)
+	InitializeGrid(room)
+
+	for y := 0; y < room.Height; y++ {
+		wall := &entities.Obstacle{ID: fmt.Sprintf("wall-%d", y), Key: "wall", Blocking: true, Position: entities.Position{X: 2, Y: y}}
+		require.NoError(t, PlaceEntity(room, wall))
+	}
+	return room
+}
+
+func TestFindIsolatedRegions(t *testing.T) {
+	room := createPartitionedRoom(t)
+	rubble := &entities.Obstacle{ID: "rubble", Key: "rubble", Position: entities.Position{X: 0, Y: 0}}
+	require.NoError(t, PlaceEntity(room, rubble))
+	service := &RoomService{}
+
+	regions, err := service.FindIsolatedRegions(room)
+	require.NoError(t, err)
+	require.Len(t, regions, 2)
+	assert.Len(t, regions[0], 15, "the larger right half comes first")
+	assert.Len(t, regions[1], 10, "non-blocking obstacles are walkable")
+	assert.Equal(t, entities.Position{X: 3, Y: 0}, regions[0][0])
+	assert.Equal(t, entities.Position{X: 0, Y: 0}, regions[1][0])
+
+	unreachable, count, err := service.HasUnreachableCells(room)
+	require.NoError(t, err)
+	assert.True(t, unreachable)
+	assert.Equal(t, 10, count)
+
+	_, err = service.FindIsolatedRegions(nil)
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
+
+func TestFindIsolatedRegionsWalls(t *testing.T) {
+	room := NewRoom(6, 5, entities.LightLevelBright)
+	InitializeGrid(room)
+	require.NoError(t, AddWallRegion(room, entities.Position{X: 2, Y: 0}, entities.Position{X: 2, Y: 4}))
+	service := &RoomService{}
+
+	regions, err := service.FindIsolatedRegions(room)
+	require.NoError(t, err)
+	require.Len(t, regions, 2)
+	assert.Len(t, regions[0], 15)
+	assert.Len(t, regions[1], 10)
+
+	unreachable, count, err := service.HasUnreachableCells(room)
+	require.NoError(t, err)
+	assert.True(t, unreachable)
+	assert.Equal(t, 10, count)
+
+	assert.Error(t, service.EnsureConnectivity(room, 5), "walls cannot be removed to connect regions")
+}
+
+func TestEnsureConnectivityMultiCellObstacle(t *testing.T) {
+	room := NewRoom(6, 5, entities.LightLevelBright)
+	InitializeGrid(room)
+	barrier := &entities.Obstacle{ID: "barrier", Blocking: true, Size: entities.Size{Width: 1, Height: 5}, Position: entities.Position{X: 2, Y: 0}}
+	require.NoError(t, PlaceEntity(room, barrier))
+	service := &RoomService{}
+
+	regions, err := service.FindIsolatedRegions(room)
+	require.NoError(t, err)
+	assert.Len(t, regions, 2)
+
+	require.NoError(t, service.EnsureConnectivity(room, 1))
+	assert.Empty(t, room.Obstacles)
+	assert.Empty(t, ValidateRoomGrid(room))
+}
+
+func TestEnsureConnectivity(t *testing.T) {
+	room := createPartitionedRoom(t)
+	service := &RoomService{}
+
+	err := service.EnsureConnectivity(room, 0)
+	assert.Error(t, err)
+	assert.Len(t, room.Obstacles, 5, "room must be unchanged when the limit is too low")
+
+	require.NoError(t, service.EnsureConnectivity(room, 1))
+	assert.Len(t, room.Obstacles, 4)
+
+	regions, err := service.FindIsolatedRegions(room)
+	require.NoError(t, err)
+	assert.Len(t, regions, 1)
+
+	unreachable, count, err := service.HasUnreachableCells(room)
+	require.NoError(t, err)
+	assert.False(t, unreachable)
+	assert.Zero(t, count)
+}
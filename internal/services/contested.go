@@ -0,0 +1,82 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// ContestedCell is an empty cell adjacent to entities from two opposing factions
+type ContestedCell struct {
+	Position         entities.Position    // The contested empty cell
+	AdjacentFaction1 []entities.Placeable // Faction 1 entities within one square
+	AdjacentFaction2 []entities.Placeable // Faction 2 entities within one square
+	ContestScore     int                  // Total number of adjacent entities from both factions
+}
+
+// GetContestedCells returns every empty cell with at least one adjacent entity from each faction
+// Adjacency uses Chebyshev distance, so diagonals count. Results are sorted by ContestScore
+// descending, then by row and column
+func (s *RoomService) GetContestedCells(room *entities.Room, faction1CellType entities.CellType, faction2CellType entities.CellType) ([]ContestedCell, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+	if faction1CellType == faction2CellType {
+		return nil, fmt.Errorf("factions must use different cell types")
+	}
+
+	placeables := collectPlaceables(room)
+	occupied := make(map[entities.Position]bool, len(placeables))
+	for _, p := range placeables {
+		occupied[p.GetPosition()] = true
+	}
+
+	contested := map[entities.Position]*ContestedCell{}
+	for _, p := range placeables {
+		cellType := p.GetCellType()
+		if cellType != faction1CellType && cellType != faction2CellType {
+			continue
+		}
+
+		for _, pos := range p.GetPosition().Neighbors(true) {
+			if !IsPositionValid(room, pos) || occupied[pos] {
+				continue
+			}
+			if room.Grid != nil && room.Grid[pos.Y][pos.X].Type != entities.CellTypeEmpty {
+				continue
+			}
+
+			cell, ok := contested[pos]
+			if !ok {
+				cell = &ContestedCell{Position: pos}
+				contested[pos] = cell
+			}
+			if cellType == faction1CellType {
+				cell.AdjacentFaction1 = append(cell.AdjacentFaction1, p)
+			} else {
+				cell.AdjacentFaction2 = append(cell.AdjacentFaction2, p)
+			}
+			cell.ContestScore++
+		}
+	}
+
+	result := []ContestedCell{}
+	for _, cell := range contested {
+		if len(cell.AdjacentFaction1) > 0 && len(cell.AdjacentFaction2) > 0 {
+			result = append(result, *cell)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ContestScore != result[j].ContestScore {
+			return result[i].ContestScore > result[j].ContestScore
+		}
+		if result[i].Position.Y != result[j].Position.Y {
+			return result[i].Position.Y < result[j].Position.Y
+		}
+		return result[i].Position.X < result[j].Position.X
+	})
+
+	return result, nil
+}
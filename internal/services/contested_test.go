@@ -0,0 +1,106 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// placeableIDs returns the IDs of the given placeables in order
+func placeableIDs(placeables []entities.Placeable) []string {
+	ids := make([]string, len(placeables))
+	for i, p := range placeables {
+		ids[i] = p.GetID()
+	}
+	return ids
+}
+
+func TestGetContestedCells(t *testing.T) {
+	testCases := []struct {
+		name     string
+		gridded  bool
+		obstacle *entities.Obstacle
+	}{
+		{
+			name:    "Cells between goblins and players",
+			gridded: true,
+		},
+		{
+			name:    "Gridless room gives the same result",
+			gridded: false,
+		},
+		{
+			name:     "Occupied cells are not contested",
+			gridded:  true,
+			obstacle: &entities.Obstacle{ID: "o1", Key: "crate", Position: entities.Position{X: 3, Y: 5}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			room := NewRoom(7, 7, entities.LightLevelBright)
+			if tc.gridded {
+				InitializeGrid(room)
+			}
+
+			// Goblins on the left, players on the right, with column 3 between them
+			entitiesToPlace := []entities.Placeable{
+				&entities.Monster{ID: "g1", Key: "goblin", Position: entities.Position{X: 2, Y: 2}},
+				&entities.Monster{ID: "g2", Key: "goblin", Position: entities.Position{X: 2, Y: 4}},
+				&entities.Player{ID: "p1", Level: 1, Position: entities.Position{X: 4, Y: 3}},
+				&entities.Player{ID: "p2", Level: 1, Position: entities.Position{X: 4, Y: 4}},
+			}
+			if tc.obstacle != nil {
+				entitiesToPlace = append(entitiesToPlace, tc.obstacle)
+			}
+			for _, p := range entitiesToPlace {
+				require.NoError(t, PlaceEntity(room, p))
+			}
+
+			service := &RoomService{}
+			cells, err := service.GetContestedCells(room, entities.CellMonster, entities.CellPlayer)
+			require.NoError(t, err)
+
+			expectedPositions := []entities.Position{{X: 3, Y: 3}, {X: 3, Y: 4}, {X: 3, Y: 2}, {X: 3, Y: 5}}
+			if tc.obstacle != nil {
+				expectedPositions = expectedPositions[:3]
+			}
+			require.Len(t, cells, len(expectedPositions))
+			for i, pos := range expectedPositions {
+				assert.Equal(t, pos, cells[i].Position)
+			}
+
+			// (3,3) touches every entity
+			assert.Equal(t, 4, cells[0].ContestScore)
+			assert.ElementsMatch(t, []string{"g1", "g2"}, placeableIDs(cells[0].AdjacentFaction1))
+			assert.ElementsMatch(t, []string{"p1", "p2"}, placeableIDs(cells[0].AdjacentFaction2))
+
+			// (3,4) is out of reach of g1
+			assert.Equal(t, 3, cells[1].ContestScore)
+			assert.Equal(t, []string{"g2"}, placeableIDs(cells[1].AdjacentFaction1))
+			assert.ElementsMatch(t, []string{"p1", "p2"}, placeableIDs(cells[1].AdjacentFaction2))
+
+			// (3,2) only touches g1 and p1
+			assert.Equal(t, 2, cells[2].ContestScore)
+			assert.Equal(t, []string{"g1"}, placeableIDs(cells[2].AdjacentFaction1))
+			assert.Equal(t, []string{"p1"}, placeableIDs(cells[2].AdjacentFaction2))
+		})
+	}
+}
+
+func TestGetContestedCellsErrors(t *testing.T) {
+	service := &RoomService{}
+
+	_, err := service.GetContestedCells(nil, entities.CellMonster, entities.CellPlayer)
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+
+	_, err = service.GetContestedCells(createTestRoom(), entities.CellMonster, entities.CellMonster)
+	assert.ErrorContains(t, err, "different cell types")
+
+	cells, err := service.GetContestedCells(createTestRoom(), entities.CellMonster, entities.CellPlayer)
+	assert.NoError(t, err)
+	assert.Empty(t, cells)
+}
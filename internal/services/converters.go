@@ -0,0 +1,133 @@
+package services
+
+import (
+	"fmt"
+
+	apientities "github.com/fadedpez/dnd5e-api/entities"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// ConvertAPISpellToZoneConfig builds a spell zone config from a D&D 5e API spell cast from casterPosition
+// Spheres, cylinders, and cubes are centered on targetPosition; cones and lines extend from casterPosition
+// toward it. Returns an error if the spell has no area of effect or an unknown one
+func ConvertAPISpellToZoneConfig(spell *apientities.Spell, casterPosition entities.Position, targetPosition entities.Position) (*SpellZoneConfig, error) {
+	if spell == nil {
+		return nil, fmt.Errorf("spell cannot be nil")
+	}
+	if spell.AreaOfEffect == nil {
+		return nil, fmt.Errorf("spell %s has no area of effect", spell.Key)
+	}
+
+	size := spell.AreaOfEffect.Size
+	if size <= 0 {
+		return nil, fmt.Errorf("spell %s has invalid area of effect size %d", spell.Key, size)
+	}
+
+	var affected []entities.Position
+	switch spell.AreaOfEffect.Type {
+	case entities.AreaSphere, entities.AreaCylinder:
+		affected = SphereArea(targetPosition, size)
+	case entities.AreaCube:
+		affected = CubeArea(targetPosition, size)
+	case entities.AreaCone, entities.AreaLine:
+		if casterPosition == targetPosition {
+			return nil, fmt.Errorf("%s spells need a target away from the caster", spell.AreaOfEffect.Type)
+		}
+		if spell.AreaOfEffect.Type == entities.AreaCone {
+			affected = ConeArea(casterPosition, targetPosition, size)
+		} else {
+			affected = LineArea(casterPosition, targetPosition, size)
+		}
+	default:
+		return nil, fmt.Errorf("unknown area of effect type %q for spell %s", spell.AreaOfEffect.Type, spell.Key)
+	}
+
+	return &SpellZoneConfig{
+		Zone: entities.SpellZone{
+			SpellKey:          spell.Key,
+			Name:              spell.Name,
+			Shape:             spell.AreaOfEffect.Type,
+			SizeFt:            size,
+			Origin:            casterPosition,
+			Position:          targetPosition,
+			AffectedPositions: affected,
+		},
+	}, nil
+}
+
+// WeaponItemConfig is an ItemConfig for a weapon, whose items also carry the weapon's damage
+type WeaponItemConfig struct {
+	ItemConfig
+	DamageDice string // Damage dice, such as "1d8"
+	DamageType string // Type of damage dealt, such as "slashing"
+}
+
+// CreatePlaceable implements PlaceableConfig for WeaponItemConfig
+func (c WeaponItemConfig) CreatePlaceable(s *RoomService) (entities.Placeable, error) {
+	placeable, err := c.ItemConfig.CreatePlaceable(s)
+	if err != nil {
+		return nil, err
+	}
+	item := placeable.(*entities.Item)
+	item.Type = "weapon"
+	item.DamageDice = c.DamageDice
+	item.DamageType = c.DamageType
+	return item, nil
+}
+
+// ArmorItemConfig is an ItemConfig for armor, whose items also carry the armor's protection
+type ArmorItemConfig struct {
+	ItemConfig
+	ArmorClass          int  // Base armor class
+	StealthDisadvantage bool // Whether the armor gives disadvantage on stealth checks
+}
+
+// CreatePlaceable implements PlaceableConfig for ArmorItemConfig
+func (c ArmorItemConfig) CreatePlaceable(s *RoomService) (entities.Placeable, error) {
+	placeable, err := c.ItemConfig.CreatePlaceable(s)
+	if err != nil {
+		return nil, err
+	}
+	item := placeable.(*entities.Item)
+	item.Type = "armor"
+	item.ArmorClass = c.ArmorClass
+	item.StealthDisadvantage = c.StealthDisadvantage
+	return item, nil
+}
+
+// ConvertAPIWeaponToConfig builds a config that places count copies of a D&D 5e API weapon at random
+// Returns nil if the weapon is nil
+func ConvertAPIWeaponToConfig(w *apientities.Weapon, count int) *WeaponItemConfig {
+	if w == nil {
+		return nil
+	}
+
+	config := &WeaponItemConfig{
+		ItemConfig: ItemConfig{Key: w.Key, Name: w.Name, Count: count, RandomPlace: true},
+	}
+	if w.Damage != nil {
+		config.DamageDice = w.Damage.DamageDice
+		if w.Damage.DamageType != nil {
+			config.DamageType = w.Damage.DamageType.Key
+		}
+	}
+	return config
+}
+
+// ConvertAPIArmorToConfig builds a config that places count copies of a D&D 5e API armor at random
+// Returns nil if the armor is nil
+func ConvertAPIArmorToConfig(a *apientities.Armor, count int) *ArmorItemConfig {
+	if a == nil {
+		return nil
+	}
+
+	config := &ArmorItemConfig{
+		ItemConfig:          ItemConfig{Key: a.Key, Name: a.Name, Count: count, RandomPlace: true},
+		StealthDisadvantage: a.StealthDisadvantage,
+	}
+	if a.ArmorClass != nil {
+		config.ArmorClass = a.ArmorClass.Base
+	}
+	return config
+}
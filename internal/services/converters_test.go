@@ -0,0 +1,154 @@
+package services
+
+import (
+	"testing"
+
+	apientities "github.com/fadedpez/dnd5e-api/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// createTestAPISpell creates an API spell with the given area of effect, or none if aoeType is empty
+func createTestAPISpell(key, aoeType string, size int) *apientities.Spell {
+	spell := &apientities.Spell{Key: key, Name: nameFromKey(key)}
+	if aoeType != "" {
+		spell.AreaOfEffect = &apientities.AreaOfEffect{Type: aoeType, Size: size}
+	}
+	return spell
+}
+
+func TestConvertAPISpellToZoneConfigCone(t *testing.T) {
+	caster := entities.Position{X: 0, Y: 5}
+	config, err := ConvertAPISpellToZoneConfig(createTestAPISpell("burning-hands", entities.AreaCone, 15), caster, entities.Position{X: 5, Y: 5})
+	require.NoError(t, err)
+
+	assert.Equal(t, "burning-hands", config.Zone.SpellKey)
+	assert.Equal(t, "Burning Hands", config.Zone.Name)
+	assert.Equal(t, entities.AreaCone, config.Zone.Shape)
+	assert.Equal(t, caster, config.Zone.Origin)
+	assert.Equal(t, []entities.Position{{X: 2, Y: 4}, {X: 1, Y: 5}, {X: 2, Y: 5}, {X: 3, Y: 5}, {X: 2, Y: 6}}, config.Zone.AffectedPositions)
+	assert.False(t, config.Zone.Covers(caster), "cones do not cover the caster")
+
+	_, err = ConvertAPISpellToZoneConfig(createTestAPISpell("burning-hands", entities.AreaCone, 15), caster, caster)
+	assert.Error(t, err, "a cone needs a direction")
+}
+
+func TestConvertAPISpellToZoneConfigSphere(t *testing.T) {
+	target := entities.Position{X: 5, Y: 5}
+	config, err := ConvertAPISpellToZoneConfig(createTestAPISpell("fireball", entities.AreaSphere, 10), entities.Position{}, target)
+	require.NoError(t, err)
+
+	assert.Len(t, config.Zone.AffectedPositions, 13)
+	assert.True(t, config.Zone.Covers(target))
+	assert.True(t, config.Zone.Covers(entities.Position{X: 7, Y: 5}))
+	assert.True(t, config.Zone.Covers(entities.Position{X: 6, Y: 6}))
+	assert.False(t, config.Zone.Covers(entities.Position{X: 7, Y: 6}))
+}
+
+func TestConvertAPISpellToZoneConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		spell *apientities.Spell
+	}{
+		{"nil spell", nil},
+		{"no area of effect", createTestAPISpell("magic-missile", "", 0)},
+		{"zero size", createTestAPISpell("fireball", entities.AreaSphere, 0)},
+		{"unknown shape", createTestAPISpell("fireball", "blob", 20)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ConvertAPISpellToZoneConfig(tc.spell, entities.Position{}, entities.Position{X: 1})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestAddSpellZoneToRoom(t *testing.T) {
+	service := &RoomService{}
+	room := createTestRoom()
+	goblin := createTestMonster("goblin", 0, 0)
+	require.NoError(t, PlaceEntity(room, &goblin))
+
+	config, err := ConvertAPISpellToZoneConfig(createTestAPISpell("fireball", entities.AreaSphere, 5), entities.Position{X: 4, Y: 4}, entities.Position{X: 0, Y: 0})
+	require.NoError(t, err)
+	require.NoError(t, service.AddPlaceablesToRoom(room, []PlaceableConfig{*config}))
+
+	require.Len(t, room.SpellZones, 1)
+	zone := room.SpellZones[0]
+	assert.NotEmpty(t, zone.ID)
+	assert.ElementsMatch(t, []entities.Position{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}}, zone.AffectedPositions, "cells outside the room are dropped")
+	assert.Equal(t, entities.CellMonster, room.Grid[0][0].Type, "zones do not occupy cells")
+
+	counts, err := service.GetEntityCounts(room)
+	require.NoError(t, err)
+	assert.Equal(t, 1, counts.SpellZones)
+
+	assert.True(t, removeEntity(room, zone.ID, entities.CellSpellZone))
+	assert.Empty(t, room.SpellZones)
+}
+
+func TestConvertAPIWeaponToConfig(t *testing.T) {
+	weapon := &apientities.Weapon{
+		Key:    "longsword",
+		Name:   "Longsword",
+		Damage: &apientities.Damage{DamageDice: "1d8", DamageType: &apientities.ReferenceItem{Key: "slashing", Name: "Slashing"}},
+	}
+
+	config := ConvertAPIWeaponToConfig(weapon, 2)
+	require.NotNil(t, config)
+	assert.Equal(t, "longsword", config.Key)
+	assert.Equal(t, 2, config.Count)
+	assert.True(t, config.ShouldPlaceRandomly())
+	assert.Equal(t, "1d8", config.DamageDice)
+	assert.Equal(t, "slashing", config.DamageType)
+
+	placeable, err := config.CreatePlaceable(nil)
+	require.NoError(t, err)
+	item := placeable.(*entities.Item)
+	assert.Equal(t, "weapon", item.Type)
+	assert.Equal(t, "1d8", item.DamageDice)
+	assert.Equal(t, "slashing", item.DamageType)
+
+	assert.Empty(t, ConvertAPIWeaponToConfig(&apientities.Weapon{Key: "net"}, 1).DamageDice, "weapons without damage")
+	assert.Nil(t, ConvertAPIWeaponToConfig(nil, 1))
+}
+
+func TestConvertAPIArmorToConfig(t *testing.T) {
+	armor := &apientities.Armor{
+		Key:                 "chain-mail",
+		Name:                "Chain Mail",
+		ArmorClass:          &apientities.ArmorClass{Base: 16},
+		StealthDisadvantage: true,
+	}
+
+	config := ConvertAPIArmorToConfig(armor, 1)
+	require.NotNil(t, config)
+	assert.Equal(t, "Chain Mail", config.GetName())
+	assert.Equal(t, entities.CellItem, config.GetCellType())
+
+	placeable, err := config.CreatePlaceable(nil)
+	require.NoError(t, err)
+	item := placeable.(*entities.Item)
+	assert.Equal(t, "armor", item.Type)
+	assert.Equal(t, 16, item.ArmorClass)
+	assert.True(t, item.StealthDisadvantage)
+
+	assert.Nil(t, ConvertAPIArmorToConfig(nil, 1))
+}
+
+func TestAddPlaceablesWithWeaponAndArmorConfigs(t *testing.T) {
+	service, err := NewRoomService()
+	require.NoError(t, err)
+	room := createTestRoom()
+
+	weapon := ConvertAPIWeaponToConfig(&apientities.Weapon{Key: "dagger", Name: "Dagger", Damage: &apientities.Damage{DamageDice: "1d4"}}, 1)
+	armor := ConvertAPIArmorToConfig(&apientities.Armor{Key: "shield", Name: "Shield", ArmorClass: &apientities.ArmorClass{Base: 2}}, 1)
+	require.NoError(t, service.AddPlaceablesToRoom(room, []PlaceableConfig{*weapon, *armor}))
+
+	require.Len(t, room.Items, 2)
+	assert.Equal(t, "1d4", room.Items[0].DamageDice)
+	assert.Equal(t, 2, room.Items[1].ArmorClass)
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// CalculateCover returns the cover a target position has from a threat position
+// A blocking obstacle on the line between them (see HasLineOfSight) gives full cover. Otherwise a blocking
+// obstacle adjacent to the target that is nearer the threat than the target gives three-quarters cover,
+// and a creature or non-blocking obstacle on the line between them gives half cover
+func CalculateCover(room *entities.Room, threat, target entities.Position) entities.CoverLevel {
+	return coverFrom(blockingObstaclePositions(room), lineObstructions(room), threat, target)
+}
+
+// GetCoverMap returns the cover of every unoccupied cell in the room from the threat position
+// Returns an error for gridless rooms and threat positions outside the room
+func (s *RoomService) GetCoverMap(room *entities.Room, threatPosition entities.Position) (map[entities.Position]entities.CoverLevel, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+	if room.Grid == nil {
+		return nil, entities.ErrNoGrid
+	}
+	if !IsPositionValid(room, threatPosition) {
+		return nil, fmt.Errorf("threat position (%d,%d) is outside the room", threatPosition.X, threatPosition.Y)
+	}
+
+	blocked := blockingObstaclePositions(room)
+	obstructions := lineObstructions(room)
+	cover := map[entities.Position]entities.CoverLevel{}
+	for y := 0; y < room.Height; y++ {
+		for x := 0; x < room.Width; x++ {
+			pos := entities.Position{X: x, Y: y}
+			if pos != threatPosition && room.Grid[y][x].Type == entities.CellTypeEmpty {
+				cover[pos] = coverFrom(blocked, obstructions, threatPosition, pos)
+			}
+		}
+	}
+
+	return cover, nil
+}
+
+// GetBestCoverPositions returns up to n unoccupied cells with the most cover from the threat position
+// Ties are broken by distance from the threat, furthest first, and then by row and column
+func (s *RoomService) GetBestCoverPositions(room *entities.Room, threatPosition entities.Position, n int) ([]entities.Position, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("position count cannot be negative")
+	}
+
+	cover, err := s.GetCoverMap(room, threatPosition)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make([]entities.Position, 0, len(cover))
+	for pos := range cover {
+		positions = append(positions, pos)
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		a, b := positions[i], positions[j]
+		if cover[a] != cover[b] {
+			return cover[a] > cover[b]
+		}
+		distA, distB := DistanceBetween(threatPosition, a, DistanceEuclidean), DistanceBetween(threatPosition, b, DistanceEuclidean)
+		if distA != distB {
+			return distA > distB
+		}
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		return a.X < b.X
+	})
+
+	return positions[:min(n, len(positions))], nil
+}
+
+// lineObstructions returns the positions of creatures and non-blocking obstacles, which give half cover
+func lineObstructions(room *entities.Room) map[entities.Position]bool {
+	obstructions := map[entities.Position]bool{}
+	for _, p := range collectPlaceables(room) {
+		switch e := p.(type) {
+		case *entities.Monster, *entities.Player, *entities.NPC:
+			obstructions[p.GetPosition()] = true
+		case *entities.Obstacle:
+			if !e.Blocking {
+				for _, pos := range e.Cells() {
+					obstructions[pos] = true
+				}
+			}
+		}
+	}
+	return obstructions
+}
+
+// coverFrom calculates cover against precomputed sets of blocking obstacles and half-cover obstructions
+func coverFrom(blocked, obstructions map[entities.Position]bool, threat, target entities.Position) entities.CoverLevel {
+	if !lineOfSight(blocked, threat, target) {
+		return entities.CoverFull
+	}
+
+	targetDist := DistanceBetween(threat, target, DistanceEuclidean)
+	for _, neighbor := range target.Neighbors(true) {
+		if blocked[neighbor] && DistanceBetween(threat, neighbor, DistanceEuclidean) < targetDist {
+			return entities.CoverThreeQuarters
+		}
+	}
+
+	if !lineOfSight(obstructions, threat, target) {
+		return entities.CoverHalf
+	}
+	return entities.CoverNone
+}
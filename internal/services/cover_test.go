@@ -0,0 +1,129 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// createCoverRoom creates a 10x10 room with a wall segment at (5,5) and a crate at (2,7)
+func createCoverRoom(t *testing.T) *entities.Room {
+	room := createTacticalRoom()
+	require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "wall", Blocking: true, Position: entities.Position{X: 5, Y: 5}}))
+	require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "crate", Position: entities.Position{X: 2, Y: 7}}))
+	return room
+}
+
+func TestGetCoverMap(t *testing.T) {
+	service := &RoomService{}
+	room := createCoverRoom(t)
+	threat := entities.Position{X: 0, Y: 5}
+
+	cover, err := service.GetCoverMap(room, threat)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		position entities.Position
+		expected entities.CoverLevel
+	}{
+		{"behind the wall", entities.Position{X: 6, Y: 5}, entities.CoverFull},
+		{"peeking past the wall", entities.Position{X: 6, Y: 4}, entities.CoverThreeQuarters},
+		{"in front of the wall", entities.Position{X: 4, Y: 4}, entities.CoverNone},
+		{"behind the crate", entities.Position{X: 4, Y: 9}, entities.CoverHalf},
+		{"open ground", entities.Position{X: 3, Y: 2}, entities.CoverNone},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, cover[tc.position])
+			assert.Equal(t, tc.expected, CalculateCover(room, threat, tc.position))
+		})
+	}
+
+	assert.NotContains(t, cover, threat, "the threat's own cell is excluded")
+	assert.NotContains(t, cover, entities.Position{X: 5, Y: 5}, "occupied cells are excluded")
+	assert.Len(t, cover, 100-3)
+}
+
+func TestGetCoverMapCreaturesGiveHalfCover(t *testing.T) {
+	service := &RoomService{}
+	room := createTacticalRoom()
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "ogre", Position: entities.Position{X: 2, Y: 0}}))
+
+	cover, err := service.GetCoverMap(room, entities.Position{X: 0, Y: 0})
+	require.NoError(t, err)
+	assert.Equal(t, entities.CoverHalf, cover[entities.Position{X: 4, Y: 0}])
+	assert.Equal(t, entities.CoverNone, cover[entities.Position{X: 4, Y: 4}])
+}
+
+func TestGetBestCoverPositions(t *testing.T) {
+	service := &RoomService{}
+	room := createCoverRoom(t)
+	threat := entities.Position{X: 0, Y: 5}
+
+	cover, err := service.GetCoverMap(room, threat)
+	require.NoError(t, err)
+
+	best, err := service.GetBestCoverPositions(room, threat, 3)
+	require.NoError(t, err)
+	require.Len(t, best, 3)
+	for _, pos := range best {
+		assert.Equal(t, entities.CoverFull, cover[pos])
+	}
+	// The furthest totally covered cell comes first
+	assert.Equal(t, entities.Position{X: 9, Y: 5}, best[0])
+
+	all, err := service.GetBestCoverPositions(room, threat, 1000)
+	require.NoError(t, err)
+	assert.Len(t, all, len(cover))
+	assert.Equal(t, entities.CoverNone, cover[all[len(all)-1]])
+}
+
+func TestGetCoverMapErrors(t *testing.T) {
+	service := &RoomService{}
+
+	_, err := service.GetCoverMap(nil, entities.Position{})
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+
+	_, err = service.GetCoverMap(createTestRoomNoGrid(), entities.Position{})
+	assert.ErrorIs(t, err, entities.ErrNoGrid)
+
+	_, err = service.GetCoverMap(createTacticalRoom(), entities.Position{X: 10, Y: 0})
+	assert.Error(t, err)
+
+	_, err = service.GetBestCoverPositions(createTacticalRoom(), entities.Position{}, -1)
+	assert.Error(t, err)
+}
+
+func TestCalculateCoverMultiCellObstacles(t *testing.T) {
+	room := createTacticalRoom()
+	require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "table", Position: entities.Position{X: 3, Y: 2}, Size: entities.Size{Width: 1, Height: 3}}))
+	require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "bookshelf", Blocking: true, Position: entities.Position{X: 3, Y: 7}, Size: entities.Size{Width: 3, Height: 1}}))
+
+	attacker := entities.Position{X: 0, Y: 4}
+	assert.Equal(t, entities.CoverHalf, CalculateCover(room, attacker, entities.Position{X: 6, Y: 4}), "the table's lower cell is in the way")
+	assert.Equal(t, entities.CoverFull, CalculateCover(room, entities.Position{X: 4, Y: 5}, entities.Position{X: 4, Y: 9}))
+	assert.Equal(t, entities.CoverThreeQuarters, CalculateCover(room, entities.Position{X: 4, Y: 4}, entities.Position{X: 6, Y: 7}))
+}
+
+func TestCoverLevelACBonus(t *testing.T) {
+	testCases := []struct {
+		level entities.CoverLevel
+		name  string
+		bonus int
+	}{
+		{entities.CoverNone, "none", 0},
+		{entities.CoverHalf, "half", 2},
+		{entities.CoverThreeQuarters, "three-quarters", 5},
+		{entities.CoverFull, "full", 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.name, tc.level.String())
+			assert.Equal(t, tc.bonus, tc.level.ACBonus())
+		})
+	}
+}
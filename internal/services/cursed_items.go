@@ -0,0 +1,84 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// AttemptItemRemoval tries to take an item from its holder
+// cellType selects the holder: CellNPC takes the item from the inventory of the NPC with entityID,
+// and CellItem picks the item up off the room floor (entityID is ignored).
+// A cursed item in an inventory is not removed; success is false and the curse description is returned.
+// Cursed items on the floor are picked up like any other loot, but the curse description is still returned
+func (s *RoomService) AttemptItemRemoval(room *entities.Room, entityID string, itemID string, cellType entities.CellType) (bool, string, error) {
+	item, err := findHeldItem(room, entityID, itemID, cellType)
+	if err != nil {
+		return false, "", err
+	}
+
+	curseMessage := ""
+	if item.Cursed {
+		curseMessage = item.CurseDescription
+		if cellType != entities.CellItem {
+			return false, curseMessage, nil
+		}
+	}
+
+	if err := s.removeHeldItem(room, entityID, itemID, cellType); err != nil {
+		return false, curseMessage, err
+	}
+
+	return true, curseMessage, nil
+}
+
+// ForceRemoveCursedItem removes an item from its holder even if it is cursed, for DM overrides
+// The holder is selected the same way as in AttemptItemRemoval
+func (s *RoomService) ForceRemoveCursedItem(room *entities.Room, entityID string, itemID string, cellType entities.CellType) error {
+	if _, err := findHeldItem(room, entityID, itemID, cellType); err != nil {
+		return err
+	}
+
+	return s.removeHeldItem(room, entityID, itemID, cellType)
+}
+
+// findHeldItem returns a pointer to an item on the room floor or in an NPC's inventory
+func findHeldItem(room *entities.Room, entityID string, itemID string, cellType entities.CellType) (*entities.Item, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+
+	switch cellType {
+	case entities.CellItem:
+		if item, _ := FindItemByID(room, itemID); item != nil {
+			return item, nil
+		}
+		return nil, fmt.Errorf("item with ID %s not found in room", itemID)
+	case entities.CellNPC:
+		npc, _ := FindNPCByID(room, entityID)
+		if npc == nil {
+			return nil, fmt.Errorf("NPC with ID %s not found in room", entityID)
+		}
+		for i := range npc.Inventory {
+			if npc.Inventory[i].ID == itemID {
+				return &npc.Inventory[i], nil
+			}
+		}
+		return nil, fmt.Errorf("item with ID %s not found in NPC's inventory", itemID)
+	default:
+		return nil, fmt.Errorf("entities of cell type %d cannot hold items", cellType)
+	}
+}
+
+// removeHeldItem removes an item found by findHeldItem using the regular removal path for its holder
+func (s *RoomService) removeHeldItem(room *entities.Room, entityID string, itemID string, cellType entities.CellType) error {
+	if cellType == entities.CellNPC {
+		_, err := s.RemoveItemFromNPCInventory(room, entityID, itemID)
+		return err
+	}
+
+	if !removeEntity(room, itemID, entities.CellItem) {
+		return fmt.Errorf("item with ID %s not found in room", itemID)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// createCursedItemRoom creates a room with a merchant carrying a cursed ring and a plain dagger,
+// and a cursed amulet lying on the floor
+func createCursedItemRoom(t *testing.T) *entities.Room {
+	room := createTestRoom()
+
+	merchant := &entities.NPC{
+		ID:   "merchant",
+		Name: "Merchant",
+		Inventory: []entities.Item{
+			{ID: "ring", Name: "Ring of Clumsiness", Cursed: true, CurseDescription: "The ring tightens around your finger"},
+			{ID: "dagger", Name: "Dagger"},
+		},
+		Position: entities.Position{X: 1, Y: 1},
+	}
+	require.NoError(t, PlaceEntity(room, merchant))
+
+	amulet := &entities.Item{ID: "amulet", Name: "Amulet", Cursed: true, CurseDescription: "A chill runs down your spine", Position: entities.Position{X: 3, Y: 3}}
+	require.NoError(t, PlaceEntity(room, amulet))
+	return room
+}
+
+func TestAttemptItemRemoval(t *testing.T) {
+	room := createCursedItemRoom(t)
+	service := &RoomService{}
+
+	success, message, err := service.AttemptItemRemoval(room, "merchant", "ring", entities.CellNPC)
+	require.NoError(t, err)
+	assert.False(t, success)
+	assert.Equal(t, "The ring tightens around your finger", message)
+	assert.Len(t, room.NPCs[0].Inventory, 2, "cursed item must stay in the inventory")
+
+	success, message, err = service.AttemptItemRemoval(room, "merchant", "dagger", entities.CellNPC)
+	require.NoError(t, err)
+	assert.True(t, success)
+	assert.Empty(t, message)
+	assert.Len(t, room.NPCs[0].Inventory, 1)
+
+	success, message, err = service.AttemptItemRemoval(room, "", "amulet", entities.CellItem)
+	require.NoError(t, err)
+	assert.True(t, success, "cursed floor items can still be looted")
+	assert.Equal(t, "A chill runs down your spine", message)
+	assert.Empty(t, room.Items)
+	assert.Equal(t, entities.CellTypeEmpty, room.Grid[3][3].Type)
+
+	_, _, err = service.AttemptItemRemoval(room, "merchant", "missing", entities.CellNPC)
+	assert.Error(t, err)
+	_, _, err = service.AttemptItemRemoval(room, "goblin", "ring", entities.CellMonster)
+	assert.Error(t, err)
+	_, _, err = service.AttemptItemRemoval(nil, "merchant", "ring", entities.CellNPC)
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
+
+func TestForceRemoveCursedItem(t *testing.T) {
+	room := createCursedItemRoom(t)
+	service := &RoomService{}
+
+	require.NoError(t, service.ForceRemoveCursedItem(room, "merchant", "ring", entities.CellNPC))
+	require.Len(t, room.NPCs[0].Inventory, 1)
+	assert.Equal(t, "dagger", room.NPCs[0].Inventory[0].ID)
+
+	assert.Error(t, service.ForceRemoveCursedItem(room, "merchant", "ring", entities.CellNPC))
+}
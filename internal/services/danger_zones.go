@@ -0,0 +1,80 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// DangerZone describes how threatened a cell is by nearby monsters
+type DangerZone struct {
+	Center      entities.Position  // The cell being scored
+	Radius      int                // Radius in squares used to find threatening monsters
+	DangerScore float64            // Sum of the CRs of the monsters within Radius
+	Sources     []entities.Monster // Monsters within Radius of Center
+}
+
+// GetRoomDangerZones scores every cell in the room by the total CR of monsters within radiusSquares (Chebyshev)
+// Only cells with a positive score are returned, sorted by DangerScore descending and then by row and column,
+// so the first N entries are the N most dangerous cells
+func (s *RoomService) GetRoomDangerZones(room *entities.Room, radiusSquares int) ([]DangerZone, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+	if radiusSquares < 0 {
+		return nil, fmt.Errorf("radius cannot be negative")
+	}
+
+	zones := []DangerZone{}
+	for y := 0; y < room.Height; y++ {
+		for x := 0; x < room.Width; x++ {
+			zone := dangerAt(room, entities.Position{X: x, Y: y}, radiusSquares)
+			if zone.DangerScore > 0 {
+				zones = append(zones, zone)
+			}
+		}
+	}
+
+	sort.SliceStable(zones, func(i, j int) bool {
+		return zones[i].DangerScore > zones[j].DangerScore
+	})
+
+	return zones, nil
+}
+
+// SafestApproachPath finds a path between two positions that minimizes the danger crossed along the way
+// The danger score of each entered cell (see GetRoomDangerZones) is added to its step cost,
+// and blocking obstacles cannot be crossed. Returns ErrNoPath if the destination is unreachable
+func (s *RoomService) SafestApproachPath(room *entities.Room, from, to entities.Position, radiusSquares int) ([]entities.Position, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+	if radiusSquares < 0 {
+		return nil, fmt.Errorf("radius cannot be negative")
+	}
+
+	danger := map[entities.Position]float64{}
+	for y := 0; y < room.Height; y++ {
+		for x := 0; x < room.Width; x++ {
+			pos := entities.Position{X: x, Y: y}
+			danger[pos] = dangerAt(room, pos, radiusSquares).DangerScore
+		}
+	}
+
+	return findWeightedPath(room, from, to, func(pos entities.Position) float64 {
+		return danger[pos]
+	})
+}
+
+// dangerAt returns the danger zone centered on pos
+func dangerAt(room *entities.Room, pos entities.Position, radiusSquares int) DangerZone {
+	zone := DangerZone{Center: pos, Radius: radiusSquares}
+	for _, monster := range room.Monsters {
+		if CalculateDistance(pos, monster.Position) <= float64(radiusSquares) {
+			zone.DangerScore += monster.CR
+			zone.Sources = append(zone.Sources, monster)
+		}
+	}
+	return zone
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// createDangerRoom creates a 9x9 gridded room with a CR 10 monster in the center
+func createDangerRoom(t *testing.T) *entities.Room {
+	room := NewRoom(9, 9, entities.LightLevelBright)
+	InitializeGrid(room)
+
+	dragon := &entities.Monster{ID: "dragon", Key: "young-red-dragon", CR: 10, Position: entities.Position{X: 4, Y: 4}}
+	require.NoError(t, PlaceEntity(room, dragon))
+	return room
+}
+
+func TestGetRoomDangerZones(t *testing.T) {
+	room := createDangerRoom(t)
+	goblin := createTestMonster("goblin", 7, 4)
+	require.NoError(t, PlaceEntity(room, &goblin))
+	service := &RoomService{}
+
+	zones, err := service.GetRoomDangerZones(room, 2)
+	require.NoError(t, err)
+
+	// 25 cells around the dragon plus the 10 goblin cells that do not overlap it
+	assert.Len(t, zones, 35)
+
+	// Cells in reach of both monsters come first
+	top := zones[0]
+	assert.Equal(t, 10.25, top.DangerScore)
+	assert.Equal(t, 2, top.Radius)
+	assert.Len(t, top.Sources, 2)
+	assert.Equal(t, 5, top.Center.X, "overlap is only at column 5 and 6")
+
+	for i := 1; i < len(zones); i++ {
+		assert.GreaterOrEqual(t, zones[i-1].DangerScore, zones[i].DangerScore)
+	}
+	assert.Equal(t, 0.25, zones[len(zones)-1].DangerScore)
+
+	_, err = service.GetRoomDangerZones(room, -1)
+	assert.Error(t, err)
+	_, err = service.GetRoomDangerZones(nil, 1)
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
+
+func TestSafestApproachPath(t *testing.T) {
+	room := createDangerRoom(t)
+	service := &RoomService{}
+	center := room.Monsters[0].Position
+	from := entities.Position{X: 0, Y: 4}
+	to := entities.Position{X: 8, Y: 4}
+
+	path, err := service.SafestApproachPath(room, from, to, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, from, path[0])
+	assert.Equal(t, to, path[len(path)-1])
+	for i, pos := range path {
+		assert.Greater(t, CalculateDistance(pos, center), 2.0, "path cell %s is within the dragon's reach", pos)
+		if i > 0 {
+			assert.Equal(t, 1.0, CalculateDistance(path[i-1], pos), "steps must be adjacent")
+		}
+	}
+
+	// Without any monsters the straight line is shortest
+	emptyRoom := NewRoom(9, 9, entities.LightLevelBright)
+	path, err = service.SafestApproachPath(emptyRoom, from, to, 2)
+	require.NoError(t, err)
+	assert.Len(t, path, 9)
+
+	_, err = service.SafestApproachPath(room, from, to, -1)
+	assert.Error(t, err)
+}
+
+func TestSafestApproachPathNoRoute(t *testing.T) {
+	room := NewRoom(5, 5, entities.LightLevelBright)
+	InitializeGrid(room)
+	for y := 0; y < 5; y++ {
+		wall := &entities.Obstacle{ID: "wall", Key: "wall", Blocking: true, Position: entities.Position{X: 2, Y: y}}
+		require.NoError(t, PlaceEntity(room, wall))
+	}
+	service := &RoomService{}
+
+	_, err := service.SafestApproachPath(room, entities.Position{X: 0, Y: 0}, entities.Position{X: 4, Y: 4}, 1)
+	assert.ErrorIs(t, err, ErrNoPath)
+
+	_, err = service.SafestApproachPath(room, entities.Position{X: 0, Y: 0}, entities.Position{X: 9, Y: 9}, 1)
+	assert.ErrorIs(t, err, entities.ErrInvalidPosition)
+}
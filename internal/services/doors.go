@@ -0,0 +1,34 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// ErrWrongKey is returned when a door is unlocked with a key it does not accept
+var ErrWrongKey = errors.New("key does not fit the door")
+
+// UnlockDoor unlocks a door in the room with the given key
+// Doors without a RequiredKeyID accept any key. Unlocking does not open the door or remove its bar,
+// and unlocking a door that is already unlocked does nothing. Returns ErrWrongKey if the key does not fit
+func (s *RoomService) UnlockDoor(room *entities.Room, doorID, keyID string) error {
+	if room == nil {
+		return entities.ErrNilRoom
+	}
+
+	door, _ := FindDoorByID(room, doorID)
+	if door == nil {
+		return fmt.Errorf("door with ID %s not found in room", doorID)
+	}
+	if !door.Locked {
+		return nil
+	}
+	if door.RequiredKeyID != "" && door.RequiredKeyID != keyID {
+		return fmt.Errorf("%w: %s requires key %s", ErrWrongKey, door.Name, door.RequiredKeyID)
+	}
+
+	door.Locked = false
+	return nil
+}
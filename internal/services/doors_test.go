@@ -0,0 +1,135 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// createDoorwayRoom creates a 5x5 room split by a wall down column 2 with a door in the middle
+func createDoorwayRoom(t *testing.T, door DoorConfig) (*entities.Room, *entities.Door) {
+	service := &RoomService{}
+	room := createTestRoom()
+
+	doorPos := entities.Position{X: 2, Y: 2}
+	door.Name, door.Position = "Iron Door", &doorPos
+	configs := []PlaceableConfig{door}
+	for _, y := range []int{0, 1, 3, 4} {
+		configs = append(configs, createTestObstacleConfig("Wall", "wall", true, 1, false, &entities.Position{X: 2, Y: y}))
+	}
+	require.NoError(t, service.AddPlaceablesToRoom(room, configs))
+
+	require.Len(t, room.Doors, 1)
+	return room, &room.Doors[0]
+}
+
+func TestAddDoorConfigToRoom(t *testing.T) {
+	room, door := createDoorwayRoom(t, DoorConfig{Key: "iron-door", Locked: true, RequiredKeyID: "iron-key"})
+
+	assert.Equal(t, "iron-door", door.Key)
+	assert.True(t, door.Locked)
+	assert.False(t, door.Open)
+	assert.Equal(t, "iron-key", door.RequiredKeyID)
+	assert.Equal(t, entities.Cell{Type: entities.CellDoor, EntityID: door.ID}, room.Grid[2][2])
+}
+
+func TestLockedDoorBlocksSightAndMovement(t *testing.T) {
+	west, east := entities.Position{X: 0, Y: 2}, entities.Position{X: 4, Y: 2}
+
+	testCases := []struct {
+		name    string
+		door    DoorConfig
+		blocked bool
+	}{
+		{"closed door", DoorConfig{}, false},
+		{"open locked door", DoorConfig{Locked: true, Open: true}, false},
+		{"locked door", DoorConfig{Locked: true}, true},
+		{"barred door", DoorConfig{Barred: true}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			room, door := createDoorwayRoom(t, tc.door)
+
+			visible, err := LineOfSight(room, west, east)
+			require.NoError(t, err)
+			assert.Equal(t, !tc.blocked, visible)
+			assert.Equal(t, !tc.blocked, CanPassThrough(door))
+
+			path, err := FindPath(room, west, east)
+			if tc.blocked {
+				assert.ErrorIs(t, err, ErrNoPath)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, path, door.Position)
+		})
+	}
+}
+
+func TestUnlockDoor(t *testing.T) {
+	service := &RoomService{}
+
+	t.Run("Matching key", func(t *testing.T) {
+		room, door := createDoorwayRoom(t, DoorConfig{Locked: true, RequiredKeyID: "iron-key"})
+
+		require.NoError(t, service.UnlockDoor(room, door.ID, "iron-key"))
+		assert.False(t, door.Locked)
+		assert.False(t, door.Open, "unlocking does not open the door")
+
+		_, err := FindPath(room, entities.Position{X: 0, Y: 2}, entities.Position{X: 4, Y: 2})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Any key", func(t *testing.T) {
+		room, door := createDoorwayRoom(t, DoorConfig{Locked: true})
+
+		require.NoError(t, service.UnlockDoor(room, door.ID, "skeleton-key"))
+		assert.False(t, door.Locked)
+	})
+
+	t.Run("Wrong key", func(t *testing.T) {
+		room, door := createDoorwayRoom(t, DoorConfig{Locked: true, RequiredKeyID: "iron-key"})
+
+		err := service.UnlockDoor(room, door.ID, "brass-key")
+		assert.ErrorIs(t, err, ErrWrongKey)
+		assert.True(t, door.Locked)
+	})
+
+	t.Run("Barred door stays blocked", func(t *testing.T) {
+		room, door := createDoorwayRoom(t, DoorConfig{Locked: true, Barred: true})
+
+		require.NoError(t, service.UnlockDoor(room, door.ID, ""))
+		assert.True(t, door.IsBlocking())
+	})
+
+	t.Run("Already unlocked", func(t *testing.T) {
+		room, door := createDoorwayRoom(t, DoorConfig{RequiredKeyID: "iron-key"})
+
+		assert.NoError(t, service.UnlockDoor(room, door.ID, "brass-key"))
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		room, _ := createDoorwayRoom(t, DoorConfig{Locked: true})
+
+		assert.Error(t, service.UnlockDoor(room, "missing", "iron-key"))
+		assert.ErrorIs(t, service.UnlockDoor(nil, "door", "iron-key"), entities.ErrNilRoom)
+	})
+}
+
+func TestCleanupRoomDoors(t *testing.T) {
+	service := &RoomService{}
+	room, door := createDoorwayRoom(t, DoorConfig{Locked: true})
+	doorID := door.ID
+
+	xp, notRemoved, err := service.CleanupRoom(room, entities.CellDoor, []string{doorID, "missing"})
+	require.NoError(t, err)
+	assert.Zero(t, xp)
+	assert.Equal(t, []string{"missing"}, notRemoved)
+	assert.Empty(t, room.Doors)
+	assert.Equal(t, entities.CellTypeEmpty, room.Grid[2][2].Type)
+	assert.Len(t, room.Obstacles, 4)
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// MarkAsEncounterStart saves a deep copy of the room as the state ResetEncounter returns to
+// Marking again replaces the snapshot and starts the reset count over
+func (s *RoomService) MarkAsEncounterStart(room *entities.Room) error {
+	if room == nil {
+		return entities.ErrNilRoom
+	}
+
+	snapshot := copyRoom(room)
+	snapshot.EncounterReset = nil
+	room.EncounterReset = &entities.EncounterResetData{StartSnapshot: snapshot}
+	return nil
+}
+
+// ResetEncounter restores the room to the state saved by MarkAsEncounterStart
+// Defeated monsters come back with their starting hit points and positions, and the grid and every other
+// field are restored. The room keeps its random source, the snapshot is kept so the encounter can be reset
+// again, and TimesReset is incremented
+func (s *RoomService) ResetEncounter(room *entities.Room) error {
+	if room == nil {
+		return entities.ErrNilRoom
+	}
+	if room.EncounterReset == nil || room.EncounterReset.StartSnapshot == nil {
+		return fmt.Errorf("room has not been marked as an encounter start")
+	}
+
+	reset := *room.EncounterReset
+	reset.TimesReset++
+
+	rng := room.Rand
+	*room = *copyRoom(reset.StartSnapshot)
+	room.Rand = rng
+	room.EncounterReset = &reset
+
+	s.Events().Publish(Event{
+		Type:   EventEncounterReset,
+		Detail: fmt.Sprintf("encounter reset %d time(s)", reset.TimesReset),
+	})
+	return nil
+}
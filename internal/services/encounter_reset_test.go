@@ -0,0 +1,132 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestResetEncounter(t *testing.T) {
+	service := &RoomService{}
+	room := createTestRoom()
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "ogre", MaxHP: 59, CurrentHP: 59, Position: entities.Position{X: 2, Y: 2}}))
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "goblin", MaxHP: 7, CurrentHP: 7, Position: entities.Position{X: 4, Y: 4}}))
+	require.NoError(t, PlaceEntity(room, &entities.Player{ID: "fighter", Position: entities.Position{X: 0, Y: 0}}))
+
+	require.NoError(t, service.MarkAsEncounterStart(room))
+
+	// The fighter wounds the goblin, chases the ogre, and kills it
+	goblin, _ := FindMonsterByID(room, "goblin")
+	goblin.CurrentHP = 2
+	require.NoError(t, MovePlaceable(room, &room.Players[0], entities.Position{X: 1, Y: 1}))
+	ogre, _ := FindMonsterByID(room, "ogre")
+	ogre.CurrentHP = 0
+	_, notRemoved, err := service.CleanupRoom(room, entities.CellMonster, []string{"ogre"})
+	require.NoError(t, err)
+	require.Empty(t, notRemoved)
+
+	var events []Event
+	service.Events().Subscribe(EventEncounterReset, func(e Event) { events = append(events, e) })
+	require.NoError(t, service.ResetEncounter(room))
+
+	ogre, _ = FindMonsterByID(room, "ogre")
+	require.NotNil(t, ogre, "the ogre should be back")
+	assert.Equal(t, 59, ogre.CurrentHP)
+	assert.Equal(t, entities.Position{X: 2, Y: 2}, ogre.Position)
+	goblin, _ = FindMonsterByID(room, "goblin")
+	assert.Equal(t, 7, goblin.CurrentHP)
+	assert.Equal(t, entities.Position{X: 0, Y: 0}, room.Players[0].Position)
+	assert.Equal(t, entities.Cell{Type: entities.CellMonster, EntityID: "ogre"}, room.Grid[2][2])
+	assert.Equal(t, entities.CellTypeEmpty, room.Grid[1][1].Type)
+	assert.Equal(t, 1, room.EncounterReset.TimesReset)
+	assert.Len(t, events, 1)
+
+	// The snapshot survives changes made after a reset
+	_, notRemoved, err = service.CleanupRoom(room, entities.CellMonster, []string{"ogre"})
+	require.NoError(t, err)
+	require.Empty(t, notRemoved)
+	require.Len(t, room.Monsters, 1)
+	require.NoError(t, service.ResetEncounter(room))
+	assert.Len(t, room.Monsters, 2)
+	assert.Equal(t, 2, room.EncounterReset.TimesReset)
+}
+
+func TestResetEncounterAmbush(t *testing.T) {
+	service := &RoomService{}
+	room, err := service.GenerateAmbushRoom(createTestRoomConfig(10, 10, entities.LightLevelDark, true), createTestAmbushConfig(true))
+	require.NoError(t, err)
+	require.NoError(t, service.MarkAsEncounterStart(room))
+
+	require.NoError(t, service.TriggerAmbush(room))
+	require.NoError(t, service.ResetEncounter(room))
+
+	assert.False(t, room.RoomType.(*entities.AmbushRoomType).Triggered)
+	for _, monster := range room.Monsters {
+		assert.Equal(t, []entities.Condition{entities.ConditionHidden}, monster.Conditions)
+	}
+
+	// The reset ambush can be sprung again, and resetting once more still restores it
+	require.NoError(t, service.TriggerAmbush(room))
+	require.NoError(t, service.ResetEncounter(room))
+	assert.False(t, room.RoomType.(*entities.AmbushRoomType).Triggered)
+}
+
+func TestResetEncounterDerivedRooms(t *testing.T) {
+	service := &RoomService{}
+	room := createTestRoom()
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "goblin", Position: entities.Position{X: 1, Y: 2}}))
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "ogre", Position: entities.Position{X: 4, Y: 2}}))
+	require.NoError(t, service.MarkAsEncounterStart(room))
+
+	clone, err := service.CloneRoom(room)
+	require.NoError(t, err)
+	rotated, err := service.RotateRoom(room, 90)
+	require.NoError(t, err)
+	first, second, err := service.SplitRoom(room, SplitRoomConfig{Axis: SplitVertical, SplitLine: 3})
+	require.NoError(t, err)
+
+	derived := map[string]*entities.Room{"clone": clone, "rotated": rotated, "first half": first, "second half": second}
+	for name, derivedRoom := range derived {
+		t.Run(name, func(t *testing.T) {
+			id, width, height := derivedRoom.ID, derivedRoom.Width, derivedRoom.Height
+			assert.Nil(t, derivedRoom.EncounterReset)
+			assert.Error(t, service.ResetEncounter(derivedRoom), "the original room's snapshot must not carry over")
+
+			// Marking the derived room resets it to its own state
+			require.NoError(t, service.MarkAsEncounterStart(derivedRoom))
+			derivedRoom.Monsters = nil
+			require.NoError(t, service.ResetEncounter(derivedRoom))
+			assert.Equal(t, id, derivedRoom.ID)
+			assert.Equal(t, width, derivedRoom.Width)
+			assert.Equal(t, height, derivedRoom.Height)
+			assert.NotEmpty(t, derivedRoom.Monsters)
+		})
+	}
+
+	require.NoError(t, service.ResetEncounter(room), "the original room can still be reset")
+	assert.Equal(t, 1, room.EncounterReset.TimesReset)
+}
+
+func TestResetEncounterKeepsRandomSource(t *testing.T) {
+	service := &RoomService{}
+	config := createTestRoomConfig(10, 10, entities.LightLevelBright, true)
+	config.Seed = 7
+	room, err := service.GenerateRoom(config)
+	require.NoError(t, err)
+	rng := room.Rand
+	require.NotNil(t, rng)
+
+	require.NoError(t, service.MarkAsEncounterStart(room))
+	require.NoError(t, service.ResetEncounter(room))
+	assert.Same(t, rng, room.Rand)
+}
+
+func TestResetEncounterErrors(t *testing.T) {
+	service := &RoomService{}
+	assert.Error(t, service.ResetEncounter(createTestRoom()), "room was never marked")
+	assert.ErrorIs(t, service.ResetEncounter(nil), entities.ErrNilRoom)
+	assert.ErrorIs(t, service.MarkAsEncounterStart(nil), entities.ErrNilRoom)
+}
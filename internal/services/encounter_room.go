@@ -0,0 +1,182 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+	"github.com/fadedpez/dnd5e-roomgen/internal/repositories"
+)
+
+// DifficultyPreset selects the difficulty of a room built by GenerateEncounterRoom
+type DifficultyPreset string
+
+const (
+	QuickEasy        DifficultyPreset = "quick_easy"
+	QuickMedium      DifficultyPreset = "quick_medium"
+	QuickHard        DifficultyPreset = "quick_hard"
+	QuickDeadly      DifficultyPreset = "quick_deadly"
+	CustomDifficulty DifficultyPreset = "custom" // Not supported by GenerateEncounterRoom, use GenerateAndPopulateRoom
+)
+
+// RoomSize selects the dimensions of a room built by GenerateEncounterRoom
+type RoomSize string
+
+const (
+	RoomSizeSmall  RoomSize = "small"  // 10x10
+	RoomSizeMedium RoomSize = "medium" // 20x15
+	RoomSizeLarge  RoomSize = "large"  // 30x20
+	RoomSizeHuge   RoomSize = "huge"   // 40x30
+)
+
+// presetDifficulties maps each quick preset to its encounter difficulty
+var presetDifficulties = map[DifficultyPreset]entities.EncounterDifficulty{
+	QuickEasy:   entities.EncounterDifficultyEasy,
+	QuickMedium: entities.EncounterDifficultyMedium,
+	QuickHard:   entities.EncounterDifficultyHard,
+	QuickDeadly: entities.EncounterDifficultyDeadly,
+}
+
+// roomSizeDimensions maps each room size to its width and height
+var roomSizeDimensions = map[RoomSize][2]int{
+	RoomSizeSmall:  {10, 10},
+	RoomSizeMedium: {20, 15},
+	RoomSizeLarge:  {30, 20},
+	RoomSizeHuge:   {40, 30},
+}
+
+// encounterMonsterTypes is the most monster types GenerateEncounterRoom mixes in one encounter
+const encounterMonsterTypes = 2
+
+// maxEncounterAttempts is how many monster selections GenerateEncounterRoom tries before giving up
+const maxEncounterAttempts = 50
+
+// encounterDressing is the obstacle layout GenerateEncounterRoom adds to every room
+var encounterDressing = ObstacleLayoutConfig{
+	DensityPercent:   0.1,
+	BlockingRatio:    0.5,
+	ClusteringFactor: 0.3,
+}
+
+// GenerateEncounterRoom builds a gridded room of the given size holding a balanced encounter for the party
+// Monsters are chosen from the monster repository if it implements repositories.MonsterLister, or from the SRD
+// monsters of repositories.NewInMemoryMonsterRepository otherwise. Up to encounterMonsterTypes types with CRs
+// between 1/8 and 1/2 of the party's target CR are picked at random, their counts are scaled by the balancer,
+// and the selection is kept only if the balancer rates it at the preset's difficulty. The room is then
+// dressed with randomly placed obstacles. Options such as WithSeed configure the room, but its size always comes
+// from size and it always has a grid; with a seed, the same party and repository give the same room
+func (s *RoomService) GenerateEncounterRoom(party entities.Party, preset DifficultyPreset, size RoomSize, opts ...RoomOption) (*entities.Room, error) {
+	if party.Size() == 0 {
+		return nil, fmt.Errorf("party cannot be empty")
+	}
+	difficulty, ok := presetDifficulties[preset]
+	if !ok {
+		return nil, fmt.Errorf("unsupported difficulty preset: %s", preset)
+	}
+	dimensions, ok := roomSizeDimensions[size]
+	if !ok {
+		return nil, fmt.Errorf("invalid room size: %s", size)
+	}
+
+	targetCR, err := s.balancer.CalculateTargetCR(party, difficulty)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := s.listEncounterMonsters(targetCR/8, targetCR/2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list monsters: %w", err)
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no monsters found with CR %.3f-%.3f", targetCR/8, targetCR/2)
+	}
+
+	roomConfig := RoomConfig{}
+	for _, opt := range opts {
+		opt(&roomConfig)
+	}
+	roomConfig.Width, roomConfig.Height, roomConfig.UseGrid = dimensions[0], dimensions[1], true
+	room, err := s.GenerateRoom(roomConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// Leave room for the obstacles added afterwards
+	capacity := int(float64(dimensions[0]*dimensions[1]) * (1 - encounterDressing.DensityPercent))
+
+	var monsterConfigs []MonsterConfig
+	for attempt := 0; attempt < maxEncounterAttempts && monsterConfigs == nil; attempt++ {
+		configs, err := s.balancer.AdjustMonsterSelection(pickEncounterMonsters(room, candidates), party, difficulty)
+		if err != nil {
+			return nil, fmt.Errorf("failed to balance monsters: %w", err)
+		}
+		if totalMonsterCount(configs) > capacity {
+			continue
+		}
+		if rated, err := s.balancer.DetermineEncounterDifficulty(expandMonsterConfigs(configs), party); err == nil && rated == difficulty {
+			monsterConfigs = configs
+		}
+	}
+	if monsterConfigs == nil {
+		return nil, fmt.Errorf("failed to find a %s encounter for the party", difficulty)
+	}
+
+	if err := s.AddPlaceablesToRoom(room, monsterPlaceableConfigs(monsterConfigs)); err != nil {
+		return nil, err
+	}
+	if err := s.GenerateRandomObstacleLayout(room, encounterDressing); err != nil {
+		return nil, err
+	}
+
+	return room, nil
+}
+
+// listEncounterMonsters lists the monsters in the CR range from the service's monster repository,
+// or from the SRD monsters if the repository cannot list monsters
+func (s *RoomService) listEncounterMonsters(minCR, maxCR float64) ([]*entities.Monster, error) {
+	if lister, ok := s.monsterRepo.(repositories.MonsterLister); ok {
+		monsters, err := lister.ListMonstersByCRRange(minCR, maxCR)
+		if !errors.Is(err, repositories.ErrListingNotSupported) {
+			return monsters, err
+		}
+	}
+
+	srd, err := repositories.NewInMemoryMonsterRepository()
+	if err != nil {
+		return nil, err
+	}
+	return srd.ListMonstersByCRRange(minCR, maxCR)
+}
+
+// pickEncounterMonsters picks up to encounterMonsterTypes distinct candidates at random, one of each
+// Random choices use room.Rand if it is set
+func pickEncounterMonsters(room *entities.Room, candidates []*entities.Monster) []MonsterConfig {
+	perm := rand.Perm
+	if room.Rand != nil {
+		perm = room.Rand.Perm
+	}
+
+	configs := []MonsterConfig{}
+	for _, i := range perm(len(candidates))[:min(encounterMonsterTypes, len(candidates))] {
+		candidate := candidates[i]
+		configs = append(configs, MonsterConfig{
+			Name:        candidate.Name,
+			Key:         candidate.Key,
+			CR:          candidate.CR,
+			Count:       1,
+			RandomPlace: true,
+		})
+	}
+	return configs
+}
+
+// expandMonsterConfigs returns one template monster per counted monster in the configs
+func expandMonsterConfigs(configs []MonsterConfig) []entities.Monster {
+	monsters := []entities.Monster{}
+	for _, config := range configs {
+		for i := 0; i < config.Count; i++ {
+			monsters = append(monsters, entities.Monster{Key: config.Key, Name: config.Name, CR: config.CR, ConditionImmunities: config.ConditionImmunities})
+		}
+	}
+	return monsters
+}
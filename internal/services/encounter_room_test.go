@@ -0,0 +1,123 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+	"github.com/fadedpez/dnd5e-roomgen/internal/repositories"
+)
+
+func TestGenerateEncounterRoom(t *testing.T) {
+	service, err := NewRoomService()
+	require.NoError(t, err)
+	party := createTestParty(4, 5)
+
+	testCases := []struct {
+		preset     DifficultyPreset
+		size       RoomSize
+		difficulty entities.EncounterDifficulty
+		width      int
+		height     int
+	}{
+		{QuickHard, RoomSizeSmall, entities.EncounterDifficultyHard, 10, 10},
+		{QuickEasy, RoomSizeMedium, entities.EncounterDifficultyEasy, 20, 15},
+		{QuickMedium, RoomSizeLarge, entities.EncounterDifficultyMedium, 30, 20},
+		{QuickDeadly, RoomSizeHuge, entities.EncounterDifficultyDeadly, 40, 30},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.preset), func(t *testing.T) {
+			room, err := service.GenerateEncounterRoom(party, tc.preset, tc.size)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.width, room.Width)
+			assert.Equal(t, tc.height, room.Height)
+			assert.NotNil(t, room.Grid)
+			assert.NotEmpty(t, room.Obstacles, "rooms are dressed with obstacles")
+			require.NotEmpty(t, room.Monsters)
+
+			difficulty, err := service.balancer.DetermineEncounterDifficulty(room.Monsters, party)
+			require.NoError(t, err)
+			assert.Equal(t, tc.difficulty, difficulty)
+
+			targetCR, err := service.balancer.CalculateTargetCR(party, tc.difficulty)
+			require.NoError(t, err)
+			for _, monster := range room.Monsters {
+				assert.GreaterOrEqual(t, monster.CR, targetCR/8)
+				assert.LessOrEqual(t, monster.CR, targetCR/2)
+			}
+		})
+	}
+}
+
+func TestGenerateEncounterRoomUsesMonsterRepository(t *testing.T) {
+	repo := repositories.NewInMemoryMonsterRepositoryFromMap(map[string]int{"bugbear": 200})
+	service, err := NewRoomService(WithMonsterRepository(repo))
+	require.NoError(t, err)
+
+	room, err := service.GenerateEncounterRoom(createTestParty(4, 5), QuickHard, RoomSizeSmall)
+	require.NoError(t, err)
+	for _, monster := range room.Monsters {
+		assert.Equal(t, "bugbear", monster.Key)
+	}
+}
+
+func TestGenerateEncounterRoomCachedRepository(t *testing.T) {
+	repo := repositories.NewInMemoryMonsterRepositoryFromMap(map[string]int{"bugbear": 200})
+	service, err := NewRoomService(WithMonsterRepository(repositories.NewCachedMonsterRepository(repo, 0)))
+	require.NoError(t, err)
+
+	room, err := service.GenerateEncounterRoom(createTestParty(4, 5), QuickHard, RoomSizeSmall)
+	require.NoError(t, err)
+	for _, monster := range room.Monsters {
+		assert.Equal(t, "bugbear", monster.Key, "listing goes through the cache to the wrapped repository")
+	}
+
+	// A cache over a repository that cannot list falls back to the SRD monsters
+	service, err = NewRoomService(WithMonsterRepository(repositories.NewCachedMonsterRepository(&mockMonsterRepository{}, 0)))
+	require.NoError(t, err)
+	room, err = service.GenerateEncounterRoom(createTestParty(4, 5), QuickHard, RoomSizeSmall)
+	require.NoError(t, err)
+	assert.NotEmpty(t, room.Monsters)
+}
+
+func TestGenerateEncounterRoomSeeded(t *testing.T) {
+	service, err := NewRoomService()
+	require.NoError(t, err)
+	party := createTestParty(4, 5)
+
+	first, err := service.GenerateEncounterRoom(party, QuickHard, RoomSizeSmall, WithSeed(42), WithTag("boss", "none"))
+	require.NoError(t, err)
+	second, err := service.GenerateEncounterRoom(party, QuickHard, RoomSizeSmall, WithSeed(42), WithDimensions(3, 3))
+	require.NoError(t, err)
+
+	assert.Equal(t, 10, second.Width, "the room size cannot be overridden")
+	assert.Equal(t, first.Monsters, second.Monsters)
+	assert.Equal(t, first.Obstacles, second.Obstacles)
+	assert.Equal(t, first.Grid, second.Grid)
+	tag, _ := first.GetTag("boss")
+	assert.Equal(t, "none", tag)
+}
+
+func TestGenerateEncounterRoomErrors(t *testing.T) {
+	service, err := NewRoomService()
+	require.NoError(t, err)
+
+	_, err = service.GenerateEncounterRoom(entities.Party{}, QuickEasy, RoomSizeSmall)
+	assert.Error(t, err)
+
+	_, err = service.GenerateEncounterRoom(createTestParty(4, 5), CustomDifficulty, RoomSizeSmall)
+	assert.Error(t, err)
+
+	_, err = service.GenerateEncounterRoom(createTestParty(4, 5), QuickEasy, RoomSize("gigantic"))
+	assert.Error(t, err)
+
+	repo := repositories.NewInMemoryMonsterRepositoryFromMap(map[string]int{"tarrasque": 155000})
+	service, err = NewRoomService(WithMonsterRepository(repo))
+	require.NoError(t, err)
+	_, err = service.GenerateEncounterRoom(createTestParty(4, 5), QuickEasy, RoomSizeSmall)
+	assert.Error(t, err, "no monsters in the CR range")
+}
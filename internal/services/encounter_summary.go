@@ -0,0 +1,65 @@
+package services
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// EncounterSummary describes the state of a room's encounter, typically once combat is over
+type EncounterSummary struct {
+	TotalMonsterXP    int // XP awarded for every monster in the room, alive or dead
+	AliveMonsterCount int // Monsters still standing
+	DeadMonsterCount  int // Monsters reduced to 0 hit points (see Monster.IsDead)
+	ItemCount         int // Items lying in the room plus items carried by NPCs
+	TotalItemValueGP  int // Value of those items in gold pieces, rounded to the nearest piece
+	PlayerCount       int
+	NPCCount          int
+	ObstacleCount     int
+
+	MaxEngagementRangeFt float64 // Greatest distance in feet between a living monster and a player (0 if either is missing)
+}
+
+// String returns a one-line human-readable summary
+func (s EncounterSummary) String() string {
+	return fmt.Sprintf("%d monsters alive, %d dead (%d XP); %d items worth %d gp; %d players, %d NPCs, %d obstacles",
+		s.AliveMonsterCount, s.DeadMonsterCount, s.TotalMonsterXP, s.ItemCount, s.TotalItemValueGP,
+		s.PlayerCount, s.NPCCount, s.ObstacleCount)
+}
+
+// GetEncounterSummary totals the monsters, loot, and other entities of the room
+// Monster XP is looked up the same way as for CleanupRoom, and item values are converted to gold
+// with entities.TreasureValueGP, covering both the room's items and every NPC's inventory
+func (s *RoomService) GetEncounterSummary(room *entities.Room) (*EncounterSummary, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+
+	summary := &EncounterSummary{
+		PlayerCount:   len(room.Players),
+		NPCCount:      len(room.NPCs),
+		ObstacleCount: len(room.Obstacles),
+	}
+
+	for i := range room.Monsters {
+		summary.TotalMonsterXP += s.monsterXP(&room.Monsters[i])
+		if room.Monsters[i].IsDead() {
+			summary.DeadMonsterCount++
+			continue
+		}
+		summary.AliveMonsterCount++
+		for _, player := range room.Players {
+			distance := CalculateDistanceFt(room, room.Monsters[i].Position, player.Position)
+			summary.MaxEngagementRangeFt = math.Max(summary.MaxEngagementRangeFt, distance)
+		}
+	}
+
+	summary.ItemCount = len(room.Items)
+	for _, npc := range room.NPCs {
+		summary.ItemCount += len(npc.Inventory)
+	}
+	summary.TotalItemValueGP = int(math.Round(entities.TreasureValueGP(room)))
+
+	return summary, nil
+}
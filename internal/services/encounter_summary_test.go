@@ -0,0 +1,53 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestGetEncounterSummary(t *testing.T) {
+	service := &RoomService{}
+	room := createTestRoom()
+
+	placeables := []entities.Placeable{
+		&entities.Monster{ID: "goblin-1", CR: 0.25, MaxHP: 7, CurrentHP: 7, Position: entities.Position{X: 0, Y: 0}},
+		&entities.Monster{ID: "goblin-2", CR: 0.25, MaxHP: 7, CurrentHP: 0, Position: entities.Position{X: 1, Y: 0}},
+		&entities.Monster{ID: "ogre", CR: 2, XP: 500, Position: entities.Position{X: 2, Y: 0}},
+		&entities.Player{ID: "fighter", Position: entities.Position{X: 0, Y: 1}},
+		&entities.Player{ID: "wizard", Position: entities.Position{X: 1, Y: 1}},
+		&entities.Item{ID: "sword", Value: 15, ValueUnit: "gp", Position: entities.Position{X: 0, Y: 2}},
+		&entities.Item{ID: "coins", Value: 25, ValueUnit: "sp", Position: entities.Position{X: 1, Y: 2}},
+		&entities.NPC{ID: "merchant", Inventory: []entities.Item{{ID: "ring", Value: 5, ValueUnit: "pp"}}, Position: entities.Position{X: 0, Y: 3}},
+		&entities.Obstacle{ID: "boulder", Blocking: true, Position: entities.Position{X: 4, Y: 4}},
+	}
+	for _, p := range placeables {
+		require.NoError(t, PlaceEntity(room, p))
+	}
+
+	summary, err := service.GetEncounterSummary(room)
+	require.NoError(t, err)
+	assert.Equal(t, &EncounterSummary{
+		TotalMonsterXP:    50 + 50 + 500,
+		AliveMonsterCount: 2,
+		DeadMonsterCount:  1,
+		ItemCount:         3,
+		TotalItemValueGP:  68, // 15 gp + 2.5 gp + 50 gp, rounded
+		PlayerCount:       2,
+		NPCCount:          1,
+		ObstacleCount:     1,
+
+		MaxEngagementRangeFt: 10, // Ogre at (2,0) to fighter at (0,1)
+	}, summary)
+	assert.Equal(t, "2 monsters alive, 1 dead (600 XP); 3 items worth 68 gp; 2 players, 1 NPCs, 1 obstacles", summary.String())
+
+	empty, err := service.GetEncounterSummary(createTestRoomNoGrid())
+	require.NoError(t, err)
+	assert.Equal(t, &EncounterSummary{}, empty)
+
+	_, err = service.GetEncounterSummary(nil)
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
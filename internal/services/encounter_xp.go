@@ -0,0 +1,162 @@
+package services
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// EncounterXPAnalysis summarizes the XP budget of a room's monsters against a party
+type EncounterXPAnalysis struct {
+	RawXP        int                                  // Sum of the XP of every monster in the room
+	AdjustedXP   int                                  // RawXP scaled by the encounter multiplier
+	XPMultiplier float64                              // Multiplier for the number of monsters and party size
+	Thresholds   map[entities.EncounterDifficulty]int // Party XP thresholds for each difficulty
+	Difficulty   entities.EncounterDifficulty         // Highest difficulty whose threshold AdjustedXP meets
+	XPPerPlayer  int                                  // RawXP split evenly between party members
+	XPToLevelUp  map[string]int                       // XP each member still needs for their next level after this encounter
+}
+
+// xpThresholdsByLevel holds the easy, medium, hard, and deadly XP thresholds per character level (DMG p. 82)
+var xpThresholdsByLevel = map[int][4]int{
+	1:  {25, 50, 75, 100},
+	2:  {50, 100, 150, 200},
+	3:  {75, 150, 225, 400},
+	4:  {125, 250, 375, 500},
+	5:  {250, 500, 750, 1100},
+	6:  {300, 600, 900, 1400},
+	7:  {350, 750, 1100, 1700},
+	8:  {450, 900, 1400, 2100},
+	9:  {550, 1100, 1600, 2400},
+	10: {600, 1200, 1900, 2800},
+	11: {800, 1600, 2400, 3600},
+	12: {1000, 2000, 3000, 4500},
+	13: {1100, 2200, 3400, 5100},
+	14: {1250, 2500, 3800, 5700},
+	15: {1400, 2800, 4300, 6400},
+	16: {1600, 3200, 4800, 7200},
+	17: {2000, 3900, 5900, 8800},
+	18: {2100, 4200, 6300, 9500},
+	19: {2400, 4900, 7300, 10900},
+	20: {2800, 5700, 8500, 12700},
+}
+
+// thresholdDifficulties lists difficulties in the same order as xpThresholdsByLevel entries
+var thresholdDifficulties = [4]entities.EncounterDifficulty{
+	entities.EncounterDifficultyEasy,
+	entities.EncounterDifficultyMedium,
+	entities.EncounterDifficultyHard,
+	entities.EncounterDifficultyDeadly,
+}
+
+// levelXP holds the total XP needed to reach each character level (PHB p. 15)
+var levelXP = map[int]int{
+	1: 0, 2: 300, 3: 900, 4: 2700, 5: 6500,
+	6: 14000, 7: 23000, 8: 34000, 9: 48000, 10: 64000,
+	11: 85000, 12: 100000, 13: 120000, 14: 140000, 15: 165000,
+	16: 195000, 17: 225000, 18: 265000, 19: 305000, 20: 355000,
+}
+
+// encounterMultipliers are the DMG encounter multipliers, including the extra steps used
+// when the party size shifts the multiplier up or down
+var encounterMultipliers = []float64{0.5, 1, 1.5, 2, 2.5, 3, 4, 5}
+
+// encounterMultiplier returns the XP multiplier for a number of monsters and party size (DMG p. 82)
+// Parties of fewer than three shift one step up, parties of six or more one step down
+func encounterMultiplier(monsterCount, partySize int) float64 {
+	if monsterCount <= 0 {
+		return 1
+	}
+
+	step := 6 // 15 or more monsters
+	switch {
+	case monsterCount == 1:
+		step = 1
+	case monsterCount == 2:
+		step = 2
+	case monsterCount <= 6:
+		step = 3
+	case monsterCount <= 10:
+		step = 4
+	case monsterCount <= 14:
+		step = 5
+	}
+
+	if partySize < 3 {
+		step++
+	} else if partySize >= 6 {
+		step--
+	}
+
+	return encounterMultipliers[step]
+}
+
+// partyXPThresholds sums the XP thresholds of every party member for each difficulty
+func partyXPThresholds(party entities.Party) (map[entities.EncounterDifficulty]int, error) {
+	totals := make(map[entities.EncounterDifficulty]int, len(thresholdDifficulties))
+	for _, member := range party.Members {
+		thresholds, ok := xpThresholdsByLevel[member.Level]
+		if !ok {
+			return nil, fmt.Errorf("invalid level %d for party member %s", member.Level, member.Name)
+		}
+		for i, difficulty := range thresholdDifficulties {
+			totals[difficulty] += thresholds[i]
+		}
+	}
+	return totals, nil
+}
+
+// CalculateEncounterXPForParty applies the official DMG encounter building rules to the room's monsters
+// Party members are keyed by ID in XPToLevelUp, falling back to their name when no ID is set.
+// Members are assumed to be at the minimum XP for their current level
+func (s *RoomService) CalculateEncounterXPForParty(room *entities.Room, party *entities.Party) (EncounterXPAnalysis, error) {
+	if room == nil {
+		return EncounterXPAnalysis{}, entities.ErrNilRoom
+	}
+	if party == nil || party.Size() == 0 {
+		return EncounterXPAnalysis{}, fmt.Errorf("party cannot be empty")
+	}
+
+	thresholds, err := partyXPThresholds(*party)
+	if err != nil {
+		return EncounterXPAnalysis{}, err
+	}
+	analysis := EncounterXPAnalysis{
+		Thresholds:  thresholds,
+		XPToLevelUp: make(map[string]int, party.Size()),
+	}
+
+	for i := range room.Monsters {
+		analysis.RawXP += s.monsterXP(&room.Monsters[i])
+	}
+	analysis.XPMultiplier = encounterMultiplier(len(room.Monsters), party.Size())
+	analysis.AdjustedXP = int(math.Round(float64(analysis.RawXP) * analysis.XPMultiplier))
+	analysis.XPPerPlayer = analysis.RawXP / party.Size()
+
+	// Encounters below the easy threshold are still reported as easy
+	analysis.Difficulty = entities.EncounterDifficultyEasy
+	for _, difficulty := range thresholdDifficulties {
+		if analysis.AdjustedXP >= analysis.Thresholds[difficulty] {
+			analysis.Difficulty = difficulty
+		}
+	}
+
+	for _, member := range party.Members {
+		key := member.ID
+		if key == "" {
+			key = member.Name
+		}
+
+		remaining := 0
+		if next, ok := levelXP[member.Level+1]; ok {
+			remaining = next - levelXP[member.Level] - analysis.XPPerPlayer
+			if remaining < 0 {
+				remaining = 0
+			}
+		}
+		analysis.XPToLevelUp[key] = remaining
+	}
+
+	return analysis, nil
+}
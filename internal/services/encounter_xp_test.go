@@ -0,0 +1,158 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// createTestPartyWithIDs creates a party whose members have IDs p1..pN
+func createTestPartyWithIDs(memberCount int, level int) *entities.Party {
+	party := createTestParty(memberCount, level)
+	for i := range party.Members {
+		party.Members[i].ID = fmt.Sprintf("p%d", i+1)
+	}
+	return &party
+}
+
+func TestCalculateEncounterXPForParty(t *testing.T) {
+	testCases := []struct {
+		name     string
+		monsters []entities.Monster
+		party    *entities.Party
+		expected EncounterXPAnalysis
+	}{
+		{
+			name:     "Four level 3 players against two goblins and an orc",
+			monsters: createTestMonsters(0.25, 0.25, 0.5),
+			party:    createTestPartyWithIDs(4, 3),
+			expected: EncounterXPAnalysis{
+				RawXP:        200,
+				AdjustedXP:   400, // 3 monsters: x2
+				XPMultiplier: 2,
+				Thresholds: map[entities.EncounterDifficulty]int{
+					entities.EncounterDifficultyEasy:   300,
+					entities.EncounterDifficultyMedium: 600,
+					entities.EncounterDifficultyHard:   900,
+					entities.EncounterDifficultyDeadly: 1600,
+				},
+				Difficulty:  entities.EncounterDifficultyEasy,
+				XPPerPlayer: 50,
+				XPToLevelUp: map[string]int{"p1": 1750, "p2": 1750, "p3": 1750, "p4": 1750}, // 2700 - 900 - 50
+			},
+		},
+		{
+			name:     "Small party shifts multiplier up",
+			monsters: createTestMonsters(3),
+			party:    createTestPartyWithIDs(2, 5),
+			expected: EncounterXPAnalysis{
+				RawXP:        700,
+				AdjustedXP:   1050, // 1 monster with fewer than 3 players: x1.5
+				XPMultiplier: 1.5,
+				Thresholds: map[entities.EncounterDifficulty]int{
+					entities.EncounterDifficultyEasy:   500,
+					entities.EncounterDifficultyMedium: 1000,
+					entities.EncounterDifficultyHard:   1500,
+					entities.EncounterDifficultyDeadly: 2200,
+				},
+				Difficulty:  entities.EncounterDifficultyMedium,
+				XPPerPlayer: 350,
+				XPToLevelUp: map[string]int{"p1": 7150, "p2": 7150}, // 14000 - 6500 - 350
+			},
+		},
+		{
+			name:     "Large party shifts multiplier down",
+			monsters: createTestMonsters(1),
+			party:    createTestPartyWithIDs(6, 1),
+			expected: EncounterXPAnalysis{
+				RawXP:        200,
+				AdjustedXP:   100, // 1 monster with 6 or more players: x0.5
+				XPMultiplier: 0.5,
+				Thresholds: map[entities.EncounterDifficulty]int{
+					entities.EncounterDifficultyEasy:   150,
+					entities.EncounterDifficultyMedium: 300,
+					entities.EncounterDifficultyHard:   450,
+					entities.EncounterDifficultyDeadly: 600,
+				},
+				Difficulty:  entities.EncounterDifficultyEasy,
+				XPPerPlayer: 33,
+				XPToLevelUp: map[string]int{"p1": 267, "p2": 267, "p3": 267, "p4": 267, "p5": 267, "p6": 267},
+			},
+		},
+		{
+			name:     "Max level members need no more XP and are keyed by name without IDs",
+			monsters: createTestMonsters(20, 20),
+			party:    &entities.Party{Members: []entities.PartyMember{{Name: "Aria", Level: 20}, {Name: "Bram", Level: 20}, {Name: "Cole", Level: 20}}},
+			expected: EncounterXPAnalysis{
+				RawXP:        50000,
+				AdjustedXP:   75000, // 2 monsters: x1.5
+				XPMultiplier: 1.5,
+				Thresholds: map[entities.EncounterDifficulty]int{
+					entities.EncounterDifficultyEasy:   8400,
+					entities.EncounterDifficultyMedium: 17100,
+					entities.EncounterDifficultyHard:   25500,
+					entities.EncounterDifficultyDeadly: 38100,
+				},
+				Difficulty:  entities.EncounterDifficultyDeadly,
+				XPPerPlayer: 16666,
+				XPToLevelUp: map[string]int{"Aria": 0, "Bram": 0, "Cole": 0},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			room := createTestRoomNoGrid()
+			room.Monsters = tc.monsters
+			service := &RoomService{}
+
+			analysis, err := service.CalculateEncounterXPForParty(room, tc.party)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, analysis)
+		})
+	}
+}
+
+func TestCalculateEncounterXPForPartyErrors(t *testing.T) {
+	service := &RoomService{}
+
+	_, err := service.CalculateEncounterXPForParty(nil, createTestPartyWithIDs(4, 1))
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+
+	_, err = service.CalculateEncounterXPForParty(createTestRoomNoGrid(), &entities.Party{})
+	assert.ErrorContains(t, err, "party cannot be empty")
+
+	_, err = service.CalculateEncounterXPForParty(createTestRoomNoGrid(), nil)
+	assert.ErrorContains(t, err, "party cannot be empty")
+
+	_, err = service.CalculateEncounterXPForParty(createTestRoomNoGrid(), createTestPartyWithIDs(4, 0))
+	assert.ErrorContains(t, err, "invalid level")
+}
+
+func TestEncounterMultiplier(t *testing.T) {
+	testCases := []struct {
+		monsterCount int
+		partySize    int
+		expected     float64
+	}{
+		{0, 4, 1},
+		{1, 4, 1},
+		{2, 4, 1.5},
+		{6, 4, 2},
+		{7, 4, 2.5},
+		{14, 4, 3},
+		{15, 4, 4},
+		{1, 6, 0.5},
+		{15, 2, 5},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%d monsters vs %d players", tc.monsterCount, tc.partySize), func(t *testing.T) {
+			assert.Equal(t, tc.expected, encounterMultiplier(tc.monsterCount, tc.partySize))
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// EntityCounts holds the number of entities of each kind in a room
+// Light sources are not yet stored on rooms, so that count is always zero
+type EntityCounts struct {
+	Monsters     int
+	Players      int
+	Items        int
+	NPCs         int
+	Obstacles    int
+	Doors        int
+	Traps        int
+	LightSources int
+	SpellZones   int
+}
+
+// Total returns the number of entities across all kinds
+func (c EntityCounts) Total() int {
+	return c.Monsters + c.Players + c.Items + c.NPCs + c.Obstacles + c.Doors + c.Traps + c.LightSources + c.SpellZones
+}
+
+// GetEntityCounts counts the entities in a room by kind
+// Only the entity slices are read, so gridded and gridless rooms are counted the same way
+func (s *RoomService) GetEntityCounts(room *entities.Room) (EntityCounts, error) {
+	if room == nil {
+		return EntityCounts{}, entities.ErrNilRoom
+	}
+
+	counts := EntityCounts{
+		Monsters:   len(room.Monsters),
+		Players:    len(room.Players),
+		Items:      len(room.Items),
+		NPCs:       len(room.NPCs),
+		Obstacles:  len(room.Obstacles),
+		Doors:      len(room.Doors),
+		Traps:      len(room.Traps),
+		SpellZones: len(room.SpellZones),
+	}
+
+	return counts, nil
+}
+
+// GetEntityCountByType returns the number of entities in a room that occupy cells of the given type
+func (s *RoomService) GetEntityCountByType(room *entities.Room, cellType entities.CellType) (int, error) {
+	if room == nil {
+		return 0, entities.ErrNilRoom
+	}
+
+	switch cellType {
+	case entities.CellMonster, entities.CellPlayer, entities.CellItem, entities.CellNPC, entities.CellObstacle, entities.CellTrap, entities.CellDoor, entities.CellSpellZone:
+		return room.CountEntities(cellType), nil
+	default:
+		return 0, fmt.Errorf("unsupported entity type: %d", cellType)
+	}
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// placeCountedEntities places two goblins, a fighter, a sword, a merchant, a boulder, and a trap
+func placeCountedEntities(t *testing.T, room *entities.Room) {
+	placeables := []entities.Placeable{
+		&entities.Monster{ID: "goblin-1", Position: entities.Position{X: 0, Y: 0}},
+		&entities.Monster{ID: "goblin-2", Position: entities.Position{X: 1, Y: 0}},
+		&entities.Player{ID: "fighter", Position: entities.Position{X: 2, Y: 0}},
+		&entities.Item{ID: "sword", Position: entities.Position{X: 3, Y: 0}},
+		&entities.NPC{ID: "merchant", Position: entities.Position{X: 4, Y: 0}},
+		&entities.Obstacle{ID: "boulder", Key: "boulder", Blocking: true, Position: entities.Position{X: 0, Y: 1}},
+		&entities.Trap{ID: "pit", Key: DefaultTrapKey, Position: entities.Position{X: 1, Y: 1}},
+	}
+	for _, p := range placeables {
+		require.NoError(t, PlaceEntity(room, p))
+	}
+}
+
+func TestGetEntityCounts(t *testing.T) {
+	service := &RoomService{}
+	expected := EntityCounts{Monsters: 2, Players: 1, Items: 1, NPCs: 1, Obstacles: 1, Traps: 1}
+
+	gridded := createTestRoom()
+	placeCountedEntities(t, gridded)
+	gridless := createTestRoomNoGrid()
+	placeCountedEntities(t, gridless)
+
+	griddedCounts, err := service.GetEntityCounts(gridded)
+	require.NoError(t, err)
+	gridlessCounts, err := service.GetEntityCounts(gridless)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, griddedCounts)
+	assert.Equal(t, griddedCounts, gridlessCounts)
+	assert.Equal(t, 7, griddedCounts.Total())
+
+	_, err = service.GetEntityCounts(nil)
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
+
+func TestGetEntityCountByType(t *testing.T) {
+	service := &RoomService{}
+	room := createTestRoom()
+	placeCountedEntities(t, room)
+	require.NoError(t, PlaceEntity(room, &entities.SpellZone{ID: "fog", Position: entities.Position{X: 2, Y: 2}}))
+
+	testCases := []struct {
+		cellType entities.CellType
+		expected int
+	}{
+		{entities.CellMonster, 2},
+		{entities.CellPlayer, 1},
+		{entities.CellItem, 1},
+		{entities.CellNPC, 1},
+		{entities.CellObstacle, 1},
+		{entities.CellTrap, 1},
+		{entities.CellSpellZone, 1},
+	}
+
+	for _, tc := range testCases {
+		count, err := service.GetEntityCountByType(room, tc.cellType)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, count, "cell type %d", tc.cellType)
+	}
+
+	_, err := service.GetEntityCountByType(room, entities.CellTypeEmpty)
+	assert.Error(t, err)
+	_, err = service.GetEntityCountByType(nil, entities.CellMonster)
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
+
+func FuzzEntityCounts(f *testing.F) {
+	f.Add([]byte{0, 1, 2, 3, 4, 5})
+	f.Add([]byte{0, 0, 0, 5, 5, 9, 200})
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, kinds []byte) {
+		service := &RoomService{}
+		room := NewRoom(8, 8, entities.LightLevelBright)
+		InitializeGrid(room)
+
+		// Each byte picks the kind of entity for the next cell in row-major order
+		for i, kind := range kinds {
+			if i >= room.Width*room.Height {
+				break
+			}
+			id := fmt.Sprintf("entity-%d", i)
+			pos := entities.Position{X: i % room.Width, Y: i / room.Width}
+
+			var p entities.Placeable
+			switch kind % 6 {
+			case 0:
+				p = &entities.Monster{ID: id, Position: pos}
+			case 1:
+				p = &entities.Player{ID: id, Position: pos}
+			case 2:
+				p = &entities.Item{ID: id, Position: pos}
+			case 3:
+				p = &entities.NPC{ID: id, Position: pos}
+			case 4:
+				p = &entities.Obstacle{ID: id, Key: "boulder", Blocking: true, Position: pos}
+			case 5:
+				p = &entities.Trap{ID: id, Key: DefaultTrapKey, Position: pos}
+			}
+			require.NoError(t, PlaceEntity(room, p))
+		}
+
+		counts, err := service.GetEntityCounts(room)
+		require.NoError(t, err)
+
+		sum := counts.Monsters + counts.Players + counts.Items + counts.NPCs + counts.Obstacles +
+			counts.Doors + counts.Traps + counts.LightSources + counts.SpellZones
+		assert.Equal(t, sum, counts.Total())
+		assert.Equal(t, min(len(kinds), room.Width*room.Height), counts.Total())
+	})
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// ExportEventLog returns the room's event log as JSON, oldest event first
+// Rooms without logging enabled (see WithEventLog) export an empty list
+func (s *RoomService) ExportEventLog(room *entities.Room) ([]byte, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+
+	events := room.EventLog
+	if events == nil {
+		events = []entities.RoomEvent{}
+	}
+	data, err := json.Marshal(events)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event log: %w", err)
+	}
+	return data, nil
+}
+
+// recordRoomEvent appends an event to the room's event log if logging is enabled
+// The positions are copied so later changes to them do not alter the log
+func recordRoomEvent(room *entities.Room, eventType string, entityID string, cellType entities.CellType, oldPosition, newPosition *entities.Position) {
+	if room.EventLog == nil {
+		return
+	}
+
+	event := entities.RoomEvent{
+		Timestamp: time.Now(),
+		EventType: eventType,
+		EntityID:  entityID,
+		Detail:    entityTypeName(cellType),
+	}
+	if oldPosition != nil {
+		pos := *oldPosition
+		event.OldPosition = &pos
+	}
+	if newPosition != nil {
+		pos := *newPosition
+		event.NewPosition = &pos
+	}
+	room.EventLog = append(room.EventLog, event)
+}
+
+// entityTypeName returns a lowercase name for a kind of entity, such as "monster", or "entity" if it is unknown
+func entityTypeName(cellType entities.CellType) string {
+	switch cellType {
+	case entities.CellPlayer:
+		return "player"
+	case entities.CellMonster:
+		return "monster"
+	case entities.CellItem:
+		return "item"
+	case entities.CellNPC:
+		return "npc"
+	case entities.CellObstacle:
+		return "obstacle"
+	case entities.CellTrap:
+		return "trap"
+	case entities.CellDoor:
+		return "door"
+	case entities.CellSpellZone:
+		return "spell zone"
+	}
+	return "entity"
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestRoomEventLog(t *testing.T) {
+	room, err := NewRoomWithOptions(WithDimensions(5, 5), WithGrid(), WithEventLog())
+	require.NoError(t, err)
+	require.NotNil(t, room.EventLog)
+
+	monster := createTestMonster("goblin", 0, 0)
+	require.NoError(t, PlaceEntity(room, &monster))
+	require.NoError(t, MovePlaceable(room, &monster, entities.Position{X: 1, Y: 1}))
+	require.NoError(t, MovePlaceable(room, &monster, entities.Position{X: 2, Y: 1}))
+	assert.Error(t, MovePlaceable(room, &monster, entities.Position{X: 5, Y: 1}), "failed moves are not logged")
+	removed, err := RemovePlaceable(room, &monster)
+	require.NoError(t, err)
+	require.True(t, removed)
+
+	pos := func(x, y int) *entities.Position { return &entities.Position{X: x, Y: y} }
+	expected := []entities.RoomEvent{
+		{EventType: entities.RoomEventPlaced, EntityID: "goblin", NewPosition: pos(0, 0), Detail: "monster"},
+		{EventType: entities.RoomEventMoved, EntityID: "goblin", OldPosition: pos(0, 0), NewPosition: pos(1, 1), Detail: "monster"},
+		{EventType: entities.RoomEventMoved, EntityID: "goblin", OldPosition: pos(1, 1), NewPosition: pos(2, 1), Detail: "monster"},
+		{EventType: entities.RoomEventRemoved, EntityID: "goblin", OldPosition: pos(2, 1), Detail: "monster"},
+	}
+	require.Len(t, room.EventLog, len(expected))
+	for i, event := range room.EventLog {
+		assert.False(t, event.Timestamp.IsZero())
+		if i > 0 {
+			assert.False(t, event.Timestamp.Before(room.EventLog[i-1].Timestamp), "events are in order")
+		}
+		event.Timestamp = expected[i].Timestamp
+		assert.Equal(t, expected[i], event)
+	}
+
+	service, err := NewRoomService()
+	require.NoError(t, err)
+	data, err := service.ExportEventLog(room)
+	require.NoError(t, err)
+	var exported []entities.RoomEvent
+	require.NoError(t, json.Unmarshal(data, &exported))
+	require.Len(t, exported, len(expected))
+	assert.Equal(t, entities.RoomEventMoved, exported[1].EventType)
+	assert.Equal(t, pos(1, 1), exported[1].NewPosition)
+}
+
+func TestRoomEventLogDisabled(t *testing.T) {
+	room := createTestRoom()
+	monster := createTestMonster("goblin", 0, 0)
+	require.NoError(t, PlaceEntity(room, &monster))
+	require.NoError(t, MovePlaceable(room, &monster, entities.Position{X: 1, Y: 1}))
+	assert.Nil(t, room.EventLog)
+
+	service, err := NewRoomService()
+	require.NoError(t, err)
+	data, err := service.ExportEventLog(room)
+	require.NoError(t, err)
+	assert.JSONEq(t, "[]", string(data))
+
+	_, err = service.ExportEventLog(nil)
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
@@ -0,0 +1,65 @@
+package services
+
+import "sync"
+
+// EventType identifies the kind of event published by a RoomService
+type EventType string
+
+const (
+	// EventAmbushTriggered is published when TriggerAmbush reveals an ambush
+	EventAmbushTriggered EventType = "ambush_triggered"
+
+	// EventEncounterReset is published when ResetEncounter restores a room
+	EventEncounterReset EventType = "encounter_reset"
+)
+
+// Event describes something that happened in a room
+type Event struct {
+	Type      EventType // Kind of event
+	EntityIDs []string  // Entities involved in the event
+	Detail    string    // Human-readable description
+}
+
+// EventHandler is called for each event it is subscribed to
+type EventHandler func(Event)
+
+// EventBus delivers published events to subscribed handlers
+type EventBus struct {
+	mu       sync.RWMutex
+	handlers map[EventType][]EventHandler
+}
+
+// NewEventBus creates an event bus with no subscribers
+func NewEventBus() *EventBus {
+	return &EventBus{handlers: make(map[EventType][]EventHandler)}
+}
+
+// Subscribe registers a handler for the given event type
+func (b *EventBus) Subscribe(eventType EventType, handler EventHandler) {
+	if handler == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// Publish calls every handler subscribed to the event's type, in subscription order
+func (b *EventBus) Publish(event Event) {
+	b.mu.RLock()
+	handlers := append([]EventHandler(nil), b.handlers[event.Type]...)
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+// Events returns the service's event bus, creating it on first use
+func (s *RoomService) Events() *EventBus {
+	if s.events == nil {
+		s.events = NewEventBus()
+	}
+	return s.events
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventBus(t *testing.T) {
+	bus := NewEventBus()
+
+	received := []string{}
+	bus.Subscribe(EventAmbushTriggered, func(e Event) { received = append(received, "first:"+e.Detail) })
+	bus.Subscribe(EventAmbushTriggered, func(e Event) { received = append(received, "second:"+e.Detail) })
+	bus.Subscribe("other", func(e Event) { received = append(received, "other:"+e.Detail) })
+	bus.Subscribe(EventAmbushTriggered, nil)
+
+	bus.Publish(Event{Type: EventAmbushTriggered, Detail: "sprung"})
+	assert.Equal(t, []string{"first:sprung", "second:sprung"}, received)
+
+	// Events without subscribers are dropped
+	bus.Publish(Event{Type: "unheard"})
+	assert.Len(t, received, 2)
+}
+
+func TestRoomServiceEventsLazyInit(t *testing.T) {
+	service := &RoomService{}
+	assert.NotNil(t, service.Events())
+	assert.Same(t, service.Events(), service.Events())
+}
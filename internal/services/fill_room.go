@@ -0,0 +1,137 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/crutil"
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+	"github.com/fadedpez/dnd5e-roomgen/internal/repositories"
+)
+
+// FillRoomConfig controls how FillWithRandomMonsters fills a room
+type FillRoomConfig struct {
+	MinCR          float64                      // Lowest CR of monsters to choose from
+	MaxCR          float64                      // Highest CR of monsters to choose from
+	MaxFillPercent float64                      // Fraction of room cells that may be occupied (0.0-1.0)
+	Party          *entities.Party              // Party whose XP budget limits the monsters added (optional)
+	Difficulty     entities.EncounterDifficulty // Difficulty used for the XP budget (defaults to Medium)
+}
+
+// FillWithRandomMonsters adds randomly chosen monsters from the monster repository to the room
+// The repository must implement repositories.MonsterLister, and if the room has an Environment and the repository
+// implements repositories.MonsterFilterLister, only monsters suited to the environment are chosen; repositories
+// without environment data, such as the embedded SRD table, return repositories.ErrNoEnvironmentData. Monsters are
+// added until MaxFillPercent of the room's cells are occupied or, if Party is set, until no candidate fits within
+// the party's XP threshold for the difficulty. Gridless rooms stop once they hold MaxFillPercent x Width x Height monsters
+func (s *RoomService) FillWithRandomMonsters(room *entities.Room, config FillRoomConfig) error {
+	if room == nil {
+		return entities.ErrNilRoom
+	}
+	if config.MinCR < 0 || config.MinCR > config.MaxCR {
+		return fmt.Errorf("invalid CR range %.3f-%.3f", config.MinCR, config.MaxCR)
+	}
+	if config.MaxFillPercent < 0 || config.MaxFillPercent > 1 {
+		return fmt.Errorf("max fill percent must be between 0 and 1, got %.2f", config.MaxFillPercent)
+	}
+
+	lister, ok := s.monsterRepo.(repositories.MonsterLister)
+	if !ok {
+		return fmt.Errorf("monster repository does not support listing monsters by CR range")
+	}
+	candidates, err := listMonstersForRoom(lister, room, config.MinCR, config.MaxCR)
+	if err != nil {
+		return fmt.Errorf("failed to list monsters: %w", err)
+	}
+	if len(candidates) == 0 {
+		return fmt.Errorf("no monsters found with CR %.3f-%.3f", config.MinCR, config.MaxCR)
+	}
+
+	// Gridded rooms count every occupied cell, gridless rooms only count monsters
+	capacity := int(config.MaxFillPercent * float64(room.Width*room.Height))
+	occupied := len(room.Monsters)
+	if room.Grid != nil {
+		occupied = len(collectPlaceables(room))
+	}
+
+	budget := -1
+	rawXP := 0
+	if config.Party != nil {
+		difficulty := config.Difficulty
+		if difficulty == "" {
+			difficulty = entities.EncounterDifficultyMedium
+		}
+		thresholds, err := partyXPThresholds(*config.Party)
+		if err != nil {
+			return err
+		}
+		var known bool
+		if budget, known = thresholds[difficulty]; !known {
+			return fmt.Errorf("invalid difficulty: %s", difficulty)
+		}
+		for i := range room.Monsters {
+			rawXP += s.monsterXP(&room.Monsters[i])
+		}
+	}
+
+	configs := []PlaceableConfig{}
+	monsterCount := len(room.Monsters)
+	for occupied < capacity {
+		choices := candidates
+		if budget >= 0 {
+			choices = nil
+			multiplier := encounterMultiplier(monsterCount+1, config.Party.Size())
+			for _, candidate := range candidates {
+				if int(math.Round(float64(rawXP+templateXP(candidate))*multiplier)) <= budget {
+					choices = append(choices, candidate)
+				}
+			}
+			if len(choices) == 0 {
+				break
+			}
+		}
+
+		choice := choices[roomIntn(room)(len(choices))]
+		configs = append(configs, MonsterConfig{
+			Name:        choice.Name,
+			Key:         choice.Key,
+			CR:          choice.CR,
+			Count:       1,
+			RandomPlace: true,
+		})
+		rawXP += templateXP(choice)
+		monsterCount++
+		occupied++
+	}
+
+	if len(configs) == 0 {
+		return nil
+	}
+
+	return s.AddPlaceablesToRoom(room, configs)
+}
+
+// templateXP returns the XP of a template monster, falling back to the CR to XP table
+func templateXP(monster *entities.Monster) int {
+	if monster.XP > 0 {
+		return monster.XP
+	}
+	return crutil.CRToXP(monster.CR)
+}
+
+// listMonstersForRoom lists the monsters in the CR range, limited to the room's environment when it has one
+// and the lister supports filtering by environment
+func listMonstersForRoom(lister repositories.MonsterLister, room *entities.Room, minCR, maxCR float64) ([]*entities.Monster, error) {
+	if filterLister, ok := lister.(repositories.MonsterFilterLister); ok && room.Environment != "" {
+		monsters, err := filterLister.ListMonstersByFilter(repositories.MonsterFilter{
+			MinCR:        minCR,
+			MaxCR:        maxCR,
+			Environments: []string{room.Environment},
+		})
+		if !errors.Is(err, repositories.ErrListingNotSupported) {
+			return monsters, err
+		}
+	}
+	return lister.ListMonstersByCRRange(minCR, maxCR)
+}
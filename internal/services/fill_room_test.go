@@ -0,0 +1,163 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+	"github.com/fadedpez/dnd5e-roomgen/internal/repositories"
+)
+
+// mockMonsterLister is a monster repository that can also list monsters by CR
+type mockMonsterLister struct {
+	monsters []*entities.Monster
+}
+
+func (m *mockMonsterLister) GetMonsterXP(key string) (int, error) {
+	for _, monster := range m.monsters {
+		if monster.Key == key {
+			return monster.XP, nil
+		}
+	}
+	return 0, nil
+}
+
+func (m *mockMonsterLister) ListMonstersByCRRange(minCR, maxCR float64) ([]*entities.Monster, error) {
+	found := []*entities.Monster{}
+	for _, monster := range m.monsters {
+		if monster.CR >= minCR && monster.CR <= maxCR {
+			found = append(found, monster)
+		}
+	}
+	return found, nil
+}
+
+// createFillService creates a service whose repository knows a kobold, a goblin, and an ogre
+func createFillService(t *testing.T) *RoomService {
+	service, err := NewRoomService(WithMonsterRepository(&mockMonsterLister{monsters: []*entities.Monster{
+		{Key: "kobold", Name: "Kobold", CR: 0.125, XP: 25},
+		{Key: "goblin", Name: "Goblin", CR: 0.25, XP: 50},
+		{Key: "ogre", Name: "Ogre", CR: 2, XP: 450},
+	}}))
+	require.NoError(t, err)
+	return service
+}
+
+func TestFillWithRandomMonstersFillCap(t *testing.T) {
+	service := createFillService(t)
+
+	t.Run("Gridded", func(t *testing.T) {
+		room := createTestRoom() // 5x5
+		boulder := &entities.Obstacle{ID: "boulder", Blocking: true, Position: entities.Position{X: 0, Y: 0}}
+		require.NoError(t, PlaceEntity(room, boulder))
+
+		err := service.FillWithRandomMonsters(room, FillRoomConfig{MinCR: 0, MaxCR: 1, MaxFillPercent: 0.4})
+		require.NoError(t, err)
+
+		// 40% of 25 cells is 10, and the boulder already fills one
+		assert.Len(t, room.Monsters, 9)
+		for _, monster := range room.Monsters {
+			assert.LessOrEqual(t, monster.CR, 1.0)
+		}
+	})
+
+	t.Run("Gridless", func(t *testing.T) {
+		room := createTestRoomNoGrid()
+
+		err := service.FillWithRandomMonsters(room, FillRoomConfig{MinCR: 0, MaxCR: 5, MaxFillPercent: 0.2})
+		require.NoError(t, err)
+		assert.Len(t, room.Monsters, 5)
+	})
+}
+
+func TestFillWithRandomMonstersXPBudget(t *testing.T) {
+	service := createFillService(t)
+	party := createTestParty(4, 1) // Medium threshold 200 XP
+	room := createTestRoom()
+
+	err := service.FillWithRandomMonsters(room, FillRoomConfig{
+		MinCR:          0,
+		MaxCR:          1,
+		MaxFillPercent: 1,
+		Party:          &party,
+		Difficulty:     entities.EncounterDifficultyMedium,
+	})
+	require.NoError(t, err)
+
+	analysis, err := service.CalculateEncounterXPForParty(room, &party)
+	require.NoError(t, err)
+	assert.NotEmpty(t, room.Monsters)
+	assert.Less(t, len(room.Monsters), 25, "the XP budget should stop filling before the room is full")
+	assert.LessOrEqual(t, analysis.AdjustedXP, 200)
+
+	// Another kobold would always exceed the budget once it is spent
+	withKobold := analysis.RawXP + 25
+	assert.Greater(t, int(float64(withKobold)*encounterMultiplier(len(room.Monsters)+1, party.Size())), 200)
+}
+
+func TestFillWithRandomMonstersEnvironment(t *testing.T) {
+	repo := repositories.NewInMemoryMonsterRepositoryFromSummaries([]repositories.MonsterSummary{
+		{Key: "wolf", Name: "Wolf", CR: 0.25, XP: 50, Environments: []string{entities.EnvironmentForest}},
+		{Key: "drow", Name: "Drow", CR: 0.25, XP: 50, Environments: []string{entities.EnvironmentUnderdark}},
+	})
+	service, err := NewRoomService(WithMonsterRepository(repo))
+	require.NoError(t, err)
+
+	keys := func(room *entities.Room) map[string]bool {
+		found := map[string]bool{}
+		for _, monster := range room.Monsters {
+			found[monster.Key] = true
+		}
+		return found
+	}
+
+	forest := createTestRoom()
+	forest.Environment = entities.EnvironmentForest
+	require.NoError(t, service.FillWithRandomMonsters(forest, FillRoomConfig{MinCR: 0, MaxCR: 1, MaxFillPercent: 0.8}))
+	assert.Equal(t, map[string]bool{"wolf": true}, keys(forest))
+
+	// Rooms without an environment choose from every monster
+	anywhere := NewRoom(10, 10, entities.LightLevelBright)
+	InitializeGrid(anywhere)
+	require.NoError(t, service.FillWithRandomMonsters(anywhere, FillRoomConfig{MinCR: 0, MaxCR: 1, MaxFillPercent: 1}))
+	assert.Equal(t, map[string]bool{"wolf": true, "drow": true}, keys(anywhere))
+
+	coastal := createTestRoom()
+	coastal.Environment = entities.EnvironmentCoastal
+	assert.Error(t, service.FillWithRandomMonsters(coastal, FillRoomConfig{MinCR: 0, MaxCR: 1, MaxFillPercent: 0.5}))
+
+	// The embedded SRD table has no environment data to choose by
+	srdRepo, err := repositories.NewInMemoryMonsterRepository()
+	require.NoError(t, err)
+	srd, err := NewRoomService(WithMonsterRepository(srdRepo))
+	require.NoError(t, err)
+	dungeon := createTestRoom()
+	dungeon.Environment = entities.EnvironmentDungeon
+	assert.ErrorIs(t, srd.FillWithRandomMonsters(dungeon, FillRoomConfig{MinCR: 0, MaxCR: 1, MaxFillPercent: 0.5}), repositories.ErrNoEnvironmentData)
+
+	// A cache in front of the repository still filters by environment
+	cached, err := NewRoomService(WithMonsterRepository(repositories.NewCachedMonsterRepository(repo, 0)))
+	require.NoError(t, err)
+	cachedForest := createTestRoom()
+	cachedForest.Environment = entities.EnvironmentForest
+	require.NoError(t, cached.FillWithRandomMonsters(cachedForest, FillRoomConfig{MinCR: 0, MaxCR: 1, MaxFillPercent: 0.8}))
+	assert.Equal(t, map[string]bool{"wolf": true}, keys(cachedForest))
+}
+
+func TestFillWithRandomMonstersErrors(t *testing.T) {
+	service := createFillService(t)
+	room := createTestRoom()
+
+	assert.ErrorIs(t, service.FillWithRandomMonsters(nil, FillRoomConfig{MaxCR: 1, MaxFillPercent: 0.5}), entities.ErrNilRoom)
+	assert.Error(t, service.FillWithRandomMonsters(room, FillRoomConfig{MinCR: 2, MaxCR: 1, MaxFillPercent: 0.5}))
+	assert.Error(t, service.FillWithRandomMonsters(room, FillRoomConfig{MaxCR: 1, MaxFillPercent: 1.5}))
+	assert.Error(t, service.FillWithRandomMonsters(room, FillRoomConfig{MinCR: 10, MaxCR: 20, MaxFillPercent: 0.5}))
+
+	noLister := &RoomService{monsterRepo: &mockMonsterRepository{}}
+	assert.ErrorContains(t, noLister.FillWithRandomMonsters(room, FillRoomConfig{MaxCR: 1, MaxFillPercent: 0.5}), "does not support")
+
+	cachedNoLister := &RoomService{monsterRepo: repositories.NewCachedMonsterRepository(&mockMonsterRepository{}, 0)}
+	assert.ErrorIs(t, cachedNoLister.FillWithRandomMonsters(room, FillRoomConfig{MaxCR: 1, MaxFillPercent: 0.5}), repositories.ErrListingNotSupported)
+}
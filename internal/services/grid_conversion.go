@@ -0,0 +1,111 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// ConversionReport lists the changes ConvertToGriddedWithReport made to fit entities onto the grid
+type ConversionReport struct {
+	EntitiesRepositioned []string // IDs of entities moved because their cell was taken or out of bounds
+}
+
+// ConvertToGridded adds a grid to a gridless room, moving entities that do not fit where they stand
+// See ConvertToGriddedWithReport
+func (s *RoomService) ConvertToGridded(room *entities.Room) error {
+	_, err := s.ConvertToGriddedWithReport(room)
+	return err
+}
+
+// ConvertToGriddedWithReport adds a grid to a gridless room and reports which entities were moved
+// Entities are placed in the order monsters, players, items, NPCs, obstacles, traps, doors. A multi-cell
+// obstacle claims every cell of its footprint and moves as a whole. An entity whose footprint is out of
+// bounds or already taken moves to the nearest position where it fits (by straight-line distance, then
+// row-major order). Spell zones overlay cells rather than occupying them, so they are left as they are.
+// Returns an error without changing the room if it already has a grid, its entities cover more cells than
+// the room has, or some footprint fits nowhere on the grid
+func (s *RoomService) ConvertToGriddedWithReport(room *entities.Room) (*ConversionReport, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+	if room.Grid != nil {
+		return nil, fmt.Errorf("room already has a grid")
+	}
+	placeables := collectPlaceables(room)
+	covered := 0
+	for _, p := range placeables {
+		covered += len(occupiedCells(p))
+	}
+	if cells := room.Width * room.Height; covered > cells {
+		return nil, fmt.Errorf("room entities cover %d cells but the room has only %d", covered, cells)
+	}
+
+	original := make([]entities.Position, len(placeables))
+	for i, p := range placeables {
+		original[i] = p.GetPosition()
+	}
+	visibility := room.VisibilityGrid
+
+	InitializeGrid(room)
+	report := &ConversionReport{EntitiesRepositioned: []string{}}
+	for _, p := range placeables {
+		pos := p.GetPosition()
+		if !footprintFits(room, p, pos) {
+			var ok bool
+			if pos, ok = nearestFittingPosition(room, p, pos); !ok {
+				for i, placed := range placeables {
+					placed.SetPosition(original[i])
+				}
+				room.Grid, room.VisibilityGrid = nil, visibility
+				return nil, fmt.Errorf("no space on the grid for entity %s", p.GetID())
+			}
+			p.SetPosition(pos)
+			report.EntitiesRepositioned = append(report.EntitiesRepositioned, p.GetID())
+		}
+		for _, cell := range occupiedCells(p) {
+			room.Grid[cell.Y][cell.X] = entities.Cell{Type: p.GetCellType(), EntityID: p.GetID()}
+		}
+	}
+
+	return report, nil
+}
+
+// footprintAt returns the cells the entity would occupy if its position were pos
+func footprintAt(p entities.Placeable, pos entities.Position) []entities.Position {
+	current := p.GetPosition()
+	cells := occupiedCells(p)
+	for i := range cells {
+		cells[i] = entities.Position{X: cells[i].X - current.X + pos.X, Y: cells[i].Y - current.Y + pos.Y}
+	}
+	return cells
+}
+
+// footprintFits reports whether every cell the entity would occupy at pos is inside the room and empty
+func footprintFits(room *entities.Room, p entities.Placeable, pos entities.Position) bool {
+	for _, cell := range footprintAt(p, pos) {
+		if entities.ValidatePosition(room, cell) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// nearestFittingPosition returns the position closest to pos where the entity's whole footprint fits
+// Ties are broken in row-major order. Returns false if the footprint fits nowhere
+func nearestFittingPosition(room *entities.Room, p entities.Placeable, pos entities.Position) (entities.Position, bool) {
+	var best entities.Position
+	bestDistance := -1.0
+	for y := range room.Grid {
+		for x := range room.Grid[y] {
+			candidate := entities.Position{X: x, Y: y}
+			if !footprintFits(room, p, candidate) {
+				continue
+			}
+			if distance := DistanceBetween(pos, candidate, DistanceEuclidean); bestDistance < 0 || distance < bestDistance {
+				best, bestDistance = candidate, distance
+			}
+		}
+	}
+	return best, bestDistance >= 0
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestConvertToGridded(t *testing.T) {
+	service := &RoomService{}
+	room := NewRoom(10, 10, entities.LightLevelBright)
+	monsters := []entities.Monster{
+		{ID: "goblin-1", Position: entities.Position{X: 2, Y: 2}},
+		{ID: "goblin-2", Position: entities.Position{X: 2, Y: 2}},
+		{ID: "goblin-3", Position: entities.Position{X: 7, Y: 1}},
+		{ID: "ogre", Position: entities.Position{X: 12, Y: 9}},
+		{ID: "wolf", Position: entities.Position{X: -1, Y: -3}},
+	}
+	for i := range monsters {
+		require.NoError(t, PlaceEntity(room, &monsters[i]))
+	}
+
+	report, err := service.ConvertToGriddedWithReport(room)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"goblin-2", "ogre", "wolf"}, report.EntitiesRepositioned)
+
+	// Every monster has its own in-bounds cell, and the grid matches the slices exactly
+	require.Len(t, room.Grid, 10)
+	occupied := 0
+	for y := range room.Grid {
+		require.Len(t, room.Grid[y], 10)
+		for x, cell := range room.Grid[y] {
+			if cell.Type == entities.CellTypeEmpty {
+				continue
+			}
+			occupied++
+			monster, _ := FindMonsterByID(room, cell.EntityID)
+			require.NotNil(t, monster)
+			assert.Equal(t, entities.Position{X: x, Y: y}, monster.Position)
+		}
+	}
+	assert.Equal(t, 5, occupied)
+
+	goblin, _ := FindMonsterByID(room, "goblin-2")
+	assert.Equal(t, 1.0, DistanceBetween(goblin.Position, entities.Position{X: 2, Y: 2}, DistanceEuclidean))
+	ogre, _ := FindMonsterByID(room, "ogre")
+	assert.Equal(t, entities.Position{X: 9, Y: 9}, ogre.Position)
+	wolf, _ := FindMonsterByID(room, "wolf")
+	assert.Equal(t, entities.Position{X: 0, Y: 0}, wolf.Position)
+
+	assert.Error(t, service.ConvertToGridded(room), "room already has a grid")
+}
+
+func TestConvertToGriddedErrors(t *testing.T) {
+	service := &RoomService{}
+	assert.ErrorIs(t, service.ConvertToGridded(nil), entities.ErrNilRoom)
+
+	room := NewRoom(1, 1, entities.LightLevelBright)
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "goblin"}))
+	require.NoError(t, PlaceEntity(room, &entities.Player{ID: "fighter"}))
+	assert.Error(t, service.ConvertToGridded(room))
+	assert.Nil(t, room.Grid, "room should be unchanged")
+}
+
+func TestConvertToGriddedMultiCellObstacles(t *testing.T) {
+	service := &RoomService{}
+
+	t.Run("Footprint is claimed", func(t *testing.T) {
+		room := NewRoom(6, 6, entities.LightLevelBright)
+		big := &entities.Obstacle{ID: "big", Blocking: true, Size: entities.Size{Width: 2, Height: 2}, Position: entities.Position{X: 2, Y: 1}}
+		require.NoError(t, PlaceEntity(room, big))
+
+		require.NoError(t, service.ConvertToGridded(room))
+		assert.Empty(t, ValidateRoomGrid(room))
+		assert.Error(t, PlaceEntity(room, &entities.Monster{ID: "goblin", Position: entities.Position{X: 2, Y: 2}}))
+	})
+
+	t.Run("Blocked footprint moves as a whole", func(t *testing.T) {
+		room := NewRoom(6, 6, entities.LightLevelBright)
+		require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "goblin", Position: entities.Position{X: 3, Y: 2}}))
+		require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "big", Size: entities.Size{Width: 2, Height: 2}, Position: entities.Position{X: 2, Y: 1}}))
+
+		report, err := service.ConvertToGriddedWithReport(room)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"big"}, report.EntitiesRepositioned)
+		assert.Empty(t, ValidateRoomGrid(room))
+		goblin, _ := FindMonsterByID(room, "goblin")
+		assert.Equal(t, entities.Position{X: 3, Y: 2}, goblin.Position)
+	})
+
+	t.Run("No fit leaves the room unchanged", func(t *testing.T) {
+		room := NewRoom(3, 3, entities.LightLevelBright)
+		require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "goblin", Position: entities.Position{X: 1, Y: 1}}))
+		require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "wolf", Position: entities.Position{X: 9, Y: 9}}))
+		require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "big", Size: entities.Size{Width: 2, Height: 2}}))
+
+		assert.ErrorContains(t, service.ConvertToGridded(room), "big")
+		assert.Nil(t, room.Grid)
+		wolf, _ := FindMonsterByID(room, "wolf")
+		assert.Equal(t, entities.Position{X: 9, Y: 9}, wolf.Position)
+	})
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// maxMonsterDexModifier caps the Dex modifier StartEncounter derives from a monster's CR
+const maxMonsterDexModifier = 5
+
+// InitiativeTracker steps through the creatures of an encounter in initiative order
+// The turn order wraps around, so calling Next repeatedly cycles through every creature once per round
+type InitiativeTracker struct {
+	entries []entities.InitiativeEntry
+	current int
+}
+
+// StartEncounter rolls initiative for every monster and player in the room and returns a tracker at the first turn
+// Each creature rolls a d20 plus its Dex modifier. Monsters get +1 for every 4 CR, up to +5, and players get +0.
+// Ties are broken by Dex modifier (see SortInitiativeOrder). Seeded rooms roll the same initiative every time
+func (s *RoomService) StartEncounter(room *entities.Room) (*InitiativeTracker, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+
+	entries := make([]entities.InitiativeEntry, 0, len(room.Monsters)+len(room.Players))
+	for _, monster := range room.Monsters {
+		dex := monsterDexModifier(monster.CR)
+		entries = append(entries, entities.InitiativeEntry{
+			EntityID:    monster.ID,
+			CellType:    entities.CellMonster,
+			Roll:        rollD20(room) + dex,
+			DexModifier: dex,
+		})
+	}
+	for _, player := range room.Players {
+		entries = append(entries, entities.InitiativeEntry{
+			EntityID: player.ID,
+			CellType: entities.CellPlayer,
+			Roll:     rollD20(room),
+		})
+	}
+	sortInitiative(entries, s.stableSort)
+
+	return &InitiativeTracker{entries: entries}, nil
+}
+
+// Entries returns a copy of the remaining entries in initiative order
+func (t *InitiativeTracker) Entries() []entities.InitiativeEntry {
+	return cloneSlice(t.entries)
+}
+
+// Current returns the entry whose turn it is, or an empty entry if no creatures are left
+func (t *InitiativeTracker) Current() entities.InitiativeEntry {
+	if len(t.entries) == 0 {
+		return entities.InitiativeEntry{}
+	}
+	return t.entries[t.current]
+}
+
+// Next advances to the next creature's turn, wrapping to the top of the order after the last creature
+// Returns false if no creatures are left
+func (t *InitiativeTracker) Next() (entities.InitiativeEntry, bool) {
+	if len(t.entries) == 0 {
+		return entities.InitiativeEntry{}, false
+	}
+	t.current = (t.current + 1) % len(t.entries)
+	return t.entries[t.current], true
+}
+
+// Remove takes a creature out of the order, for example when it dies or flees
+// If it is the current creature's turn, the turn passes back to the creature before it so the next call to Next
+// moves to the creature that would have followed. Unknown IDs are ignored
+func (t *InitiativeTracker) Remove(entityID string) {
+	for i, entry := range t.entries {
+		if entry.EntityID != entityID {
+			continue
+		}
+
+		t.entries = append(t.entries[:i], t.entries[i+1:]...)
+		if i <= t.current {
+			t.current--
+		}
+		if t.current < 0 {
+			t.current = max(len(t.entries)-1, 0)
+		}
+		return
+	}
+}
+
+// monsterDexModifier derives a monster's Dex modifier from its CR
+func monsterDexModifier(cr float64) int {
+	return min(int(cr/4), maxMonsterDexModifier)
+}
+
+// rollD20 rolls a twenty-sided die, using the room's random source if it has one
+func rollD20(room *entities.Room) int {
+	return roomIntn(room)(20) + 1
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// createTestTracker creates a tracker with fixed entries in the given order
+func createTestTracker(ids ...string) *InitiativeTracker {
+	tracker := &InitiativeTracker{}
+	for i, id := range ids {
+		tracker.entries = append(tracker.entries, entities.InitiativeEntry{EntityID: id, Roll: 20 - i})
+	}
+	return tracker
+}
+
+// trackerIDs returns the IDs of the tracker's entries in order
+func trackerIDs(tracker *InitiativeTracker) []string {
+	ids := []string{}
+	for _, entry := range tracker.Entries() {
+		ids = append(ids, entry.EntityID)
+	}
+	return ids
+}
+
+func TestStartEncounter(t *testing.T) {
+	service := &RoomService{}
+	room := createTacticalRoom()
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "dragon", CR: 17, Position: entities.Position{X: 1, Y: 1}}))
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "goblin", CR: 0.25, Position: entities.Position{X: 2, Y: 2}}))
+	require.NoError(t, PlaceEntity(room, &entities.Player{ID: "fighter", Position: entities.Position{X: 3, Y: 3}}))
+	require.NoError(t, PlaceEntity(room, &entities.NPC{ID: "merchant", Position: entities.Position{X: 4, Y: 4}}))
+
+	for i := 0; i < 20; i++ {
+		tracker, err := service.StartEncounter(room)
+		require.NoError(t, err)
+
+		entries := tracker.Entries()
+		assert.ElementsMatch(t, []string{"dragon", "goblin", "fighter"}, trackerIDs(tracker), "NPCs do not roll initiative")
+		for j := 1; j < len(entries); j++ {
+			prev, entry := entries[j-1], entries[j]
+			assert.True(t, prev.Roll > entry.Roll || (prev.Roll == entry.Roll && prev.DexModifier >= entry.DexModifier), "entries are sorted")
+		}
+		for _, entry := range entries {
+			switch entry.EntityID {
+			case "dragon":
+				assert.Equal(t, 4, entry.DexModifier)
+				assert.Equal(t, entities.CellMonster, entry.CellType)
+				assert.GreaterOrEqual(t, entry.Roll, 5)
+				assert.LessOrEqual(t, entry.Roll, 24)
+			case "fighter":
+				assert.Equal(t, entities.CellPlayer, entry.CellType)
+				assert.GreaterOrEqual(t, entry.Roll, 1)
+				assert.LessOrEqual(t, entry.Roll, 20)
+			}
+		}
+		assert.Equal(t, entries[0], tracker.Current())
+	}
+
+	_, err := service.StartEncounter(nil)
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
+
+func TestStartEncounterSeeded(t *testing.T) {
+	service := &RoomService{}
+	startSeeded := func() []entities.InitiativeEntry {
+		room := createTacticalRoom()
+		room.Rand = rand.New(rand.NewSource(11))
+		for i := 0; i < 6; i++ {
+			require.NoError(t, PlaceEntity(room, &entities.Monster{ID: fmt.Sprintf("goblin%d", i), CR: 0.25, Position: entities.Position{X: i, Y: 0}}))
+		}
+		tracker, err := service.StartEncounter(room)
+		require.NoError(t, err)
+		return tracker.Entries()
+	}
+
+	assert.Equal(t, startSeeded(), startSeeded(), "rooms with the same seed roll the same initiative")
+}
+
+func TestInitiativeTrackerCycles(t *testing.T) {
+	tracker := createTestTracker("a", "b", "c")
+	assert.Equal(t, "a", tracker.Current().EntityID)
+
+	turns := []string{}
+	for i := 0; i < 7; i++ {
+		entry, ok := tracker.Next()
+		require.True(t, ok)
+		turns = append(turns, entry.EntityID)
+	}
+	assert.Equal(t, []string{"b", "c", "a", "b", "c", "a", "b"}, turns)
+	assert.Equal(t, "b", tracker.Current().EntityID)
+}
+
+func TestInitiativeTrackerRemove(t *testing.T) {
+	testCases := []struct {
+		name         string
+		advance      int
+		remove       string
+		expectedIDs  []string
+		expectedNext string
+	}{
+		{"before the current creature", 2, "a", []string{"b", "c", "d"}, "d"},
+		{"after the current creature", 1, "d", []string{"a", "b", "c"}, "c"},
+		{"the current creature", 1, "b", []string{"a", "c", "d"}, "c"},
+		{"the current first creature", 0, "a", []string{"b", "c", "d"}, "b"},
+		{"the current last creature", 3, "d", []string{"a", "b", "c"}, "a"},
+		{"unknown creature", 1, "z", []string{"a", "b", "c", "d"}, "c"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker := createTestTracker("a", "b", "c", "d")
+			for i := 0; i < tc.advance; i++ {
+				tracker.Next()
+			}
+
+			tracker.Remove(tc.remove)
+			assert.Equal(t, tc.expectedIDs, trackerIDs(tracker))
+			next, ok := tracker.Next()
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedNext, next.EntityID)
+		})
+	}
+}
+
+func TestInitiativeTrackerEmpty(t *testing.T) {
+	tracker := createTestTracker("a")
+	tracker.Remove("a")
+
+	_, ok := tracker.Next()
+	assert.False(t, ok)
+	assert.Equal(t, entities.InitiativeEntry{}, tracker.Current())
+}
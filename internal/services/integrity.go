@@ -0,0 +1,209 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// RepairReport summarizes the changes made by RepairRoomIntegrity
+type RepairReport struct {
+	EntitiesRemovedFromSlice int      // Entities dropped because their cell belongs to something else
+	GridCellsCleared         int      // Cells cleared because they referenced missing or misplaced entities
+	GridCellsRepopulated     int      // Cells restored for entities whose cell was empty or mistyped
+	Warnings                 []string // Human-readable description of every repair
+}
+
+// entityKey identifies an entity by cell type and ID
+type entityKey struct {
+	cellType entities.CellType
+	id       string
+}
+
+// cellClaim records that an entity occupies a grid cell
+type cellClaim struct {
+	entity entityKey
+	pos    entities.Position
+}
+
+// ValidateRoomGrid checks that the grid and the entity slices agree with each other
+// Every entity must be in bounds and referenced by the grid cells it occupies (every cell of a multi-cell
+// obstacle), and every occupied grid cell other than a wall must reference an entity of the same type occupying it
+// Gridless rooms have nothing to validate and always return nil
+func ValidateRoomGrid(room *entities.Room) []error {
+	if room == nil {
+		return []error{entities.ErrNilRoom}
+	}
+	if room.Grid == nil {
+		return nil
+	}
+
+	var errs []error
+	positions := map[entityKey]entities.Position{}
+	claims := map[cellClaim]bool{}
+
+	for _, p := range collectPlaceables(room) {
+		key := entityKey{p.GetCellType(), p.GetID()}
+		pos := p.GetPosition()
+		positions[key] = pos
+
+		cells := occupiedCells(p)
+		if !allPositionsValid(room, cells) {
+			errs = append(errs, fmt.Errorf("entity %s is outside room bounds at %s", p.GetID(), pos))
+			continue
+		}
+
+		for _, cellPos := range cells {
+			claims[cellClaim{key, cellPos}] = true
+			cell := room.Grid[cellPos.Y][cellPos.X]
+			if cell.EntityID != p.GetID() || cell.Type != p.GetCellType() {
+				errs = append(errs, fmt.Errorf("entity %s at %s is not referenced by its grid cell", p.GetID(), cellPos))
+			}
+		}
+	}
+
+	for y := range room.Grid {
+		for x, cell := range room.Grid[y] {
+			if cell.Type == entities.CellTypeEmpty || cell.Type == entities.CellWall {
+				continue
+			}
+			key := entityKey{cell.Type, cell.EntityID}
+			pos, ok := positions[key]
+			if !ok {
+				errs = append(errs, fmt.Errorf("grid cell (%d,%d) references missing entity %s", x, y, cell.EntityID))
+			} else if !claims[cellClaim{key, entities.Position{X: x, Y: y}}] {
+				errs = append(errs, fmt.Errorf("grid cell (%d,%d) references entity %s located at %s", x, y, cell.EntityID, pos))
+			}
+		}
+	}
+
+	return errs
+}
+
+// allPositionsValid reports whether every position lies within the room's bounds
+func allPositionsValid(room *entities.Room, positions []entities.Position) bool {
+	for _, pos := range positions {
+		if !IsPositionValid(room, pos) {
+			return false
+		}
+	}
+	return true
+}
+
+// RepairRoomIntegrity repairs inconsistencies between the grid and the entity slices
+// It clears grid cells other than walls that reference missing or misplaced entities, restores grid cells
+// for entities whose cell is empty, and removes entities that are out of bounds or whose
+// cell is occupied by a different entity. It never fails and always returns a report
+func (s *RoomService) RepairRoomIntegrity(room *entities.Room) RepairReport {
+	report := RepairReport{Warnings: []string{}}
+
+	if room == nil {
+		report.Warnings = append(report.Warnings, "room is nil, nothing to repair")
+		return report
+	}
+	if room.Grid == nil {
+		report.Warnings = append(report.Warnings, "room has no grid, nothing to repair")
+		return report
+	}
+
+	claims := map[cellClaim]bool{}
+	for _, p := range collectPlaceables(room) {
+		key := entityKey{p.GetCellType(), p.GetID()}
+		for _, pos := range occupiedCells(p) {
+			claims[cellClaim{key, pos}] = true
+		}
+	}
+
+	// Clear cells that reference entities which don't exist or are located elsewhere
+	for y := range room.Grid {
+		for x, cell := range room.Grid[y] {
+			if cell.Type == entities.CellTypeEmpty || cell.Type == entities.CellWall {
+				continue
+			}
+			if claims[cellClaim{entityKey{cell.Type, cell.EntityID}, entities.Position{X: x, Y: y}}] {
+				continue
+			}
+
+			room.Grid[y][x] = entities.Cell{Type: entities.CellTypeEmpty}
+			report.GridCellsCleared++
+			report.Warnings = append(report.Warnings,
+				fmt.Sprintf("cleared grid cell (%d,%d) referencing entity %s", x, y, cell.EntityID))
+		}
+	}
+
+	// Restore cells for entities that lost them and mark conflicting entities for removal
+	remove := map[entityKey]bool{}
+	for _, p := range collectPlaceables(room) {
+		key := entityKey{p.GetCellType(), p.GetID()}
+		cells := occupiedCells(p)
+
+		if !allPositionsValid(room, cells) {
+			remove[key] = true
+			report.Warnings = append(report.Warnings,
+				fmt.Sprintf("removed entity %s positioned outside the room at %s", p.GetID(), p.GetPosition()))
+			continue
+		}
+
+		conflict := false
+		for _, pos := range cells {
+			cell := room.Grid[pos.Y][pos.X]
+			if cell.Type != entities.CellTypeEmpty && (cell.EntityID != p.GetID() || cell.Type != p.GetCellType()) {
+				conflict = true
+				remove[key] = true
+				report.Warnings = append(report.Warnings,
+					fmt.Sprintf("removed entity %s whose cell %s is occupied by %s", p.GetID(), pos, cell.EntityID))
+				break
+			}
+		}
+		if conflict {
+			continue
+		}
+
+		for _, pos := range cells {
+			if room.Grid[pos.Y][pos.X].Type == entities.CellTypeEmpty {
+				room.Grid[pos.Y][pos.X] = entities.Cell{Type: p.GetCellType(), EntityID: p.GetID()}
+				report.GridCellsRepopulated++
+				report.Warnings = append(report.Warnings,
+					fmt.Sprintf("restored grid cell %s for entity %s", pos, p.GetID()))
+			}
+		}
+	}
+
+	if len(remove) > 0 {
+		before := room.TotalEntityCount()
+		room.Monsters = filterEntities(room.Monsters, func(m *entities.Monster) bool { return !remove[entityKey{entities.CellMonster, m.ID}] })
+		room.Players = filterEntities(room.Players, func(p *entities.Player) bool { return !remove[entityKey{entities.CellPlayer, p.ID}] })
+		room.Items = filterEntities(room.Items, func(i *entities.Item) bool { return !remove[entityKey{entities.CellItem, i.ID}] })
+		room.NPCs = filterEntities(room.NPCs, func(n *entities.NPC) bool { return !remove[entityKey{entities.CellNPC, n.ID}] })
+		room.Obstacles = filterEntities(room.Obstacles, func(o *entities.Obstacle) bool { return !remove[entityKey{entities.CellObstacle, o.ID}] })
+		room.Traps = filterEntities(room.Traps, func(t *entities.Trap) bool { return !remove[entityKey{entities.CellTrap, t.ID}] })
+		room.Doors = filterEntities(room.Doors, func(d *entities.Door) bool { return !remove[entityKey{entities.CellDoor, d.ID}] })
+		after := room.TotalEntityCount()
+		report.EntitiesRemovedFromSlice = before - after
+
+		// Removed multi-cell obstacles can still own cells that did not conflict
+		for y := range room.Grid {
+			for x, cell := range room.Grid[y] {
+				if remove[entityKey{cell.Type, cell.EntityID}] {
+					room.Grid[y][x] = entities.Cell{Type: entities.CellTypeEmpty}
+					report.GridCellsCleared++
+					report.Warnings = append(report.Warnings,
+						fmt.Sprintf("cleared grid cell (%d,%d) referencing removed entity %s", x, y, cell.EntityID))
+				}
+			}
+		}
+	}
+
+	return report
+}
+
+// filterEntities returns the elements of items for which keep returns true, preserving order
+func filterEntities[T any](items []T, keep func(*T) bool) []T {
+	kept := items[:0]
+	for i := range items {
+		if keep(&items[i]) {
+			kept = append(kept, items[i])
+		}
+	}
+	return kept
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestValidateRoomGrid(t *testing.T) {
+	room := createTestRoom()
+	monster := createTestMonster("monster1", 1, 1)
+	assert.NoError(t, PlaceEntity(room, &monster))
+	assert.Empty(t, ValidateRoomGrid(room))
+
+	// Dangling cell
+	room.Grid[3][3] = entities.Cell{Type: entities.CellItem, EntityID: "ghost"}
+	assert.Len(t, ValidateRoomGrid(room), 1)
+
+	// Entity without a cell
+	room.Grid[1][1] = entities.Cell{Type: entities.CellTypeEmpty}
+	assert.Len(t, ValidateRoomGrid(room), 2)
+
+	assert.Nil(t, ValidateRoomGrid(createTestRoomNoGrid()))
+	assert.Len(t, ValidateRoomGrid(nil), 1)
+}
+
+func TestRepairRoomIntegrity(t *testing.T) {
+	service := &RoomService{}
+
+	room := createTestRoom()
+	monster1 := createTestMonster("monster1", 1, 1)
+	monster2 := createTestMonster("monster2", 3, 3)
+	player := createTestPlayer("player1", 3, 0, 4)
+	assert.NoError(t, PlaceEntity(room, &monster1))
+	assert.NoError(t, PlaceEntity(room, &monster2))
+	assert.NoError(t, PlaceEntity(room, &player))
+
+	// Corruption 1: an entity whose cell is occupied by someone else
+	intruder := createTestMonster("intruder", 3, 3)
+	room.Monsters = append(room.Monsters, intruder)
+
+	// Corruption 2: grid cells referencing entities that don't exist
+	room.Grid[0][0] = entities.Cell{Type: entities.CellItem, EntityID: "ghost-item"}
+	room.Grid[2][4] = entities.Cell{Type: entities.CellNPC, EntityID: "ghost-npc"}
+
+	// Corruption 3: an entity whose grid cell was wiped
+	room.Grid[4][0] = entities.Cell{Type: entities.CellTypeEmpty}
+
+	assert.NotEmpty(t, ValidateRoomGrid(room))
+
+	report := service.RepairRoomIntegrity(room)
+	assert.Equal(t, 1, report.EntitiesRemovedFromSlice)
+	assert.Equal(t, 2, report.GridCellsCleared)
+	assert.Equal(t, 1, report.GridCellsRepopulated)
+	assert.Len(t, report.Warnings, 4)
+
+	assert.Empty(t, ValidateRoomGrid(room))
+	assert.Len(t, room.Monsters, 2)
+	assert.Equal(t, "player1", room.Grid[4][0].EntityID)
+	assert.Equal(t, "monster2", room.Grid[3][3].EntityID)
+
+	// A consistent room needs no repairs
+	report = service.RepairRoomIntegrity(room)
+	assert.Equal(t, RepairReport{Warnings: []string{}}, report)
+}
+
+func TestRepairRoomIntegrityEdgeCases(t *testing.T) {
+	service := &RoomService{}
+
+	t.Run("Nil room", func(t *testing.T) {
+		report := service.RepairRoomIntegrity(nil)
+		assert.Len(t, report.Warnings, 1)
+	})
+
+	t.Run("Gridless room", func(t *testing.T) {
+		report := service.RepairRoomIntegrity(createTestRoomNoGrid())
+		assert.Len(t, report.Warnings, 1)
+	})
+
+	t.Run("Out of bounds and misplaced", func(t *testing.T) {
+		room := createTestRoom()
+		monster := createTestMonster("monster1", 1, 1)
+		assert.NoError(t, PlaceEntity(room, &monster))
+
+		// Cell references the monster at the wrong location and another entity is out of bounds
+		room.Grid[2][2] = entities.Cell{Type: entities.CellMonster, EntityID: "monster1"}
+		room.Obstacles = append(room.Obstacles, entities.Obstacle{ID: "wall1", Position: entities.Position{X: 9, Y: 9}})
+
+		report := service.RepairRoomIntegrity(room)
+		assert.Equal(t, 1, report.GridCellsCleared)
+		assert.Equal(t, 1, report.EntitiesRemovedFromSlice)
+		assert.Empty(t, room.Obstacles)
+		assert.Empty(t, ValidateRoomGrid(room))
+	})
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexedMonsterNameGenerator(t *testing.T) {
+	gen := IndexedMonsterNameGenerator{}
+	assert.Equal(t, "Goblin 1", gen.GenerateName("goblin", 1))
+	assert.Equal(t, "Goblin 2", gen.GenerateName("goblin", 2))
+	assert.Equal(t, "Giant Spider 3", gen.GenerateName("giant-spider", 3))
+	assert.Equal(t, "Monster 1", gen.GenerateName("", 1))
+}
+
+func TestEpithetMonsterNameGenerator(t *testing.T) {
+	gen := EpithetMonsterNameGenerator{Epithets: []string{"Scar-faced"}}
+	assert.Equal(t, "Scar-faced Goblin", gen.GenerateName("goblin", 1))
+
+	name := EpithetMonsterNameGenerator{}.GenerateName("orc", 1)
+	assert.True(t, strings.HasSuffix(name, " Orc"), name)
+	assert.Equal(t, name, EpithetMonsterNameGenerator{}.GenerateName("orc", 1), "names do not depend on the shared random source")
+}
+
+func TestUnnamedMonstersGetNames(t *testing.T) {
+	service, err := NewRoomService()
+	require.NoError(t, err)
+	room := createTestRoom()
+
+	configs := []PlaceableConfig{
+		MonsterConfig{Key: "goblin", RandomPlace: true},
+		MonsterConfig{Key: "goblin", RandomPlace: true},
+		MonsterConfig{Key: "wolf", RandomPlace: true},
+		MonsterConfig{Name: "Grak", Key: "goblin", RandomPlace: true},
+	}
+	require.NoError(t, service.AddPlaceablesToRoom(room, configs))
+
+	names := []string{}
+	for _, monster := range room.Monsters {
+		names = append(names, monster.Name)
+	}
+	assert.ElementsMatch(t, []string{"Goblin 1", "Goblin 2", "Wolf 1", "Grak"}, names)
+
+	// Numbering continues across calls
+	require.NoError(t, service.AddPlaceablesToRoom(room, configs[:1]))
+	assert.Equal(t, "Goblin 3", room.Monsters[len(room.Monsters)-1].Name)
+
+	service.SetMonsterNameGenerator(MonsterNameGeneratorFunc(func(key string, index int) string {
+		return fmt.Sprintf("%s #%d", key, index)
+	}))
+	require.NoError(t, service.AddPlaceablesToRoom(room, configs[:1]))
+	assert.Equal(t, "goblin #4", room.Monsters[len(room.Monsters)-1].Name)
+
+	service.SetMonsterNameGenerator(nil)
+	require.NoError(t, service.AddPlaceablesToRoom(room, configs[:1]))
+	assert.Empty(t, room.Monsters[len(room.Monsters)-1].Name)
+}
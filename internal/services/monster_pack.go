@@ -0,0 +1,154 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// GroupMonstersIntoPack groups monsters already in the room into a pack
+// The formation center is the average position of the members
+// Returns an error if any ID is missing from the room, repeated, or already in another pack,
+// or if the leader is not one of the members
+func (s *RoomService) GroupMonstersIntoPack(room *entities.Room, monsterIDs []string, leaderID string) (entities.MonsterPack, error) {
+	if room == nil {
+		return entities.MonsterPack{}, entities.ErrNilRoom
+	}
+	if len(monsterIDs) == 0 {
+		return entities.MonsterPack{}, fmt.Errorf("a pack must have at least one monster")
+	}
+
+	seen := map[string]bool{}
+	sumX, sumY := 0, 0
+	for _, id := range monsterIDs {
+		if seen[id] {
+			return entities.MonsterPack{}, fmt.Errorf("monster %s is listed more than once", id)
+		}
+		seen[id] = true
+
+		monster, _ := FindMonsterByID(room, id)
+		if monster == nil {
+			return entities.MonsterPack{}, fmt.Errorf("monster with ID %s not found in room", id)
+		}
+		for _, pack := range room.MonsterPacks {
+			if pack.HasMember(id) {
+				return entities.MonsterPack{}, fmt.Errorf("monster %s already belongs to pack %s", id, pack.PackID)
+			}
+		}
+
+		sumX += monster.Position.X
+		sumY += monster.Position.Y
+	}
+
+	if leaderID != "" && !seen[leaderID] {
+		return entities.MonsterPack{}, fmt.Errorf("leader %s is not a member of the pack", leaderID)
+	}
+
+	pack := entities.MonsterPack{
+		PackID:     newEntityID(room),
+		MonsterIDs: append([]string(nil), monsterIDs...),
+		LeaderID:   leaderID,
+		FormationCenter: entities.Position{
+			X: sumX / len(monsterIDs),
+			Y: sumY / len(monsterIDs),
+		},
+	}
+	room.MonsterPacks = append(room.MonsterPacks, pack)
+
+	return pack, nil
+}
+
+// GetPackMembers returns copies of the monsters belonging to the pack
+func (s *RoomService) GetPackMembers(room *entities.Room, packID string) ([]entities.Monster, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+
+	pack := findPack(room, packID)
+	if pack == nil {
+		return nil, fmt.Errorf("pack with ID %s not found in room", packID)
+	}
+
+	members := make([]entities.Monster, 0, len(pack.MonsterIDs))
+	for _, id := range pack.MonsterIDs {
+		monster, _ := FindMonsterByID(room, id)
+		if monster == nil {
+			return nil, fmt.Errorf("pack member %s no longer exists in room", id)
+		}
+		members = append(members, *monster)
+	}
+
+	return members, nil
+}
+
+// MovePack moves every member of the pack so that the formation is centered on newCenter
+// Each member keeps its offset from the formation center. The move is all-or-nothing:
+// if any member is out of bounds now or would be after the move, or a destination cell
+// is occupied by a non-member, nothing is moved. Members are moved with MovePlaceable, so each move is logged
+func (s *RoomService) MovePack(room *entities.Room, packID string, newCenter entities.Position) error {
+	if room == nil {
+		return entities.ErrNilRoom
+	}
+
+	pack := findPack(room, packID)
+	if pack == nil {
+		return fmt.Errorf("pack with ID %s not found in room", packID)
+	}
+
+	delta := newCenter.Sub(pack.FormationCenter)
+	members := make([]*entities.Monster, 0, len(pack.MonsterIDs))
+	targets := make([]entities.Position, 0, len(pack.MonsterIDs))
+
+	for _, id := range pack.MonsterIDs {
+		monster, _ := FindMonsterByID(room, id)
+		if monster == nil {
+			return fmt.Errorf("pack member %s no longer exists in room", id)
+		}
+		if !IsPositionValid(room, monster.Position) {
+			return fmt.Errorf("pack member %s is outside room bounds at %s", id, monster.Position)
+		}
+
+		target := monster.Position.Add(delta)
+		if !IsPositionValid(room, target) {
+			return fmt.Errorf("pack member %s would move outside room bounds to %s", id, target)
+		}
+		if room.Grid != nil {
+			cell := room.Grid[target.Y][target.X]
+			if cell.Type != entities.CellTypeEmpty && !(cell.Type == entities.CellMonster && pack.HasMember(cell.EntityID)) {
+				return fmt.Errorf("cell %s is already occupied", target)
+			}
+		}
+
+		members = append(members, monster)
+		targets = append(targets, target)
+	}
+
+	// Move the members furthest along the direction of travel first so the others can shift into their cells
+	order := make([]int, len(members))
+	for i := range order {
+		order[i] = i
+	}
+	along := func(pos entities.Position) int { return pos.X*delta.X + pos.Y*delta.Y }
+	sort.SliceStable(order, func(i, j int) bool {
+		return along(members[order[i]].Position) > along(members[order[j]].Position)
+	})
+	for _, i := range order {
+		if err := MovePlaceable(room, members[i], targets[i]); err != nil {
+			return fmt.Errorf("failed to move pack member %s: %w", members[i].ID, err)
+		}
+	}
+	pack.FormationCenter = newCenter
+
+	return nil
+}
+
+// findPack returns a pointer to the pack with the given ID, or nil if not found
+func findPack(room *entities.Room, packID string) *entities.MonsterPack {
+	for i := range room.MonsterPacks {
+		if room.MonsterPacks[i].PackID == packID {
+			return &room.MonsterPacks[i]
+		}
+	}
+	return nil
+}
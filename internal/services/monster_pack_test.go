@@ -0,0 +1,230 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// createPackRoom creates a gridded room with three goblins and one player placed on the grid
+func createPackRoom(t *testing.T) *entities.Room {
+	room := NewRoom(10, 10, entities.LightLevelBright)
+	InitializeGrid(room)
+
+	monsters := []entities.Monster{
+		createTestMonster("m1", 2, 2),
+		createTestMonster("m2", 3, 2),
+		createTestMonster("m3", 2, 3),
+	}
+	for i := range monsters {
+		require.NoError(t, PlaceEntity(room, &monsters[i]))
+	}
+	player := createTestPlayer("p1", 3, 8, 8)
+	require.NoError(t, PlaceEntity(room, &player))
+
+	return room
+}
+
+func TestGroupMonstersIntoPack(t *testing.T) {
+	testCases := []struct {
+		name           string
+		monsterIDs     []string
+		leaderID       string
+		expectedCenter entities.Position
+		errorSubstring string
+	}{
+		{
+			name:           "Pack with leader",
+			monsterIDs:     []string{"m1", "m2", "m3"},
+			leaderID:       "m1",
+			expectedCenter: entities.Position{X: 2, Y: 2},
+		},
+		{
+			name:           "Pack without leader",
+			monsterIDs:     []string{"m2", "m3"},
+			expectedCenter: entities.Position{X: 2, Y: 2},
+		},
+		{
+			name:           "Empty pack",
+			monsterIDs:     nil,
+			errorSubstring: "at least one monster",
+		},
+		{
+			name:           "Unknown monster",
+			monsterIDs:     []string{"m1", "ghost"},
+			errorSubstring: "not found",
+		},
+		{
+			name:           "Duplicate monster",
+			monsterIDs:     []string{"m1", "m1"},
+			errorSubstring: "more than once",
+		},
+		{
+			name:           "Leader not in pack",
+			monsterIDs:     []string{"m1", "m2"},
+			leaderID:       "m3",
+			errorSubstring: "not a member",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			room := createPackRoom(t)
+			service := &RoomService{}
+
+			pack, err := service.GroupMonstersIntoPack(room, tc.monsterIDs, tc.leaderID)
+
+			if tc.errorSubstring != "" {
+				assert.ErrorContains(t, err, tc.errorSubstring)
+				assert.Empty(t, room.MonsterPacks)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, pack.PackID)
+			assert.Equal(t, tc.monsterIDs, pack.MonsterIDs)
+			assert.Equal(t, tc.leaderID, pack.LeaderID)
+			assert.Equal(t, tc.expectedCenter, pack.FormationCenter)
+			assert.Len(t, room.MonsterPacks, 1)
+		})
+	}
+
+	t.Run("Monster already in a pack", func(t *testing.T) {
+		room := createPackRoom(t)
+		service := &RoomService{}
+
+		_, err := service.GroupMonstersIntoPack(room, []string{"m1", "m2"}, "")
+		require.NoError(t, err)
+
+		_, err = service.GroupMonstersIntoPack(room, []string{"m2", "m3"}, "")
+		assert.ErrorContains(t, err, "already belongs")
+	})
+
+	t.Run("Nil room", func(t *testing.T) {
+		service := &RoomService{}
+		_, err := service.GroupMonstersIntoPack(nil, []string{"m1"}, "")
+		assert.ErrorIs(t, err, entities.ErrNilRoom)
+	})
+}
+
+func TestGetPackMembers(t *testing.T) {
+	room := createPackRoom(t)
+	service := &RoomService{}
+
+	pack, err := service.GroupMonstersIntoPack(room, []string{"m1", "m3"}, "m1")
+	require.NoError(t, err)
+
+	members, err := service.GetPackMembers(room, pack.PackID)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	assert.Equal(t, "m1", members[0].ID)
+	assert.Equal(t, "m3", members[1].ID)
+
+	_, err = service.GetPackMembers(room, "missing")
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestMovePack(t *testing.T) {
+	testCases := []struct {
+		name           string
+		newCenter      entities.Position
+		setup          func(room *entities.Room)
+		expected       map[string]entities.Position
+		errorSubstring string
+	}{
+		{
+			name:      "Move keeps offsets",
+			newCenter: entities.Position{X: 5, Y: 6},
+			expected: map[string]entities.Position{
+				"m1": {X: 5, Y: 6},
+				"m2": {X: 6, Y: 6},
+				"m3": {X: 5, Y: 7},
+			},
+		},
+		{
+			name:      "Members shift into each other's cells",
+			newCenter: entities.Position{X: 3, Y: 2},
+			expected: map[string]entities.Position{
+				"m1": {X: 3, Y: 2},
+				"m2": {X: 4, Y: 2},
+				"m3": {X: 3, Y: 3},
+			},
+		},
+		{
+			name:           "Member would leave the room",
+			newCenter:      entities.Position{X: 9, Y: 9},
+			errorSubstring: "outside room bounds",
+		},
+		{
+			name:           "Destination occupied by non-member",
+			newCenter:      entities.Position{X: 7, Y: 8},
+			errorSubstring: "already occupied",
+		},
+		{
+			name:      "Member already out of bounds",
+			newCenter: entities.Position{X: 4, Y: 4},
+			setup: func(room *entities.Room) {
+				room.Grid[2][3] = entities.Cell{Type: entities.CellTypeEmpty}
+				room.Monsters[1].Position = entities.Position{X: 20, Y: 20}
+			},
+			errorSubstring: "is outside room bounds",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			room := createPackRoom(t)
+			service := &RoomService{}
+
+			pack, err := service.GroupMonstersIntoPack(room, []string{"m1", "m2", "m3"}, "m1")
+			require.NoError(t, err)
+			if tc.setup != nil {
+				tc.setup(room)
+			}
+			before := make([]entities.Monster, len(room.Monsters))
+			copy(before, room.Monsters)
+
+			err = service.MovePack(room, pack.PackID, tc.newCenter)
+
+			if tc.errorSubstring != "" {
+				assert.ErrorContains(t, err, tc.errorSubstring)
+				assert.Equal(t, before, room.Monsters, "no member should move on error")
+				return
+			}
+
+			require.NoError(t, err)
+			for _, monster := range room.Monsters {
+				assert.Equal(t, tc.expected[monster.ID], monster.Position)
+				cell := room.Grid[monster.Position.Y][monster.Position.X]
+				assert.Equal(t, entities.CellMonster, cell.Type)
+				assert.Equal(t, monster.ID, cell.EntityID)
+			}
+			assert.Equal(t, tc.newCenter, room.MonsterPacks[0].FormationCenter)
+			assert.Empty(t, ValidateRoomGrid(room))
+		})
+	}
+}
+
+func TestMovePackEventLog(t *testing.T) {
+	room := createPackRoom(t)
+	room.EventLog = []entities.RoomEvent{}
+	service := &RoomService{}
+
+	pack, err := service.GroupMonstersIntoPack(room, []string{"m1", "m2", "m3"}, "m1")
+	require.NoError(t, err)
+	require.NoError(t, service.MovePack(room, pack.PackID, entities.Position{X: 3, Y: 2}))
+
+	// m2 leads the move to the right, so it moves before m1 takes its cell
+	require.Len(t, room.EventLog, 3)
+	ids := []string{}
+	for _, event := range room.EventLog {
+		assert.Equal(t, entities.RoomEventMoved, event.EventType)
+		ids = append(ids, event.EntityID)
+	}
+	assert.Equal(t, []string{"m2", "m1", "m3"}, ids)
+	assert.Equal(t, entities.Position{X: 2, Y: 2}, *room.EventLog[1].OldPosition)
+	assert.Equal(t, entities.Position{X: 3, Y: 2}, *room.EventLog[1].NewPosition)
+}
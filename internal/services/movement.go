@@ -0,0 +1,113 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// DefaultMonsterSpeedFt is the walking speed used for monsters, players, and NPCs without a Speed
+const DefaultMonsterSpeedFt = 30
+
+// Reasons reported in MoveResult.StopReason when movement ends before the path does
+const (
+	StopReasonOutOfMovement = "out of movement"
+	StopReasonBlocked       = "path blocked"
+)
+
+// MoveResult describes the outcome of walking an entity along a path
+type MoveResult struct {
+	FinalPosition entities.Position // Where the entity ended up
+	StepsTaken    int               // Number of cells entered
+	FeetUsed      int               // Movement spent in feet
+	StoppedEarly  bool              // Whether movement ended before the final path cell
+	StopReason    string            // Why movement ended early, empty if the path was completed
+}
+
+// MoveEntityAlongPath walks an entity along path one cell at a time and returns its final position
+// See MoveEntityAlongPathWithResult for details on how movement is spent
+func (s *RoomService) MoveEntityAlongPath(room *entities.Room, entityID string, cellType entities.CellType, path []entities.Position, speedFt int) (entities.Position, error) {
+	result, err := s.MoveEntityAlongPathWithResult(room, entityID, cellType, path, speedFt)
+	if err != nil {
+		return entities.Position{}, err
+	}
+	return result.FinalPosition, nil
+}
+
+// MoveEntityAlongPathWithResult walks an entity along path one cell at a time
+// Each step costs the room's cell size (5 ft unless CellSizeFt is set), doubled when entering difficult terrain,
+// and speedFt caps the total
+// The path may begin with the entity's current position; every other step must be adjacent to the previous one
+// Movement stops when speed is exhausted, the path ends, or the next cell is blocked
+// The entity is moved in the room to the last cell it could legally reach
+func (s *RoomService) MoveEntityAlongPathWithResult(room *entities.Room, entityID string, cellType entities.CellType, path []entities.Position, speedFt int) (MoveResult, error) {
+	if room == nil {
+		return MoveResult{}, entities.ErrNilRoom
+	}
+
+	entity := findPlaceable(room, entityID, cellType)
+	if entity == nil {
+		return MoveResult{}, fmt.Errorf("entity with ID %s not found in room", entityID)
+	}
+
+	current := entity.GetPosition()
+	result := MoveResult{FinalPosition: current}
+
+	steps := path
+	if len(steps) > 0 && steps[0] == current {
+		steps = steps[1:]
+	}
+
+	for i, next := range steps {
+		if CalculateDistance(current, next) != 1 {
+			return MoveResult{}, fmt.Errorf("path step %d %s is not adjacent to %s", i, next, current)
+		}
+
+		cost := stepCostFt(room, next)
+		if result.FeetUsed+cost > speedFt {
+			result.StoppedEarly = true
+			result.StopReason = StopReasonOutOfMovement
+			break
+		}
+
+		if !isCellEnterable(room, next) {
+			result.StoppedEarly = true
+			result.StopReason = StopReasonBlocked
+			break
+		}
+
+		current = next
+		result.FeetUsed += cost
+		result.StepsTaken++
+	}
+
+	if current != entity.GetPosition() {
+		if err := MovePlaceable(room, entity, current); err != nil {
+			return MoveResult{}, err
+		}
+	}
+	result.FinalPosition = current
+
+	return result, nil
+}
+
+// isCellEnterable reports whether a moving entity may step into the position
+// Positions outside the room are never enterable and occupied grid cells block movement
+func isCellEnterable(room *entities.Room, pos entities.Position) bool {
+	if !IsPositionValid(room, pos) {
+		return false
+	}
+	if room.Grid == nil {
+		return true
+	}
+	return room.Grid[pos.Y][pos.X].Type == entities.CellTypeEmpty
+}
+
+// stepCostFt returns the movement cost in feet of entering the cell: the room's cell size, doubled for
+// difficult terrain
+func stepCostFt(room *entities.Room, pos entities.Position) int {
+	if room.IsDifficultTerrain(pos) {
+		return 2 * roomCellSizeFt(room)
+	}
+	return roomCellSizeFt(room)
+}
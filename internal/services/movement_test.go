@@ -0,0 +1,166 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestMoveEntityAlongPath(t *testing.T) {
+	service := &RoomService{}
+
+	// straightPath is a path along the top row from (0,0) to (4,0)
+	straightPath := []entities.Position{
+		{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 3, Y: 0}, {X: 4, Y: 0},
+	}
+
+	setupRoom := func() *entities.Room {
+		room := createTestRoom()
+		monster := createTestMonster("monster1", 0, 0)
+		assert.NoError(t, PlaceEntity(room, &monster))
+		return room
+	}
+
+	testCases := []struct {
+		name     string
+		setup    func(room *entities.Room)
+		path     []entities.Position
+		speedFt  int
+		expected MoveResult
+	}{
+		{
+			name:    "Path ends before speed is exhausted",
+			path:    straightPath[:3],
+			speedFt: 30,
+			expected: MoveResult{
+				FinalPosition: entities.Position{X: 2, Y: 0},
+				StepsTaken:    2,
+				FeetUsed:      10,
+			},
+		},
+		{
+			name:    "Speed caps movement",
+			path:    straightPath,
+			speedFt: 15,
+			expected: MoveResult{
+				FinalPosition: entities.Position{X: 3, Y: 0},
+				StepsTaken:    3,
+				FeetUsed:      15,
+				StoppedEarly:  true,
+				StopReason:    StopReasonOutOfMovement,
+			},
+		},
+		{
+			name: "Difficult terrain forces early stop",
+			setup: func(room *entities.Room) {
+				room.SetDifficultTerrain(entities.Position{X: 2, Y: 0}, true)
+				room.SetDifficultTerrain(entities.Position{X: 3, Y: 0}, true)
+			},
+			path:    straightPath,
+			speedFt: 20,
+			expected: MoveResult{
+				FinalPosition: entities.Position{X: 2, Y: 0},
+				StepsTaken:    2,
+				FeetUsed:      15,
+				StoppedEarly:  true,
+				StopReason:    StopReasonOutOfMovement,
+			},
+		},
+		{
+			name: "Blocked cell stops movement",
+			setup: func(room *entities.Room) {
+				wall := &entities.Obstacle{ID: "wall1", Blocking: true, Position: entities.Position{X: 2, Y: 0}}
+				assert.NoError(t, PlaceEntity(room, wall))
+			},
+			path:    straightPath,
+			speedFt: 30,
+			expected: MoveResult{
+				FinalPosition: entities.Position{X: 1, Y: 0},
+				StepsTaken:    1,
+				FeetUsed:      5,
+				StoppedEarly:  true,
+				StopReason:    StopReasonBlocked,
+			},
+		},
+		{
+			name:    "Path without starting position",
+			path:    []entities.Position{{X: 1, Y: 1}, {X: 2, Y: 2}},
+			speedFt: 30,
+			expected: MoveResult{
+				FinalPosition: entities.Position{X: 2, Y: 2},
+				StepsTaken:    2,
+				FeetUsed:      10,
+			},
+		},
+		{
+			name:    "Larger cells cost their size in feet",
+			setup:   func(room *entities.Room) { room.CellSizeFt = 10 },
+			path:    straightPath[:4],
+			speedFt: 30,
+			expected: MoveResult{
+				FinalPosition: entities.Position{X: 3, Y: 0},
+				StepsTaken:    3,
+				FeetUsed:      30,
+			},
+		},
+		{
+			name:    "Larger cells exhaust speed sooner",
+			setup:   func(room *entities.Room) { room.CellSizeFt = 10 },
+			path:    straightPath,
+			speedFt: 20,
+			expected: MoveResult{
+				FinalPosition: entities.Position{X: 2, Y: 0},
+				StepsTaken:    2,
+				FeetUsed:      20,
+				StoppedEarly:  true,
+				StopReason:    StopReasonOutOfMovement,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			room := setupRoom()
+			if tc.setup != nil {
+				tc.setup(room)
+			}
+
+			result, err := service.MoveEntityAlongPathWithResult(room, "monster1", entities.CellMonster, tc.path, tc.speedFt)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+
+			// The room reflects the final position
+			final := tc.expected.FinalPosition
+			assert.Equal(t, final, room.Monsters[0].Position)
+			assert.Equal(t, "monster1", room.Grid[final.Y][final.X].EntityID)
+			if final != (entities.Position{X: 0, Y: 0}) {
+				assert.Equal(t, entities.CellTypeEmpty, room.Grid[0][0].Type)
+			}
+		})
+	}
+
+	t.Run("Position-only variant", func(t *testing.T) {
+		room := setupRoom()
+		pos, err := service.MoveEntityAlongPath(room, "monster1", entities.CellMonster, straightPath, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Position{X: 2, Y: 0}, pos)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		room := setupRoom()
+
+		_, err := service.MoveEntityAlongPath(nil, "monster1", entities.CellMonster, straightPath, 30)
+		assert.ErrorIs(t, err, entities.ErrNilRoom)
+
+		_, err = service.MoveEntityAlongPath(room, "missing", entities.CellMonster, straightPath, 30)
+		assert.Error(t, err)
+
+		// Non-adjacent steps are rejected without moving the entity
+		_, err = service.MoveEntityAlongPath(room, "monster1", entities.CellMonster,
+			[]entities.Position{{X: 0, Y: 0}, {X: 2, Y: 0}}, 30)
+		assert.Error(t, err)
+		assert.Equal(t, entities.Position{X: 0, Y: 0}, room.Monsters[0].Position)
+	})
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// NPC group types supported by GenerateNPCGroup
+const (
+	NPCGroupPatrol  = "patrol"
+	NPCGroupCrowd   = "crowd"
+	NPCGroupCaravan = "caravan"
+	NPCGroupCourt   = "court"
+)
+
+// NPCGroupConfig contains parameters for placing a group of NPCs in formation
+type NPCGroupConfig struct {
+	GroupType       string          // One of the NPCGroup constants
+	MemberCount     int             // Number of members, not counting the leader
+	Level           int             // Character level of the members
+	SpawnZoneID     string          // Named zone the whole group must fit in (see WithSpawnZones), or empty for the whole room
+	TeamID          string          // Team assigned to every member and the leader
+	SharedInventory []entities.Item // Items carried by the group
+	LeaderConfig    *NPCConfig      // Optional named NPC placed first, at the head or center of the formation
+}
+
+// GenerateNPCGroup places a group of NPCs in the room in a formation chosen by GroupType
+// Patrols stand in a line, crowds in a cluster, caravans in a column, and courts in a semicircle around
+// the leader. Caravans split the shared inventory between the members in turn; other groups give it to
+// the leader, or to the first member if there is no leader. The leader, if any, is returned first.
+// Returns an error without changing the room if the formation does not fit
+func (s *RoomService) GenerateNPCGroup(room *entities.Room, config NPCGroupConfig) ([]entities.NPC, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+	if config.MemberCount <= 0 {
+		return nil, fmt.Errorf("member count must be positive, got %d", config.MemberCount)
+	}
+
+	zone := SpawnZone{MaxX: room.Width - 1, MaxY: room.Height - 1}
+	if config.SpawnZoneID != "" {
+		var ok bool
+		if zone, ok = s.spawnZones[config.SpawnZoneID]; !ok {
+			return nil, fmt.Errorf("spawn zone %s not found", config.SpawnZoneID)
+		}
+	}
+	if err := zone.Validate(room); err != nil {
+		return nil, err
+	}
+
+	hasLeader := config.LeaderConfig != nil
+	formations, err := npcGroupFormations(config.GroupType, config.MemberCount, hasLeader)
+	if err != nil {
+		return nil, err
+	}
+
+	var positions []entities.Position
+	for _, offsets := range formations {
+		if positions, err = findFormationPlacement(room, zone, offsets); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("no room for a %s of %d: %w", config.GroupType, len(formations[0]), err)
+	}
+
+	group := make([]entities.NPC, 0, len(positions))
+	if hasLeader {
+		leader, err := config.LeaderConfig.CreatePlaceable(s)
+		if err != nil {
+			return nil, err
+		}
+		assignSeededID(room, leader)
+		npc := *leader.(*entities.NPC)
+		npc.Inventory = cloneSlice(npc.Inventory)
+		group = append(group, npc)
+	}
+	for i := 0; i < config.MemberCount; i++ {
+		group = append(group, entities.NPC{
+			ID:    newEntityID(room),
+			Name:  fmt.Sprintf("%s member %d", config.GroupType, i+1),
+			Level: config.Level,
+		})
+	}
+
+	for i, item := range config.SharedInventory {
+		carrier := 0
+		if config.GroupType == NPCGroupCaravan {
+			carrier = len(group) - config.MemberCount + i%config.MemberCount
+		}
+		group[carrier].Inventory = append(group[carrier].Inventory, item)
+	}
+
+	for i := range group {
+		group[i].TeamID = config.TeamID
+		group[i].Position = positions[i]
+		if err := PlaceEntity(room, &group[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return group, nil
+}
+
+// npcGroupFormations returns the candidate formations for a group as offsets from an anchor, best first
+// The first offset of each formation is the leader's slot when there is a leader
+func npcGroupFormations(groupType string, memberCount int, hasLeader bool) ([][]entities.Position, error) {
+	size := memberCount
+	if hasLeader {
+		size++
+	}
+
+	line := func(dx, dy int) []entities.Position {
+		offsets := make([]entities.Position, size)
+		for i := range offsets {
+			offsets[i] = entities.Position{X: i * dx, Y: i * dy}
+		}
+		return offsets
+	}
+
+	switch groupType {
+	case NPCGroupPatrol:
+		return [][]entities.Position{line(1, 0), line(0, 1)}, nil
+	case NPCGroupCaravan:
+		return [][]entities.Position{line(0, 1), line(1, 0)}, nil
+	case NPCGroupCrowd:
+		return [][]entities.Position{clusterOffsets(size)}, nil
+	case NPCGroupCourt:
+		arc := semicircleOffsets(memberCount)
+		if hasLeader {
+			arc = append([]entities.Position{{}}, arc...)
+		}
+		return [][]entities.Position{arc}, nil
+	}
+
+	return nil, fmt.Errorf("unknown NPC group type: %s", groupType)
+}
+
+// clusterOffsets returns the count offsets nearest the anchor, closest first
+func clusterOffsets(count int) []entities.Position {
+	radius := int(math.Ceil(math.Sqrt(float64(count))))
+	offsets := []entities.Position{}
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			offsets = append(offsets, entities.Position{X: x, Y: y})
+		}
+	}
+	sort.SliceStable(offsets, func(i, j int) bool {
+		return offsets[i].X*offsets[i].X+offsets[i].Y*offsets[i].Y < offsets[j].X*offsets[j].X+offsets[j].Y*offsets[j].Y
+	})
+	return offsets[:count]
+}
+
+// semicircleOffsets spreads count offsets evenly along the upper half of a circle around the anchor
+// The radius grows until every offset lands on a distinct cell
+func semicircleOffsets(count int) []entities.Position {
+	for radius := 1; ; radius++ {
+		offsets := make([]entities.Position, 0, count)
+		seen := map[entities.Position]bool{{}: true}
+		for i := 0; i < count; i++ {
+			angle := math.Pi / 2
+			if count > 1 {
+				angle = math.Pi * float64(i) / float64(count-1)
+			}
+			pos := entities.Position{
+				X: int(math.Round(float64(radius) * math.Cos(angle))),
+				Y: -int(math.Round(float64(radius) * math.Sin(angle))),
+			}
+			if seen[pos] {
+				break
+			}
+			seen[pos] = true
+			offsets = append(offsets, pos)
+		}
+		if len(offsets) == count {
+			return offsets
+		}
+	}
+}
+
+// findFormationPlacement picks a random anchor in the zone where every cell of the formation is inside
+// the zone and empty, and returns the formation's positions from that anchor
+func findFormationPlacement(room *entities.Room, zone SpawnZone, offsets []entities.Position) ([]entities.Position, error) {
+	anchors := []entities.Position{}
+	for y := zone.MinY; y <= zone.MaxY; y++ {
+		for x := zone.MinX; x <= zone.MaxX; x++ {
+			anchor := entities.Position{X: x, Y: y}
+			fits := true
+			for _, offset := range offsets {
+				pos := anchor.Add(offset)
+				if !zone.Contains(pos) || (room.Grid != nil && room.Grid[pos.Y][pos.X].Type != entities.CellTypeEmpty) {
+					fits = false
+					break
+				}
+			}
+			if fits {
+				anchors = append(anchors, anchor)
+			}
+		}
+	}
+
+	if len(anchors) == 0 {
+		return nil, ErrNoEmptyPositions
+	}
+
+	anchor := anchors[roomIntn(room)(len(anchors))]
+	positions := make([]entities.Position, len(offsets))
+	for i, offset := range offsets {
+		positions[i] = anchor.Add(offset)
+	}
+	return positions, nil
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestGenerateNPCGroup(t *testing.T) {
+	for _, groupType := range []string{NPCGroupPatrol, NPCGroupCrowd, NPCGroupCaravan, NPCGroupCourt} {
+		t.Run(groupType, func(t *testing.T) {
+			service := &RoomService{}
+			room := NewRoom(12, 12, entities.LightLevelBright)
+			InitializeGrid(room)
+
+			group, err := service.GenerateNPCGroup(room, NPCGroupConfig{
+				GroupType:    groupType,
+				MemberCount:  5,
+				Level:        3,
+				TeamID:       "town-guard",
+				LeaderConfig: &NPCConfig{Name: "Captain", Level: 6},
+			})
+			require.NoError(t, err)
+
+			require.Len(t, group, 6)
+			assert.Len(t, room.NPCs, 6)
+			assert.Equal(t, "Captain", group[0].Name)
+			assert.Equal(t, 6, group[0].Level)
+			seen := map[entities.Position]bool{}
+			for _, npc := range group {
+				assert.Equal(t, "town-guard", npc.TeamID)
+				assert.Equal(t, entities.CellNPC, room.Grid[npc.Position.Y][npc.Position.X].Type)
+				assert.False(t, seen[npc.Position], "members should not share a cell")
+				seen[npc.Position] = true
+			}
+			for _, member := range group[1:] {
+				assert.Equal(t, 3, member.Level)
+			}
+		})
+	}
+}
+
+func TestGenerateNPCGroupPatrolIsCollinear(t *testing.T) {
+	service := &RoomService{}
+
+	// A 3-wide room forces the patrol into a vertical line
+	for _, room := range []*entities.Room{createTacticalRoom(), NewRoom(3, 10, entities.LightLevelBright)} {
+		InitializeGrid(room)
+		group, err := service.GenerateNPCGroup(room, NPCGroupConfig{GroupType: NPCGroupPatrol, MemberCount: 4})
+		require.NoError(t, err)
+		require.Len(t, group, 4)
+
+		sameRow, sameColumn := true, true
+		for _, npc := range group[1:] {
+			sameRow = sameRow && npc.Position.Y == group[0].Position.Y
+			sameColumn = sameColumn && npc.Position.X == group[0].Position.X
+		}
+		assert.True(t, sameRow || sameColumn, "patrol members should be in a line: %v", group)
+	}
+}
+
+func TestGenerateNPCGroupCaravanInventory(t *testing.T) {
+	service := &RoomService{}
+	room := createTacticalRoom()
+	goods := []entities.Item{{ID: "silk"}, {ID: "spices"}, {ID: "wine"}, {ID: "salt"}}
+
+	group, err := service.GenerateNPCGroup(room, NPCGroupConfig{
+		GroupType:       NPCGroupCaravan,
+		MemberCount:     3,
+		SharedInventory: goods,
+		LeaderConfig:    &NPCConfig{Name: "Caravan Master"},
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, group[0].Inventory, "caravan goods go to the members, not the leader")
+	carriers, total := 0, 0
+	for _, npc := range group[1:] {
+		if len(npc.Inventory) > 0 {
+			carriers++
+		}
+		total += len(npc.Inventory)
+		assert.Equal(t, npc.Position.X, group[0].Position.X, "caravans travel in a column")
+	}
+	assert.Equal(t, len(goods), total)
+	assert.Equal(t, 3, carriers)
+
+	// Other groups give the shared inventory to the leader
+	group, err = service.GenerateNPCGroup(room, NPCGroupConfig{
+		GroupType:       NPCGroupCourt,
+		MemberCount:     3,
+		SharedInventory: goods,
+		LeaderConfig:    &NPCConfig{Name: "Queen"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, group[0].Inventory, len(goods))
+}
+
+func TestGenerateNPCGroupSpawnZone(t *testing.T) {
+	zone := SpawnZone{MinX: 5, MinY: 5, MaxX: 9, MaxY: 9}
+	service, err := NewRoomService(WithSpawnZones(map[string]SpawnZone{"courtyard": zone}))
+	require.NoError(t, err)
+	room := createTacticalRoom()
+
+	group, err := service.GenerateNPCGroup(room, NPCGroupConfig{GroupType: NPCGroupCrowd, MemberCount: 8, SpawnZoneID: "courtyard"})
+	require.NoError(t, err)
+	for _, npc := range group {
+		assert.True(t, zone.Contains(npc.Position), "%v should be in the zone", npc.Position)
+	}
+
+	// A patrol of six cannot fit in a 5x5 zone
+	_, err = service.GenerateNPCGroup(room, NPCGroupConfig{GroupType: NPCGroupPatrol, MemberCount: 6, SpawnZoneID: "courtyard"})
+	assert.Error(t, err)
+	assert.Len(t, room.NPCs, 8, "room should be unchanged")
+
+	_, err = service.GenerateNPCGroup(room, NPCGroupConfig{GroupType: NPCGroupPatrol, MemberCount: 2, SpawnZoneID: "dungeon"})
+	assert.Error(t, err)
+	_, err = service.GenerateNPCGroup(room, NPCGroupConfig{GroupType: "mob", MemberCount: 2})
+	assert.Error(t, err)
+	_, err = service.GenerateNPCGroup(room, NPCGroupConfig{GroupType: NPCGroupPatrol})
+	assert.Error(t, err)
+	_, err = service.GenerateNPCGroup(nil, NPCGroupConfig{GroupType: NPCGroupPatrol, MemberCount: 2})
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// Density bounds for ObstacleLayoutConfig.DensityPercent
+const (
+	MinObstacleDensity = 0.05
+	MaxObstacleDensity = 0.4
+)
+
+// obstacleDensityBackoff is how much the obstacle count shrinks each time a layout breaks connectivity
+const obstacleDensityBackoff = 0.9
+
+// defaultBlockingObstacleKeys and defaultOpenObstacleKeys are used when no PreferredKeys are given
+var (
+	defaultBlockingObstacleKeys = []string{"pillar", "boulder", "wall"}
+	defaultOpenObstacleKeys     = []string{"rubble", "crate", "brush"}
+)
+
+// ObstacleLayoutConfig controls how GenerateRandomObstacleLayout dresses a room
+type ObstacleLayoutConfig struct {
+	DensityPercent   float64  // Fraction of room cells to fill with obstacles (0.05-0.4)
+	BlockingRatio    float64  // Fraction of placed obstacles that block movement (0-1)
+	PreferredKeys    []string // Obstacle keys to pick from (optional)
+	ClusteringFactor float64  // Chance each obstacle grows an existing cluster: 0 is fully random, 1 is all clustered
+	Seed             int64    // Seed for reproducible layouts (optional, 0 uses the room's random source)
+}
+
+// obstaclePlan is a planned obstacle position before it is added to the room
+type obstaclePlan struct {
+	position entities.Position
+	blocking bool
+}
+
+// GenerateRandomObstacleLayout adds randomly placed obstacles to the room
+// Obstacles are placed on empty cells, never on the four corners, and the layout keeps
+// every corner reachable from every other corner. If a layout would break connectivity,
+// the obstacle count is reduced until it no longer does
+func (s *RoomService) GenerateRandomObstacleLayout(room *entities.Room, config ObstacleLayoutConfig) error {
+	if room == nil {
+		return entities.ErrNilRoom
+	}
+	if config.DensityPercent < MinObstacleDensity || config.DensityPercent > MaxObstacleDensity {
+		return fmt.Errorf("density must be between %.2f and %.2f, got %.2f", MinObstacleDensity, MaxObstacleDensity, config.DensityPercent)
+	}
+	if config.BlockingRatio < 0 || config.BlockingRatio > 1 {
+		return fmt.Errorf("blocking ratio must be between 0 and 1, got %.2f", config.BlockingRatio)
+	}
+	if config.ClusteringFactor < 0 || config.ClusteringFactor > 1 {
+		return fmt.Errorf("clustering factor must be between 0 and 1, got %.2f", config.ClusteringFactor)
+	}
+
+	intn := rand.Intn
+	float := rand.Float64
+	if room.Rand != nil {
+		intn = room.Rand.Intn
+		float = room.Rand.Float64
+	}
+	if config.Seed != 0 {
+		rng := rand.New(rand.NewSource(config.Seed))
+		intn = rng.Intn
+		float = rng.Float64
+	}
+
+	occupied := map[entities.Position]bool{}
+	for pos := range entities.GetOccupiedCells(room) {
+		occupied[pos] = true
+	}
+	blocked := blockingObstaclePositions(room)
+	corners := roomCorners(room)
+	for _, corner := range corners {
+		occupied[corner] = true
+	}
+	if !cornersConnected(room, blocked, corners) {
+		return fmt.Errorf("room corners are already disconnected")
+	}
+
+	candidates := []entities.Position{}
+	for y := 0; y < room.Height; y++ {
+		for x := 0; x < room.Width; x++ {
+			pos := entities.Position{X: x, Y: y}
+			if !occupied[pos] {
+				candidates = append(candidates, pos)
+			}
+		}
+	}
+
+	target := int(math.Round(config.DensityPercent * float64(room.Width*room.Height)))
+	if target > len(candidates) {
+		target = len(candidates)
+	}
+	plan := planObstacleLayout(candidates, target, config, intn, float)
+
+	// Back off the density until the corners stay connected
+	for count := len(plan); ; count = int(float64(count) * obstacleDensityBackoff) {
+		layoutBlocked := make(map[entities.Position]bool, len(blocked)+count)
+		for pos := range blocked {
+			layoutBlocked[pos] = true
+		}
+		for _, planned := range plan[:count] {
+			if planned.blocking {
+				layoutBlocked[planned.position] = true
+			}
+		}
+		if cornersConnected(room, layoutBlocked, corners) {
+			plan = plan[:count]
+			break
+		}
+	}
+
+	for _, planned := range plan {
+		key := pickObstacleKey(config.PreferredKeys, planned.blocking, intn)
+		obstacle := &entities.Obstacle{
+			ID:       newEntityID(room),
+			Name:     nameFromKey(key),
+			Key:      key,
+			Position: planned.position,
+			Blocking: planned.blocking,
+		}
+		if err := PlaceEntity(room, obstacle); err != nil {
+			return fmt.Errorf("failed to place obstacle at %s: %w", planned.position, err)
+		}
+	}
+
+	return nil
+}
+
+// planObstacleLayout picks count positions from candidates, growing clusters with
+// probability config.ClusteringFactor, and marks round(count * BlockingRatio) of them as blocking
+func planObstacleLayout(candidates []entities.Position, count int, config ObstacleLayoutConfig, intn func(int) int, float func() float64) []obstaclePlan {
+	free := make(map[entities.Position]bool, len(candidates))
+	for _, pos := range candidates {
+		free[pos] = true
+	}
+
+	plan := make([]obstaclePlan, 0, count)
+	for len(plan) < count {
+		var pos entities.Position
+		found := false
+
+		if len(plan) > 0 && float() < config.ClusteringFactor {
+			frontier := []entities.Position{}
+			for _, planned := range plan {
+				for _, neighbor := range planned.position.Neighbors(false) {
+					if free[neighbor] {
+						frontier = append(frontier, neighbor)
+					}
+				}
+			}
+			if len(frontier) > 0 {
+				pos = frontier[intn(len(frontier))]
+				found = true
+			}
+		}
+
+		if !found {
+			remaining := make([]entities.Position, 0, len(free))
+			for _, candidate := range candidates {
+				if free[candidate] {
+					remaining = append(remaining, candidate)
+				}
+			}
+			pos = remaining[intn(len(remaining))]
+		}
+
+		delete(free, pos)
+		plan = append(plan, obstaclePlan{position: pos})
+	}
+
+	// Spread the blocking obstacles across the plan, so backing off the count keeps the ratio roughly intact
+	blockingCount := int(math.Round(float64(count) * config.BlockingRatio))
+	for i := 0; i < blockingCount; i++ {
+		plan[i*count/blockingCount].blocking = true
+	}
+
+	return plan
+}
+
+// roomCorners returns the distinct corner positions of the room
+func roomCorners(room *entities.Room) []entities.Position {
+	corners := []entities.Position{}
+	seen := map[entities.Position]bool{}
+	for _, pos := range []entities.Position{
+		{X: 0, Y: 0},
+		{X: room.Width - 1, Y: 0},
+		{X: 0, Y: room.Height - 1},
+		{X: room.Width - 1, Y: room.Height - 1},
+	} {
+		if !seen[pos] {
+			seen[pos] = true
+			corners = append(corners, pos)
+		}
+	}
+	return corners
+}
+
+// cornersConnected reports whether every corner can reach every other corner without crossing a blocked cell
+// Each corner is checked with the pathfinder's A* search (see findWeightedPath) from the first corner
+func cornersConnected(room *entities.Room, blocked map[entities.Position]bool, corners []entities.Position) bool {
+	if len(corners) == 0 || blocked[corners[0]] {
+		return len(corners) == 0
+	}
+
+	for _, corner := range corners[1:] {
+		if _, err := findPathAround(room, corners[0], corner, blocked, nil); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// pickObstacleKey returns a random preferred key, or a default key suited to the blocking flag
+func pickObstacleKey(preferred []string, blocking bool, intn func(int) int) string {
+	keys := preferred
+	if len(keys) == 0 {
+		keys = defaultOpenObstacleKeys
+		if blocking {
+			keys = defaultBlockingObstacleKeys
+		}
+	}
+	return keys[intn(len(keys))]
+}
+
+// nameFromKey turns a key like "stone_wall" into a display name like "Stone Wall"
+func nameFromKey(key string) string {
+	words := strings.FieldsFunc(key, func(r rune) bool { return r == '_' || r == '-' || r == ' ' })
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// blockedPositions returns the positions of every blocking obstacle in the room
+func blockedPositions(room *entities.Room) map[entities.Position]bool {
+	blocked := map[entities.Position]bool{}
+	for _, obstacle := range room.Obstacles {
+		if obstacle.Blocking {
+			blocked[obstacle.Position] = true
+		}
+	}
+	return blocked
+}
+
+func TestGenerateRandomObstacleLayout(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        ObstacleLayoutConfig
+		expectedCount int // exact count when connectivity cannot force a back-off
+		maxCount      int
+	}{
+		{
+			name:          "Non-blocking obstacles at minimum density",
+			config:        ObstacleLayoutConfig{DensityPercent: 0.05, Seed: 1},
+			expectedCount: 5,
+		},
+		{
+			name:     "Mixed obstacles",
+			config:   ObstacleLayoutConfig{DensityPercent: 0.2, BlockingRatio: 0.5, Seed: 2},
+			maxCount: 20,
+		},
+		{
+			name:     "All blocking at maximum density",
+			config:   ObstacleLayoutConfig{DensityPercent: 0.4, BlockingRatio: 1, ClusteringFactor: 0.5, Seed: 3},
+			maxCount: 40,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			room := NewRoom(10, 10, entities.LightLevelBright)
+			InitializeGrid(room)
+			service := &RoomService{}
+
+			err := service.GenerateRandomObstacleLayout(room, tc.config)
+			require.NoError(t, err)
+
+			if tc.expectedCount > 0 {
+				assert.Len(t, room.Obstacles, tc.expectedCount)
+			} else {
+				assert.NotEmpty(t, room.Obstacles)
+				assert.LessOrEqual(t, len(room.Obstacles), tc.maxCount)
+			}
+
+			if tc.config.BlockingRatio == 0 {
+				assert.Empty(t, blockedPositions(room))
+			}
+			assert.True(t, cornersConnected(room, blockedPositions(room), roomCorners(room)))
+			assert.Empty(t, ValidateRoomGrid(room))
+
+			for _, corner := range roomCorners(room) {
+				assert.Equal(t, entities.CellTypeEmpty, room.Grid[corner.Y][corner.X].Type)
+			}
+		})
+	}
+}
+
+func TestGenerateRandomObstacleLayoutConnectivity(t *testing.T) {
+	service := &RoomService{}
+
+	for seed := int64(1); seed <= 50; seed++ {
+		room := NewRoom(6, 6, entities.LightLevelBright)
+		InitializeGrid(room)
+
+		err := service.GenerateRandomObstacleLayout(room, ObstacleLayoutConfig{
+			DensityPercent:   0.4,
+			BlockingRatio:    1,
+			ClusteringFactor: 1,
+			Seed:             seed,
+		})
+		require.NoError(t, err)
+		assert.True(t, cornersConnected(room, blockedPositions(room), roomCorners(room)), "seed %d", seed)
+	}
+}
+
+func TestGenerateRandomObstacleLayoutClustering(t *testing.T) {
+	room := NewRoom(12, 12, entities.LightLevelBright)
+	InitializeGrid(room)
+	service := &RoomService{}
+
+	err := service.GenerateRandomObstacleLayout(room, ObstacleLayoutConfig{
+		DensityPercent:   0.1,
+		ClusteringFactor: 1,
+		PreferredKeys:    []string{"stone_pillar"},
+		Seed:             7,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, room.Obstacles)
+
+	// With full clustering every obstacle after the first grows the same cluster
+	positions := map[entities.Position]bool{}
+	for _, obstacle := range room.Obstacles {
+		positions[obstacle.Position] = true
+		assert.Equal(t, "stone_pillar", obstacle.Key)
+		assert.Equal(t, "Stone Pillar", obstacle.Name)
+	}
+	reached := map[entities.Position]bool{room.Obstacles[0].Position: true}
+	queue := []entities.Position{room.Obstacles[0].Position}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, neighbor := range current.Neighbors(false) {
+			if positions[neighbor] && !reached[neighbor] {
+				reached[neighbor] = true
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+	assert.Len(t, reached, len(room.Obstacles))
+}
+
+func TestGenerateRandomObstacleLayoutSeeded(t *testing.T) {
+	service := &RoomService{}
+	config := ObstacleLayoutConfig{DensityPercent: 0.25, BlockingRatio: 0.5, ClusteringFactor: 0.3, Seed: 42}
+
+	positions := func() []entities.Position {
+		room := NewRoom(8, 8, entities.LightLevelBright)
+		InitializeGrid(room)
+		require.NoError(t, service.GenerateRandomObstacleLayout(room, config))
+		result := make([]entities.Position, len(room.Obstacles))
+		for i, obstacle := range room.Obstacles {
+			result[i] = obstacle.Position
+		}
+		return result
+	}
+
+	assert.Equal(t, positions(), positions())
+}
+
+func TestGenerateRandomObstacleLayoutInvalid(t *testing.T) {
+	service := &RoomService{}
+
+	testCases := []struct {
+		name           string
+		config         ObstacleLayoutConfig
+		errorSubstring string
+	}{
+		{"Density too low", ObstacleLayoutConfig{DensityPercent: 0.01}, "density"},
+		{"Density too high", ObstacleLayoutConfig{DensityPercent: 0.5}, "density"},
+		{"Blocking ratio out of range", ObstacleLayoutConfig{DensityPercent: 0.1, BlockingRatio: 1.5}, "blocking ratio"},
+		{"Clustering out of range", ObstacleLayoutConfig{DensityPercent: 0.1, ClusteringFactor: -0.1}, "clustering factor"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := service.GenerateRandomObstacleLayout(createTestRoom(), tc.config)
+			assert.ErrorContains(t, err, tc.errorSubstring)
+		})
+	}
+
+	assert.ErrorIs(t, service.GenerateRandomObstacleLayout(nil, ObstacleLayoutConfig{DensityPercent: 0.1}), entities.ErrNilRoom)
+
+	walled := createTestRoom()
+	require.NoError(t, AddWallRegion(walled, entities.Position{X: 2, Y: 0}, entities.Position{X: 2, Y: 4}))
+	assert.ErrorContains(t, service.GenerateRandomObstacleLayout(walled, ObstacleLayoutConfig{DensityPercent: 0.1}), "already disconnected")
+}
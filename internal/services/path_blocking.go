@@ -0,0 +1,72 @@
+package services
+
+import (
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// GetEntitiesBlockingPath returns every entity standing on the straight line between two positions
+// A multi-cell obstacle is included if any cell of its footprint is on the line.
+// The line is traced with Bresenham's algorithm (see HasLineOfSight), and entities at either end are not included.
+// Entities are returned in the order collectPlaceables lists them, whether or not they can be passed through.
+// Wall cells are not entities and are not reported; use HasLineOfSight to check for them
+func (s *RoomService) GetEntitiesBlockingPath(room *entities.Room, from, to entities.Position) ([]entities.Placeable, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+	if !IsPositionValid(room, from) || !IsPositionValid(room, to) {
+		return nil, entities.ErrInvalidPosition
+	}
+
+	onLine := map[entities.Position]bool{}
+	for _, pos := range lineBetween(from, to) {
+		onLine[pos] = true
+	}
+
+	blocking := []entities.Placeable{}
+	for _, p := range collectPlaceables(room) {
+		for _, pos := range occupiedCells(p) {
+			if onLine[pos] {
+				blocking = append(blocking, p)
+				break
+			}
+		}
+	}
+
+	return blocking, nil
+}
+
+// CanPassThrough reports whether a creature can move through the entity's cell
+// Blocking obstacles, locked or barred doors (see Door.IsBlocking), and dead monsters (see Monster.IsDead),
+// whose bodies fill the cell, cannot be passed; everything else can
+func CanPassThrough(entity entities.Placeable) bool {
+	switch e := entity.(type) {
+	case *entities.Obstacle:
+		return !e.Blocking
+	case *entities.Door:
+		return !e.IsBlocking()
+	case *entities.Monster:
+		return !e.IsDead()
+	}
+	return true
+}
+
+// FindUnblockedPath finds the shortest path between two positions that only enters cells CanPassThrough allows
+// Wall cells are never entered.
+// Movement is one square at a time in any of eight directions. The path starts at from and ends at to,
+// and ErrNoPath is returned if there is none
+func (s *RoomService) FindUnblockedPath(room *entities.Room, from, to entities.Position) ([]entities.Position, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+
+	blocked := blockingObstaclePositions(room)
+	for _, p := range collectPlaceables(room) {
+		if !CanPassThrough(p) {
+			for _, pos := range occupiedCells(p) {
+				blocked[pos] = true
+			}
+		}
+	}
+
+	return findPathAround(room, from, to, blocked, nil)
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestGetEntitiesBlockingPath(t *testing.T) {
+	service := &RoomService{}
+	room := createTacticalRoom()
+	require.NoError(t, PlaceEntity(room, &entities.Player{ID: "start", Position: entities.Position{X: 0, Y: 0}}))
+	require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "crate", Position: entities.Position{X: 2, Y: 0}}))
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "goblin", MaxHP: 7, CurrentHP: 7, Position: entities.Position{X: 3, Y: 0}}))
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "target", MaxHP: 7, CurrentHP: 7, Position: entities.Position{X: 5, Y: 0}}))
+	require.NoError(t, PlaceEntity(room, &entities.Item{ID: "coin", Position: entities.Position{X: 3, Y: 1}}))
+
+	blocking, err := service.GetEntitiesBlockingPath(room, entities.Position{X: 0, Y: 0}, entities.Position{X: 5, Y: 0})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"crate", "goblin"}, placeableIDs(blocking), "entities at either end and off the line are excluded")
+
+	blocking, err = service.GetEntitiesBlockingPath(room, entities.Position{X: 0, Y: 5}, entities.Position{X: 5, Y: 5})
+	require.NoError(t, err)
+	assert.Empty(t, blocking)
+
+	_, err = service.GetEntitiesBlockingPath(room, entities.Position{}, entities.Position{X: 10, Y: 0})
+	assert.ErrorIs(t, err, entities.ErrInvalidPosition)
+	_, err = service.GetEntitiesBlockingPath(nil, entities.Position{}, entities.Position{})
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
+
+func TestCanPassThrough(t *testing.T) {
+	assert.False(t, CanPassThrough(&entities.Obstacle{Blocking: true}))
+	assert.True(t, CanPassThrough(&entities.Obstacle{Blocking: false}))
+	assert.False(t, CanPassThrough(&entities.Monster{MaxHP: 7, CurrentHP: 0}))
+	assert.True(t, CanPassThrough(&entities.Monster{MaxHP: 7, CurrentHP: 3}))
+	assert.True(t, CanPassThrough(&entities.Monster{}), "monsters without tracked hit points are alive")
+	assert.True(t, CanPassThrough(&entities.Player{}))
+	assert.True(t, CanPassThrough(&entities.Item{}))
+}
+
+func TestFindUnblockedPath(t *testing.T) {
+	from, to := entities.Position{X: 0, Y: 0}, entities.Position{X: 4, Y: 0}
+
+	testCases := []struct {
+		name         string
+		obstacles    []entities.Obstacle
+		monsters     []entities.Monster
+		expectedLen  int
+		avoidedCells []entities.Position
+	}{
+		{
+			name:        "clear path",
+			expectedLen: 5,
+		},
+		{
+			name:        "non-blocking obstacle is passable",
+			obstacles:   []entities.Obstacle{{ID: "rubble", Position: entities.Position{X: 2, Y: 0}}, {ID: "table", Blocking: true, Position: entities.Position{X: 2, Y: 1}}},
+			expectedLen: 5,
+		},
+		{
+			name: "blocking obstacle requires a reroute",
+			obstacles: []entities.Obstacle{
+				{ID: "wall1", Blocking: true, Position: entities.Position{X: 2, Y: 0}},
+				{ID: "wall2", Blocking: true, Position: entities.Position{X: 2, Y: 1}},
+				{ID: "wall3", Blocking: true, Position: entities.Position{X: 2, Y: 2}},
+			},
+			expectedLen:  7,
+			avoidedCells: []entities.Position{{X: 2, Y: 0}, {X: 2, Y: 1}, {X: 2, Y: 2}},
+		},
+		{
+			name:      "dead monster requires a reroute",
+			obstacles: []entities.Obstacle{{ID: "wall", Blocking: true, Position: entities.Position{X: 2, Y: 1}}},
+			monsters:  []entities.Monster{{ID: "corpse", MaxHP: 7, Position: entities.Position{X: 2, Y: 0}}},
+			// Around the wall and corpse through (2,2)
+			expectedLen:  5,
+			avoidedCells: []entities.Position{{X: 2, Y: 0}, {X: 2, Y: 1}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := &RoomService{}
+			room := createTacticalRoom()
+			for i := range tc.obstacles {
+				require.NoError(t, PlaceEntity(room, &tc.obstacles[i]))
+			}
+			for i := range tc.monsters {
+				require.NoError(t, PlaceEntity(room, &tc.monsters[i]))
+			}
+
+			path, err := service.FindUnblockedPath(room, from, to)
+			require.NoError(t, err)
+			assert.Equal(t, from, path[0])
+			assert.Equal(t, to, path[len(path)-1])
+			assert.Len(t, path, tc.expectedLen)
+			for _, cell := range tc.avoidedCells {
+				assert.NotContains(t, path, cell)
+			}
+		})
+	}
+}
+
+func TestFindUnblockedPathNoPath(t *testing.T) {
+	service := &RoomService{}
+	room := createTacticalRoom()
+	for y := 0; y < room.Height; y++ {
+		require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "wall", Blocking: true, Position: entities.Position{X: 5, Y: y}}))
+	}
+
+	_, err := service.FindUnblockedPath(room, entities.Position{X: 0, Y: 0}, entities.Position{X: 9, Y: 0})
+	assert.ErrorIs(t, err, ErrNoPath)
+}
+
+func TestFindUnblockedPathWalls(t *testing.T) {
+	service := &RoomService{}
+	room := createTacticalRoom()
+	require.NoError(t, AddWallRegion(room, entities.Position{X: 5, Y: 0}, entities.Position{X: 5, Y: 9}))
+
+	_, err := service.FindUnblockedPath(room, entities.Position{X: 0, Y: 0}, entities.Position{X: 9, Y: 0})
+	assert.ErrorIs(t, err, ErrNoPath)
+
+	require.NoError(t, ClearWallRegion(room, entities.Position{X: 5, Y: 9}, entities.Position{X: 5, Y: 9}))
+	path, err := service.FindUnblockedPath(room, entities.Position{X: 0, Y: 0}, entities.Position{X: 9, Y: 0})
+	require.NoError(t, err)
+	assert.Contains(t, path, entities.Position{X: 5, Y: 9}, "the path goes through the gap in the wall")
+}
+
+func TestMultiCellObstacleBlocksPath(t *testing.T) {
+	service := &RoomService{}
+	room := createTacticalRoom()
+	// A 1x10 barrier anchored at the top of column 5 cuts the room in two
+	require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "barrier", Blocking: true, Size: entities.Size{Width: 1, Height: 10}, Position: entities.Position{X: 5, Y: 0}}))
+
+	blocking, err := service.GetEntitiesBlockingPath(room, entities.Position{X: 0, Y: 4}, entities.Position{X: 9, Y: 4})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"barrier"}, placeableIDs(blocking), "cells off the anchor block the line")
+
+	_, err = service.FindUnblockedPath(room, entities.Position{X: 0, Y: 4}, entities.Position{X: 9, Y: 4})
+	assert.ErrorIs(t, err, ErrNoPath)
+}
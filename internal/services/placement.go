@@ -2,9 +2,11 @@ package services
 
 import (
 	"errors"
+	"fmt"
 	"math/rand"
 
 	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+	"github.com/google/uuid"
 )
 
 // Error constants for placement operations
@@ -20,19 +22,21 @@ func PlaceEntity(room *entities.Room, entity entities.Placeable) error {
 		return entities.ErrNilRoom
 	}
 
-	// For rooms with a grid, validate position before adding to slices
-	if room.Grid != nil {
-		pos := entity.GetPosition()
-
-		// Check if position is within room boundaries
-		if pos.X < 0 || pos.X >= room.Width ||
-			pos.Y < 0 || pos.Y >= room.Height {
-			return entities.ErrInvalidPosition
+	// Spell zones overlay cells instead of occupying them, so only the target point is checked
+	if zone, ok := entity.(*entities.SpellZone); ok {
+		if err := placeSpellZone(room, zone); err != nil {
+			return err
 		}
+		recordRoomEvent(room, entities.RoomEventPlaced, entity.GetID(), entity.GetCellType(), nil, &zone.Position)
+		return nil
+	}
 
-		// Check if cell is already occupied
-		if room.Grid[pos.Y][pos.X].Type != entities.CellTypeEmpty {
-			return entities.ErrCellOccupied
+	// For rooms with a grid, validate every occupied cell before adding to slices
+	if room.Grid != nil {
+		for _, pos := range occupiedCells(entity) {
+			if err := ValidatePosition(room, pos); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -58,24 +62,44 @@ func PlaceEntity(room *entities.Room, entity entities.Placeable) error {
 		if obstacle, ok := entity.(*entities.Obstacle); ok {
 			room.Obstacles = append(room.Obstacles, *obstacle)
 		}
+	case entities.CellTrap:
+		if trap, ok := entity.(*entities.Trap); ok {
+			room.Traps = append(room.Traps, *trap)
+		}
+	case entities.CellDoor:
+		if door, ok := entity.(*entities.Door); ok {
+			room.Doors = append(room.Doors, *door)
+		}
 	}
 
+	newPosition := entity.GetPosition()
+	recordRoomEvent(room, entities.RoomEventPlaced, entity.GetID(), entity.GetCellType(), nil, &newPosition)
+
 	// If this is a gridless room, we're done
 	if room.Grid == nil {
 		return nil
 	}
 
-	pos := entity.GetPosition()
-
 	// Update grid
-	room.Grid[pos.Y][pos.X] = entities.Cell{
-		Type:     entity.GetCellType(),
-		EntityID: entity.GetID(),
+	for _, pos := range occupiedCells(entity) {
+		room.Grid[pos.Y][pos.X] = entities.Cell{
+			Type:     entity.GetCellType(),
+			EntityID: entity.GetID(),
+		}
 	}
 
 	return nil
 }
 
+// occupiedCells returns the grid positions an entity occupies
+// Obstacles cover every cell of their footprint, and all other entities cover only their position
+func occupiedCells(entity entities.Placeable) []entities.Position {
+	if obstacle, ok := entity.(*entities.Obstacle); ok {
+		return obstacle.Cells()
+	}
+	return []entities.Position{entity.GetPosition()}
+}
+
 // removeEntity removes a placeable entity from a room by ID and cell type
 // Returns true if the entity was found and removed, false otherwise
 // For gridless rooms (room.Grid == nil), grid updates are skipped
@@ -83,102 +107,130 @@ func removeEntity(room *entities.Room, entityID string, cellType entities.CellTy
 	if room == nil {
 		return false
 	}
+	if room.EventLog == nil {
+		return deleteEntity(room, entityID, cellType)
+	}
 
+	stored, err := findEntityOfType(room, entityID, cellType)
+	if err != nil {
+		return false
+	}
+	oldPosition := stored.GetPosition()
+	if !deleteEntity(room, entityID, cellType) {
+		return false
+	}
+	recordRoomEvent(room, entities.RoomEventRemoved, entityID, cellType, &oldPosition, nil)
+	return true
+}
+
+// deleteEntity removes the entity from the room's slices and grid without logging the removal
+func deleteEntity(room *entities.Room, entityID string, cellType entities.CellType) bool {
 	// Find and remove the entity based on its type
 	switch cellType {
 	case entities.CellMonster:
-		for i, monster := range room.Monsters {
-			if monster.ID == entityID {
-				// Clear grid cell if grid exists
-				if room.Grid != nil {
-					pos := monster.Position
-					room.Grid[pos.Y][pos.X] = entities.Cell{
-						Type:     entities.CellTypeEmpty,
-						EntityID: "",
-					}
-				}
-
-				// Remove monster from slice
-				room.Monsters = append(room.Monsters[:i], room.Monsters[i+1:]...)
-				return true
-			}
+		if monster, i := FindMonsterByID(room, entityID); monster != nil {
+			clearGridCell(room, monster.Position)
+			room.Monsters = append(room.Monsters[:i], room.Monsters[i+1:]...)
+			return true
 		}
 	case entities.CellPlayer:
-		for i, player := range room.Players {
-			if player.ID == entityID {
-				// Clear grid cell if grid exists
-				if room.Grid != nil {
-					pos := player.Position
-					room.Grid[pos.Y][pos.X] = entities.Cell{
-						Type:     entities.CellTypeEmpty,
-						EntityID: "",
-					}
-				}
-
-				// Remove player from slice
-				room.Players = append(room.Players[:i], room.Players[i+1:]...)
-				return true
-			}
+		if player, i := FindPlayerByID(room, entityID); player != nil {
+			clearGridCell(room, player.Position)
+			room.Players = append(room.Players[:i], room.Players[i+1:]...)
+			return true
 		}
 	case entities.CellItem:
-		for i, item := range room.Items {
-			if item.ID == entityID {
-				// Clear grid cell if grid exists
-				if room.Grid != nil {
-					pos := item.Position
-					room.Grid[pos.Y][pos.X] = entities.Cell{
-						Type:     entities.CellTypeEmpty,
-						EntityID: "",
-					}
-				}
-
-				// Remove item from slice
-				room.Items = append(room.Items[:i], room.Items[i+1:]...)
-				return true
-			}
+		if item, i := FindItemByID(room, entityID); item != nil {
+			clearGridCell(room, item.Position)
+			room.Items = append(room.Items[:i], room.Items[i+1:]...)
+			return true
 		}
 	case entities.CellNPC:
-		for i, npc := range room.NPCs {
-			if npc.ID == entityID {
-				// Clear grid cell if grid exists
-				if room.Grid != nil {
-					pos := npc.Position
-					room.Grid[pos.Y][pos.X] = entities.Cell{
-						Type:     entities.CellTypeEmpty,
-						EntityID: "",
-					}
-				}
-
-				// Remove NPC from slice
-				room.NPCs = append(room.NPCs[:i], room.NPCs[i+1:]...)
-				return true
-			}
+		if npc, i := FindNPCByID(room, entityID); npc != nil {
+			clearGridCell(room, npc.Position)
+			room.NPCs = append(room.NPCs[:i], room.NPCs[i+1:]...)
+			return true
 		}
 	case entities.CellObstacle:
-		for i, obstacle := range room.Obstacles {
-			if obstacle.ID == entityID {
-				// Clear grid cell if grid exists
-				if room.Grid != nil {
-					pos := obstacle.Position
-					room.Grid[pos.Y][pos.X] = entities.Cell{
-						Type:     entities.CellTypeEmpty,
-						EntityID: "",
-					}
-				}
-
-				// Remove obstacle from slice
-				room.Obstacles = append(room.Obstacles[:i], room.Obstacles[i+1:]...)
-				return true
+		if obstacle, i := FindObstacleByID(room, entityID); obstacle != nil {
+			for _, pos := range obstacle.Cells() {
+				clearGridCell(room, pos)
 			}
+			room.Obstacles = append(room.Obstacles[:i], room.Obstacles[i+1:]...)
+			return true
+		}
+	case entities.CellTrap:
+		if trap, i := FindTrapByID(room, entityID); trap != nil {
+			clearGridCell(room, trap.Position)
+			room.Traps = append(room.Traps[:i], room.Traps[i+1:]...)
+			return true
+		}
+	case entities.CellDoor:
+		if door, i := FindDoorByID(room, entityID); door != nil {
+			clearGridCell(room, door.Position)
+			room.Doors = append(room.Doors[:i], room.Doors[i+1:]...)
+			return true
+		}
+	case entities.CellSpellZone:
+		if zone, i := FindSpellZoneByID(room, entityID); zone != nil {
+			room.SpellZones = append(room.SpellZones[:i], room.SpellZones[i+1:]...)
+			return true
 		}
 	}
 
 	return false
 }
 
+// placeSpellZone adds a copy of the zone to the room, dropping affected positions outside the room
+// The grid is not changed, and the target point may be occupied
+func placeSpellZone(room *entities.Room, zone *entities.SpellZone) error {
+	if !IsPositionValid(room, zone.Position) {
+		return entities.ErrInvalidPosition
+	}
+
+	placed := *zone
+	placed.AffectedPositions = []entities.Position{}
+	for _, pos := range zone.AffectedPositions {
+		if IsPositionValid(room, pos) {
+			placed.AffectedPositions = append(placed.AffectedPositions, pos)
+		}
+	}
+	room.SpellZones = append(room.SpellZones, placed)
+	return nil
+}
+
+// clearGridCell marks the cell at pos as empty if the room has a grid
+func clearGridCell(room *entities.Room, pos entities.Position) {
+	if room.Grid != nil {
+		room.Grid[pos.Y][pos.X] = entities.Cell{
+			Type:     entities.CellTypeEmpty,
+			EntityID: "",
+		}
+	}
+}
+
+// IsPositionValid reports whether position lies within the room's bounds
+// It does not check whether the cell is occupied
+func IsPositionValid(room *entities.Room, position entities.Position) bool {
+	return entities.IsPositionValid(room, position)
+}
+
+// IsPositionEmpty reports whether the grid cell at position is unoccupied
+// Returns an error for gridless rooms, which cannot be checked, and for positions outside the room
+func IsPositionEmpty(room *entities.Room, position entities.Position) (bool, error) {
+	return entities.IsPositionEmpty(room, position)
+}
+
+// ValidatePosition returns ErrInvalidPosition if position is outside the room
+// or ErrCellOccupied if the room's grid already has an entity there
+func ValidatePosition(room *entities.Room, position entities.Position) error {
+	return entities.ValidatePosition(room, position)
+}
+
 // FindEmptyPosition finds an empty position in the room
 // Returns the position and nil error if successful, or an error if no empty position is found
 // For gridless rooms (room.Grid == nil), returns a random position within room dimensions
+// The search algorithm is selected by room.PlacementStrategy, and random choices use room.Rand if it is set
 func FindEmptyPosition(room *entities.Room) (entities.Position, error) {
 	if room == nil {
 		return entities.Position{}, entities.ErrNilRoom
@@ -186,26 +238,156 @@ func FindEmptyPosition(room *entities.Room) (entities.Position, error) {
 
 	// For gridless rooms, return a random position within room dimensions
 	if room.Grid == nil {
+		intn := roomIntn(room)
 		return entities.Position{
-			X: rand.Intn(room.Width),
-			Y: rand.Intn(room.Height),
+			X: intn(room.Width),
+			Y: intn(room.Height),
 		}, nil
 	}
 
-	// Try to find an empty position
-	emptyCells := []entities.Position{}
+	switch room.PlacementStrategy {
+	case entities.PlacementFisherYates:
+		return findEmptyPositionFisherYates(room)
+	case entities.PlacementReservoir:
+		return findEmptyPositionReservoir(room)
+	default:
+		return findEmptyPositionSequential(room)
+	}
+}
+
+// FindEmptyPositionNear finds a random empty position within radius cells of the anchor, counting diagonal
+// steps as one cell. Returns ErrInvalidPosition if the anchor is outside the room, or ErrNoEmptyPositions
+// if every cell in range is occupied
+func FindEmptyPositionNear(room *entities.Room, anchor entities.Position, radius int) (entities.Position, error) {
+	if room == nil {
+		return entities.Position{}, entities.ErrNilRoom
+	}
+	if !IsPositionValid(room, anchor) {
+		return entities.Position{}, entities.ErrInvalidPosition
+	}
+	if radius < 0 {
+		return entities.Position{}, fmt.Errorf("radius must not be negative, got %d", radius)
+	}
+
+	return FindEmptyPositionInZone(room, nearZone(room, anchor, radius))
+}
+
+// nearZone returns the zone of cells within radius of the anchor, clipped to the room
+func nearZone(room *entities.Room, anchor entities.Position, radius int) PlacementZone {
+	return PlacementZone{
+		MinX: max(anchor.X-radius, 0),
+		MinY: max(anchor.Y-radius, 0),
+		MaxX: min(anchor.X+radius, room.Width-1),
+		MaxY: min(anchor.Y+radius, room.Height-1),
+	}
+}
+
+// findEmptyPositionSequential collects every empty cell and returns one at random
+func findEmptyPositionSequential(room *entities.Room) (entities.Position, error) {
+	emptyCells := entities.GetEmptyCells(room)
+	if len(emptyCells) == 0 {
+		return entities.Position{}, ErrNoEmptyPositions
+	}
+
+	// Return a random empty position
+	return emptyCells[roomIntn(room)(len(emptyCells))], nil
+}
+
+// findEmptyPositionFisherYates visits cells in random order using a lazy Fisher-Yates shuffle
+// Only swapped indices are stored, so sparse rooms terminate quickly without a full permutation
+func findEmptyPositionFisherYates(room *entities.Room) (entities.Position, error) {
+	total := room.Width * room.Height
+	swapped := map[int]int{}
+	intn := roomIntn(room)
+
+	indexAt := func(i int) int {
+		if v, ok := swapped[i]; ok {
+			return v
+		}
+		return i
+	}
+
+	for i := 0; i < total; i++ {
+		j := i + intn(total-i)
+		vi, vj := indexAt(i), indexAt(j)
+		swapped[i], swapped[j] = vj, vi
+
+		x, y := vj%room.Width, vj/room.Width
+		if room.Grid[y][x].Type == entities.CellTypeEmpty {
+			return entities.Position{X: x, Y: y}, nil
+		}
+	}
+
+	return entities.Position{}, ErrNoEmptyPositions
+}
+
+// findEmptyPositionReservoir picks a uniformly random empty cell in a single pass without allocating
+func findEmptyPositionReservoir(room *entities.Room) (entities.Position, error) {
+	var chosen entities.Position
+	seen := 0
+	intn := roomIntn(room)
+
 	for y := 0; y < room.Height; y++ {
 		for x := 0; x < room.Width; x++ {
-			if room.Grid[y][x].Type == entities.CellTypeEmpty {
-				emptyCells = append(emptyCells, entities.Position{X: x, Y: y})
+			if room.Grid[y][x].Type != entities.CellTypeEmpty {
+				continue
+			}
+			seen++
+			// Replace the current choice with probability 1/seen
+			if intn(seen) == 0 {
+				chosen = entities.Position{X: x, Y: y}
 			}
 		}
 	}
 
-	if len(emptyCells) == 0 {
+	if seen == 0 {
 		return entities.Position{}, ErrNoEmptyPositions
 	}
 
-	// Return a random empty position
-	return emptyCells[rand.Intn(len(emptyCells))], nil
+	return chosen, nil
+}
+
+// roomIntn returns the room's random source if it has one, or the shared random source
+func roomIntn(room *entities.Room) func(int) int {
+	if room.Rand != nil {
+		return room.Rand.Intn
+	}
+	return rand.Intn
+}
+
+// newEntityID returns a new UUID, drawn from the room's random source if it has one so that
+// seeded rooms get the same IDs every time
+func newEntityID(room *entities.Room) string {
+	if room.Rand != nil {
+		return uuid.Must(uuid.NewRandomFromReader(room.Rand)).String()
+	}
+	return uuid.NewString()
+}
+
+// assignSeededID gives an entity created for a seeded room an ID drawn from the room's random source
+// Entities of types outside this package keep the ID they were created with
+func assignSeededID(room *entities.Room, entity entities.Placeable) {
+	if room.Rand == nil {
+		return
+	}
+
+	id := newEntityID(room)
+	switch e := entity.(type) {
+	case *entities.Monster:
+		e.ID = id
+	case *entities.Player:
+		e.ID = id
+	case *entities.NPC:
+		e.ID = id
+	case *entities.Item:
+		e.ID = id
+	case *entities.Obstacle:
+		e.ID = id
+	case *entities.Trap:
+		e.ID = id
+	case *entities.Door:
+		e.ID = id
+	case *entities.SpellZone:
+		e.ID = id
+	}
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // MockPlaceable implements the Placeable interface for testing
@@ -205,6 +206,87 @@ func TestRemoveEntity(t *testing.T) {
 	}
 }
 
+func TestPositionValidation(t *testing.T) {
+	room := NewRoom(3, 3, entities.LightLevelBright)
+	InitializeGrid(room)
+	monster := createTestMonster("goblin", 1, 1)
+	assert.NoError(t, PlaceEntity(room, &monster))
+
+	assert.True(t, IsPositionValid(room, entities.Position{X: 2, Y: 2}))
+	assert.False(t, IsPositionValid(room, entities.Position{X: 3, Y: 0}))
+
+	empty, err := IsPositionEmpty(room, entities.Position{X: 1, Y: 1})
+	assert.NoError(t, err)
+	assert.False(t, empty)
+	_, err = IsPositionEmpty(NewRoom(3, 3, entities.LightLevelBright), entities.Position{})
+	assert.ErrorIs(t, err, entities.ErrNoGrid)
+
+	assert.NoError(t, ValidatePosition(room, entities.Position{X: 0, Y: 0}))
+	assert.ErrorIs(t, ValidatePosition(room, entities.Position{X: 1, Y: 1}), entities.ErrCellOccupied)
+	assert.ErrorIs(t, ValidatePosition(room, entities.Position{X: 0, Y: -1}), entities.ErrInvalidPosition)
+	assert.ErrorIs(t, ValidatePosition(nil, entities.Position{}), entities.ErrNilRoom)
+
+	// MovePlaceable wraps the same errors
+	err = MovePlaceable(room, &room.Monsters[0], entities.Position{X: 5, Y: 5})
+	assert.ErrorIs(t, err, entities.ErrInvalidPosition)
+	assert.NoError(t, MovePlaceable(room, &room.Monsters[0], entities.Position{X: 1, Y: 1}), "staying in place is allowed")
+}
+
+func TestRoomEntityCountsThroughPlaceRemoveMove(t *testing.T) {
+	room := NewRoom(2, 2, entities.LightLevelBright)
+	InitializeGrid(room)
+	assert.True(t, room.IsEmpty())
+
+	goblin := createTestMonster("goblin", 0, 0)
+	require.NoError(t, PlaceEntity(room, &goblin))
+	require.NoError(t, PlaceEntity(room, &entities.Player{ID: "fighter", Position: entities.Position{X: 1, Y: 0}}))
+	require.NoError(t, PlaceEntity(room, &entities.Item{ID: "sword", Position: entities.Position{X: 0, Y: 1}}))
+	assert.Equal(t, 1, room.CountEntities(entities.CellMonster))
+	assert.Equal(t, 1, room.CountEntities(entities.CellPlayer))
+	assert.Equal(t, 3, room.TotalEntityCount())
+	full, err := room.IsFull()
+	require.NoError(t, err)
+	assert.False(t, full)
+
+	require.NoError(t, MovePlaceable(room, &room.Monsters[0], entities.Position{X: 1, Y: 1}))
+	assert.Equal(t, 1, room.CountEntities(entities.CellMonster), "moving does not change counts")
+	require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "crate", Position: entities.Position{X: 0, Y: 0}}))
+	full, err = room.IsFull()
+	require.NoError(t, err)
+	assert.True(t, full)
+
+	assert.True(t, removeEntity(room, "goblin", entities.CellMonster))
+	assert.False(t, removeEntity(room, "goblin", entities.CellMonster))
+	assert.Zero(t, room.CountEntities(entities.CellMonster))
+	assert.Equal(t, 1, room.CountEntities(entities.CellObstacle))
+	assert.Equal(t, 3, room.TotalEntityCount())
+	full, err = room.IsFull()
+	require.NoError(t, err)
+	assert.False(t, full)
+
+	for _, id := range []string{"fighter", "sword", "crate"} {
+		p, err := FindEntityByID(room, id)
+		require.NoError(t, err)
+		require.True(t, removeEntity(room, p.GetID(), p.GetCellType()))
+	}
+	assert.True(t, room.IsEmpty())
+	assert.Zero(t, room.CountEntities(entities.CellTypeEmpty))
+
+	gridless := NewRoom(1, 2, entities.LightLevelBright)
+	require.NoError(t, PlaceEntity(gridless, &goblin))
+	full, err = gridless.IsFull()
+	require.NoError(t, err)
+	assert.False(t, full)
+	require.NoError(t, PlaceEntity(gridless, &entities.NPC{ID: "merchant"}))
+	full, err = gridless.IsFull()
+	require.NoError(t, err)
+	assert.True(t, full)
+
+	room.Grid = room.Grid[:1]
+	_, err = room.IsFull()
+	assert.Error(t, err)
+}
+
 func TestFindEmptyPositionWithFullRoom(t *testing.T) {
 	// Create a room with a grid
 	room := NewRoom(3, 3, entities.LightLevelBright)
@@ -227,3 +309,141 @@ func TestFindEmptyPositionWithFullRoom(t *testing.T) {
 	_, err := FindEmptyPosition(room)
 	assert.Equal(t, ErrNoEmptyPositions, err)
 }
+
+// placementStrategies lists every supported random placement strategy
+var placementStrategies = []entities.PlacementStrategy{
+	entities.PlacementSequential,
+	entities.PlacementFisherYates,
+	entities.PlacementReservoir,
+}
+
+// createHalfFullRoom creates a gridded room where every other cell is occupied
+func createHalfFullRoom(width, height int, strategy entities.PlacementStrategy) *entities.Room {
+	room := NewRoom(width, height, entities.LightLevelBright)
+	room.PlacementStrategy = strategy
+	InitializeGrid(room)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if (x+y)%2 == 0 {
+				room.Grid[y][x] = entities.Cell{Type: entities.CellObstacle, EntityID: "filler"}
+			}
+		}
+	}
+	return room
+}
+
+func TestFindEmptyPositionStrategies(t *testing.T) {
+	for _, strategy := range placementStrategies {
+		t.Run(string(strategy), func(t *testing.T) {
+			room := createHalfFullRoom(10, 10, strategy)
+
+			for i := 0; i < 50; i++ {
+				pos, err := FindEmptyPosition(room)
+				assert.NoError(t, err)
+				assert.True(t, IsPositionValid(room, pos))
+				assert.Equal(t, entities.CellTypeEmpty, room.Grid[pos.Y][pos.X].Type)
+
+				// Occupy the cell so every empty cell is eventually found
+				room.Grid[pos.Y][pos.X] = entities.Cell{Type: entities.CellMonster, EntityID: "m"}
+			}
+
+			_, err := FindEmptyPosition(room)
+			assert.ErrorIs(t, err, ErrNoEmptyPositions)
+		})
+	}
+}
+
+func TestFindEmptyPositionNear(t *testing.T) {
+	room := NewRoom(10, 10, entities.LightLevelBright)
+	InitializeGrid(room)
+	anchor := entities.Position{X: 0, Y: 1}
+
+	// A radius of 1 around a cell on the edge holds 6 cells, including the anchor
+	for i := 0; i < 6; i++ {
+		pos, err := FindEmptyPositionNear(room, anchor, 1)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, DistanceBetween(anchor, pos, DistanceChebyshev), 1.0)
+		room.Grid[pos.Y][pos.X] = entities.Cell{Type: entities.CellMonster, EntityID: "m"}
+	}
+
+	_, err := FindEmptyPositionNear(room, anchor, 1)
+	assert.ErrorIs(t, err, ErrNoEmptyPositions)
+
+	_, err = FindEmptyPositionNear(room, entities.Position{X: 10, Y: 0}, 1)
+	assert.ErrorIs(t, err, entities.ErrInvalidPosition)
+	_, err = FindEmptyPositionNear(room, anchor, -1)
+	assert.Error(t, err)
+	_, err = FindEmptyPositionNear(nil, anchor, 1)
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
+
+func TestFindEmptyPositionReservoirDoesNotAllocate(t *testing.T) {
+	room := createHalfFullRoom(100, 100, entities.PlacementReservoir)
+
+	allocs := testing.AllocsPerRun(20, func() {
+		_, _ = FindEmptyPosition(room)
+	})
+	assert.Zero(t, allocs)
+
+	// The sequential strategy allocates the empty-cell slice for comparison
+	room.PlacementStrategy = entities.PlacementSequential
+	allocs = testing.AllocsPerRun(20, func() {
+		_, _ = FindEmptyPosition(room)
+	})
+	assert.NotZero(t, allocs)
+}
+
+func BenchmarkFindEmptyPosition(b *testing.B) {
+	for _, strategy := range placementStrategies {
+		b.Run(string(strategy), func(b *testing.B) {
+			room := createHalfFullRoom(100, 100, strategy)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := FindEmptyPosition(room); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestMultiCellObstacle(t *testing.T) {
+	room := NewRoom(5, 5, entities.LightLevelBright)
+	InitializeGrid(room)
+	boulder := entities.Obstacle{ID: "b1", Name: "Boulder", Key: "boulder", Blocking: true,
+		Position: entities.Position{X: 1, Y: 1}, Size: entities.Size{Width: 2, Height: 2}}
+
+	require.NoError(t, PlaceEntity(room, &boulder))
+	for _, pos := range []entities.Position{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 1, Y: 2}, {X: 2, Y: 2}} {
+		assert.Equal(t, entities.Cell{Type: entities.CellObstacle, EntityID: "b1"}, room.Grid[pos.Y][pos.X])
+	}
+	assert.Len(t, blockingObstaclePositions(room), 4)
+	assert.Empty(t, ValidateRoomGrid(room))
+
+	// Cells outside the room or already occupied reject the whole footprint
+	edge := entities.Obstacle{ID: "b2", Position: entities.Position{X: 4, Y: 4}, Size: entities.Size{Width: 2, Height: 1}}
+	assert.ErrorIs(t, PlaceEntity(room, &edge), entities.ErrInvalidPosition)
+	monster := createTestMonster("m1", 3, 2)
+	require.NoError(t, PlaceEntity(room, &monster))
+	assert.ErrorIs(t, MovePlaceable(room, &boulder, entities.Position{X: 2, Y: 1}), entities.ErrCellOccupied)
+
+	// Moving onto its own cells is allowed
+	require.NoError(t, MovePlaceable(room, &boulder, entities.Position{X: 1, Y: 2}))
+	assert.Equal(t, entities.CellTypeEmpty, room.Grid[1][1].Type)
+	assert.Equal(t, entities.CellTypeEmpty, room.Grid[1][2].Type)
+	assert.Equal(t, "b1", room.Grid[3][2].EntityID)
+	assert.Empty(t, ValidateRoomGrid(room))
+
+	removed, err := RemovePlaceable(room, &boulder)
+	require.NoError(t, err)
+	assert.True(t, removed)
+	for y := range room.Grid {
+		for x, cell := range room.Grid[y] {
+			if x != 3 || y != 2 {
+				assert.Equal(t, entities.CellTypeEmpty, cell.Type)
+			}
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// puzzleEntranceRows is the number of rows at the top of a puzzle room treated as the entrance
+const puzzleEntranceRows = 2
+
+// PuzzleEncounterConfig contains parameters for generating a puzzle room
+type PuzzleEncounterConfig struct {
+	PuzzleDescription   string          // Description of the puzzle
+	SolutionKey         string          // Key identifying the puzzle's solution
+	SuccessLoot         []ItemConfig    // Reward placed along the far wall
+	FailureTrap         *TrapConfig     // Trap placed next to the reward (optional)
+	HintNPCConfig       *NPCConfig      // NPC who can give hints, placed near the center (optional)
+	GuardMonsterConfigs []MonsterConfig // Monsters guarding the entrance
+}
+
+// GeneratePuzzleEncounter creates a room laid out around a puzzle
+// Guards are placed in the first two rows (the entrance), the hint NPC near the center,
+// the loot along the last row, and the failure trap adjacent to the loot
+func (s *RoomService) GeneratePuzzleEncounter(roomConfig RoomConfig, config PuzzleEncounterConfig) (*entities.Room, error) {
+	if roomConfig.Height <= puzzleEntranceRows {
+		return nil, fmt.Errorf("puzzle rooms must be more than %d rows tall", puzzleEntranceRows)
+	}
+	if config.FailureTrap != nil && len(config.SuccessLoot) == 0 {
+		return nil, fmt.Errorf("a failure trap requires success loot to guard")
+	}
+
+	room, err := s.GenerateRoom(roomConfig)
+	if err != nil {
+		return nil, err
+	}
+	room.RoomType = &entities.PuzzleRoomType{
+		Puzzle:      config.PuzzleDescription,
+		SolutionKey: config.SolutionKey,
+	}
+	if room.Description == "" {
+		room.Description = config.PuzzleDescription
+	}
+
+	entrance := SpawnZone{MinX: 0, MinY: 0, MaxX: room.Width - 1, MaxY: puzzleEntranceRows - 1}
+	farEnd := SpawnZone{MinX: 0, MinY: room.Height - 1, MaxX: room.Width - 1, MaxY: room.Height - 1}
+
+	// Place guards at the entrance
+	for _, guard := range config.GuardMonsterConfigs {
+		for i := 0; i < guard.Count; i++ {
+			position, err := FindEmptyPositionInZone(room, entrance)
+			if err != nil {
+				return nil, fmt.Errorf("failed to place %s (monster): %w", guard.GetName(), err)
+			}
+			if err := s.placeConfigAt(room, guard, position); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Place loot at the far end
+	lootPositions := []entities.Position{}
+	for _, loot := range config.SuccessLoot {
+		for i := 0; i < loot.Count; i++ {
+			position, err := FindEmptyPositionInZone(room, farEnd)
+			if err != nil {
+				return nil, fmt.Errorf("failed to place %s (item): %w", loot.GetName(), err)
+			}
+			if err := s.placeConfigAt(room, loot, position); err != nil {
+				return nil, err
+			}
+			lootPositions = append(lootPositions, position)
+		}
+	}
+
+	// Place the failure trap next to the loot
+	if config.FailureTrap != nil {
+		count := config.FailureTrap.Count
+		if count < 1 {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			position, err := s.findEmptyPositionAdjacentTo(room, lootPositions)
+			if err != nil {
+				return nil, fmt.Errorf("failed to place %s (trap): %w", config.FailureTrap.GetName(), err)
+			}
+			if err := s.placeConfigAt(room, *config.FailureTrap, position); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Place the hint NPC near the center
+	if config.HintNPCConfig != nil {
+		center := entities.Position{X: room.Width / 2, Y: room.Height / 2}
+		position, err := findEmptyPositionNearest(room, center)
+		if err != nil {
+			return nil, fmt.Errorf("failed to place %s (npc): %w", config.HintNPCConfig.GetName(), err)
+		}
+		if err := s.placeConfigAt(room, *config.HintNPCConfig, position); err != nil {
+			return nil, err
+		}
+	}
+
+	return room, nil
+}
+
+// findEmptyPositionNearest picks a random empty cell among those closest to target (Chebyshev distance)
+// For gridless rooms the target itself is returned
+func findEmptyPositionNearest(room *entities.Room, target entities.Position) (entities.Position, error) {
+	if !IsPositionValid(room, target) {
+		return entities.Position{}, entities.ErrInvalidPosition
+	}
+	if room.Grid == nil {
+		return target, nil
+	}
+
+	maxRadius := room.Width
+	if room.Height > maxRadius {
+		maxRadius = room.Height
+	}
+
+	for radius := 0; radius <= maxRadius; radius++ {
+		candidates := []entities.Position{}
+		for y := target.Y - radius; y <= target.Y+radius; y++ {
+			for x := target.X - radius; x <= target.X+radius; x++ {
+				pos := entities.Position{X: x, Y: y}
+				if CalculateDistance(pos, target) != float64(radius) || !IsPositionValid(room, pos) {
+					continue
+				}
+				if room.Grid[y][x].Type == entities.CellTypeEmpty {
+					candidates = append(candidates, pos)
+				}
+			}
+		}
+		if len(candidates) > 0 {
+			return candidates[roomIntn(room)(len(candidates))], nil
+		}
+	}
+
+	return entities.Position{}, ErrNoEmptyPositions
+}
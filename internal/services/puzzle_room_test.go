@@ -0,0 +1,119 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// createTestPuzzleConfig creates a puzzle with two guards, two pieces of loot, a trap, and a hint NPC
+func createTestPuzzleConfig() PuzzleEncounterConfig {
+	chest := createTestItemConfig("Gilded Chest", "chest", true, nil)
+	chest.Count = 2
+	sphinx := createTestNPCConfig("Sphinx", 5, 1, true, nil, nil)
+
+	return PuzzleEncounterConfig{
+		PuzzleDescription:   "Three levers, one correct order",
+		SolutionKey:         "lever-order-231",
+		SuccessLoot:         []ItemConfig{chest},
+		FailureTrap:         &TrapConfig{Name: "Poison Dart Trap", Key: "poison_dart_trap", Count: 1},
+		HintNPCConfig:       &sphinx,
+		GuardMonsterConfigs: []MonsterConfig{createTestMonsterConfig("Goblin", "goblin", 0.25, 2, true, nil)},
+	}
+}
+
+func TestGeneratePuzzleEncounter(t *testing.T) {
+	service := &RoomService{}
+	roomConfig := createTestRoomConfig(7, 7, entities.LightLevelDim, true)
+
+	room, err := service.GeneratePuzzleEncounter(roomConfig, createTestPuzzleConfig())
+	require.NoError(t, err)
+
+	require.IsType(t, &entities.PuzzleRoomType{}, room.RoomType)
+	assert.Equal(t, "puzzle", room.RoomType.Type())
+	assert.Equal(t, "lever-order-231", room.RoomType.(*entities.PuzzleRoomType).SolutionKey)
+
+	// Guards hold the entrance
+	require.Len(t, room.Monsters, 2)
+	for _, monster := range room.Monsters {
+		assert.LessOrEqual(t, monster.Position.Y, 1)
+	}
+
+	// The hint NPC stands in the middle of the room
+	require.Len(t, room.NPCs, 1)
+	assert.Equal(t, entities.Position{X: 3, Y: 3}, room.NPCs[0].Position)
+
+	// Loot is along the far wall with the trap beside it
+	require.Len(t, room.Items, 2)
+	for _, item := range room.Items {
+		assert.Equal(t, 6, item.Position.Y)
+	}
+	require.Len(t, room.Traps, 1)
+	trap := room.Traps[0]
+	assert.Equal(t, "poison_dart_trap", trap.Key)
+	nextToLoot := false
+	for _, item := range room.Items {
+		if CalculateDistance(item.Position, trap.Position) == 1 {
+			nextToLoot = true
+		}
+	}
+	assert.True(t, nextToLoot, "trap at %s should be adjacent to the loot", trap.Position)
+
+	assert.Empty(t, ValidateRoomGrid(room))
+}
+
+func TestGeneratePuzzleEncounterMinimal(t *testing.T) {
+	service := &RoomService{}
+	roomConfig := createTestRoomConfig(5, 5, entities.LightLevelBright, true)
+	roomConfig.Description = ""
+
+	room, err := service.GeneratePuzzleEncounter(roomConfig, PuzzleEncounterConfig{PuzzleDescription: "A riddle carved in stone"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "A riddle carved in stone", room.Description)
+	assert.Empty(t, room.Monsters)
+	assert.Empty(t, room.NPCs)
+	assert.Empty(t, room.Traps)
+}
+
+func TestGeneratePuzzleEncounterErrors(t *testing.T) {
+	service := &RoomService{}
+
+	testCases := []struct {
+		name           string
+		roomConfig     RoomConfig
+		config         PuzzleEncounterConfig
+		errorSubstring string
+	}{
+		{
+			name:           "Room too short",
+			roomConfig:     createTestRoomConfig(5, 2, entities.LightLevelBright, true),
+			config:         createTestPuzzleConfig(),
+			errorSubstring: "more than 2 rows",
+		},
+		{
+			name:           "Trap without loot",
+			roomConfig:     createTestRoomConfig(5, 5, entities.LightLevelBright, true),
+			config:         PuzzleEncounterConfig{FailureTrap: &TrapConfig{Name: "Pit"}},
+			errorSubstring: "requires success loot",
+		},
+		{
+			name:       "Too many guards for the entrance",
+			roomConfig: createTestRoomConfig(3, 5, entities.LightLevelBright, true),
+			config: PuzzleEncounterConfig{
+				GuardMonsterConfigs: []MonsterConfig{createTestMonsterConfig("Goblin", "goblin", 0.25, 7, true, nil)},
+			},
+			errorSubstring: "failed to place Goblin",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := service.GeneratePuzzleEncounter(tc.roomConfig, tc.config)
+			assert.ErrorContains(t, err, tc.errorSubstring)
+		})
+	}
+}
@@ -0,0 +1,414 @@
+package services
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// PlaceableConfigFactory builds a PlaceableConfig from a generic parameter map
+type PlaceableConfigFactory func(params map[string]interface{}) (PlaceableConfig, error)
+
+// PlaceableConfigRegistry maps config type names to factories so that third-party
+// entity types can be created by name and accepted by AddPlaceablesToRoom
+type PlaceableConfigRegistry struct {
+	mu        sync.RWMutex
+	factories map[string]PlaceableConfigFactory
+}
+
+// NewPlaceableConfigRegistry creates a registry with all built-in config types registered
+func NewPlaceableConfigRegistry() *PlaceableConfigRegistry {
+	r := &PlaceableConfigRegistry{
+		factories: make(map[string]PlaceableConfigFactory),
+	}
+
+	// Built-in names are unique, so registration cannot fail here
+	_ = r.Register("MonsterConfig", newMonsterConfigFromParams)
+	_ = r.Register("PlayerConfig", newPlayerConfigFromParams)
+	_ = r.Register("ItemConfig", newItemConfigFromParams)
+	_ = r.Register("NPCConfig", newNPCConfigFromParams)
+	_ = r.Register("ObstacleConfig", newObstacleConfigFromParams)
+	_ = r.Register("TrapConfig", newTrapConfigFromParams)
+	_ = r.Register("DoorConfig", newDoorConfigFromParams)
+	_ = r.Register("SpellZoneConfig", newSpellZoneConfigFromParams)
+	_ = r.Register("WeaponItemConfig", newWeaponItemConfigFromParams)
+	_ = r.Register("ArmorItemConfig", newArmorItemConfigFromParams)
+
+	return r
+}
+
+// Register adds a factory under the given type name
+// The type name should match the Go type name of the config the factory produces
+// Returns an error if the name is empty, the factory is nil, or the name is already registered
+func (r *PlaceableConfigRegistry) Register(typeName string, factory PlaceableConfigFactory) error {
+	if typeName == "" {
+		return fmt.Errorf("type name cannot be empty")
+	}
+	if factory == nil {
+		return fmt.Errorf("factory for %s cannot be nil", typeName)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.factories[typeName]; exists {
+		return fmt.Errorf("config type %s is already registered", typeName)
+	}
+	r.factories[typeName] = factory
+	return nil
+}
+
+// Create builds a config of the named type from the provided parameters
+func (r *PlaceableConfigRegistry) Create(typeName string, params map[string]interface{}) (PlaceableConfig, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[typeName]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("config type %s is not registered", typeName)
+	}
+
+	return factory(params)
+}
+
+// IsRegistered reports whether a factory exists for the given type name
+func (r *PlaceableConfigRegistry) IsRegistered(typeName string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.factories[typeName]
+	return ok
+}
+
+// RegisteredConfigTypes returns the names of all registered config types in sorted order
+func (r *PlaceableConfigRegistry) RegisteredConfigTypes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Registry returns the service's config registry, creating it on first use
+func (s *RoomService) Registry() *PlaceableConfigRegistry {
+	if s.registry == nil {
+		s.registry = NewPlaceableConfigRegistry()
+	}
+	return s.registry
+}
+
+// RegisteredConfigTypes returns the names of all config types the service accepts
+func (s *RoomService) RegisteredConfigTypes() []string {
+	return s.Registry().RegisteredConfigTypes()
+}
+
+// placeableConfigTypeName returns the Go type name of a config, dereferencing pointers
+func placeableConfigTypeName(config PlaceableConfig) string {
+	t := reflect.TypeOf(config)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// paramString reads an optional string parameter
+func paramString(params map[string]interface{}, key string) (string, error) {
+	v, ok := params[key]
+	if !ok || v == nil {
+		return "", nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("parameter %s must be a string", key)
+	}
+	return s, nil
+}
+
+// paramInt reads an optional integer parameter, accepting any numeric type
+func paramInt(params map[string]interface{}, key string) (int, error) {
+	v, ok := params[key]
+	if !ok || v == nil {
+		return 0, nil
+	}
+	switch n := v.(type) {
+	case int:
+		return n, nil
+	case int64:
+		return int(n), nil
+	case float64:
+		return int(n), nil
+	default:
+		return 0, fmt.Errorf("parameter %s must be a number", key)
+	}
+}
+
+// paramFloat reads an optional floating point parameter, accepting any numeric type
+func paramFloat(params map[string]interface{}, key string) (float64, error) {
+	v, ok := params[key]
+	if !ok || v == nil {
+		return 0, nil
+	}
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case int:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	default:
+		return 0, fmt.Errorf("parameter %s must be a number", key)
+	}
+}
+
+// paramBool reads an optional boolean parameter
+func paramBool(params map[string]interface{}, key string) (bool, error) {
+	v, ok := params[key]
+	if !ok || v == nil {
+		return false, nil
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("parameter %s must be a bool", key)
+	}
+	return b, nil
+}
+
+// paramPosition reads an optional position parameter given as a Position or *Position
+func paramPosition(params map[string]interface{}, key string) (*entities.Position, error) {
+	v, ok := params[key]
+	if !ok || v == nil {
+		return nil, nil
+	}
+	switch p := v.(type) {
+	case entities.Position:
+		return &p, nil
+	case *entities.Position:
+		return p, nil
+	default:
+		return nil, fmt.Errorf("parameter %s must be a position", key)
+	}
+}
+
+// placementParams holds the parameters shared by all built-in configs
+type placementParams struct {
+	name        string
+	key         string
+	count       int
+	randomPlace bool
+	position    *entities.Position
+}
+
+// readPlacementParams reads the parameters shared by all built-in configs
+func readPlacementParams(params map[string]interface{}) (placementParams, error) {
+	var p placementParams
+	var err error
+
+	if p.name, err = paramString(params, "name"); err != nil {
+		return p, err
+	}
+	if p.key, err = paramString(params, "key"); err != nil {
+		return p, err
+	}
+	if p.count, err = paramInt(params, "count"); err != nil {
+		return p, err
+	}
+	if p.randomPlace, err = paramBool(params, "random_place"); err != nil {
+		return p, err
+	}
+	if p.position, err = paramPosition(params, "position"); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+func newMonsterConfigFromParams(params map[string]interface{}) (PlaceableConfig, error) {
+	p, err := readPlacementParams(params)
+	if err != nil {
+		return nil, err
+	}
+	cr, err := paramFloat(params, "cr")
+	if err != nil {
+		return nil, err
+	}
+	return MonsterConfig{
+		Name:        p.name,
+		Key:         p.key,
+		CR:          cr,
+		Count:       p.count,
+		RandomPlace: p.randomPlace,
+		Position:    p.position,
+	}, nil
+}
+
+func newPlayerConfigFromParams(params map[string]interface{}) (PlaceableConfig, error) {
+	p, err := readPlacementParams(params)
+	if err != nil {
+		return nil, err
+	}
+	level, err := paramInt(params, "level")
+	if err != nil {
+		return nil, err
+	}
+	return PlayerConfig{
+		Name:        p.name,
+		Level:       level,
+		RandomPlace: p.randomPlace,
+		Position:    p.position,
+	}, nil
+}
+
+func newItemConfigFromParams(params map[string]interface{}) (PlaceableConfig, error) {
+	p, err := readPlacementParams(params)
+	if err != nil {
+		return nil, err
+	}
+	return ItemConfig{
+		Key:         p.key,
+		Name:        p.name,
+		Count:       p.count,
+		RandomPlace: p.randomPlace,
+		Position:    p.position,
+	}, nil
+}
+
+func newNPCConfigFromParams(params map[string]interface{}) (PlaceableConfig, error) {
+	p, err := readPlacementParams(params)
+	if err != nil {
+		return nil, err
+	}
+	level, err := paramInt(params, "level")
+	if err != nil {
+		return nil, err
+	}
+	return NPCConfig{
+		Name:        p.name,
+		Level:       level,
+		Count:       p.count,
+		RandomPlace: p.randomPlace,
+		Position:    p.position,
+	}, nil
+}
+
+func newObstacleConfigFromParams(params map[string]interface{}) (PlaceableConfig, error) {
+	p, err := readPlacementParams(params)
+	if err != nil {
+		return nil, err
+	}
+	blocking, err := paramBool(params, "blocking")
+	if err != nil {
+		return nil, err
+	}
+	return ObstacleConfig{
+		Name:        p.name,
+		Key:         p.key,
+		Blocking:    blocking,
+		Count:       p.count,
+		RandomPlace: p.randomPlace,
+		Position:    p.position,
+	}, nil
+}
+
+func newTrapConfigFromParams(params map[string]interface{}) (PlaceableConfig, error) {
+	p, err := readPlacementParams(params)
+	if err != nil {
+		return nil, err
+	}
+	config := TrapConfig{
+		Name:        p.name,
+		Key:         p.key,
+		Count:       p.count,
+		RandomPlace: p.randomPlace,
+		Position:    p.position,
+	}
+	if config.DamageDice, err = paramString(params, "damage_dice"); err != nil {
+		return nil, err
+	}
+	if config.DamageType, err = paramString(params, "damage_type"); err != nil {
+		return nil, err
+	}
+	if config.SaveDC, err = paramInt(params, "save_dc"); err != nil {
+		return nil, err
+	}
+	if config.SaveAbility, err = paramString(params, "save_ability"); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func newDoorConfigFromParams(params map[string]interface{}) (PlaceableConfig, error) {
+	p, err := readPlacementParams(params)
+	if err != nil {
+		return nil, err
+	}
+	config := DoorConfig{
+		Name:        p.name,
+		Key:         p.key,
+		Count:       p.count,
+		RandomPlace: p.randomPlace,
+		Position:    p.position,
+	}
+	if config.Locked, err = paramBool(params, "locked"); err != nil {
+		return nil, err
+	}
+	if config.Barred, err = paramBool(params, "barred"); err != nil {
+		return nil, err
+	}
+	if config.RequiredKeyID, err = paramString(params, "required_key_id"); err != nil {
+		return nil, err
+	}
+	if config.Open, err = paramBool(params, "open"); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// newSpellZoneConfigFromParams reads the zone to place from the "zone" parameter,
+// given as a SpellZone or *SpellZone
+func newSpellZoneConfigFromParams(params map[string]interface{}) (PlaceableConfig, error) {
+	switch zone := params["zone"].(type) {
+	case entities.SpellZone:
+		return SpellZoneConfig{Zone: zone}, nil
+	case *entities.SpellZone:
+		if zone != nil {
+			return SpellZoneConfig{Zone: *zone}, nil
+		}
+	}
+	return nil, fmt.Errorf("parameter zone must be a spell zone")
+}
+
+func newWeaponItemConfigFromParams(params map[string]interface{}) (PlaceableConfig, error) {
+	item, err := newItemConfigFromParams(params)
+	if err != nil {
+		return nil, err
+	}
+	config := WeaponItemConfig{ItemConfig: item.(ItemConfig)}
+	if config.DamageDice, err = paramString(params, "damage_dice"); err != nil {
+		return nil, err
+	}
+	if config.DamageType, err = paramString(params, "damage_type"); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func newArmorItemConfigFromParams(params map[string]interface{}) (PlaceableConfig, error) {
+	item, err := newItemConfigFromParams(params)
+	if err != nil {
+		return nil, err
+	}
+	config := ArmorItemConfig{ItemConfig: item.(ItemConfig)}
+	if config.ArmorClass, err = paramInt(params, "armor_class"); err != nil {
+		return nil, err
+	}
+	if config.StealthDisadvantage, err = paramBool(params, "stealth_disadvantage"); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
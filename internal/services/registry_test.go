@@ -0,0 +1,177 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// TurretConfig is a third-party config type that places a blocking turret obstacle
+type TurretConfig struct {
+	Name     string
+	Position *entities.Position
+}
+
+func (c TurretConfig) CreatePlaceable(s *RoomService) (entities.Placeable, error) {
+	return &entities.Obstacle{
+		ID:       uuid.NewString(),
+		Name:     c.Name,
+		Key:      "turret",
+		Blocking: true,
+	}, nil
+}
+
+func (c TurretConfig) ShouldPlaceRandomly() bool {
+	return c.Position == nil
+}
+
+func (c TurretConfig) GetPosition() *entities.Position {
+	return c.Position
+}
+
+func (c TurretConfig) GetName() string {
+	return c.Name
+}
+
+func (c TurretConfig) GetCellType() entities.CellType {
+	return entities.CellObstacle
+}
+
+// newTurretConfigFromParams builds a TurretConfig from registry parameters
+func newTurretConfigFromParams(params map[string]interface{}) (PlaceableConfig, error) {
+	name, err := paramString(params, "name")
+	if err != nil {
+		return nil, err
+	}
+	position, err := paramPosition(params, "position")
+	if err != nil {
+		return nil, err
+	}
+	return TurretConfig{Name: name, Position: position}, nil
+}
+
+func TestPlaceableConfigRegistry(t *testing.T) {
+	t.Run("Built-in types are registered", func(t *testing.T) {
+		registry := NewPlaceableConfigRegistry()
+		assert.Equal(t, []string{"ArmorItemConfig", "DoorConfig", "ItemConfig", "MonsterConfig", "NPCConfig",
+			"ObstacleConfig", "PlayerConfig", "SpellZoneConfig", "TrapConfig", "WeaponItemConfig"},
+			registry.RegisteredConfigTypes())
+	})
+
+	t.Run("Create trap, door, spell zone, weapon and armor configs from params", func(t *testing.T) {
+		registry := NewPlaceableConfigRegistry()
+		pos := entities.Position{X: 1, Y: 2}
+
+		config, err := registry.Create("TrapConfig", map[string]interface{}{
+			"name": "Pit", "key": "pit", "count": 1, "position": pos,
+			"damage_dice": "2d6", "damage_type": "bludgeoning", "save_dc": 12, "save_ability": "dex",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, TrapConfig{Name: "Pit", Key: "pit", Count: 1, Position: &pos,
+			DamageDice: "2d6", DamageType: "bludgeoning", SaveDC: 12, SaveAbility: "dex"}, config)
+
+		config, err = registry.Create("DoorConfig", map[string]interface{}{
+			"name": "Vault Door", "count": 1, "random_place": true, "locked": true, "required_key_id": "vault-key",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, DoorConfig{Name: "Vault Door", Count: 1, RandomPlace: true, Locked: true,
+			RequiredKeyID: "vault-key"}, config)
+
+		zone := entities.SpellZone{Name: "Fog Cloud", Position: pos}
+		config, err = registry.Create("SpellZoneConfig", map[string]interface{}{"zone": &zone})
+		require.NoError(t, err)
+		assert.Equal(t, SpellZoneConfig{Zone: zone}, config)
+		_, err = registry.Create("SpellZoneConfig", nil)
+		assert.Error(t, err)
+
+		config, err = registry.Create("WeaponItemConfig", map[string]interface{}{
+			"name": "Longsword", "key": "longsword", "count": 1, "damage_dice": "1d8", "damage_type": "slashing",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, WeaponItemConfig{ItemConfig: ItemConfig{Name: "Longsword", Key: "longsword", Count: 1},
+			DamageDice: "1d8", DamageType: "slashing"}, config)
+
+		config, err = registry.Create("ArmorItemConfig", map[string]interface{}{
+			"name": "Chain Mail", "key": "chain-mail", "count": 1, "armor_class": 16, "stealth_disadvantage": true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, ArmorItemConfig{ItemConfig: ItemConfig{Name: "Chain Mail", Key: "chain-mail", Count: 1},
+			ArmorClass: 16, StealthDisadvantage: true}, config)
+	})
+
+	t.Run("Create built-in config from params", func(t *testing.T) {
+		registry := NewPlaceableConfigRegistry()
+		config, err := registry.Create("MonsterConfig", map[string]interface{}{
+			"name":     "Goblin",
+			"key":      "goblin",
+			"cr":       0.25,
+			"count":    2,
+			"position": entities.Position{X: 1, Y: 2},
+		})
+		assert.NoError(t, err)
+
+		monsterConfig, ok := config.(MonsterConfig)
+		assert.True(t, ok)
+		assert.Equal(t, "Goblin", monsterConfig.Name)
+		assert.Equal(t, 0.25, monsterConfig.CR)
+		assert.Equal(t, 2, monsterConfig.Count)
+		assert.Equal(t, &entities.Position{X: 1, Y: 2}, monsterConfig.Position)
+	})
+
+	t.Run("Create with invalid param type", func(t *testing.T) {
+		registry := NewPlaceableConfigRegistry()
+		_, err := registry.Create("MonsterConfig", map[string]interface{}{"cr": "high"})
+		assert.Error(t, err)
+	})
+
+	t.Run("Create unknown type", func(t *testing.T) {
+		registry := NewPlaceableConfigRegistry()
+		_, err := registry.Create("DragonHoardConfig", nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("Register validation", func(t *testing.T) {
+		registry := NewPlaceableConfigRegistry()
+		assert.Error(t, registry.Register("", newTurretConfigFromParams))
+		assert.Error(t, registry.Register("TurretConfig", nil))
+		assert.Error(t, registry.Register("MonsterConfig", newTurretConfigFromParams))
+		assert.NoError(t, registry.Register("TurretConfig", newTurretConfigFromParams))
+		assert.True(t, registry.IsRegistered("TurretConfig"))
+	})
+}
+
+func TestAddPlaceablesToRoomWithRegisteredType(t *testing.T) {
+	service, err := NewRoomService()
+	assert.NoError(t, err)
+
+	room := createTestRoom()
+	turret := TurretConfig{Name: "Arrow Turret", Position: &entities.Position{X: 3, Y: 1}}
+
+	// Unregistered third-party types are rejected
+	err = service.AddPlaceablesToRoom(room, []PlaceableConfig{turret})
+	assert.Error(t, err)
+	assert.Empty(t, room.Obstacles)
+
+	// Once registered, the turret can be created by name and placed
+	assert.NoError(t, service.Registry().Register("TurretConfig", newTurretConfigFromParams))
+	assert.Contains(t, service.RegisteredConfigTypes(), "TurretConfig")
+
+	config, err := service.Registry().Create("TurretConfig", map[string]interface{}{
+		"name":     "Arrow Turret",
+		"position": &entities.Position{X: 3, Y: 1},
+	})
+	assert.NoError(t, err)
+
+	err = service.AddPlaceablesToRoom(room, []PlaceableConfig{config})
+	assert.NoError(t, err)
+
+	assert.Len(t, room.Obstacles, 1)
+	assert.Equal(t, "turret", room.Obstacles[0].Key)
+	assert.Equal(t, entities.Position{X: 3, Y: 1}, room.Obstacles[0].Position)
+	assert.Equal(t, entities.CellObstacle, room.Grid[1][3].Type)
+	assert.Equal(t, room.Obstacles[0].ID, room.Grid[1][3].EntityID)
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// asciiCellRunes maps each grid cell type to the character RenderASCII draws for it
+var asciiCellRunes = map[entities.CellType]rune{
+	entities.CellTypeEmpty: '.',
+	entities.CellMonster:   'M',
+	entities.CellPlayer:    'P',
+	entities.CellItem:      'I',
+	entities.CellNPC:       'N',
+	entities.CellObstacle:  '#',
+	entities.CellTrap:      'T',
+	entities.CellDoor:      'D',
+	entities.CellWall:      '█',
+}
+
+// asciiSummaryGroups lists the entity kinds in the order RenderASCII summarizes gridless rooms
+var asciiSummaryGroups = []struct {
+	label    string
+	cellType entities.CellType
+}{
+	{"Monsters", entities.CellMonster},
+	{"Players", entities.CellPlayer},
+	{"Items", entities.CellItem},
+	{"NPCs", entities.CellNPC},
+	{"Obstacles", entities.CellObstacle},
+	{"Traps", entities.CellTrap},
+	{"Doors", entities.CellDoor},
+}
+
+// RenderASCII draws the room as text for debugging
+// Gridded rooms are drawn as a grid framed by a +-| border, one character per cell (see asciiCellRunes).
+// Gridless rooms have no layout to draw, so their entities are listed by kind with their positions instead.
+// Returns an empty string for a nil room
+func RenderASCII(room *entities.Room) string {
+	if room == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	if room.Grid == nil {
+		fmt.Fprintf(&b, "%dx%d gridless room\n", room.Width, room.Height)
+		placeables := collectPlaceables(room)
+		for _, group := range asciiSummaryGroups {
+			header := false
+			for _, p := range placeables {
+				if p.GetCellType() != group.cellType {
+					continue
+				}
+				if !header {
+					fmt.Fprintf(&b, "%s:\n", group.label)
+					header = true
+				}
+				fmt.Fprintf(&b, "  %s at %s\n", p.GetID(), p.GetPosition())
+			}
+		}
+		return b.String()
+	}
+
+	border := "+" + strings.Repeat("-", room.Width) + "+\n"
+	b.WriteString(border)
+	for _, row := range room.Grid {
+		b.WriteRune('|')
+		for _, cell := range row {
+			r, ok := asciiCellRunes[cell.Type]
+			if !ok {
+				r = '?'
+			}
+			b.WriteRune(r)
+		}
+		b.WriteString("|\n")
+	}
+	b.WriteString(border)
+
+	return b.String()
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// placeRenderedEntities places one entity of every kind along the diagonal of a 5x5 room
+func placeRenderedEntities(t *testing.T, room *entities.Room) {
+	placeables := []entities.Placeable{
+		&entities.Monster{ID: "goblin", Position: entities.Position{X: 0, Y: 0}},
+		&entities.Player{ID: "fighter", Position: entities.Position{X: 1, Y: 1}},
+		&entities.Item{ID: "sword", Position: entities.Position{X: 2, Y: 2}},
+		&entities.NPC{ID: "merchant", Position: entities.Position{X: 3, Y: 3}},
+		&entities.Obstacle{ID: "boulder", Blocking: true, Position: entities.Position{X: 4, Y: 4}},
+		&entities.Door{ID: "door", Position: entities.Position{X: 4, Y: 0}},
+		&entities.Trap{ID: "pit", Position: entities.Position{X: 0, Y: 4}},
+	}
+	for _, p := range placeables {
+		require.NoError(t, PlaceEntity(room, p))
+	}
+}
+
+func TestRenderASCII(t *testing.T) {
+	t.Run("Gridded room", func(t *testing.T) {
+		room := createTestRoom()
+		placeRenderedEntities(t, room)
+		require.NoError(t, AddWallRegion(room, entities.Position{X: 2, Y: 0}, entities.Position{X: 3, Y: 0}))
+
+		expected := "+-----+\n" +
+			"|M.██D|\n" +
+			"|.P...|\n" +
+			"|..I..|\n" +
+			"|...N.|\n" +
+			"|T...#|\n" +
+			"+-----+\n"
+		assert.Equal(t, expected, RenderASCII(room))
+	})
+
+	t.Run("Gridless room", func(t *testing.T) {
+		room := createTestRoomNoGrid()
+		placeRenderedEntities(t, room)
+
+		expected := "5x5 gridless room\n" +
+			"Monsters:\n  goblin at (0,0)\n" +
+			"Players:\n  fighter at (1,1)\n" +
+			"Items:\n  sword at (2,2)\n" +
+			"NPCs:\n  merchant at (3,3)\n" +
+			"Obstacles:\n  boulder at (4,4)\n" +
+			"Traps:\n  pit at (0,4)\n" +
+			"Doors:\n  door at (4,0)\n"
+		assert.Equal(t, expected, RenderASCII(room))
+	})
+
+	t.Run("Empty rooms", func(t *testing.T) {
+		assert.Equal(t, "+-----+\n|.....|\n|.....|\n|.....|\n|.....|\n|.....|\n+-----+\n", RenderASCII(createTestRoom()))
+		assert.Equal(t, "5x5 gridless room\n", RenderASCII(createTestRoomNoGrid()))
+		assert.Empty(t, RenderASCII(nil))
+	})
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// RoomOption configures a room created by NewRoomWithOptions
+type RoomOption func(*RoomConfig)
+
+// WithGrid gives the room a grid for spatial tracking
+func WithGrid() RoomOption {
+	return func(c *RoomConfig) {
+		c.UseGrid = true
+	}
+}
+
+// WithLightLevel sets the room's light level
+func WithLightLevel(l entities.LightLevel) RoomOption {
+	return func(c *RoomConfig) {
+		c.LightLevel = l
+	}
+}
+
+// WithDescription sets the room's description
+func WithDescription(d string) RoomOption {
+	return func(c *RoomConfig) {
+		c.Description = d
+	}
+}
+
+// WithDimensions sets the room's width and height in grid units
+func WithDimensions(w, h int) RoomOption {
+	return func(c *RoomConfig) {
+		c.Width, c.Height = w, h
+	}
+}
+
+// WithCellSizeFt sets the width of one grid square in feet
+func WithCellSizeFt(ft int) RoomOption {
+	return func(c *RoomConfig) {
+		c.CellSizeFt = ft
+	}
+}
+
+// WithSeed makes the room's generation and placement reproducible (see RoomConfig.Seed)
+func WithSeed(seed int64) RoomOption {
+	return func(c *RoomConfig) {
+		c.Seed = seed
+	}
+}
+
+// WithTag adds a tag to the room (see entities.Room.SetTag)
+func WithTag(key, value string) RoomOption {
+	return func(c *RoomConfig) {
+		if c.Tags == nil {
+			c.Tags = make(map[string]string)
+		}
+		c.Tags[key] = value
+	}
+}
+
+// WithEventLog records the entities placed, removed, and moved in the room (see entities.Room.EventLog)
+func WithEventLog() RoomOption {
+	return func(c *RoomConfig) {
+		c.EventLog = true
+	}
+}
+
+// NewRoomWithOptions creates a room like GenerateRoom, building the RoomConfig from options
+// Unset options keep GenerateRoom's defaults: bright light, no grid, and no description.
+// Dimensions are required, so an error is returned unless WithDimensions sets positive ones
+func NewRoomWithOptions(opts ...RoomOption) (*entities.Room, error) {
+	config := RoomConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return generateRoom(config)
+}
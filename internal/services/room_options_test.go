@@ -0,0 +1,66 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestNewRoomWithOptions(t *testing.T) {
+	t.Run("Defaults match GenerateRoom", func(t *testing.T) {
+		room, err := NewRoomWithOptions(WithDimensions(6, 4))
+		require.NoError(t, err)
+
+		expected, err := (&RoomService{}).GenerateRoom(RoomConfig{Width: 6, Height: 4})
+		require.NoError(t, err)
+		expected.ID = room.ID
+		assert.Equal(t, expected, room)
+		assert.Equal(t, entities.LightLevelBright, room.LightLevel)
+		assert.Nil(t, room.Grid)
+		assert.Zero(t, room.CellSizeFt)
+		assert.True(t, room.IsValidID())
+	})
+
+	t.Run("Option order does not matter", func(t *testing.T) {
+		opts := []RoomOption{
+			WithGrid(),
+			WithLightLevel(entities.LightLevelDim),
+			WithDescription("A flooded crypt"),
+			WithDimensions(8, 5),
+			WithCellSizeFt(10),
+			WithTag("boss", "true"),
+		}
+		reversed := make([]RoomOption, len(opts))
+		for i, opt := range opts {
+			reversed[len(opts)-1-i] = opt
+		}
+
+		first, err := NewRoomWithOptions(opts...)
+		require.NoError(t, err)
+		second, err := NewRoomWithOptions(reversed...)
+		require.NoError(t, err)
+
+		second.ID = first.ID
+		assert.Equal(t, first, second)
+		assert.Equal(t, 8, first.Width)
+		assert.Equal(t, 5, first.Height)
+		assert.Equal(t, entities.LightLevelDim, first.LightLevel)
+		assert.Equal(t, "A flooded crypt", first.Description)
+		assert.Equal(t, 10, first.CellSizeFt)
+		assert.True(t, first.HasTag("boss"))
+		require.Len(t, first.Grid, 5)
+		assert.Len(t, first.Grid[0], 8)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := NewRoomWithOptions()
+		assert.Error(t, err, "dimensions are required")
+		_, err = NewRoomWithOptions(WithDimensions(0, 5))
+		assert.Error(t, err)
+		_, err = NewRoomWithOptions(WithDimensions(5, 5), WithCellSizeFt(-5))
+		assert.Error(t, err)
+	})
+}
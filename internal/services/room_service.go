@@ -2,53 +2,145 @@ package services
 
 import (
 	"fmt"
+	"log/slog"
+	"math"
+	"math/rand"
+	"sort"
 	"strings"
+	"sync"
 
+	"github.com/fadedpez/dnd5e-roomgen/internal/crutil"
 	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+	"github.com/fadedpez/dnd5e-roomgen/internal/repositories"
 	"github.com/google/uuid"
 )
 
 // RoomService handles the business logic for room generation and management
 type RoomService struct {
-	balancer Balancer
+	balancer    Balancer
+	registry    *PlaceableConfigRegistry
+	events      *EventBus
+	monsterRepo repositories.MonsterRepository
+	itemRepo    repositories.ItemRepository
+
+	defaultMonsterKey string               // Monster placed for CellMonster cells by GenerateRoomFromByteMap
+	spawnZones        map[string]SpawnZone // Named zones that NPC groups can spawn in
+	stableSort        bool                 // Whether SortInitiativeOrder keeps the existing order of full ties
+
+	nameMu               sync.Mutex
+	monsterNameGenerator MonsterNameGenerator // Names monsters placed without a name
+	monsterNameCounts    map[string]int       // Unnamed monsters named so far, by key
+}
+
+// RoomServiceOption configures optional dependencies of a RoomService
+type RoomServiceOption func(*RoomService)
+
+// WithMonsterRepository sets the repository used to look up monster data such as XP
+func WithMonsterRepository(repo repositories.MonsterRepository) RoomServiceOption {
+	return func(s *RoomService) {
+		s.monsterRepo = repo
+	}
+}
+
+// WithItemRepository sets the repository used to choose treasure items
+func WithItemRepository(repo repositories.ItemRepository) RoomServiceOption {
+	return func(s *RoomService) {
+		s.itemRepo = repo
+	}
+}
+
+// WithDefaultMonsterKey sets the monster key placed for monster cells of hand-drawn maps
+func WithDefaultMonsterKey(key string) RoomServiceOption {
+	return func(s *RoomService) {
+		s.defaultMonsterKey = key
+	}
+}
+
+// WithSpawnZones sets the named zones that NPC groups can be spawned in by ID
+func WithSpawnZones(zones map[string]SpawnZone) RoomServiceOption {
+	return func(s *RoomService) {
+		s.spawnZones = zones
+	}
+}
+
+// WithStableSort makes SortInitiativeOrder keep creatures with the same roll and Dex modifier in their existing order
+func WithStableSort(stable bool) RoomServiceOption {
+	return func(s *RoomService) {
+		s.stableSort = stable
+	}
 }
 
 // NewRoomService creates a new RoomService with the required dependencies
-func NewRoomService() (*RoomService, error) {
+// Optional dependencies such as a monster repository can be supplied as options
+func NewRoomService(opts ...RoomServiceOption) (*RoomService, error) {
 	// Create a balancer with the same repository
 	balancer := NewBalancer()
 
 	// Return the service with the repository interface
-	return &RoomService{
-		balancer: balancer,
-	}, nil
+	service := &RoomService{
+		balancer:             balancer,
+		registry:             NewPlaceableConfigRegistry(),
+		monsterNameGenerator: IndexedMonsterNameGenerator{},
+	}
+	for _, opt := range opts {
+		opt(service)
+	}
+
+	return service, nil
 }
 
 // RoomConfig contains all the parameters for room generation
 type RoomConfig struct {
-	Width       int
-	Height      int
-	LightLevel  entities.LightLevel
-	Description string
-	UseGrid     bool
+	Width             int
+	Height            int
+	LightLevel        entities.LightLevel
+	Description       string
+	UseGrid           bool
+	PlacementStrategy entities.PlacementStrategy // Algorithm for random placement (defaults to sequential)
+	CellSizeFt        int                        // Width of one grid square in feet (optional, 0 means entities.MovementStepFt; see CalculateDistanceFt)
+	Seed              int64                      // Seed for reproducible rooms (optional, 0 uses the shared random source)
+	Tags              map[string]string          // Tags copied onto the room (optional, see entities.Room.SetTag)
+	Environment       string                     // Setting of the room, one of the entities.Environment constants (optional)
+	EventLog          bool                       // Whether to record placements, removals, and moves (see entities.Room.EventLog)
 }
 
+// PostPlacementCallback is called after an entity has been placed in a room
+// The entity points at the room's own copy, so changes made by the callback are kept
+type PostPlacementCallback func(room *entities.Room, entity entities.Placeable) error
+
 // MonsterConfig contains parameters for monster generation
 type MonsterConfig struct {
-	Name        string
-	Key         string
-	CR          float64
-	Count       int                // Number of this monster type to add
-	RandomPlace bool               // Whether to place monsters randomly
-	Position    *entities.Position // Optional specific position (only used if RandomPlace is false)
+	Name                  string
+	Key                   string
+	CR                    float64
+	Count                 int                   // Number of this monster type to add
+	RandomPlace           bool                  // Whether to place monsters randomly
+	Position              *entities.Position    // Optional specific position (only used if RandomPlace is false)
+	Zone                  *PlacementZone        // Optional region to place in (only used if RandomPlace is true)
+	Required              bool                  // Whether AddPlaceablesStrict must place the entity (optional)
+	ClusterRadius         int                   // Places later instances within this many cells of the first (optional, 0 scatters them)
+	PostPlacementCallback PostPlacementCallback // Optional hook run after each monster is placed
+
+	Size       entities.CreatureSize // Size category (optional, defaults to medium)
+	Speed      int                   // Walking speed in feet (optional, defaults to DefaultMonsterSpeedFt)
+	MeleeReach int                   // Melee reach in squares (optional, 1 for Medium and smaller, 2 for Large and bigger)
+
+	ConditionImmunities []entities.Condition // Conditions the monsters are immune to (optional)
+	HP                  int                  // Maximum hit points (optional, derived from CR with MonsterHPForCR if 0)
 }
 
 // PlayerConfig contains parameters for player character placement
 type PlayerConfig struct {
 	Name        string
 	Level       int                // Character level
+	Class       string             // Character class (optional)
+	Race        string             // Character race (optional)
 	RandomPlace bool               // Whether to place player randomly
 	Position    *entities.Position // Optional specific position (only used if RandomPlace is false)
+	Zone        *PlacementZone     // Optional region to place in (only used if RandomPlace is true)
+	Required    bool               // Whether AddPlaceablesStrict must place the entity (optional)
+	HP          int                // Maximum hit points (optional, 0 leaves hit points untracked)
+	Speed       int                // Walking speed in feet (optional, defaults to DefaultMonsterSpeedFt)
 }
 
 // ItemConfig contains parameters for item generation
@@ -58,16 +150,27 @@ type ItemConfig struct {
 	Count       int                // Number of this item type to add
 	RandomPlace bool               // Whether to place items randomly
 	Position    *entities.Position // Optional specific position (only used if RandomPlace is false)
+	Zone        *PlacementZone     // Optional region to place in (only used if RandomPlace is true)
+	Required    bool               // Whether AddPlaceablesStrict must place the entity (optional)
+
+	Cursed           bool   // Whether the item is cursed
+	CurseDescription string // Message shown when the curse takes effect
 }
 
 // NPCConfig contains parameters for NPC placement
 type NPCConfig struct {
 	Name        string
 	Level       int                // Character level
+	Disposition string             // Attitude toward the party, one of the entities.Disposition constants (optional)
+	Speed       int                // Walking speed in feet (optional, defaults to DefaultMonsterSpeedFt)
 	Count       int                // Number of this NPC type to add
 	Inventory   []entities.Item    // Items in the NPC's inventory
 	RandomPlace bool               // Whether to place NPC randomly
 	Position    *entities.Position // Optional specific position (only used if RandomPlace is false)
+	Zone        *PlacementZone     // Optional region to place in (only used if RandomPlace is true)
+	Required    bool               // Whether AddPlaceablesStrict must place the entity (optional)
+
+	PostPlacementCallback PostPlacementCallback // Optional hook run after each NPC is placed
 }
 
 // ObstacleConfig contains parameters for obstacle placement
@@ -78,6 +181,14 @@ type ObstacleConfig struct {
 	Count       int                // Number of this obstacle type to add
 	RandomPlace bool               // Whether to place obstacle randomly
 	Position    *entities.Position // Optional specific position (only used if RandomPlace is false)
+	Zone        *PlacementZone     // Optional region to place in (only used if RandomPlace is true)
+	Required    bool               // Whether AddPlaceablesStrict must place the entity (optional)
+
+	ClusterRadius int // Places later instances within this many cells of the first (optional, 0 scatters them)
+	SizeWidth     int // Width in cells (optional, defaults to 1)
+	SizeHeight    int // Height in cells (optional, defaults to 1)
+
+	PostPlacementCallback PostPlacementCallback `json:"-"` // Optional hook run after each obstacle is placed
 }
 
 // ShouldPlaceRandomly implements PlaceableConfig for NPCConfig
@@ -98,9 +209,12 @@ func (c NPCConfig) GetName() string {
 // CreatePlaceable implements PlaceableConfig for NPCConfig
 func (c NPCConfig) CreatePlaceable(s *RoomService) (entities.Placeable, error) {
 	npc := &entities.NPC{
-		ID:        uuid.NewString(),
-		Name:      c.Name,
-		Inventory: c.Inventory,
+		ID:          uuid.NewString(),
+		Name:        c.Name,
+		Level:       c.Level,
+		Inventory:   c.Inventory,
+		Disposition: c.Disposition,
+		Speed:       c.Speed,
 	}
 	return npc, nil
 }
@@ -127,11 +241,16 @@ func (c ObstacleConfig) GetName() string {
 
 // CreatePlaceable implements PlaceableConfig for ObstacleConfig
 func (c ObstacleConfig) CreatePlaceable(s *RoomService) (entities.Placeable, error) {
+	if c.SizeWidth < 0 || c.SizeHeight < 0 {
+		return nil, fmt.Errorf("obstacle size must not be negative, got %dx%d", c.SizeWidth, c.SizeHeight)
+	}
+
 	obstacle := &entities.Obstacle{
 		ID:       uuid.NewString(),
 		Name:     c.Name,
 		Key:      c.Key,
 		Blocking: c.Blocking,
+		Size:     entities.Size{Width: max(c.SizeWidth, 1), Height: max(c.SizeHeight, 1)},
 	}
 	return obstacle, nil
 }
@@ -141,6 +260,144 @@ func (c ObstacleConfig) GetCellType() entities.CellType {
 	return entities.CellObstacle
 }
 
+// TrapConfig contains parameters for adding a trap to a room
+type TrapConfig struct {
+	Name        string             // Name of the trap
+	Key         string             // Key for identifying the trap type (defaults to DefaultTrapKey)
+	Count       int                // Number of this trap type to add
+	RandomPlace bool               // Whether to place the trap randomly
+	Position    *entities.Position // Optional specific position (only used if RandomPlace is false)
+	Zone        *PlacementZone     // Optional region to place in (only used if RandomPlace is true)
+	Required    bool               // Whether AddPlaceablesStrict must place the entity (optional)
+
+	DamageDice  string // Damage dealt when triggered, in dice notation (optional)
+	DamageType  string // Type of damage dealt (optional)
+	SaveDC      int    // Difficulty class of the saving throw to avoid the trap (optional)
+	SaveAbility string // Ability used for the saving throw (optional)
+}
+
+// ShouldPlaceRandomly implements PlaceableConfig for TrapConfig
+func (c TrapConfig) ShouldPlaceRandomly() bool {
+	return c.RandomPlace
+}
+
+// GetPosition implements PlaceableConfig for TrapConfig
+func (c TrapConfig) GetPosition() *entities.Position {
+	return c.Position
+}
+
+// GetName implements PlaceableConfig for TrapConfig
+func (c TrapConfig) GetName() string {
+	return c.Name
+}
+
+// CreatePlaceable implements PlaceableConfig for TrapConfig
+func (c TrapConfig) CreatePlaceable(s *RoomService) (entities.Placeable, error) {
+	key := c.Key
+	if key == "" {
+		key = DefaultTrapKey
+	}
+	trap := &entities.Trap{
+		ID:          uuid.NewString(),
+		Name:        c.Name,
+		Key:         key,
+		DamageDice:  c.DamageDice,
+		DamageType:  c.DamageType,
+		SaveDC:      c.SaveDC,
+		SaveAbility: c.SaveAbility,
+	}
+	return trap, nil
+}
+
+// GetCellType implements PlaceableConfig for TrapConfig
+func (c TrapConfig) GetCellType() entities.CellType {
+	return entities.CellTrap
+}
+
+// DoorConfig contains parameters for adding a door to a room
+type DoorConfig struct {
+	Name          string             // Name of the door
+	Key           string             // Key for identifying the door type
+	Locked        bool               // Whether the door starts locked
+	Barred        bool               // Whether the door starts barred
+	RequiredKeyID string             // ID of the key that unlocks the door (optional, any key if empty)
+	Open          bool               // Whether the door starts open
+	Count         int                // Number of this door type to add
+	RandomPlace   bool               // Whether to place the door randomly
+	Position      *entities.Position // Optional specific position (only used if RandomPlace is false)
+	Zone          *PlacementZone     // Optional region to place in (only used if RandomPlace is true)
+	Required      bool               // Whether AddPlaceablesStrict must place the entity (optional)
+}
+
+// ShouldPlaceRandomly implements PlaceableConfig for DoorConfig
+func (c DoorConfig) ShouldPlaceRandomly() bool {
+	return c.RandomPlace
+}
+
+// GetPosition implements PlaceableConfig for DoorConfig
+func (c DoorConfig) GetPosition() *entities.Position {
+	return c.Position
+}
+
+// GetName implements PlaceableConfig for DoorConfig
+func (c DoorConfig) GetName() string {
+	return c.Name
+}
+
+// CreatePlaceable implements PlaceableConfig for DoorConfig
+func (c DoorConfig) CreatePlaceable(s *RoomService) (entities.Placeable, error) {
+	door := &entities.Door{
+		ID:            uuid.NewString(),
+		Name:          c.Name,
+		Key:           c.Key,
+		Locked:        c.Locked,
+		Barred:        c.Barred,
+		RequiredKeyID: c.RequiredKeyID,
+		Open:          c.Open,
+	}
+	return door, nil
+}
+
+// GetCellType implements PlaceableConfig for DoorConfig
+func (c DoorConfig) GetCellType() entities.CellType {
+	return entities.CellDoor
+}
+
+// SpellZoneConfig places a spell's area of effect in a room
+// Zones are always placed at their target point; see ConvertAPISpellToZoneConfig
+type SpellZoneConfig struct {
+	Zone entities.SpellZone // Zone to place; its ID is replaced when the zone is created
+}
+
+// ShouldPlaceRandomly implements PlaceableConfig for SpellZoneConfig
+func (c SpellZoneConfig) ShouldPlaceRandomly() bool {
+	return false
+}
+
+// GetPosition implements PlaceableConfig for SpellZoneConfig
+func (c SpellZoneConfig) GetPosition() *entities.Position {
+	pos := c.Zone.Position
+	return &pos
+}
+
+// GetName implements PlaceableConfig for SpellZoneConfig
+func (c SpellZoneConfig) GetName() string {
+	return c.Zone.Name
+}
+
+// CreatePlaceable implements PlaceableConfig for SpellZoneConfig
+func (c SpellZoneConfig) CreatePlaceable(s *RoomService) (entities.Placeable, error) {
+	zone := c.Zone
+	zone.ID = uuid.NewString()
+	zone.AffectedPositions = cloneSlice(c.Zone.AffectedPositions)
+	return &zone, nil
+}
+
+// GetCellType implements PlaceableConfig for SpellZoneConfig
+func (c SpellZoneConfig) GetCellType() entities.CellType {
+	return entities.CellSpellZone
+}
+
 // PlaceableConfig defines the interface for any placeable entity configuration
 type PlaceableConfig interface {
 	// CreatePlaceable creates a new placeable entity from this configuration
@@ -166,14 +423,38 @@ var _ PlaceableConfig = (*PlayerConfig)(nil)
 var _ PlaceableConfig = (*ItemConfig)(nil)
 var _ PlaceableConfig = (*NPCConfig)(nil)
 var _ PlaceableConfig = (*ObstacleConfig)(nil)
+var _ PlaceableConfig = (*TrapConfig)(nil)
+var _ PlaceableConfig = (*DoorConfig)(nil)
+var _ PlaceableConfig = (*SpellZoneConfig)(nil)
+
+// MonsterHPForCR estimates a monster's average hit points from its CR as 7 x CR + 3, rounded down and at least 1
+func MonsterHPForCR(cr float64) int {
+	return max(int(math.Floor(7*cr+3)), 1)
+}
 
 // CreatePlaceable implements PlaceableConfig for MonsterConfig
 func (c MonsterConfig) CreatePlaceable(s *RoomService) (entities.Placeable, error) {
 	monster := &entities.Monster{
-		ID:   uuid.NewString(),
-		Name: c.Name,
-		Key:  c.Key,
-		CR:   c.CR,
+		ID:         uuid.NewString(),
+		Name:       c.Name,
+		Key:        c.Key,
+		CR:         c.CR,
+		Size:       c.Size,
+		Speed:      c.Speed,
+		MeleeReach: c.MeleeReach,
+
+		ConditionImmunities: cloneSlice(c.ConditionImmunities),
+	}
+	if monster.MeleeReach == 0 {
+		monster.MeleeReach = monster.Size.DefaultMeleeReach()
+	}
+	monster.MaxHP = c.HP
+	if monster.MaxHP <= 0 {
+		monster.MaxHP = MonsterHPForCR(c.CR)
+	}
+	monster.CurrentHP = monster.MaxHP
+	if monster.Name == "" {
+		monster.Name = s.generateMonsterName(c.Key)
 	}
 	return monster, nil
 }
@@ -201,9 +482,14 @@ func (c MonsterConfig) GetCellType() entities.CellType {
 // CreatePlaceable implements PlaceableConfig for PlayerConfig
 func (c PlayerConfig) CreatePlaceable(s *RoomService) (entities.Placeable, error) {
 	player := &entities.Player{
-		ID:    uuid.NewString(),
-		Name:  c.Name,
-		Level: c.Level,
+		ID:        uuid.NewString(),
+		Name:      c.Name,
+		Level:     c.Level,
+		Class:     c.Class,
+		Race:      c.Race,
+		MaxHP:     c.HP,
+		CurrentHP: c.HP,
+		Speed:     c.Speed,
 	}
 	return player, nil
 }
@@ -231,9 +517,11 @@ func (c PlayerConfig) GetCellType() entities.CellType {
 // CreatePlaceable implements PlaceableConfig for ItemConfig
 func (c ItemConfig) CreatePlaceable(s *RoomService) (entities.Placeable, error) {
 	item := &entities.Item{
-		ID:   uuid.NewString(),
-		Key:  c.Key,
-		Name: c.Name,
+		ID:               uuid.NewString(),
+		Key:              c.Key,
+		Name:             c.Name,
+		Cursed:           c.Cursed,
+		CurseDescription: c.CurseDescription,
 	}
 
 	return item, nil
@@ -259,16 +547,58 @@ func (c ItemConfig) GetCellType() entities.CellType {
 	return entities.CellItem
 }
 
+// AddPlaceablesResult describes the non-fatal problems encountered by AddPlaceablesToRoomWithResult
+type AddPlaceablesResult struct {
+	Discarded []string // Entities that could not be placed because the room was full
+	Warnings  []string // Errors returned by post-placement callbacks
+
+	placed []PlacedEntity    // Entities placed, in placement order
+	failed []FailedPlacement // Entities discarded, with the reason each could not be placed
+}
+
 // AddPlaceablesToRoom adds any placeable entities to a room based on their configurations
 // Players will always be placed first. If the room becomes full, monsters and items may be discarded
 // with a warning message rather than causing an error.
 func (s *RoomService) AddPlaceablesToRoom(room *entities.Room, configs []PlaceableConfig) error {
+	result, err := s.AddPlaceablesToRoomWithResult(room, configs)
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range result.Warnings {
+		slog.Warn("post-placement callback failed", "error", warning)
+	}
+
+	return nil
+}
+
+// AddMonstersToRoom adds Count monsters for each config to a room, like GenerateAndPopulateRoom
+// but without balancing them against a party. Room-full behavior matches AddPlaceablesToRoom, and an
+// error is returned if the configs add no monsters
+func (s *RoomService) AddMonstersToRoom(room *entities.Room, configs []MonsterConfig) error {
+	return s.AddPlaceablesToRoom(room, monsterPlaceableConfigs(configs))
+}
+
+// monsterPlaceableConfigs returns one placeable config per monster instance, repeating each config Count times
+func monsterPlaceableConfigs(configs []MonsterConfig) []PlaceableConfig {
+	placeableConfigs := []PlaceableConfig{}
+	for _, config := range configs {
+		for i := 0; i < config.Count; i++ {
+			placeableConfigs = append(placeableConfigs, config)
+		}
+	}
+	return placeableConfigs
+}
+
+// AddPlaceablesToRoomWithResult behaves like AddPlaceablesToRoom but also reports discarded entities
+// and post-placement callback errors instead of only logging them
+func (s *RoomService) AddPlaceablesToRoomWithResult(room *entities.Room, configs []PlaceableConfig) (*AddPlaceablesResult, error) {
 	if room == nil {
-		return fmt.Errorf("room cannot be nil")
+		return nil, fmt.Errorf("room cannot be nil")
 	}
 
 	if len(configs) == 0 {
-		return fmt.Errorf("at least one placeable entity must be provided")
+		return nil, fmt.Errorf("at least one placeable entity must be provided")
 	}
 
 	// Group configs by entity type for prioritization
@@ -277,6 +607,9 @@ func (s *RoomService) AddPlaceablesToRoom(room *entities.Room, configs []Placeab
 	itemConfigs := []PlaceableConfig{}
 	npcConfigs := []PlaceableConfig{}
 	obstacleConfigs := []PlaceableConfig{}
+	doorConfigs := []PlaceableConfig{}
+	trapConfigs := []PlaceableConfig{}
+	spellZoneConfigs := []PlaceableConfig{}
 	otherConfigs := []PlaceableConfig{}
 
 	// First pass: categorize configs without creating entities
@@ -287,60 +620,90 @@ func (s *RoomService) AddPlaceablesToRoom(room *entities.Room, configs []Placeab
 			playerConfigs = append(playerConfigs, config)
 		case MonsterConfig:
 			monsterConfigs = append(monsterConfigs, config)
-		case ItemConfig:
+		case ItemConfig, WeaponItemConfig, ArmorItemConfig:
 			itemConfigs = append(itemConfigs, config)
 		case NPCConfig:
 			npcConfigs = append(npcConfigs, config)
 		case ObstacleConfig:
 			obstacleConfigs = append(obstacleConfigs, config)
+		case DoorConfig:
+			doorConfigs = append(doorConfigs, config)
+		case TrapConfig:
+			trapConfigs = append(trapConfigs, config)
+		case SpellZoneConfig:
+			spellZoneConfigs = append(spellZoneConfigs, config)
 		default:
+			// Third-party config types must be registered before they can be placed
+			typeName := placeableConfigTypeName(config)
+			if !s.Registry().IsRegistered(typeName) {
+				return nil, fmt.Errorf("unregistered placeable config type: %s", typeName)
+			}
 			otherConfigs = append(otherConfigs, config)
 		}
 	}
 
-	// Combine in priority order: players, monsters, NPCs, obstacles, items, others
+	// Combine in priority order: players, monsters, NPCs, obstacles, doors, traps, items, spell zones, others
 	prioritizedConfigs := append(playerConfigs, monsterConfigs...)
 	prioritizedConfigs = append(prioritizedConfigs, npcConfigs...)
 	prioritizedConfigs = append(prioritizedConfigs, obstacleConfigs...)
+	prioritizedConfigs = append(prioritizedConfigs, doorConfigs...)
+	prioritizedConfigs = append(prioritizedConfigs, trapConfigs...)
 	prioritizedConfigs = append(prioritizedConfigs, itemConfigs...)
+	prioritizedConfigs = append(prioritizedConfigs, spellZoneConfigs...)
 	prioritizedConfigs = append(prioritizedConfigs, otherConfigs...)
 
 	// Track which entities couldn't be placed
 	var discardedEntities []string
+	result := &AddPlaceablesResult{}
+
+	// Positions of the first placed instance of each clustered config
+	clusterAnchors := map[string]entities.Position{}
 
 	for _, config := range prioritizedConfigs {
 		// Create the placeable entity
 		entity, err := config.CreatePlaceable(s)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		assignSeededID(room, entity)
 
 		// Get the entity type for logging
-		entityType := "entity"
-		switch entity.GetCellType() {
-		case entities.CellPlayer:
-			entityType = "player"
-		case entities.CellMonster:
-			entityType = "monster"
-		case entities.CellItem:
-			entityType = "item"
-		case entities.CellNPC:
-			entityType = "npc"
-		case entities.CellObstacle:
-			entityType = "obstacle"
-		}
+		entityType := entityTypeName(entity.GetCellType())
 
 		// Place entity either randomly or at a specific position
 		if config.ShouldPlaceRandomly() {
-			position, err := FindEmptyPosition(room)
+			zone := placementZone(config)
+			if zone != nil {
+				if err := zone.Validate(room); err != nil {
+					return nil, fmt.Errorf("invalid placement zone for %s: %w", config.GetName(), err)
+				}
+			}
+
+			clusterKey, radius := clusterOf(config)
+			if anchor, ok := clusterAnchors[clusterKey]; ok {
+				// Clustered instances must also stay inside the config's zone
+				cluster := nearZone(room, anchor, radius)
+				if zone != nil {
+					cluster = PlacementZone{
+						MinX: max(cluster.MinX, zone.MinX),
+						MinY: max(cluster.MinY, zone.MinY),
+						MaxX: min(cluster.MaxX, zone.MaxX),
+						MaxY: min(cluster.MaxY, zone.MaxY),
+					}
+				}
+				zone = &cluster
+			}
+
+			position, err := findPlacementPosition(room, zone, entity)
 			if err != nil {
 				// For players, this is a critical error
 				if entity.GetCellType() == entities.CellPlayer {
-					return fmt.Errorf("failed to place %s (player): %w", config.GetName(), err)
+					return nil, fmt.Errorf("failed to place %s (player): %w", config.GetName(), err)
 				}
 
 				// For monsters and items, just log and continue
 				discardedEntities = append(discardedEntities, fmt.Sprintf("%s (%s)", config.GetName(), entityType))
+				result.failed = append(result.failed, FailedPlacement{Name: config.GetName(), Reason: err, Required: isRequired(config)})
 				continue
 			}
 			entity.SetPosition(position)
@@ -348,20 +711,39 @@ func (s *RoomService) AddPlaceablesToRoom(room *entities.Room, configs []Placeab
 			// Use the specified position
 			entity.SetPosition(*pos)
 		} else {
-			return fmt.Errorf("%s must have a position when RandomPlace is false", config.GetName())
+			return nil, fmt.Errorf("%s must have a position when RandomPlace is false", config.GetName())
 		}
 
 		// Add the entity to the room using the interface-based method
 		if err := PlaceEntity(room, entity); err != nil {
 			// For players with specific positions, this is a critical error
 			if entity.GetCellType() == entities.CellPlayer {
-				return fmt.Errorf("failed to add %s (player): %w", config.GetName(), err)
+				return nil, fmt.Errorf("failed to add %s (player): %w", config.GetName(), err)
 			}
 
 			// For monsters and items, just log and continue
 			discardedEntities = append(discardedEntities, fmt.Sprintf("%s (%s)", config.GetName(), entityType))
+			result.failed = append(result.failed, FailedPlacement{Name: config.GetName(), Reason: err, Required: isRequired(config)})
 			continue
 		}
+		result.placed = append(result.placed, PlacedEntity{ID: entity.GetID(), Name: config.GetName(), Position: entity.GetPosition()})
+
+		if clusterKey, radius := clusterOf(config); radius > 0 {
+			if _, ok := clusterAnchors[clusterKey]; !ok {
+				clusterAnchors[clusterKey] = entity.GetPosition()
+			}
+		}
+
+		// Run the config's post-placement hook against the room's copy of the entity
+		if callback := postPlacementCallback(config); callback != nil {
+			placed := findPlaceable(room, entity.GetID(), entity.GetCellType())
+			if placed == nil {
+				placed = entity
+			}
+			if err := callback(room, placed); err != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s (%s): %v", config.GetName(), entityType, err))
+			}
+		}
 	}
 
 	// Log a warning if any entities were discarded
@@ -369,7 +751,82 @@ func (s *RoomService) AddPlaceablesToRoom(room *entities.Room, configs []Placeab
 		fmt.Printf("Warning: Could not place %d entities in the room because it was full: %s\n",
 			len(discardedEntities), strings.Join(discardedEntities, ", "))
 	}
+	result.Discarded = discardedEntities
+
+	return result, nil
+}
 
+// placementZone returns the placement zone of a config, or nil if it has none
+func placementZone(config PlaceableConfig) *PlacementZone {
+	switch c := config.(type) {
+	case MonsterConfig:
+		return c.Zone
+	case PlayerConfig:
+		return c.Zone
+	case ItemConfig:
+		return c.Zone
+	case WeaponItemConfig:
+		return c.Zone
+	case ArmorItemConfig:
+		return c.Zone
+	case NPCConfig:
+		return c.Zone
+	case ObstacleConfig:
+		return c.Zone
+	case TrapConfig:
+		return c.Zone
+	case DoorConfig:
+		return c.Zone
+	}
+	return nil
+}
+
+// clusterOf returns the key shared by instances of a clustered config and its cluster radius
+// Instances are configs of the same type with the same key and name; the radius is 0 for configs that do not cluster
+func clusterOf(config PlaceableConfig) (string, int) {
+	switch c := config.(type) {
+	case MonsterConfig:
+		return fmt.Sprintf("monster:%s:%s", c.Key, c.Name), c.ClusterRadius
+	case ObstacleConfig:
+		return fmt.Sprintf("obstacle:%s:%s", c.Key, c.Name), c.ClusterRadius
+	}
+	return "", 0
+}
+
+// findPlacementPosition finds an empty position for the entity in the zone, or anywhere in the room if zone is nil
+// Obstacles larger than one cell get a position where their whole footprint is inside the zone and empty
+func findPlacementPosition(room *entities.Room, zone *PlacementZone, entity entities.Placeable) (entities.Position, error) {
+	if obstacle, ok := entity.(*entities.Obstacle); ok && obstacle.Footprint() != (entities.Size{Width: 1, Height: 1}) {
+		area := PlacementZone{MaxX: room.Width - 1, MaxY: room.Height - 1}
+		if zone != nil {
+			area = *zone
+		}
+		offsets := []entities.Position{}
+		for _, cell := range obstacle.Cells() {
+			offsets = append(offsets, cell.Sub(obstacle.Position))
+		}
+		positions, err := findFormationPlacement(room, area, offsets)
+		if err != nil {
+			return entities.Position{}, err
+		}
+		return positions[0], nil
+	}
+	if zone != nil {
+		return FindEmptyPositionInZone(room, *zone)
+	}
+	return FindEmptyPosition(room)
+}
+
+// postPlacementCallback returns the post-placement hook of a config, or nil if it has none
+func postPlacementCallback(config PlaceableConfig) PostPlacementCallback {
+	switch c := config.(type) {
+	case MonsterConfig:
+		return c.PostPlacementCallback
+	case NPCConfig:
+		return c.PostPlacementCallback
+	case ObstacleConfig:
+		return c.PostPlacementCallback
+	}
 	return nil
 }
 
@@ -412,16 +869,8 @@ func (s *RoomService) GenerateAndPopulateRoom(
 		return nil, err
 	}
 
-	// Collect all placeable configs into a single slice
-	placeableConfigs := []PlaceableConfig{}
-
-	// Add monster configs
-	for _, config := range monsterConfigs {
-		for i := 0; i < config.Count; i++ {
-			instanceConfig := config
-			placeableConfigs = append(placeableConfigs, instanceConfig)
-		}
-	}
+	// Collect all placeable configs into a single slice, starting with the monsters
+	placeableConfigs := monsterPlaceableConfigs(monsterConfigs)
 
 	// Add player configs
 	for _, config := range playerConfigs {
@@ -463,7 +912,8 @@ func (s *RoomService) GenerateAndPopulateRoom(
 }
 
 // CleanupRoom removes entities from a room and returns XP gained for monsters
-// If entityIDs is empty for a type, all entities of that type are removed
+// If entityIDs is empty for a type, all entities of that type are removed. Each removal is recorded in the
+// room's battle log with the XP it awarded, credited to the entity that last damaged it (see DamageEntity)
 // Returns the total XP gained, a slice of entity IDs that weren't removed, and any error encountered
 func (s *RoomService) CleanupRoom(room *entities.Room, entityType entities.CellType, entityIDs []string) (int, []string, error) {
 	if room == nil {
@@ -474,304 +924,183 @@ func (s *RoomService) CleanupRoom(room *entities.Room, entityType entities.CellT
 	totalXP := 0
 	notRemoved := []string{}
 
-	switch entityType {
-	case entities.CellMonster:
-		// If entityIDs is empty, remove all monsters
-		if len(entityIDs) == 0 {
-			// First calculate XP for all monsters
-			for _, monster := range room.Monsters {
-				// Use the explicit XP value if provided, otherwise calculate based on CR
-				if monster.XP > 0 {
-					totalXP += monster.XP
-				} else {
-					// Use CR-based estimate
-					totalXP += int(monster.CR * 100)
-				}
-			}
-
-			// Create a copy of monster IDs to avoid modification during iteration
-			monsterIDs := make([]string, len(room.Monsters))
-			for i, monster := range room.Monsters {
-				monsterIDs[i] = monster.ID
-			}
-
-			// Remove each monster by ID
-			for _, id := range monsterIDs {
-				// Find the monster entity
-				var monster *entities.Monster
-				for i := range room.Monsters {
-					if room.Monsters[i].ID == id {
-						monster = &room.Monsters[i]
-						break
-					}
-				}
+	if err := validateCleanupType(entityType); err != nil {
+		return 0, notRemoved, err
+	}
 
-				if monster != nil {
-					removed, err := RemovePlaceable(room, monster)
-					if !removed || err != nil {
-						notRemoved = append(notRemoved, id)
-					}
-				} else {
-					notRemoved = append(notRemoved, id)
-				}
+	// If entityIDs is empty, remove every entity of the type
+	ids := entityIDs
+	if len(ids) == 0 {
+		for _, p := range collectPlaceables(room) {
+			if p.GetCellType() == entityType {
+				ids = append(ids, p.GetID())
 			}
-		} else {
-			// Remove specific monsters by ID
-			for _, monsterID := range entityIDs {
-				// Find the monster entity
-				var monster *entities.Monster
-				for i := range room.Monsters {
-					if room.Monsters[i].ID == monsterID {
-						monster = &room.Monsters[i]
-						break
-					}
-				}
+		}
+	}
 
-				if monster != nil {
-					// Use the explicit XP value if provided, otherwise calculate based on CR
-					if monster.XP > 0 {
-						totalXP += monster.XP
-					} else {
-						// Use CR-based estimate
-						totalXP += int(monster.CR * 100)
-					}
+	for _, id := range ids {
+		entity, err := findEntityOfType(room, id, entityType)
+		if err != nil {
+			notRemoved = append(notRemoved, id)
+			continue
+		}
+		entry := newBattleLogEntry(entities.BattleEventEntityRemoved, nil, entity)
+		if monster, ok := entity.(*entities.Monster); ok {
+			entry.Amount = s.monsterXP(monster)
+			totalXP += entry.Amount
+		}
 
-					removed, err := RemovePlaceable(room, monster)
-					if !removed || err != nil {
-						notRemoved = append(notRemoved, monsterID)
-					}
-				} else {
-					notRemoved = append(notRemoved, monsterID)
-				}
-			}
+		removed, err := RemovePlaceable(room, entity)
+		if !removed || err != nil {
+			notRemoved = append(notRemoved, id)
+			continue
+		}
+		if killer := lastDamage(room, id); killer != nil {
+			entry.ActorID, entry.ActorKey, entry.ActorType = killer.ActorID, killer.ActorKey, killer.ActorType
 		}
+		recordBattleEvent(room, entry)
+	}
 
-	case entities.CellItem:
-		// If entityIDs is empty, remove all items
-		if len(entityIDs) == 0 {
-			// Create a copy of item IDs to avoid modification during iteration
-			itemIDs := make([]string, len(room.Items))
-			for i, item := range room.Items {
-				itemIDs[i] = item.ID
-			}
+	return totalXP, notRemoved, nil
+}
 
-			// Remove each item by ID
-			for _, id := range itemIDs {
-				// Find the item entity
-				var item *entities.Item
-				for i := range room.Items {
-					if room.Items[i].ID == id {
-						item = &room.Items[i]
-						break
-					}
-				}
+// CleanupEntities removes entities of several types in one call, applying CleanupRoom to each type in
+// cell type order. An empty ID list removes every entity of its type. Returns the total XP gained and the
+// IDs that weren't removed, keyed by type and listing only types with misses. If any type is unsupported,
+// an error is returned before anything is removed
+func (s *RoomService) CleanupEntities(room *entities.Room, removals map[entities.CellType][]string) (int, map[entities.CellType][]string, error) {
+	if room == nil {
+		return 0, nil, fmt.Errorf("room cannot be nil")
+	}
 
-				if item != nil {
-					removed, err := RemovePlaceable(room, item)
-					if !removed || err != nil {
-						notRemoved = append(notRemoved, id)
-					}
-				} else {
-					notRemoved = append(notRemoved, id)
-				}
-			}
-		} else {
-			// Remove specific items by ID
-			for _, itemID := range entityIDs {
-				// Find the item entity
-				var item *entities.Item
-				for i := range room.Items {
-					if room.Items[i].ID == itemID {
-						item = &room.Items[i]
-						break
-					}
-				}
+	types := make([]entities.CellType, 0, len(removals))
+	for entityType := range removals {
+		if err := validateCleanupType(entityType); err != nil {
+			return 0, nil, err
+		}
+		types = append(types, entityType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
 
-				if item != nil {
-					removed, err := RemovePlaceable(room, item)
-					if !removed || err != nil {
-						notRemoved = append(notRemoved, itemID)
-					}
-				} else {
-					notRemoved = append(notRemoved, itemID)
-				}
-			}
+	totalXP := 0
+	notRemoved := map[entities.CellType][]string{}
+	for _, entityType := range types {
+		xp, missed, err := s.CleanupRoom(room, entityType, removals[entityType])
+		if err != nil {
+			return totalXP, notRemoved, err
+		}
+		totalXP += xp
+		if len(missed) > 0 {
+			notRemoved[entityType] = missed
 		}
+	}
 
-	case entities.CellPlayer:
-		// If entityIDs is empty, remove all players
-		if len(entityIDs) == 0 {
-			// Create a copy of player IDs to avoid modification during iteration
-			playerIDs := make([]string, len(room.Players))
-			for i, player := range room.Players {
-				playerIDs[i] = player.ID
-			}
+	return totalXP, notRemoved, nil
+}
 
-			// Remove each player by ID
-			for _, id := range playerIDs {
-				// Find the player entity
-				var player *entities.Player
-				for i := range room.Players {
-					if room.Players[i].ID == id {
-						player = &room.Players[i]
-						break
-					}
-				}
+// validateCleanupType returns an error if entities of the type cannot be removed by CleanupRoom
+func validateCleanupType(entityType entities.CellType) error {
+	switch entityType {
+	case entities.CellMonster, entities.CellPlayer, entities.CellItem, entities.CellNPC,
+		entities.CellObstacle, entities.CellTrap, entities.CellDoor:
+		return nil
+	}
+	return fmt.Errorf("unsupported entity type: %d", entityType)
+}
 
-				if player != nil {
-					removed, err := RemovePlaceable(room, player)
-					if !removed || err != nil {
-						notRemoved = append(notRemoved, id)
-					}
-				} else {
-					notRemoved = append(notRemoved, id)
-				}
-			}
-		} else {
-			// Remove specific players by ID
-			for _, playerID := range entityIDs {
-				// Find the player entity
-				var player *entities.Player
-				for i := range room.Players {
-					if room.Players[i].ID == playerID {
-						player = &room.Players[i]
-						break
-					}
-				}
+// monsterXP returns the XP awarded for defeating a monster
+// The explicit XP value is used if set, then the monster repository if one is configured,
+// and finally the official CR to XP table
+func (s *RoomService) monsterXP(monster *entities.Monster) int {
+	if monster.XP > 0 {
+		return monster.XP
+	}
 
-				if player != nil {
-					removed, err := RemovePlaceable(room, player)
-					if !removed || err != nil {
-						notRemoved = append(notRemoved, playerID)
-					}
-				} else {
-					notRemoved = append(notRemoved, playerID)
-				}
-			}
+	if s.monsterRepo != nil {
+		xp, err := s.monsterRepo.GetMonsterXP(monster.Key)
+		if err == nil {
+			return xp
 		}
+		slog.Warn("monster XP lookup failed, falling back to CR table",
+			"key", monster.Key, "cr", monster.CR, "error", err)
+	}
 
-	case entities.CellNPC:
-		// If entityIDs is empty, remove all NPCs
-		if len(entityIDs) == 0 {
-			// Create a copy of NPC IDs to avoid modification during iteration
-			npcIDs := make([]string, len(room.NPCs))
-			for i, npc := range room.NPCs {
-				npcIDs[i] = npc.ID
-			}
-
-			// Remove each NPC by ID
-			for _, id := range npcIDs {
-				// Find the NPC entity
-				var npc *entities.NPC
-				for i := range room.NPCs {
-					if room.NPCs[i].ID == id {
-						npc = &room.NPCs[i]
-						break
-					}
-				}
-
-				if npc != nil {
-					removed, err := RemovePlaceable(room, npc)
-					if !removed || err != nil {
-						notRemoved = append(notRemoved, id)
-					}
-				} else {
-					notRemoved = append(notRemoved, id)
-				}
-			}
-		} else {
-			// Remove specific NPCs by ID
-			for _, npcID := range entityIDs {
-				// Find the NPC entity
-				var npc *entities.NPC
-				for i := range room.NPCs {
-					if room.NPCs[i].ID == npcID {
-						npc = &room.NPCs[i]
-						break
-					}
-				}
+	return crutil.CRToXP(monster.CR)
+}
 
-				if npc != nil {
-					removed, err := RemovePlaceable(room, npc)
-					if !removed || err != nil {
-						notRemoved = append(notRemoved, npcID)
-					}
-				} else {
-					notRemoved = append(notRemoved, npcID)
-				}
-			}
-		}
+// MoveEntity moves a placeable entity from its current position to a new position
+// Returns an error if the move cannot be completed
+func (s *RoomService) MoveEntity(room *entities.Room, entity entities.Placeable, newPosition entities.Position) error {
+	return MovePlaceable(room, entity, newPosition)
+}
 
-	case entities.CellObstacle:
-		// If entityIDs is empty, remove all obstacles
-		if len(entityIDs) == 0 {
-			// Create a copy of obstacle IDs to avoid modification during iteration
-			obstacleIDs := make([]string, len(room.Obstacles))
-			for i, obstacle := range room.Obstacles {
-				obstacleIDs[i] = obstacle.ID
-			}
+// SwapEntityPositions exchanges the positions of two entities, along with their grid cells
+// A multi-cell obstacle takes its whole footprint to the other entity's position, so in gridded rooms every
+// cell of the new footprints must be inside the room and free of anything but the two entities, and the
+// footprints must not overlap. Returns an error without changing the room if either entity is not found,
+// both IDs are the same, or the swapped entities would not fit. Each entity gets a move in the event log
+func (s *RoomService) SwapEntityPositions(room *entities.Room, idA, idB string) error {
+	if room == nil {
+		return entities.ErrNilRoom
+	}
+	if idA == idB {
+		return fmt.Errorf("cannot swap entity %s with itself", idA)
+	}
 
-			// Remove each obstacle by ID
-			for _, id := range obstacleIDs {
-				// Find the obstacle entity
-				var obstacle *entities.Obstacle
-				for i := range room.Obstacles {
-					if room.Obstacles[i].ID == id {
-						obstacle = &room.Obstacles[i]
-						break
-					}
-				}
+	a, err := FindEntityByID(room, idA)
+	if err != nil {
+		return err
+	}
+	b, err := FindEntityByID(room, idB)
+	if err != nil {
+		return err
+	}
 
-				if obstacle != nil {
-					removed, err := RemovePlaceable(room, obstacle)
-					if !removed || err != nil {
-						notRemoved = append(notRemoved, id)
-					}
-				} else {
-					notRemoved = append(notRemoved, id)
-				}
+	posA, posB := a.GetPosition(), b.GetPosition()
+	if room.Grid != nil {
+		footprintA, footprintB := footprintAt(a, posB), footprintAt(b, posA)
+		claimed := map[entities.Position]bool{}
+		for _, pos := range append(footprintA, footprintB...) {
+			if !IsPositionValid(room, pos) {
+				return fmt.Errorf("swapping %s and %s: %w", idA, idB, entities.ErrInvalidPosition)
 			}
-		} else {
-			// Remove specific obstacles by ID
-			for _, obstacleID := range entityIDs {
-				// Find the obstacle entity
-				var obstacle *entities.Obstacle
-				for i := range room.Obstacles {
-					if room.Obstacles[i].ID == obstacleID {
-						obstacle = &room.Obstacles[i]
-						break
-					}
-				}
-
-				if obstacle != nil {
-					removed, err := RemovePlaceable(room, obstacle)
-					if !removed || err != nil {
-						notRemoved = append(notRemoved, obstacleID)
-					}
-				} else {
-					notRemoved = append(notRemoved, obstacleID)
-				}
+			if owner := room.Grid[pos.Y][pos.X].EntityID; claimed[pos] || (room.Grid[pos.Y][pos.X].Type != entities.CellTypeEmpty && owner != idA && owner != idB) {
+				return fmt.Errorf("swapping %s and %s: %w", idA, idB, entities.ErrCellOccupied)
 			}
+			claimed[pos] = true
 		}
 
-	default:
-		return 0, notRemoved, fmt.Errorf("unsupported entity type: %d", entityType)
+		for _, pos := range append(occupiedCells(a), occupiedCells(b)...) {
+			clearGridCell(room, pos)
+		}
+		for _, pos := range footprintA {
+			room.Grid[pos.Y][pos.X] = entities.Cell{Type: a.GetCellType(), EntityID: idA}
+		}
+		for _, pos := range footprintB {
+			room.Grid[pos.Y][pos.X] = entities.Cell{Type: b.GetCellType(), EntityID: idB}
+		}
 	}
+	a.SetPosition(posB)
+	b.SetPosition(posA)
+	recordRoomEvent(room, entities.RoomEventMoved, idA, a.GetCellType(), &posA, &posB)
+	recordRoomEvent(room, entities.RoomEventMoved, idB, b.GetCellType(), &posB, &posA)
 
-	return totalXP, notRemoved, nil
-}
-
-// MoveEntity moves a placeable entity from its current position to a new position
-// Returns an error if the move cannot be completed
-func (s *RoomService) MoveEntity(room *entities.Room, entity entities.Placeable, newPosition entities.Position) error {
-	return MovePlaceable(room, entity, newPosition)
+	return nil
 }
 
 // GenerateRoom creates a new room based on the provided configuration
 func (s *RoomService) GenerateRoom(config RoomConfig) (*entities.Room, error) {
+	return generateRoom(config)
+}
+
+// generateRoom creates a new room based on the provided configuration
+func generateRoom(config RoomConfig) (*entities.Room, error) {
 	if config.Width <= 0 || config.Height <= 0 {
 		return nil, fmt.Errorf("room dimensions must be positive")
 	}
+	if config.CellSizeFt < 0 {
+		return nil, fmt.Errorf("cell size must not be negative, got %d", config.CellSizeFt)
+	}
 
 	// Set default light level if not specified
 	lightLevel := config.LightLevel
@@ -782,12 +1111,29 @@ func (s *RoomService) GenerateRoom(config RoomConfig) (*entities.Room, error) {
 	// Create the room
 	room := NewRoom(config.Width, config.Height, lightLevel)
 	room.Description = config.Description
+	room.Environment = config.Environment
+	room.PlacementStrategy = config.PlacementStrategy
+	room.CellSizeFt = config.CellSizeFt
+	for key, value := range config.Tags {
+		room.SetTag(key, value)
+	}
+	if config.EventLog {
+		room.EventLog = []entities.RoomEvent{}
+	}
+	if config.Seed != 0 {
+		room.Rand = rand.New(rand.NewSource(config.Seed))
+		room.ID = newEntityID(room)
+	}
 
 	// Initialize grid if requested
 	if config.UseGrid {
 		InitializeGrid(room)
 	}
 
+	if errs := ValidateRoom(room); len(errs) > 0 {
+		return nil, fmt.Errorf("generated room is invalid: %w", errs[0])
+	}
+
 	return room, nil
 }
 
@@ -826,6 +1172,32 @@ func (s *RoomService) GetNPCInventory(room *entities.Room, npcID string) ([]enti
 	return npc.GetInventory(), nil
 }
 
+// GetHostileNPCs returns copies of the room's NPCs whose disposition is hostile
+func (s *RoomService) GetHostileNPCs(room *entities.Room) []entities.NPC {
+	return npcsWithDisposition(room, entities.DispositionHostile)
+}
+
+// GetFriendlyNPCs returns copies of the room's NPCs whose disposition is friendly
+func (s *RoomService) GetFriendlyNPCs(room *entities.Room) []entities.NPC {
+	return npcsWithDisposition(room, entities.DispositionFriendly)
+}
+
+// npcsWithDisposition returns copies of the room's NPCs with the given disposition, in room order
+// Returns nil for a nil room
+func npcsWithDisposition(room *entities.Room, disposition string) []entities.NPC {
+	if room == nil {
+		return nil
+	}
+
+	npcs := []entities.NPC{}
+	for _, npc := range room.NPCs {
+		if npc.Disposition == disposition {
+			npcs = append(npcs, npc)
+		}
+	}
+	return npcs
+}
+
 // RemoveItemFromNPCInventory removes an item from an NPC's inventory by ID
 // Returns the removed item and an error if any occurred
 func (s *RoomService) RemoveItemFromNPCInventory(room *entities.Room, npcID string, itemID string) (entities.Item, error) {
@@ -846,3 +1218,67 @@ func (s *RoomService) RemoveItemFromNPCInventory(room *entities.Room, npcID stri
 
 	return item, nil
 }
+
+// Level thresholds for the items AutoPopulateNPCInventory gives an NPC
+const (
+	NPCGearMaxLevel  = 4  // NPCs up to this level carry adventuring gear
+	NPCMagicMinLevel = 11 // NPCs of at least this level carry magic items; those in between carry weapons
+)
+
+// npcInventorySize is the number of items AutoPopulateNPCInventory asks the repository for
+const npcInventorySize = 3
+
+// AutoPopulateNPCInventory adds items suited to the NPC's level to its inventory
+// NPCs up to NPCGearMaxLevel get adventuring gear, those from NPCMagicMinLevel get magic items (or weapons if
+// the repository has no magic items), and the rest get weapons. Existing inventory is kept
+func (s *RoomService) AutoPopulateNPCInventory(room *entities.Room, npcID string, itemRepo repositories.ItemCategoryRepository) error {
+	if room == nil {
+		return entities.ErrNilRoom
+	}
+	if itemRepo == nil {
+		return fmt.Errorf("item repository cannot be nil")
+	}
+
+	npc, _ := FindNPCByID(room, npcID)
+	if npc == nil {
+		return fmt.Errorf("NPC with ID %s not found in room", npcID)
+	}
+
+	var items []*entities.Item
+	var err error
+	switch {
+	case npc.Level >= NPCMagicMinLevel:
+		items, err = itemRepo.GetMagicItems(npcInventorySize)
+		if err == nil && len(items) == 0 {
+			items, err = itemRepo.GetRandomItemsByCategory(repositories.CategoryWeapon, npcInventorySize)
+		}
+	case npc.Level > NPCGearMaxLevel:
+		items, err = itemRepo.GetRandomItemsByCategory(repositories.CategoryWeapon, npcInventorySize)
+	default:
+		items, err = itemRepo.GetRandomItemsByCategory(repositories.CategoryAdventuringGear, npcInventorySize)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get items for NPC %s: %w", npcID, err)
+	}
+
+	for _, template := range items {
+		item := *template
+		item.ID = newEntityID(room)
+		item.Properties = cloneSlice(template.Properties)
+		npc.AddItemToInventory(item)
+	}
+	return nil
+}
+
+// GetRoomByID looks up a room in the repository by its ID
+// Returns an error if the repository is nil or the ID is not a valid UUID
+func (s *RoomService) GetRoomByID(repo repositories.RoomRepository, id string) (*entities.Room, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("room repository cannot be nil")
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("invalid room ID %q: %w", id, err)
+	}
+
+	return repo.GetRoom(id)
+}
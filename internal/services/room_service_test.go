@@ -1,9 +1,12 @@
 package services
 
 import (
+	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+	"github.com/fadedpez/dnd5e-roomgen/internal/repositories"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -85,6 +88,20 @@ func TestGenerateRoom(t *testing.T) {
 				assert.NotNil(t, room.Grid)
 			},
 		},
+		{
+			name: "Tags and environment are copied",
+			config: RoomConfig{
+				Width:       5,
+				Height:      5,
+				Tags:        map[string]string{"boss": "lich", "explored": "false"},
+				Environment: entities.EnvironmentForest,
+			},
+			expectError: false,
+			checkFunc: func(t *testing.T, room *entities.Room) {
+				assert.Equal(t, map[string]string{"boss": "lich", "explored": "false"}, room.Tags)
+				assert.Equal(t, entities.EnvironmentForest, room.Environment)
+			},
+		},
 		{
 			name:        "Invalid width",
 			config:      createTestRoomConfig(0, 10, entities.LightLevelBright, true),
@@ -392,6 +409,40 @@ func TestCleanupRoom(t *testing.T) {
 			expectedCount: 1,                    // Table should remain
 			notRemovedIDs: []string{},           // All requested obstacles should be removed
 		},
+		{
+			name: "Remove all traps",
+			setupRoom: func() *entities.Room {
+				room := NewRoom(10, 10, entities.LightLevelBright)
+				InitializeGrid(room)
+
+				PlaceEntity(room, &entities.Trap{ID: "t1", Name: "Pit", Position: entities.Position{X: 1, Y: 1}})
+				PlaceEntity(room, &entities.Trap{ID: "t2", Name: "Darts", Position: entities.Position{X: 2, Y: 2}})
+
+				return room
+			},
+			entityType:    entities.CellTrap,
+			entityIDs:     []string{},
+			expectedXP:    0,
+			expectedCount: 0,
+			notRemovedIDs: []string{},
+		},
+		{
+			name: "IDs of other entity types are not removed",
+			setupRoom: func() *entities.Room {
+				room := NewRoom(10, 10, entities.LightLevelBright)
+				InitializeGrid(room)
+
+				PlaceEntity(room, &entities.Monster{ID: "m1", Name: "Goblin", Position: entities.Position{X: 1, Y: 1}, XP: 50})
+				PlaceEntity(room, &entities.Player{ID: "p1", Name: "Fighter", Position: entities.Position{X: 2, Y: 2}})
+
+				return room
+			},
+			entityType:    entities.CellMonster,
+			entityIDs:     []string{"p1", "missing"},
+			expectedXP:    0,
+			expectedCount: 1,
+			notRemovedIDs: []string{"p1", "missing"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -416,6 +467,8 @@ func TestCleanupRoom(t *testing.T) {
 				assert.Equal(t, tc.expectedCount, len(room.NPCs), "Unexpected number of NPCs remaining")
 			case entities.CellObstacle:
 				assert.Equal(t, tc.expectedCount, len(room.Obstacles), "Unexpected number of obstacles remaining")
+			case entities.CellTrap:
+				assert.Equal(t, tc.expectedCount, len(room.Traps), "Unexpected number of traps remaining")
 			}
 
 			if tc.expectedCount > 0 && len(tc.entityIDs) > 0 {
@@ -1205,3 +1258,811 @@ func TestEntityPlacementPriority(t *testing.T) {
 		assert.Less(t, item.Position.Y, 5)
 	}
 }
+
+// mockMonsterRepository is a MonsterRepository backed by a map
+type mockMonsterRepository struct {
+	xp    map[string]int
+	calls int
+}
+
+func (m *mockMonsterRepository) GetMonsterXP(key string) (int, error) {
+	m.calls++
+	xp, ok := m.xp[key]
+	if !ok {
+		return 0, repositories.ErrMonsterNotFound
+	}
+	return xp, nil
+}
+
+func TestMonsterConfigHP(t *testing.T) {
+	testCases := []struct {
+		name       string
+		config     MonsterConfig
+		expectedHP int
+	}{
+		{"Explicit HP", MonsterConfig{Key: "ogre", CR: 2, HP: 59}, 59},
+		{"Derived from CR", MonsterConfig{Key: "ogre", CR: 2}, 17},
+		{"Fractional CR rounds down", MonsterConfig{Key: "goblin", CR: 0.25}, 4},
+		{"CR 0", MonsterConfig{Key: "rat", CR: 0}, 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			placeable, err := tc.config.CreatePlaceable(&RoomService{})
+			require.NoError(t, err)
+			monster := placeable.(*entities.Monster)
+			assert.Equal(t, tc.expectedHP, monster.MaxHP)
+			assert.Equal(t, tc.expectedHP, monster.CurrentHP)
+		})
+	}
+
+	assert.Equal(t, 1, MonsterHPForCR(-1), "HP is at least 1")
+}
+
+func TestPlayerConfigHP(t *testing.T) {
+	placeable, err := PlayerConfig{Name: "Vex", Level: 5, HP: 38}.CreatePlaceable(&RoomService{})
+	require.NoError(t, err)
+	player := placeable.(*entities.Player)
+	assert.Equal(t, 38, player.MaxHP)
+	assert.Equal(t, 38, player.CurrentHP)
+}
+
+func TestPlayerConfigClassAndRace(t *testing.T) {
+	placeable, err := PlayerConfig{Name: "Vex", Level: 5, Class: "ranger", Race: "half-elf"}.CreatePlaceable(&RoomService{})
+	require.NoError(t, err)
+	player := placeable.(*entities.Player)
+	assert.Equal(t, "ranger", player.Class)
+	assert.Equal(t, "half-elf", player.Race)
+}
+
+func TestCleanupRoomDeadMonster(t *testing.T) {
+	service, err := NewRoomService()
+	require.NoError(t, err)
+	room := createTestRoom()
+	require.NoError(t, service.AddPlaceablesToRoom(room, []PlaceableConfig{MonsterConfig{Key: "ogre", CR: 2, Count: 1, RandomPlace: true}}))
+
+	ogre := &room.Monsters[0]
+	require.True(t, ogre.ApplyDamage(ogre.MaxHP))
+	require.True(t, ogre.IsDead())
+
+	xp, notRemoved, err := service.CleanupRoom(room, entities.CellMonster, []string{ogre.ID})
+	require.NoError(t, err)
+	assert.Empty(t, notRemoved)
+	assert.Equal(t, 450, xp, "dead monsters still award XP")
+}
+
+func TestCleanupRoomXPFallback(t *testing.T) {
+	testCases := []struct {
+		name          string
+		monster       entities.Monster
+		repo          *mockMonsterRepository
+		expectedXP    int
+		expectedCalls int
+	}{
+		{
+			name:          "Explicit XP is used first",
+			monster:       entities.Monster{ID: "1", Key: "goblin", CR: 0.25, XP: 75},
+			repo:          &mockMonsterRepository{xp: map[string]int{"goblin": 50}},
+			expectedXP:    75,
+			expectedCalls: 0,
+		},
+		{
+			name:          "Repository lookup when XP is unset",
+			monster:       entities.Monster{ID: "1", Key: "goblin", CR: 0.25},
+			repo:          &mockMonsterRepository{xp: map[string]int{"goblin": 65}},
+			expectedXP:    65,
+			expectedCalls: 1,
+		},
+		{
+			name:          "CR table when repository lookup fails",
+			monster:       entities.Monster{ID: "1", Key: "owlbear", CR: 3},
+			repo:          &mockMonsterRepository{xp: map[string]int{}},
+			expectedXP:    700,
+			expectedCalls: 1,
+		},
+		{
+			name:       "CR table without a repository",
+			monster:    entities.Monster{ID: "1", Key: "owlbear", CR: 3},
+			expectedXP: 700,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var service *RoomService
+			var err error
+			if tc.repo != nil {
+				service, err = NewRoomService(WithMonsterRepository(tc.repo))
+			} else {
+				service, err = NewRoomService()
+			}
+			assert.NoError(t, err)
+
+			room := NewRoom(3, 3, entities.LightLevelBright)
+			monster := tc.monster
+			assert.NoError(t, PlaceEntity(room, &monster))
+
+			xp, notRemoved, err := service.CleanupRoom(room, entities.CellMonster, []string{monster.ID})
+			assert.NoError(t, err)
+			assert.Empty(t, notRemoved)
+			assert.Equal(t, tc.expectedXP, xp)
+
+			if tc.repo != nil {
+				assert.Equal(t, tc.expectedCalls, tc.repo.calls)
+			}
+		})
+	}
+}
+
+func TestAddTrapConfigToRoom(t *testing.T) {
+	service := &RoomService{}
+	room := createTestRoom()
+	trapPos := entities.Position{X: 2, Y: 2}
+
+	err := service.AddPlaceablesToRoom(room, []PlaceableConfig{
+		TrapConfig{Name: "Pit Trap", RandomPlace: false, Position: &trapPos, DamageDice: "2d10", DamageType: "bludgeoning", SaveDC: 15, SaveAbility: "dex"},
+		createTestObstacleConfig("Boulder", "boulder", true, 1, false, &entities.Position{X: 0, Y: 4}),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, room.Obstacles, 1)
+	require.Len(t, room.Traps, 1)
+	trap := room.Traps[0]
+	assert.Equal(t, DefaultTrapKey, trap.Key)
+	assert.Equal(t, trapPos, trap.Position)
+	assert.Equal(t, "2d10", trap.DamageDice)
+	assert.Equal(t, "bludgeoning", trap.DamageType)
+	assert.Equal(t, 15, trap.SaveDC)
+	assert.Equal(t, "dex", trap.SaveAbility)
+	assert.False(t, trap.Triggered)
+	assert.Equal(t, entities.Cell{Type: entities.CellTrap, EntityID: trap.ID}, room.Grid[trapPos.Y][trapPos.X])
+
+	removed, err := RemovePlaceable(room, &trap)
+	require.NoError(t, err)
+	assert.True(t, removed)
+	assert.Empty(t, room.Traps)
+	assert.Equal(t, entities.CellTypeEmpty, room.Grid[trapPos.Y][trapPos.X].Type)
+}
+
+func TestAddTrapConfigPlacedAfterObstacles(t *testing.T) {
+	service := &RoomService{}
+	room := NewRoom(1, 1, entities.LightLevelBright)
+	InitializeGrid(room)
+
+	result, err := service.AddPlaceablesToRoomWithResult(room, []PlaceableConfig{
+		TrapConfig{Name: "Pit Trap", RandomPlace: true},
+		createTestObstacleConfig("Boulder", "boulder", true, 1, true, nil),
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, room.Obstacles, 1, "obstacles are placed before traps")
+	assert.Empty(t, room.Traps)
+	assert.Equal(t, []string{"Pit Trap (trap)"}, result.Discarded)
+}
+
+func TestAddPlaceablesPostPlacementCallback(t *testing.T) {
+	service := &RoomService{}
+	room := createTestRoom()
+
+	shaman := createTestMonsterConfig("Goblin Shaman", "goblin-shaman", 0.25, 1, true, nil)
+	shaman.PostPlacementCallback = func(room *entities.Room, entity entities.Placeable) error {
+		monster, ok := entity.(*entities.Monster)
+		if !ok {
+			return fmt.Errorf("expected a monster, got %T", entity)
+		}
+		monster.Conditions = append(monster.Conditions, entities.ConditionHidden)
+		return nil
+	}
+
+	boulder := createTestObstacleConfig("Boulder", "boulder", true, 1, true, nil)
+	boulder.PostPlacementCallback = func(room *entities.Room, entity entities.Placeable) error {
+		return fmt.Errorf("boulder refused to settle")
+	}
+
+	result, err := service.AddPlaceablesToRoomWithResult(room, []PlaceableConfig{shaman, boulder})
+	require.NoError(t, err)
+
+	require.Len(t, room.Monsters, 1)
+	assert.Contains(t, room.Monsters[0].Conditions, entities.ConditionHidden)
+	require.Len(t, room.Obstacles, 1, "callback errors must not undo placement")
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0], "boulder refused to settle")
+	assert.Empty(t, result.Discarded)
+}
+
+func TestAddPlaceablesWithPlacementZone(t *testing.T) {
+	service, err := NewRoomService()
+	require.NoError(t, err)
+	zone := &PlacementZone{MinX: 1, MinY: 1, MaxX: 2, MaxY: 2}
+
+	t.Run("Entities land inside their zone", func(t *testing.T) {
+		room := createTestRoom()
+
+		configs := []PlaceableConfig{}
+		for i := 0; i < 3; i++ {
+			goblin := createTestMonsterConfig("Goblin", "goblin", 0.25, 1, true, nil)
+			goblin.Zone = zone
+			configs = append(configs, goblin)
+		}
+		configs = append(configs, PlayerConfig{Name: "Hero", Level: 1, RandomPlace: true, Zone: zone})
+
+		require.NoError(t, service.AddPlaceablesToRoom(room, configs))
+		require.Len(t, room.Monsters, 3)
+		require.Len(t, room.Players, 1)
+		for _, p := range collectPlaceables(room) {
+			assert.True(t, zone.Contains(p.GetPosition()), "%s at %s should be inside the zone", p.GetID(), p.GetPosition())
+		}
+	})
+
+	t.Run("Entities that do not fit are discarded", func(t *testing.T) {
+		room := createTestRoom()
+
+		configs := []PlaceableConfig{}
+		for i := 0; i < 5; i++ {
+			configs = append(configs, ItemConfig{Name: "Coin", Key: "coin", RandomPlace: true, Zone: zone})
+		}
+
+		result, err := service.AddPlaceablesToRoomWithResult(room, configs)
+		require.NoError(t, err)
+		assert.Len(t, room.Items, 4)
+		assert.Len(t, result.Discarded, 1)
+	})
+
+	t.Run("Gridless rooms", func(t *testing.T) {
+		room := createTestRoomNoGrid()
+
+		obstacle := createTestObstacleConfig("Crate", "crate", false, 1, true, nil)
+		obstacle.Zone = zone
+		require.NoError(t, service.AddPlaceablesToRoom(room, []PlaceableConfig{obstacle}))
+		require.Len(t, room.Obstacles, 1)
+		assert.True(t, zone.Contains(room.Obstacles[0].Position))
+	})
+
+	t.Run("Zone outside the room", func(t *testing.T) {
+		room := createTestRoom()
+
+		npc := NPCConfig{Name: "Guide", Level: 1, RandomPlace: true, Zone: &PlacementZone{MinX: 3, MinY: 3, MaxX: 9, MaxY: 9}}
+		err := service.AddPlaceablesToRoom(room, []PlaceableConfig{npc})
+		assert.Error(t, err)
+		assert.Empty(t, room.NPCs)
+	})
+
+	t.Run("Zone is ignored for fixed positions", func(t *testing.T) {
+		room := createTestRoom()
+
+		pos := entities.Position{X: 4, Y: 4}
+		door := DoorConfig{Name: "Door", Key: "door", Position: &pos, Zone: zone}
+		require.NoError(t, service.AddPlaceablesToRoom(room, []PlaceableConfig{door}))
+		require.Len(t, room.Doors, 1)
+		assert.Equal(t, pos, room.Doors[0].Position)
+	})
+}
+
+func TestAddPlaceablesWithClusterRadius(t *testing.T) {
+	service, err := NewRoomService()
+	require.NoError(t, err)
+	roomConfig := createTestRoomConfig(20, 20, entities.LightLevelBright, true)
+
+	t.Run("Clustered goblins stay near the first", func(t *testing.T) {
+		room, err := service.GenerateRoom(roomConfig)
+		require.NoError(t, err)
+
+		goblin := createTestMonsterConfig("Goblin", "goblin", 0.25, 1, true, nil)
+		goblin.ClusterRadius = 2
+		wolf := createTestMonsterConfig("Wolf", "wolf", 0.25, 1, true, nil)
+		configs := []PlaceableConfig{}
+		for i := 0; i < 6; i++ {
+			configs = append(configs, goblin, wolf)
+		}
+		require.NoError(t, service.AddPlaceablesToRoom(room, configs))
+
+		goblins := []entities.Monster{}
+		for _, monster := range room.Monsters {
+			if monster.Key == "goblin" {
+				goblins = append(goblins, monster)
+			}
+		}
+		require.Len(t, goblins, 6)
+		for _, g := range goblins[1:] {
+			assert.LessOrEqual(t, DistanceBetween(goblins[0].Position, g.Position, DistanceChebyshev), 2.0)
+		}
+	})
+
+	t.Run("Clustered obstacles respect their zone", func(t *testing.T) {
+		room, err := service.GenerateRoom(roomConfig)
+		require.NoError(t, err)
+
+		zone := &PlacementZone{MinX: 10, MinY: 0, MaxX: 19, MaxY: 19}
+		rubble := createTestObstacleConfig("Rubble", "rubble", false, 1, true, nil)
+		rubble.ClusterRadius = 1
+		rubble.Zone = zone
+		configs := []PlaceableConfig{rubble, rubble, rubble, rubble}
+		require.NoError(t, service.AddPlaceablesToRoom(room, configs))
+
+		require.Len(t, room.Obstacles, 4)
+		for _, o := range room.Obstacles {
+			assert.True(t, zone.Contains(o.Position))
+			assert.LessOrEqual(t, DistanceBetween(room.Obstacles[0].Position, o.Position, DistanceChebyshev), 1.0)
+		}
+	})
+
+	t.Run("Full clusters discard the rest", func(t *testing.T) {
+		room, err := service.GenerateRoom(roomConfig)
+		require.NoError(t, err)
+
+		pos := entities.Position{X: 0, Y: 0}
+		leader := createTestMonsterConfig("Goblin", "goblin", 0.25, 1, false, &pos)
+		leader.ClusterRadius = 1
+		follower := leader
+		follower.RandomPlace = true
+		follower.Position = nil
+
+		result, err := service.AddPlaceablesToRoomWithResult(room, []PlaceableConfig{leader, follower, follower, follower, follower})
+		require.NoError(t, err)
+		assert.Len(t, room.Monsters, 4)
+		assert.Len(t, result.Discarded, 1)
+	})
+}
+
+func TestSwapEntityPositions(t *testing.T) {
+	service := &RoomService{}
+
+	setup := func() *entities.Room {
+		room := createTestRoom()
+		goblin := createTestMonster("goblin", 0, 0)
+		orc := createTestMonster("orc", 4, 4)
+		fighter := createTestPlayer("fighter", 3, 2, 1)
+		for _, p := range []entities.Placeable{&goblin, &orc, &fighter} {
+			require.NoError(t, PlaceEntity(room, p))
+		}
+		return room
+	}
+
+	t.Run("Two monsters", func(t *testing.T) {
+		room := setup()
+		require.NoError(t, service.SwapEntityPositions(room, "goblin", "orc"))
+
+		assert.Equal(t, entities.Position{X: 4, Y: 4}, room.Monsters[0].Position)
+		assert.Equal(t, entities.Position{X: 0, Y: 0}, room.Monsters[1].Position)
+		assert.Equal(t, entities.Cell{Type: entities.CellMonster, EntityID: "orc"}, room.Grid[0][0])
+		assert.Equal(t, entities.Cell{Type: entities.CellMonster, EntityID: "goblin"}, room.Grid[4][4])
+	})
+
+	t.Run("Monster and player", func(t *testing.T) {
+		room := setup()
+		require.NoError(t, service.SwapEntityPositions(room, "fighter", "goblin"))
+
+		assert.Equal(t, entities.Position{X: 0, Y: 0}, room.Players[0].Position)
+		assert.Equal(t, entities.Position{X: 2, Y: 1}, room.Monsters[0].Position)
+		assert.Equal(t, entities.Cell{Type: entities.CellPlayer, EntityID: "fighter"}, room.Grid[0][0])
+		assert.Equal(t, entities.Cell{Type: entities.CellMonster, EntityID: "goblin"}, room.Grid[1][2])
+	})
+
+	t.Run("Event log", func(t *testing.T) {
+		room := setup()
+		room.EventLog = []entities.RoomEvent{}
+		require.NoError(t, service.SwapEntityPositions(room, "goblin", "fighter"))
+
+		require.Len(t, room.EventLog, 2)
+		assert.Equal(t, entities.RoomEventMoved, room.EventLog[0].EventType)
+		assert.Equal(t, "goblin", room.EventLog[0].EntityID)
+		assert.Equal(t, entities.Position{X: 0, Y: 0}, *room.EventLog[0].OldPosition)
+		assert.Equal(t, entities.Position{X: 2, Y: 1}, *room.EventLog[0].NewPosition)
+		assert.Equal(t, "fighter", room.EventLog[1].EntityID)
+		assert.Equal(t, "player", room.EventLog[1].Detail)
+		assert.Equal(t, entities.Position{X: 0, Y: 0}, *room.EventLog[1].NewPosition)
+	})
+
+	t.Run("Gridless room", func(t *testing.T) {
+		room := createTestRoomNoGrid()
+		goblin := createTestMonster("goblin", 1, 1)
+		fighter := createTestPlayer("fighter", 3, 1, 1)
+		require.NoError(t, PlaceEntity(room, &goblin))
+		require.NoError(t, PlaceEntity(room, &fighter))
+		room.Players[0].Position = entities.Position{X: 3, Y: 2}
+
+		require.NoError(t, service.SwapEntityPositions(room, "goblin", "fighter"))
+		assert.Equal(t, entities.Position{X: 3, Y: 2}, room.Monsters[0].Position)
+		assert.Equal(t, entities.Position{X: 1, Y: 1}, room.Players[0].Position)
+	})
+
+	t.Run("Multi-cell obstacle", func(t *testing.T) {
+		room := createTestRoom()
+		table := &entities.Obstacle{ID: "table", Size: entities.Size{Width: 2, Height: 2}}
+		goblin := createTestMonster("goblin", 3, 3)
+		orc := createTestMonster("orc", 4, 0)
+		for _, p := range []entities.Placeable{table, &goblin, &orc} {
+			require.NoError(t, PlaceEntity(room, p))
+		}
+
+		before := Clone(room)
+		assert.ErrorIs(t, service.SwapEntityPositions(room, "table", "orc"), entities.ErrInvalidPosition)
+		assert.Equal(t, before, room)
+
+		require.NoError(t, service.SwapEntityPositions(room, "table", "goblin"))
+		assert.Equal(t, entities.Position{X: 3, Y: 3}, room.Obstacles[0].Position)
+		assert.Equal(t, entities.Position{X: 0, Y: 0}, room.Monsters[0].Position)
+		assert.Empty(t, ValidateRoomGrid(room))
+		assert.Equal(t, entities.CellTypeEmpty, room.Grid[1][1].Type)
+		assert.Equal(t, "table", room.Grid[4][4].EntityID)
+	})
+
+	t.Run("Errors leave the room unchanged", func(t *testing.T) {
+		room := setup()
+		before := Clone(room)
+
+		assert.Error(t, service.SwapEntityPositions(room, "goblin", "goblin"))
+		assert.ErrorIs(t, service.SwapEntityPositions(room, "goblin", "missing"), ErrEntityNotFound)
+		assert.ErrorIs(t, service.SwapEntityPositions(room, "missing", "orc"), ErrEntityNotFound)
+		assert.ErrorIs(t, service.SwapEntityPositions(nil, "goblin", "orc"), entities.ErrNilRoom)
+		assert.Equal(t, before, room)
+	})
+}
+
+// mockRoomRepository is a RoomRepository backed by a map
+type mockRoomRepository struct {
+	rooms map[string]*entities.Room
+}
+
+func (m *mockRoomRepository) GetRoom(id string) (*entities.Room, error) {
+	room, ok := m.rooms[id]
+	if !ok {
+		return nil, repositories.ErrRoomNotFound
+	}
+	return room, nil
+}
+
+func TestRoomIDs(t *testing.T) {
+	service, err := NewRoomService()
+	require.NoError(t, err)
+	roomConfig := createTestRoomConfig(10, 10, entities.LightLevelBright, true)
+
+	first, err := service.GenerateRoom(roomConfig)
+	require.NoError(t, err)
+	second, err := service.GenerateRoom(roomConfig)
+	require.NoError(t, err)
+	trapRoom, err := service.GenerateTrapRoom(roomConfig, TrapRoomConfig{TrapCount: 1, TrapZone: SpawnZone{MaxX: 9, MaxY: 2}, GuardZone: SpawnZone{MinY: 7, MaxX: 9, MaxY: 9}})
+	require.NoError(t, err)
+	ambushRoom, err := service.GenerateAmbushRoom(roomConfig, createTestAmbushConfig(false))
+	require.NoError(t, err)
+
+	for _, room := range []*entities.Room{first, second, trapRoom, ambushRoom} {
+		assert.NotEmpty(t, room.ID)
+		assert.True(t, room.IsValidID(), "room ID %q should be a UUID", room.ID)
+	}
+	assert.NotEqual(t, first.ID, second.ID)
+
+	clone, err := service.CloneRoom(first)
+	require.NoError(t, err)
+	assert.True(t, clone.IsValidID())
+	assert.NotEqual(t, first.ID, clone.ID)
+	assert.Equal(t, first.Width, clone.Width)
+
+	assert.False(t, (&entities.Room{ID: "not-a-uuid"}).IsValidID())
+}
+
+func TestSeededRoomGeneration(t *testing.T) {
+	generate := func(seed int64, strategy entities.PlacementStrategy, useGrid bool) []byte {
+		service, err := NewRoomService()
+		require.NoError(t, err)
+
+		roomConfig := createTestRoomConfig(10, 10, entities.LightLevelBright, useGrid)
+		roomConfig.Seed = seed
+		roomConfig.PlacementStrategy = strategy
+		room, err := service.GenerateRoom(roomConfig)
+		require.NoError(t, err)
+
+		configs := []PlaceableConfig{
+			PlayerConfig{Name: "Hero", Level: 3, RandomPlace: true},
+			MonsterConfig{Key: "goblin", CR: 0.25, Count: 1, RandomPlace: true},
+			MonsterConfig{Key: "orc", CR: 0.5, Count: 1, RandomPlace: true},
+			ItemConfig{Name: "Torch", Key: "torch", RandomPlace: true},
+			createTestObstacleConfig("Pillar", "pillar", true, 1, true, nil),
+		}
+		require.NoError(t, service.AddPlaceablesToRoom(room, configs))
+		require.NoError(t, service.AddPlaceablesToRoom(room, configs[1:3]))
+
+		data, err := json.Marshal(room)
+		require.NoError(t, err)
+		return data
+	}
+
+	tests := []struct {
+		name     string
+		strategy entities.PlacementStrategy
+		useGrid  bool
+	}{
+		{name: "Sequential", strategy: entities.PlacementSequential, useGrid: true},
+		{name: "Fisher-Yates", strategy: entities.PlacementFisherYates, useGrid: true},
+		{name: "Reservoir", strategy: entities.PlacementReservoir, useGrid: true},
+		{name: "Gridless", useGrid: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			first := generate(42, tc.strategy, tc.useGrid)
+			assert.Equal(t, string(first), string(generate(42, tc.strategy, tc.useGrid)), "same seed should produce identical rooms")
+			assert.NotEqual(t, string(first), string(generate(7, tc.strategy, tc.useGrid)), "different seeds should produce different rooms")
+		})
+	}
+
+	t.Run("Unseeded rooms use the shared source", func(t *testing.T) {
+		room, err := (&RoomService{}).GenerateRoom(createTestRoomConfig(5, 5, entities.LightLevelBright, true))
+		require.NoError(t, err)
+		assert.Nil(t, room.Rand)
+		assert.NotEqual(t, string(generate(0, entities.PlacementSequential, true)), string(generate(0, entities.PlacementSequential, true)))
+	})
+
+	t.Run("Themed rooms", func(t *testing.T) {
+		roomConfig := createTestRoomConfig(10, 10, entities.LightLevelDim, true)
+		roomConfig.Seed = 42
+		trapConfig := TrapRoomConfig{
+			TrapCount:          2,
+			TrapZone:           SpawnZone{MinX: 0, MinY: 0, MaxX: 9, MaxY: 2},
+			GuardMonsterConfig: []MonsterConfig{createTestMonsterConfig("Goblin", "goblin", 0.25, 2, true, nil)},
+			GuardZone:          SpawnZone{MinX: 2, MinY: 7, MaxX: 7, MaxY: 8},
+		}
+		generators := map[string]func(*RoomService) (*entities.Room, error){
+			"Trap": func(s *RoomService) (*entities.Room, error) { return s.GenerateTrapRoom(roomConfig, trapConfig) },
+			"Ambush": func(s *RoomService) (*entities.Room, error) {
+				return s.GenerateAmbushRoom(roomConfig, createTestAmbushConfig(true))
+			},
+			"Puzzle": func(s *RoomService) (*entities.Room, error) {
+				return s.GeneratePuzzleEncounter(roomConfig, createTestPuzzleConfig())
+			},
+		}
+
+		for name, generateRoom := range generators {
+			rooms := make([]string, 2)
+			for i := range rooms {
+				service, err := NewRoomService()
+				require.NoError(t, err)
+				room, err := generateRoom(service)
+				require.NoError(t, err, name)
+				data, err := json.Marshal(room)
+				require.NoError(t, err)
+				rooms[i] = string(data)
+			}
+			assert.Equal(t, rooms[0], rooms[1], "%s rooms should have the same entity IDs", name)
+		}
+	})
+
+	t.Run("Clones do not share the random source", func(t *testing.T) {
+		room, err := NewRoomWithOptions(WithDimensions(5, 5), WithGrid(), WithSeed(42))
+		require.NoError(t, err)
+		require.NotNil(t, room.Rand)
+		assert.True(t, room.IsValidID())
+		assert.Nil(t, Clone(room).Rand)
+	})
+}
+
+func TestGetRoomByID(t *testing.T) {
+	service := &RoomService{}
+	room := NewRoom(5, 5, entities.LightLevelBright)
+	repo := &mockRoomRepository{rooms: map[string]*entities.Room{room.ID: room}}
+
+	found, err := service.GetRoomByID(repo, room.ID)
+	require.NoError(t, err)
+	assert.Same(t, room, found)
+
+	_, err = service.GetRoomByID(repo, NewRoom(5, 5, entities.LightLevelBright).ID)
+	assert.ErrorIs(t, err, repositories.ErrRoomNotFound)
+	_, err = service.GetRoomByID(repo, "room-1")
+	assert.ErrorContains(t, err, "invalid room ID")
+	_, err = service.GetRoomByID(nil, room.ID)
+	assert.Error(t, err)
+}
+
+func TestAddPlaceablesWithMultiCellObstacles(t *testing.T) {
+	service, err := NewRoomService()
+	require.NoError(t, err)
+	room, err := service.GenerateRoom(createTestRoomConfig(6, 6, entities.LightLevelBright, true))
+	require.NoError(t, err)
+
+	table := createTestObstacleConfig("Table", "table", true, 1, true, nil)
+	table.SizeWidth, table.SizeHeight = 3, 2
+	require.NoError(t, service.AddPlaceablesToRoom(room, []PlaceableConfig{table, table}))
+
+	require.Len(t, room.Obstacles, 2)
+	for _, o := range room.Obstacles {
+		assert.Equal(t, entities.Size{Width: 3, Height: 2}, o.Size)
+	}
+	assert.Len(t, blockingObstaclePositions(room), 12)
+	assert.Empty(t, ValidateRoomGrid(room))
+
+	table.SizeWidth = -1
+	assert.Error(t, service.AddPlaceablesToRoom(room, []PlaceableConfig{table}))
+}
+
+func TestGetNPCsByDisposition(t *testing.T) {
+	service, err := NewRoomService()
+	require.NoError(t, err)
+	room, err := service.GenerateRoom(createTestRoomConfig(5, 5, entities.LightLevelBright, true))
+	require.NoError(t, err)
+
+	configs := []PlaceableConfig{}
+	for i, disposition := range []string{entities.DispositionHostile, entities.DispositionFriendly, entities.DispositionNeutral,
+		entities.DispositionFearful, "", entities.DispositionHostile} {
+		config := createTestNPCConfig(fmt.Sprintf("NPC %d", i), 2, 1, false, &entities.Position{X: i % 5, Y: i / 5}, nil)
+		config.Disposition = disposition
+		configs = append(configs, config)
+	}
+	require.NoError(t, service.AddPlaceablesToRoom(room, configs))
+	require.Len(t, room.NPCs, 6)
+	assert.Equal(t, 2, room.NPCs[0].Level)
+	assert.Equal(t, entities.DispositionHostile, room.NPCs[0].Disposition)
+
+	names := func(npcs []entities.NPC) []string {
+		result := []string{}
+		for _, npc := range npcs {
+			result = append(result, npc.Name)
+		}
+		return result
+	}
+	assert.Equal(t, []string{"NPC 0", "NPC 5"}, names(service.GetHostileNPCs(room)))
+	assert.Equal(t, []string{"NPC 1"}, names(service.GetFriendlyNPCs(room)))
+
+	// Results are copies
+	hostile := service.GetHostileNPCs(room)
+	hostile[0].Disposition = entities.DispositionFriendly
+	assert.Equal(t, entities.DispositionHostile, room.NPCs[0].Disposition)
+
+	empty := NewRoom(5, 5, entities.LightLevelBright)
+	assert.Empty(t, service.GetHostileNPCs(empty))
+	assert.Empty(t, service.GetFriendlyNPCs(empty))
+	assert.Nil(t, service.GetHostileNPCs(nil))
+	assert.Nil(t, service.GetFriendlyNPCs(nil))
+}
+
+func TestCleanupEntities(t *testing.T) {
+	service := &RoomService{}
+	setupRoom := func() *entities.Room {
+		room := createTestRoom()
+		require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "goblin", Position: entities.Position{X: 0, Y: 0}, XP: 50}))
+		require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "dragon", Position: entities.Position{X: 1, Y: 0}, XP: 15000}))
+		require.NoError(t, PlaceEntity(room, &entities.Item{ID: "sword", Position: entities.Position{X: 2, Y: 0}}))
+		require.NoError(t, PlaceEntity(room, &entities.Item{ID: "shield", Position: entities.Position{X: 3, Y: 0}}))
+		require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "boulder", Position: entities.Position{X: 4, Y: 0}}))
+		return room
+	}
+
+	t.Run("Monsters and items in one call", func(t *testing.T) {
+		room := setupRoom()
+		xp, notRemoved, err := service.CleanupEntities(room, map[entities.CellType][]string{
+			entities.CellMonster: {"goblin", "dragon", "lich"},
+			entities.CellItem:    {"sword", "goblin"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 15050, xp, "XP is returned even though some IDs were not found")
+		assert.Equal(t, map[entities.CellType][]string{
+			entities.CellMonster: {"lich"},
+			entities.CellItem:    {"goblin"},
+		}, notRemoved)
+		assert.Empty(t, room.Monsters)
+		require.Len(t, room.Items, 1)
+		assert.Equal(t, "shield", room.Items[0].ID)
+		assert.Len(t, room.Obstacles, 1)
+		assert.Equal(t, entities.CellTypeEmpty, room.Grid[0][2].Type)
+	})
+
+	t.Run("Empty ID list removes the whole type", func(t *testing.T) {
+		room := setupRoom()
+		xp, notRemoved, err := service.CleanupEntities(room, map[entities.CellType][]string{
+			entities.CellItem:     nil,
+			entities.CellObstacle: {"boulder"},
+		})
+		require.NoError(t, err)
+		assert.Zero(t, xp)
+		assert.Empty(t, notRemoved)
+		assert.Empty(t, room.Items)
+		assert.Empty(t, room.Obstacles)
+		assert.Len(t, room.Monsters, 2)
+	})
+
+	t.Run("Unsupported type removes nothing", func(t *testing.T) {
+		room := setupRoom()
+		_, _, err := service.CleanupEntities(room, map[entities.CellType][]string{
+			entities.CellMonster: {"goblin"},
+			entities.CellWall:    {"wall"},
+		})
+		assert.Error(t, err)
+		assert.Len(t, room.Monsters, 2)
+	})
+
+	t.Run("Nil room", func(t *testing.T) {
+		_, _, err := service.CleanupEntities(nil, map[entities.CellType][]string{entities.CellMonster: nil})
+		assert.Error(t, err)
+	})
+}
+
+func TestAddMonstersToRoom(t *testing.T) {
+	service, err := NewRoomService()
+	require.NoError(t, err)
+	room, err := service.GenerateRoom(createTestRoomConfig(10, 10, entities.LightLevelBright, true))
+	require.NoError(t, err)
+
+	pos := entities.Position{X: 4, Y: 4}
+	// The fixed-position ogre goes first so a random goblin cannot take its cell
+	configs := []MonsterConfig{
+		createTestMonsterConfig("Ogre", "ogre", 2, 1, false, &pos),
+		createTestMonsterConfig("Goblin", "goblin", 0.25, 3, true, nil),
+		createTestMonsterConfig("Wolf", "wolf", 0.25, 0, true, nil),
+	}
+	require.NoError(t, service.AddMonstersToRoom(room, configs))
+
+	counts := map[string]int{}
+	for _, monster := range room.Monsters {
+		counts[monster.Key]++
+	}
+	assert.Equal(t, map[string]int{"goblin": 3, "ogre": 1}, counts)
+	ogre, _ := FindMonsterByID(room, room.Grid[4][4].EntityID)
+	require.NotNil(t, ogre)
+	assert.Equal(t, "ogre", ogre.Key)
+	assert.Empty(t, ValidateRoom(room))
+
+	assert.Error(t, service.AddMonstersToRoom(room, nil), "no monsters to add")
+	assert.Error(t, service.AddMonstersToRoom(room, configs[2:]), "no monsters to add")
+	assert.Len(t, room.Monsters, 4)
+	assert.Error(t, service.AddMonstersToRoom(nil, configs))
+}
+
+func TestAutoPopulateNPCInventory(t *testing.T) {
+	gear := &entities.Item{Key: "rope", Category: repositories.CategoryAdventuringGear}
+	weapon := &entities.Item{Key: "longsword", Type: "weapon", Category: repositories.CategoryWeapon, Properties: []string{"versatile"}}
+	magic := &entities.Item{Key: "flame-tongue", Type: "weapon", Category: repositories.CategoryWeapon, Rarity: entities.RarityRare}
+
+	testCases := []struct {
+		name     string
+		level    int
+		items    []*entities.Item
+		expected []string
+	}{
+		{"Low level gets adventuring gear", 2, []*entities.Item{gear, weapon, magic}, []string{"rope"}},
+		{"Threshold level still gets gear", NPCGearMaxLevel, []*entities.Item{gear, weapon}, []string{"rope"}},
+		{"Mid level gets weapons", 7, []*entities.Item{gear, weapon, magic}, []string{"longsword", "flame-tongue"}},
+		{"High level gets magic items", NPCMagicMinLevel, []*entities.Item{gear, weapon, magic}, []string{"flame-tongue"}},
+		{"High level falls back to weapons", 15, []*entities.Item{gear, weapon}, []string{"longsword"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, err := NewRoomService()
+			require.NoError(t, err)
+			room := createTestRoom()
+			npc := createTestNPCConfig("Guard", tc.level, 1, false, &entities.Position{X: 1, Y: 1}, nil)
+			require.NoError(t, service.AddPlaceablesToRoom(room, []PlaceableConfig{npc}))
+			npcID := room.NPCs[0].ID
+
+			require.NoError(t, service.AutoPopulateNPCInventory(room, npcID, &repositories.TestItemRepository{Items: tc.items}))
+
+			keys := []string{}
+			for _, item := range room.NPCs[0].Inventory {
+				assert.NotEmpty(t, item.ID)
+				keys = append(keys, item.Key)
+			}
+			assert.Equal(t, tc.expected, keys)
+		})
+	}
+
+	t.Run("Items are copies", func(t *testing.T) {
+		service, err := NewRoomService()
+		require.NoError(t, err)
+		room := createTestRoom()
+		npc := createTestNPCConfig("Guard", 7, 1, false, &entities.Position{X: 1, Y: 1}, nil)
+		require.NoError(t, service.AddPlaceablesToRoom(room, []PlaceableConfig{npc}))
+
+		require.NoError(t, service.AutoPopulateNPCInventory(room, room.NPCs[0].ID, &repositories.TestItemRepository{Items: []*entities.Item{weapon}}))
+		room.NPCs[0].Inventory[0].Properties[0] = "heavy"
+		assert.Equal(t, []string{"versatile"}, weapon.Properties)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		service, err := NewRoomService()
+		require.NoError(t, err)
+		repo := &repositories.TestItemRepository{}
+		assert.ErrorIs(t, service.AutoPopulateNPCInventory(nil, "npc", repo), entities.ErrNilRoom)
+		assert.Error(t, service.AutoPopulateNPCInventory(createTestRoom(), "missing", repo))
+		assert.Error(t, service.AutoPopulateNPCInventory(createTestRoom(), "missing", nil))
+	})
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// Keys and names used for the entities of string templates
+const (
+	TemplateOpenObstacleKey = "rubble"
+	TemplatePlayerName      = "Player"
+	TemplateItemKey         = "item"
+	TemplateNPCName         = "NPC"
+)
+
+// ParseRoomTemplate turns a room drawn as a multi-line string into a room config and placeable configs
+// Lines starting with '+' (e.g. "+---+") are borders and are skipped, as are blank lines. Tabs indenting a
+// line are ignored, and a row drawn between '|' borders is read from just inside them. Every other character
+// is a cell: '.' is empty, ' ' is room boundary with nothing placed on it, '#' a blocking obstacle,
+// 'o' a non-blocking obstacle, 'M' the service's default monster (see WithDefaultMonsterKey),
+// 'P' a level 1 player, 'I' an item, and 'N' an NPC. The room is gridded and brightly lit
+func (s *RoomService) ParseRoomTemplate(template string) (RoomConfig, []PlaceableConfig, error) {
+	rows := []string{}
+	for _, line := range strings.Split(template, "\n") {
+		row := strings.TrimRight(strings.TrimLeft(line, "\t"), "\t\r")
+		trimmed := strings.TrimSpace(row)
+		if trimmed == "" || strings.HasPrefix(trimmed, "+") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "|") {
+			row = strings.TrimSuffix(strings.TrimPrefix(trimmed, "|"), "|")
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return RoomConfig{}, nil, fmt.Errorf("room template cannot be empty")
+	}
+
+	width := len(rows[0])
+	configs := []PlaceableConfig{}
+	for y, row := range rows {
+		if len(row) != width {
+			return RoomConfig{}, nil, fmt.Errorf("template row %d has width %d, expected %d", y, len(row), width)
+		}
+
+		for x, r := range row {
+			pos := &entities.Position{X: x, Y: y}
+			switch r {
+			case '.', ' ':
+			case '#':
+				configs = append(configs, ObstacleConfig{Name: nameFromKey(ByteMapObstacleKey), Key: ByteMapObstacleKey, Blocking: true, Position: pos})
+			case 'o':
+				configs = append(configs, ObstacleConfig{Name: nameFromKey(TemplateOpenObstacleKey), Key: TemplateOpenObstacleKey, Position: pos})
+			case 'M':
+				if s.defaultMonsterKey == "" {
+					return RoomConfig{}, nil, fmt.Errorf("template has monster cells but no default monster key is configured")
+				}
+				configs = append(configs, MonsterConfig{Key: s.defaultMonsterKey, Position: pos})
+			case 'P':
+				configs = append(configs, PlayerConfig{Name: TemplatePlayerName, Level: 1, Position: pos})
+			case 'I':
+				configs = append(configs, ItemConfig{Name: nameFromKey(TemplateItemKey), Key: TemplateItemKey, Position: pos})
+			case 'N':
+				configs = append(configs, NPCConfig{Name: TemplateNPCName, Position: pos})
+			default:
+				return RoomConfig{}, nil, fmt.Errorf("unknown template character %q at (%d,%d)", r, x, y)
+			}
+		}
+	}
+
+	config := RoomConfig{
+		Width:      width,
+		Height:     len(rows),
+		LightLevel: entities.LightLevelBright,
+		UseGrid:    true,
+	}
+	return config, configs, nil
+}
+
+// ApplyRoomTemplate parses the template (see ParseRoomTemplate) and places its entities in the room
+// The template must fit within the room; its top-left cell is placed at the room's origin
+func (s *RoomService) ApplyRoomTemplate(room *entities.Room, template string) error {
+	if room == nil {
+		return entities.ErrNilRoom
+	}
+
+	config, configs, err := s.ParseRoomTemplate(template)
+	if err != nil {
+		return err
+	}
+	if config.Width > room.Width || config.Height > room.Height {
+		return fmt.Errorf("template of %dx%d does not fit in room of %dx%d", config.Width, config.Height, room.Width, room.Height)
+	}
+	if len(configs) == 0 {
+		return nil
+	}
+
+	return s.AddPlaceablesToRoom(room, configs)
+}
+
+// CreateRoomFromStringTemplate generates a new room sized to the template and applies the template to it
+func (s *RoomService) CreateRoomFromStringTemplate(template string) (*entities.Room, error) {
+	config, _, err := s.ParseRoomTemplate(template)
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := s.GenerateRoom(config)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ApplyRoomTemplate(room, template); err != nil {
+		return nil, err
+	}
+
+	return room, nil
+}
+
+// RoomTemplate is a reusable room layout, such as a guard barracks or a throne room, stored as JSON
+type RoomTemplate struct {
+	Name           string
+	Description    string
+	Width          int
+	Height         int
+	ObstacleLayout []ObstacleConfig // Obstacles placed in every room made from the template
+	LightLevel     entities.LightLevel
+	Tags           map[string]string
+}
+
+// LoadRoomTemplate reads a JSON room template
+// The template is given the name if it does not name itself
+func LoadRoomTemplate(name string, r io.Reader) (*RoomTemplate, error) {
+	var tmpl RoomTemplate
+	if err := json.NewDecoder(r).Decode(&tmpl); err != nil {
+		return nil, fmt.Errorf("failed to decode room template %s: %w", name, err)
+	}
+	if tmpl.Name == "" {
+		tmpl.Name = name
+	}
+	return &tmpl, nil
+}
+
+// SaveRoomTemplate writes the template as indented JSON
+func SaveRoomTemplate(tmpl *RoomTemplate, w io.Writer) error {
+	if tmpl == nil {
+		return fmt.Errorf("room template cannot be nil")
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(tmpl); err != nil {
+		return fmt.Errorf("failed to encode room template %s: %w", tmpl.Name, err)
+	}
+	return nil
+}
+
+// GenerateRoomFromTemplate generates a gridded room with the template's size, light, and tags and places its
+// obstacle layout. Each obstacle config places Count obstacles, or one if Count is zero
+func (s *RoomService) GenerateRoomFromTemplate(tmpl *RoomTemplate) (*entities.Room, error) {
+	if tmpl == nil {
+		return nil, fmt.Errorf("room template cannot be nil")
+	}
+
+	room, err := s.GenerateRoom(RoomConfig{
+		Width:       tmpl.Width,
+		Height:      tmpl.Height,
+		LightLevel:  tmpl.LightLevel,
+		Description: tmpl.Description,
+		Tags:        tmpl.Tags,
+		UseGrid:     true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate room from template %s: %w", tmpl.Name, err)
+	}
+
+	configs := []PlaceableConfig{}
+	for _, config := range tmpl.ObstacleLayout {
+		for i := 0; i < max(config.Count, 1); i++ {
+			configs = append(configs, config)
+		}
+	}
+	if len(configs) == 0 {
+		return room, nil
+	}
+
+	if err := s.AddPlaceablesToRoom(room, configs); err != nil {
+		return nil, fmt.Errorf("failed to place obstacles from template %s: %w", tmpl.Name, err)
+	}
+	return room, nil
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+const testRoomTemplate = `
+	+-----+
+	|#.M.o|
+	|.P.I.|
+	|N..##|
+	+-----+
+`
+
+func TestParseRoomTemplate(t *testing.T) {
+	service, err := NewRoomService(WithDefaultMonsterKey("goblin"))
+	require.NoError(t, err)
+
+	config, configs, err := service.ParseRoomTemplate(testRoomTemplate)
+	require.NoError(t, err)
+	assert.Equal(t, 5, config.Width)
+	assert.Equal(t, 3, config.Height)
+	assert.True(t, config.UseGrid)
+	require.Len(t, configs, 8)
+
+	blocking, open := []entities.Position{}, []entities.Position{}
+	for _, c := range configs {
+		if obstacle, ok := c.(ObstacleConfig); ok {
+			if obstacle.Blocking {
+				blocking = append(blocking, *obstacle.Position)
+			} else {
+				open = append(open, *obstacle.Position)
+			}
+		}
+	}
+	assert.ElementsMatch(t, []entities.Position{{X: 0, Y: 0}, {X: 3, Y: 2}, {X: 4, Y: 2}}, blocking)
+	assert.Equal(t, []entities.Position{{X: 4, Y: 0}}, open)
+
+	// Borders are optional
+	config, _, err = service.ParseRoomTemplate("...\n.M.")
+	require.NoError(t, err)
+	assert.Equal(t, 3, config.Width)
+	assert.Equal(t, 2, config.Height)
+}
+
+func TestParseRoomTemplateBoundaryCells(t *testing.T) {
+	service, err := NewRoomService(WithDefaultMonsterKey("goblin"))
+	require.NoError(t, err)
+
+	// Spaces are cells, so the entities after them keep their columns
+	config, configs, err := service.ParseRoomTemplate(`
+	+-----+
+	|  .M.|
+	|P   #|
+	+-----+
+`)
+	require.NoError(t, err)
+	assert.Equal(t, 5, config.Width)
+	assert.Equal(t, 2, config.Height)
+	require.Len(t, configs, 3)
+	assert.Equal(t, entities.Position{X: 3, Y: 0}, *configs[0].(MonsterConfig).Position)
+	assert.Equal(t, entities.Position{X: 0, Y: 1}, *configs[1].(PlayerConfig).Position)
+	assert.Equal(t, entities.Position{X: 4, Y: 1}, *configs[2].(ObstacleConfig).Position)
+
+	// Rows without borders keep their spaces too
+	config, configs, err = service.ParseRoomTemplate(" M\n..")
+	require.NoError(t, err)
+	assert.Equal(t, 2, config.Width)
+	require.Len(t, configs, 1)
+	assert.Equal(t, entities.Position{X: 1, Y: 0}, *configs[0].(MonsterConfig).Position)
+}
+
+func TestParseRoomTemplateErrors(t *testing.T) {
+	service, err := NewRoomService()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		template string
+	}{
+		{"empty", "+--+\n+--+"},
+		{"ragged rows", "...\n.."},
+		{"unknown character", ".X."},
+		{"monster without default key", ".M."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := service.ParseRoomTemplate(tc.template)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestApplyRoomTemplate(t *testing.T) {
+	service, err := NewRoomService(WithDefaultMonsterKey("goblin"))
+	require.NoError(t, err)
+
+	room, err := service.CreateRoomFromStringTemplate(testRoomTemplate)
+	require.NoError(t, err)
+	assert.Equal(t, 5, room.Width)
+	assert.Equal(t, 3, room.Height)
+	assert.Len(t, room.Monsters, 1)
+	assert.Len(t, room.Players, 1)
+	assert.Len(t, room.Items, 1)
+	assert.Len(t, room.NPCs, 1)
+	assert.Len(t, room.Obstacles, 4)
+	assert.Equal(t, entities.CellMonster, room.Grid[0][2].Type)
+	assert.Equal(t, "goblin", room.Monsters[0].Key)
+
+	// Templates may be smaller than the room but not larger
+	room = createTestRoom()
+	require.NoError(t, service.ApplyRoomTemplate(room, "P.\n.#"))
+	assert.Equal(t, entities.CellPlayer, room.Grid[0][0].Type)
+	assert.Equal(t, entities.CellObstacle, room.Grid[1][1].Type)
+	assert.Error(t, service.ApplyRoomTemplate(room, "......"))
+	assert.ErrorIs(t, service.ApplyRoomTemplate(nil, "."), entities.ErrNilRoom)
+}
+
+func TestGenerateRoomFromTemplateFiles(t *testing.T) {
+	testCases := []struct {
+		file          string
+		name          string
+		width, height int
+		obstacles     int
+	}{
+		{"guard_barracks.json", "Guard Barracks", 10, 8, 6},
+		{"throne_room.json", "Throne Room", 12, 16, 9},
+		{"library.json", "Library", 10, 10, 4},
+	}
+
+	service, err := NewRoomService()
+	require.NoError(t, err)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := os.Open(filepath.Join("..", "..", "templates", tc.file))
+			require.NoError(t, err)
+			defer f.Close()
+
+			tmpl, err := LoadRoomTemplate(tc.file, f)
+			require.NoError(t, err)
+			assert.Equal(t, tc.name, tmpl.Name)
+
+			room, err := service.GenerateRoomFromTemplate(tmpl)
+			require.NoError(t, err)
+			assert.Equal(t, tc.width, room.Width)
+			assert.Equal(t, tc.height, room.Height)
+			assert.Equal(t, tmpl.LightLevel, room.LightLevel)
+			assert.Equal(t, tmpl.Tags, room.Tags)
+			assert.Len(t, room.Obstacles, tc.obstacles)
+			assert.Empty(t, ValidateRoom(room))
+		})
+	}
+}
+
+func TestSaveRoomTemplate(t *testing.T) {
+	tmpl := &RoomTemplate{
+		Width:      4,
+		Height:     3,
+		LightLevel: entities.LightLevelDark,
+		ObstacleLayout: []ObstacleConfig{
+			{Name: "Crate", Key: "crate", Blocking: true, Count: 2, RandomPlace: true},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, SaveRoomTemplate(tmpl, &buf))
+	loaded, err := LoadRoomTemplate("storeroom", &buf)
+	require.NoError(t, err)
+	assert.Equal(t, "storeroom", loaded.Name, "unnamed templates take the loaded name")
+	loaded.Name = ""
+	assert.Equal(t, tmpl, loaded)
+
+	service, err := NewRoomService()
+	require.NoError(t, err)
+	room, err := service.GenerateRoomFromTemplate(loaded)
+	require.NoError(t, err)
+	assert.Len(t, room.Obstacles, 2)
+
+	assert.Error(t, SaveRoomTemplate(nil, &buf))
+	_, err = LoadRoomTemplate("broken", strings.NewReader("{"))
+	assert.Error(t, err)
+	_, err = service.GenerateRoomFromTemplate(nil)
+	assert.Error(t, err)
+	_, err = service.GenerateRoomFromTemplate(&RoomTemplate{Name: "empty"})
+	assert.Error(t, err, "templates need a size")
+}
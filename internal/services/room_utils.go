@@ -1,24 +1,24 @@
 package services
 
 import (
+	"container/heap"
+	"errors"
 	"fmt"
 	"math"
 
 	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
 )
 
-// NewRoom creates a new room with the specified dimensions
-func NewRoom(width, height int, lightLevel entities.LightLevel) *entities.Room {
-	room := &entities.Room{
-		Width:      width,
-		Height:     height,
-		LightLevel: lightLevel,
-		Monsters:   make([]entities.Monster, 0),
-		Players:    make([]entities.Player, 0),
-		Items:      make([]entities.Item, 0),
-	}
+var (
+	ErrNoPath = errors.New("no traversable path between positions")
+
+	// ErrEntityNotFound is returned when no entity in the room has the requested ID
+	ErrEntityNotFound = errors.New("entity not found in room")
+)
 
-	return room
+// NewRoom creates a new room with the specified dimensions and a unique ID
+func NewRoom(width, height int, lightLevel entities.LightLevel) *entities.Room {
+	return entities.NewRoom(width, height, lightLevel)
 }
 
 // InitializeGrid creates and initializes the grid for a room
@@ -35,6 +35,7 @@ func InitializeGrid(room *entities.Room) {
 			room.Grid[i][j] = entities.Cell{Type: entities.CellTypeEmpty}
 		}
 	}
+	room.VisibilityGrid = newVisibilityGrid(room.Width, room.Height)
 }
 
 // MovePlaceable moves any placeable entity from its current position to a new position
@@ -55,115 +56,58 @@ func MovePlaceable(room *entities.Room, entity entities.Placeable, newPosition e
 
 	entityID := entity.GetID()
 	cellType := entity.GetCellType()
-	oldPosition := entity.GetPosition()
-
-	// If room has no grid, just update the entity's position in the appropriate slice
-	if room.Grid == nil {
-		// Find and update the entity in the appropriate slice
-		switch cellType {
-		case entities.CellMonster:
-			for i := range room.Monsters {
-				if room.Monsters[i].ID == entityID {
-					room.Monsters[i].Position = newPosition
-					// Also update the passed entity
-					entity.SetPosition(newPosition)
-					return nil
-				}
-			}
-		case entities.CellPlayer:
-			for i := range room.Players {
-				if room.Players[i].ID == entityID {
-					room.Players[i].Position = newPosition
-					// Also update the passed entity
-					entity.SetPosition(newPosition)
-					return nil
-				}
-			}
-		case entities.CellItem:
-			for i := range room.Items {
-				if room.Items[i].ID == entityID {
-					room.Items[i].Position = newPosition
-					// Also update the passed entity
-					entity.SetPosition(newPosition)
-					return nil
-				}
-			}
-		case entities.CellNPC:
-			for i := range room.NPCs {
-				if room.NPCs[i].ID == entityID {
-					room.NPCs[i].Position = newPosition
-					// Also update the passed entity
-					entity.SetPosition(newPosition)
-					return nil
-				}
-			}
-		}
-		return fmt.Errorf("entity with ID %s not found in room", entityID)
-	}
-
-	// For rooms with a grid, validate the new position
 
-	// Check if new position is within bounds
-	if newPosition.X < 0 || newPosition.X >= room.Width ||
-		newPosition.Y < 0 || newPosition.Y >= room.Height {
-		return fmt.Errorf("new position (%d, %d) is outside room bounds (%d, %d)",
-			newPosition.X, newPosition.Y, room.Width, room.Height)
+	// Find the room's copy of the entity
+	stored, err := findEntityOfType(room, entityID, cellType)
+	if err != nil {
+		return err
 	}
 
-	// Check if target cell is empty or is the entity's current position
-	if room.Grid[newPosition.Y][newPosition.X].Type != entities.CellTypeEmpty &&
-		(newPosition.X != oldPosition.X || newPosition.Y != oldPosition.Y) {
-		return fmt.Errorf("cell (%d, %d) is already occupied", newPosition.X, newPosition.Y)
+	// If room has no grid, just update the entity's position
+	oldPosition := stored.GetPosition()
+	if room.Grid == nil {
+		stored.SetPosition(newPosition)
+		// Also update the passed entity
+		entity.SetPosition(newPosition)
+		recordRoomEvent(room, entities.RoomEventMoved, entityID, cellType, &oldPosition, &newPosition)
+		return nil
 	}
 
-	// Find and update the entity in the appropriate slice
-	entityFound := false
-	switch cellType {
-	case entities.CellMonster:
-		for i := range room.Monsters {
-			if room.Monsters[i].ID == entityID {
-				room.Monsters[i].Position = newPosition
-				entityFound = true
-				break
-			}
+	// For rooms with a grid, validate every cell the entity would cover at the new position
+	// Cells the entity already occupies count as empty, so it may move onto or overlap its own cells
+	oldCells := occupiedCells(stored)
+	for _, cell := range oldCells {
+		pos := cell.Sub(oldPosition).Add(newPosition)
+		if !IsPositionValid(room, pos) {
+			return fmt.Errorf("new position (%d, %d) is outside room bounds (%d, %d): %w",
+				pos.X, pos.Y, room.Width, room.Height, entities.ErrInvalidPosition)
 		}
-	case entities.CellPlayer:
-		for i := range room.Players {
-			if room.Players[i].ID == entityID {
-				room.Players[i].Position = newPosition
-				entityFound = true
-				break
-			}
-		}
-	case entities.CellItem:
-		for i := range room.Items {
-			if room.Items[i].ID == entityID {
-				room.Items[i].Position = newPosition
-				entityFound = true
-				break
-			}
+		if current := room.Grid[pos.Y][pos.X]; current.Type != entities.CellTypeEmpty &&
+			(current.EntityID != entityID || current.Type != cellType) {
+			return fmt.Errorf("cell (%d, %d) is already occupied: %w", pos.X, pos.Y, entities.ErrCellOccupied)
 		}
 	}
 
-	if !entityFound {
-		return fmt.Errorf("entity with ID %s not found in room", entityID)
-	}
-
 	// Update the grid
-	// Clear old position
-	if oldPosition.X >= 0 && oldPosition.X < room.Width &&
-		oldPosition.Y >= 0 && oldPosition.Y < room.Height {
-		room.Grid[oldPosition.Y][oldPosition.X] = entities.Cell{Type: entities.CellTypeEmpty}
+	// Clear old cells
+	for _, pos := range oldCells {
+		if IsPositionValid(room, pos) {
+			room.Grid[pos.Y][pos.X] = entities.Cell{Type: entities.CellTypeEmpty}
+		}
 	}
 
-	// Set new position
-	room.Grid[newPosition.Y][newPosition.X] = entities.Cell{
-		Type:     cellType,
-		EntityID: entityID,
+	// Set new cells
+	stored.SetPosition(newPosition)
+	for _, pos := range occupiedCells(stored) {
+		room.Grid[pos.Y][pos.X] = entities.Cell{
+			Type:     cellType,
+			EntityID: entityID,
+		}
 	}
 
 	// Also update the passed entity
 	entity.SetPosition(newPosition)
+	recordRoomEvent(room, entities.RoomEventMoved, entityID, cellType, &oldPosition, &newPosition)
 
 	return nil
 }
@@ -179,6 +123,12 @@ func CalculateDistance(pos1, pos2 entities.Position) float64 {
 	return math.Max(math.Abs(dx), math.Abs(dy))
 }
 
+// CalculateDistanceFt returns the CalculateDistance between two positions in feet
+// Each cell counts as the room's CellSizeFt, or entities.MovementStepFt if the room has none or is nil
+func CalculateDistanceFt(room *entities.Room, pos1, pos2 entities.Position) float64 {
+	return CalculateDistance(pos1, pos2) * float64(roomCellSizeFt(room))
+}
+
 // RemovePlaceable removes any placeable entity from the room
 // Returns true if the entity was found and removed, false otherwise
 func RemovePlaceable(room *entities.Room, entity entities.Placeable) (bool, error) {
@@ -192,18 +142,794 @@ func RemovePlaceable(room *entities.Room, entity entities.Placeable) (bool, erro
 	return removeEntity(room, entity.GetID(), entity.GetCellType()), nil
 }
 
+// FindMonsterByID finds a monster in the room by ID
+// Returns a pointer to the monster and its index, or nil and -1 if not found
+func FindMonsterByID(room *entities.Room, id string) (*entities.Monster, int) {
+	if room == nil {
+		return nil, -1
+	}
+
+	for i := range room.Monsters {
+		if room.Monsters[i].ID == id {
+			return &room.Monsters[i], i
+		}
+	}
+
+	return nil, -1
+}
+
+// FindPlayerByID finds a player in the room by ID
+// Returns a pointer to the player and its index, or nil and -1 if not found
+func FindPlayerByID(room *entities.Room, id string) (*entities.Player, int) {
+	if room == nil {
+		return nil, -1
+	}
+
+	for i := range room.Players {
+		if room.Players[i].ID == id {
+			return &room.Players[i], i
+		}
+	}
+
+	return nil, -1
+}
+
+// FindItemByID finds an item lying in the room by ID
+// Returns a pointer to the item and its index, or nil and -1 if not found
+func FindItemByID(room *entities.Room, id string) (*entities.Item, int) {
+	if room == nil {
+		return nil, -1
+	}
+
+	for i := range room.Items {
+		if room.Items[i].ID == id {
+			return &room.Items[i], i
+		}
+	}
+
+	return nil, -1
+}
+
 // FindNPCByID finds an NPC in the room by ID
 // Returns a pointer to the NPC and its index, or nil and -1 if not found
-func FindNPCByID(room *entities.Room, npcID string) (*entities.NPC, int) {
+func FindNPCByID(room *entities.Room, id string) (*entities.NPC, int) {
 	if room == nil {
 		return nil, -1
 	}
 
 	for i := range room.NPCs {
-		if room.NPCs[i].ID == npcID {
+		if room.NPCs[i].ID == id {
 			return &room.NPCs[i], i
 		}
 	}
 
 	return nil, -1
 }
+
+// FindObstacleByID finds an obstacle in the room by ID
+// Returns a pointer to the obstacle and its index, or nil and -1 if not found
+func FindObstacleByID(room *entities.Room, id string) (*entities.Obstacle, int) {
+	if room == nil {
+		return nil, -1
+	}
+
+	for i := range room.Obstacles {
+		if room.Obstacles[i].ID == id {
+			return &room.Obstacles[i], i
+		}
+	}
+
+	return nil, -1
+}
+
+// FindTrapByID finds a trap in the room by ID
+// Returns a pointer to the trap and its index, or nil and -1 if not found
+func FindTrapByID(room *entities.Room, id string) (*entities.Trap, int) {
+	if room == nil {
+		return nil, -1
+	}
+
+	for i := range room.Traps {
+		if room.Traps[i].ID == id {
+			return &room.Traps[i], i
+		}
+	}
+
+	return nil, -1
+}
+
+// FindDoorByID finds a door in the room by ID
+// Returns a pointer to the door and its index, or nil and -1 if not found
+func FindDoorByID(room *entities.Room, id string) (*entities.Door, int) {
+	if room == nil {
+		return nil, -1
+	}
+
+	for i := range room.Doors {
+		if room.Doors[i].ID == id {
+			return &room.Doors[i], i
+		}
+	}
+
+	return nil, -1
+}
+
+// FindSpellZoneByID finds a spell zone in the room by ID
+// Returns a pointer to the zone and its index, or nil and -1 if not found
+func FindSpellZoneByID(room *entities.Room, id string) (*entities.SpellZone, int) {
+	if room == nil {
+		return nil, -1
+	}
+
+	for i := range room.SpellZones {
+		if room.SpellZones[i].ID == id {
+			return &room.SpellZones[i], i
+		}
+	}
+
+	return nil, -1
+}
+
+// Clone returns a deep copy of the room, including its ID, that shares no slices, maps, pointers, or grid rows with it
+// Returns nil for a nil room
+func Clone(room *entities.Room) *entities.Room {
+	if room == nil {
+		return nil
+	}
+	return copyRoom(room)
+}
+
+// FilterRoomsByTag returns the rooms whose tag key has the given value, in their original order
+// Nil rooms are skipped
+func FilterRoomsByTag(rooms []*entities.Room, key, value string) []*entities.Room {
+	matches := []*entities.Room{}
+	for _, room := range rooms {
+		if room == nil {
+			continue
+		}
+		if tag, ok := room.GetTag(key); ok && tag == value {
+			matches = append(matches, room)
+		}
+	}
+	return matches
+}
+
+// FindEntityByID searches the room's monsters, players, items, NPCs, obstacles, traps, and doors, in that order,
+// and returns a pointer to the first entity with the given ID. Returns ErrEntityNotFound if there is none
+func FindEntityByID(room *entities.Room, id string) (entities.Placeable, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+
+	for _, p := range collectPlaceables(room) {
+		if p.GetID() == id {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("entity with ID %s: %w", id, ErrEntityNotFound)
+}
+
+// findEntityOfType returns a pointer to the entity with the given ID if it has the given cell type
+// Returns ErrEntityNotFound if there is no such entity
+func findEntityOfType(room *entities.Room, id string, cellType entities.CellType) (entities.Placeable, error) {
+	entity, err := FindEntityByID(room, id)
+	if err != nil {
+		return nil, err
+	}
+	if entity.GetCellType() != cellType {
+		return nil, fmt.Errorf("entity with ID %s of type %d: %w", id, cellType, ErrEntityNotFound)
+	}
+	return entity, nil
+}
+
+// collectPlaceables returns pointers to every entity in the room as Placeables
+// The returned pointers reference the room's slices, so they must not be held across removals
+func collectPlaceables(room *entities.Room) []entities.Placeable {
+	if room == nil {
+		return nil
+	}
+
+	placeables := make([]entities.Placeable, 0,
+		len(room.Monsters)+len(room.Players)+len(room.Items)+len(room.NPCs)+len(room.Obstacles)+len(room.Traps)+len(room.Doors))
+	for i := range room.Monsters {
+		placeables = append(placeables, &room.Monsters[i])
+	}
+	for i := range room.Players {
+		placeables = append(placeables, &room.Players[i])
+	}
+	for i := range room.Items {
+		placeables = append(placeables, &room.Items[i])
+	}
+	for i := range room.NPCs {
+		placeables = append(placeables, &room.NPCs[i])
+	}
+	for i := range room.Obstacles {
+		placeables = append(placeables, &room.Obstacles[i])
+	}
+	for i := range room.Traps {
+		placeables = append(placeables, &room.Traps[i])
+	}
+	for i := range room.Doors {
+		placeables = append(placeables, &room.Doors[i])
+	}
+
+	return placeables
+}
+
+// findPlaceable returns a pointer to the entity with the given ID and cell type, or nil if not found
+func findPlaceable(room *entities.Room, entityID string, cellType entities.CellType) entities.Placeable {
+	for _, p := range collectPlaceables(room) {
+		if p.GetID() == entityID && p.GetCellType() == cellType {
+			return p
+		}
+	}
+	return nil
+}
+
+// Codes reported by ValidateRoom
+const (
+	ValidationNilRoom      = "nil_room"      // The room is nil
+	ValidationGridSize     = "grid_size"     // The grid is not fully initialized for the room's dimensions
+	ValidationOutOfBounds  = "out_of_bounds" // An entity lies outside the room
+	ValidationDuplicateID  = "duplicate_id"  // Two entities share an ID, whatever their types
+	ValidationGridMismatch = "grid_mismatch" // A grid cell and the entity slices disagree
+)
+
+// ValidationError describes one problem found by ValidateRoom
+type ValidationError struct {
+	Code     string // One of the Validation constants
+	Message  string // Human-readable description of the problem
+	EntityID string // ID of the entity involved, or empty if the problem is not tied to one
+}
+
+// Error implements error so a ValidationError can be returned or wrapped directly
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// ValidateRoom checks a room for states that placement would never produce
+// Entities must lie in bounds and have unique IDs across all types. Gridded rooms must have a grid matching
+// their dimensions whose cells agree with the entity slices, as checked by ValidateRoomGrid.
+// Returns nil if the room is valid
+func ValidateRoom(room *entities.Room) []ValidationError {
+	if room == nil {
+		return []ValidationError{{Code: ValidationNilRoom, Message: entities.ErrNilRoom.Error()}}
+	}
+
+	var errs []ValidationError
+	gridOK := true
+	if room.Grid != nil {
+		if err := validateGridSize(room); err != nil {
+			gridOK = false
+			errs = append(errs, ValidationError{Code: ValidationGridSize, Message: err.Error()})
+		}
+	}
+
+	seen := map[string]bool{}
+	claims := map[cellClaim]bool{}
+	for _, p := range collectPlaceables(room) {
+		id := p.GetID()
+		if seen[id] {
+			errs = append(errs, ValidationError{Code: ValidationDuplicateID,
+				Message: fmt.Sprintf("ID %s is used by more than one entity", id), EntityID: id})
+		}
+		seen[id] = true
+
+		cells := occupiedCells(p)
+		if !allPositionsValid(room, cells) {
+			errs = append(errs, ValidationError{Code: ValidationOutOfBounds,
+				Message: fmt.Sprintf("entity %s is outside room bounds at %s", id, p.GetPosition()), EntityID: id})
+			continue
+		}
+		if room.Grid == nil || !gridOK {
+			continue
+		}
+
+		key := entityKey{p.GetCellType(), id}
+		for _, pos := range cells {
+			claims[cellClaim{key, pos}] = true
+			if cell := room.Grid[pos.Y][pos.X]; cell.EntityID != id || cell.Type != p.GetCellType() {
+				errs = append(errs, ValidationError{Code: ValidationGridMismatch,
+					Message: fmt.Sprintf("entity %s at %s is not referenced by its grid cell", id, pos), EntityID: id})
+			}
+		}
+	}
+
+	if room.Grid == nil || !gridOK {
+		return errs
+	}
+	for y := range room.Grid {
+		for x, cell := range room.Grid[y] {
+			if cell.Type == entities.CellTypeEmpty || cell.Type == entities.CellWall {
+				continue
+			}
+			if !claims[cellClaim{entityKey{cell.Type, cell.EntityID}, entities.Position{X: x, Y: y}}] {
+				errs = append(errs, ValidationError{Code: ValidationGridMismatch,
+					Message: fmt.Sprintf("grid cell (%d,%d) references entity %s, which is not there", x, y, cell.EntityID), EntityID: cell.EntityID})
+			}
+		}
+	}
+
+	return errs
+}
+
+// DistanceMetric selects how distances between grid positions are measured
+type DistanceMetric string
+
+const (
+	// DistanceChebyshev counts diagonal steps the same as orthogonal ones (standard D&D 5e)
+	DistanceChebyshev DistanceMetric = "chebyshev"
+
+	// DistanceEuclidean measures straight-line distance
+	DistanceEuclidean DistanceMetric = "euclidean"
+
+	// DistanceManhattan sums the horizontal and vertical offsets
+	DistanceManhattan DistanceMetric = "manhattan"
+)
+
+// isValidDistanceMetric reports whether metric is one of the supported metrics
+func isValidDistanceMetric(metric DistanceMetric) bool {
+	switch metric {
+	case DistanceChebyshev, DistanceEuclidean, DistanceManhattan:
+		return true
+	}
+	return false
+}
+
+// DistanceBetween calculates the distance between two positions in grid units using the given metric
+// Unknown metrics fall back to Chebyshev distance to match CalculateDistance
+func DistanceBetween(pos1, pos2 entities.Position, metric DistanceMetric) float64 {
+	dx := math.Abs(float64(pos2.X - pos1.X))
+	dy := math.Abs(float64(pos2.Y - pos1.Y))
+
+	switch metric {
+	case DistanceEuclidean:
+		return math.Sqrt(dx*dx + dy*dy)
+	case DistanceManhattan:
+		return dx + dy
+	default:
+		return math.Max(dx, dy)
+	}
+}
+
+// GetCellsInRadius returns every in-bounds position within radius of center, excluding center itself
+func GetCellsInRadius(room *entities.Room, center entities.Position, radius float64, metric DistanceMetric) ([]entities.Position, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+	if !isValidDistanceMetric(metric) {
+		return nil, fmt.Errorf("unknown distance metric: %s", metric)
+	}
+
+	reach := int(math.Ceil(radius))
+	positions := []entities.Position{}
+	for y := center.Y - reach; y <= center.Y+reach; y++ {
+		for x := center.X - reach; x <= center.X+reach; x++ {
+			pos := entities.Position{X: x, Y: y}
+			if pos == center || x < 0 || x >= room.Width || y < 0 || y >= room.Height {
+				continue
+			}
+			if DistanceBetween(center, pos, metric) <= radius {
+				positions = append(positions, pos)
+			}
+		}
+	}
+
+	return positions, nil
+}
+
+// GetEntitiesNear returns all entities within radiusSquares of center using the given metric
+// Entities at exactly radiusSquares are included
+func (s *RoomService) GetEntitiesNear(room *entities.Room, center entities.Position, radiusSquares float64, metric DistanceMetric) ([]entities.Placeable, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+	if !isValidDistanceMetric(metric) {
+		return nil, fmt.Errorf("unknown distance metric: %s", metric)
+	}
+
+	return entitiesNear(room, center, radiusSquares, metric), nil
+}
+
+// GetEntitiesInRange returns every entity within rangeUnits of center, measured with CalculateDistance
+// It is GetEntitiesNear with DistanceChebyshev. Entities at exactly rangeUnits are included. Returns nil for a nil room
+func GetEntitiesInRange(room *entities.Room, center entities.Position, rangeUnits float64) []entities.Placeable {
+	if room == nil {
+		return nil
+	}
+	return entitiesNear(room, center, rangeUnits, DistanceChebyshev)
+}
+
+// entitiesNear returns all entities within radiusSquares of center using the given metric
+func entitiesNear(room *entities.Room, center entities.Position, radiusSquares float64, metric DistanceMetric) []entities.Placeable {
+	nearby := []entities.Placeable{}
+	for _, p := range collectPlaceables(room) {
+		if DistanceBetween(center, p.GetPosition(), metric) <= radiusSquares {
+			nearby = append(nearby, p)
+		}
+	}
+	return nearby
+}
+
+// GetMonstersInRange returns pointers to the monsters within rangeUnits of center (see GetEntitiesInRange)
+func GetMonstersInRange(room *entities.Room, center entities.Position, rangeUnits float64) []*entities.Monster {
+	if room == nil {
+		return nil
+	}
+
+	monsters := []*entities.Monster{}
+	for i := range room.Monsters {
+		if CalculateDistance(center, room.Monsters[i].Position) <= rangeUnits {
+			monsters = append(monsters, &room.Monsters[i])
+		}
+	}
+	return monsters
+}
+
+// GetPlayersInRange returns pointers to the players within rangeUnits of center (see GetEntitiesInRange)
+func GetPlayersInRange(room *entities.Room, center entities.Position, rangeUnits float64) []*entities.Player {
+	if room == nil {
+		return nil
+	}
+
+	players := []*entities.Player{}
+	for i := range room.Players {
+		if CalculateDistance(center, room.Players[i].Position) <= rangeUnits {
+			players = append(players, &room.Players[i])
+		}
+	}
+	return players
+}
+
+// GetNPCsInRange returns pointers to the NPCs within rangeUnits of center (see GetEntitiesInRange)
+func GetNPCsInRange(room *entities.Room, center entities.Position, rangeUnits float64) []*entities.NPC {
+	if room == nil {
+		return nil
+	}
+
+	npcs := []*entities.NPC{}
+	for i := range room.NPCs {
+		if CalculateDistance(center, room.NPCs[i].Position) <= rangeUnits {
+			npcs = append(npcs, &room.NPCs[i])
+		}
+	}
+	return npcs
+}
+
+// CanReachInOneTurn reports whether the target is within the entity's walking speed
+// The distance from CalculateDistance is converted to feet with the room's cell size and compared against
+// the entity's Speed (DefaultMonsterSpeedFt if unset). Obstacles and difficult terrain are ignored.
+// Only monsters, players, and NPCs can move, so other entities never reach
+func CanReachInOneTurn(room *entities.Room, entity entities.Placeable, target entities.Placeable) bool {
+	return canReachWithin(room, entity, target, 1)
+}
+
+// CanReachWithDash reports whether the target is within twice the entity's walking speed, as when the
+// entity takes the Dash action. See CanReachInOneTurn
+func CanReachWithDash(room *entities.Room, entity entities.Placeable, target entities.Placeable) bool {
+	return canReachWithin(room, entity, target, 2)
+}
+
+// canReachWithin reports whether the target is within multiplier times the entity's walking speed
+func canReachWithin(room *entities.Room, entity entities.Placeable, target entities.Placeable, multiplier int) bool {
+	if room == nil || entity == nil || target == nil {
+		return false
+	}
+
+	speedFt := walkingSpeedFt(entity)
+	if speedFt <= 0 {
+		return false
+	}
+	return CalculateDistanceFt(room, entity.GetPosition(), target.GetPosition()) <= float64(speedFt*multiplier)
+}
+
+// walkingSpeedFt returns the entity's walking speed in feet, defaulting to DefaultMonsterSpeedFt
+// Entities that cannot move return 0
+func walkingSpeedFt(entity entities.Placeable) int {
+	var speed int
+	switch e := entity.(type) {
+	case *entities.Monster:
+		speed = e.Speed
+	case *entities.Player:
+		speed = e.Speed
+	case *entities.NPC:
+		speed = e.Speed
+	default:
+		return 0
+	}
+	if speed <= 0 {
+		return DefaultMonsterSpeedFt
+	}
+	return speed
+}
+
+// roomCellSizeFt returns the width of one of the room's grid squares in feet, defaulting to entities.MovementStepFt
+func roomCellSizeFt(room *entities.Room) int {
+	if room != nil && room.CellSizeFt > 0 {
+		return room.CellSizeFt
+	}
+	return entities.MovementStepFt
+}
+
+// blockingObstaclePositions returns the positions of every blocking obstacle in the room
+// Doors that are closed and locked or barred (see Door.IsBlocking) and wall cells of the grid block like
+// obstacles and are included
+func blockingObstaclePositions(room *entities.Room) map[entities.Position]bool {
+	blocked := make(map[entities.Position]bool, len(room.Obstacles))
+	for i := range room.Obstacles {
+		if room.Obstacles[i].Blocking {
+			for _, pos := range room.Obstacles[i].Cells() {
+				blocked[pos] = true
+			}
+		}
+	}
+	for i := range room.Doors {
+		if room.Doors[i].IsBlocking() {
+			blocked[room.Doors[i].Position] = true
+		}
+	}
+	for y := range room.Grid {
+		for x, cell := range room.Grid[y] {
+			if cell.Type == entities.CellWall {
+				blocked[entities.Position{X: x, Y: y}] = true
+			}
+		}
+	}
+	return blocked
+}
+
+// pathNode is an entry in the A* open set
+type pathNode struct {
+	position entities.Position
+	priority float64 // cost so far plus heuristic
+	index    int
+}
+
+// pathQueue is a min-heap of pathNodes ordered by priority
+type pathQueue []*pathNode
+
+func (q pathQueue) Len() int           { return len(q) }
+func (q pathQueue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q pathQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *pathQueue) Push(x any) {
+	node := x.(*pathNode)
+	node.index = len(*q)
+	*q = append(*q, node)
+}
+
+func (q *pathQueue) Pop() any {
+	old := *q
+	node := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return node
+}
+
+// FindPath finds a shortest path between two positions that avoids blocking obstacles
+// Movement is one square at a time in any of eight directions, and every step costs 1 so the path length matches
+// CalculateDistance in an open room. The path starts at from and ends at to, and ErrNoPath is returned if
+// there is none. Creatures and non-blocking obstacles do not block the path
+func FindPath(room *entities.Room, from, to entities.Position) ([]entities.Position, error) {
+	return findWeightedPath(room, from, to, nil)
+}
+
+// LineOfSight reports whether a straight line between two positions is free of blocking obstacles
+// It is HasLineOfSight with bounds checking: returns entities.ErrInvalidPosition if either position is outside the room.
+// A position always has line of sight to itself
+func LineOfSight(room *entities.Room, from, to entities.Position) (bool, error) {
+	if room == nil {
+		return false, entities.ErrNilRoom
+	}
+	if !IsPositionValid(room, from) || !IsPositionValid(room, to) {
+		return false, entities.ErrInvalidPosition
+	}
+	return HasLineOfSight(room, from, to), nil
+}
+
+// UpdateVisibility reveals the cells the player can currently see on the room's visibility grid
+// Cells within DefaultVisibilityRangeFt of the player and in line of sight (see HasLineOfSight) become
+// VisibilityVisible, and cells that were visible but no longer are become VisibilitySeen. The visibility grid
+// is created if the room does not have one yet. Returns entities.ErrNoGrid for gridless rooms
+func UpdateVisibility(room *entities.Room, playerID string) error {
+	if room == nil {
+		return entities.ErrNilRoom
+	}
+	if room.Grid == nil {
+		return entities.ErrNoGrid
+	}
+	player, _ := FindPlayerByID(room, playerID)
+	if player == nil {
+		return fmt.Errorf("player with ID %s not found in room", playerID)
+	}
+
+	ensureVisibilityGrid(room)
+	for y := range room.VisibilityGrid {
+		for x, state := range room.VisibilityGrid[y] {
+			if state == entities.VisibilityVisible {
+				room.VisibilityGrid[y][x] = entities.VisibilitySeen
+			}
+		}
+	}
+
+	blocked := blockingObstaclePositions(room)
+	rangeCells := float64(DefaultVisibilityRangeFt / roomCellSizeFt(room))
+	for y := range room.VisibilityGrid {
+		for x := range room.VisibilityGrid[y] {
+			pos := entities.Position{X: x, Y: y}
+			if DistanceBetween(player.Position, pos, DistanceChebyshev) <= rangeCells && lineOfSight(blocked, player.Position, pos) {
+				room.VisibilityGrid[y][x] = entities.VisibilityVisible
+			}
+		}
+	}
+	return nil
+}
+
+// RevealAllCells marks every cell of the room visible, for game masters who want to see past the fog of war
+// Returns entities.ErrNoGrid for gridless rooms
+func RevealAllCells(room *entities.Room) error {
+	if room == nil {
+		return entities.ErrNilRoom
+	}
+	if room.Grid == nil {
+		return entities.ErrNoGrid
+	}
+
+	ensureVisibilityGrid(room)
+	for y := range room.VisibilityGrid {
+		for x := range room.VisibilityGrid[y] {
+			room.VisibilityGrid[y][x] = entities.VisibilityVisible
+		}
+	}
+	return nil
+}
+
+// ensureVisibilityGrid replaces the room's visibility grid with an unseen one if it does not match the room's size
+func ensureVisibilityGrid(room *entities.Room) {
+	if len(room.VisibilityGrid) == room.Height && (room.Height == 0 || len(room.VisibilityGrid[0]) == room.Width) {
+		return
+	}
+	room.VisibilityGrid = newVisibilityGrid(room.Width, room.Height)
+}
+
+// newVisibilityGrid returns a visibility grid of the given size with every cell unseen
+func newVisibilityGrid(width, height int) [][]entities.VisibilityState {
+	grid := make([][]entities.VisibilityState, height)
+	for y := range grid {
+		grid[y] = make([]entities.VisibilityState, width)
+	}
+	return grid
+}
+
+// AddWallRegion fills the rectangle between the two corner positions, inclusive, with wall cells
+// Walls are stored only in the grid, so no entity slices change. Cells that are already walls are kept.
+// Returns entities.ErrNoGrid for gridless rooms, entities.ErrInvalidPosition if either corner is outside the room,
+// and entities.ErrCellOccupied without changing the grid if an entity stands in the region
+func AddWallRegion(room *entities.Room, from, to entities.Position) error {
+	minPos, maxPos, err := wallRegionBounds(room, from, to)
+	if err != nil {
+		return err
+	}
+
+	for y := minPos.Y; y <= maxPos.Y; y++ {
+		for x := minPos.X; x <= maxPos.X; x++ {
+			if cell := room.Grid[y][x]; cell.Type != entities.CellTypeEmpty && cell.Type != entities.CellWall {
+				return fmt.Errorf("cannot build a wall at (%d,%d) occupied by %s: %w", x, y, cell.EntityID, entities.ErrCellOccupied)
+			}
+		}
+	}
+
+	for y := minPos.Y; y <= maxPos.Y; y++ {
+		for x := minPos.X; x <= maxPos.X; x++ {
+			room.Grid[y][x] = entities.Cell{Type: entities.CellWall}
+		}
+	}
+	return nil
+}
+
+// ClearWallRegion turns the wall cells in the rectangle between the two corner positions, inclusive, back into
+// empty cells. Other cells in the region are left alone. Returns the same errors as AddWallRegion for
+// gridless rooms and corners outside the room
+func ClearWallRegion(room *entities.Room, from, to entities.Position) error {
+	minPos, maxPos, err := wallRegionBounds(room, from, to)
+	if err != nil {
+		return err
+	}
+
+	for y := minPos.Y; y <= maxPos.Y; y++ {
+		for x := minPos.X; x <= maxPos.X; x++ {
+			if room.Grid[y][x].Type == entities.CellWall {
+				room.Grid[y][x] = entities.Cell{Type: entities.CellTypeEmpty}
+			}
+		}
+	}
+	return nil
+}
+
+// wallRegionBounds validates the corners of a wall region and returns its top-left and bottom-right corners
+func wallRegionBounds(room *entities.Room, from, to entities.Position) (entities.Position, entities.Position, error) {
+	if room == nil {
+		return entities.Position{}, entities.Position{}, entities.ErrNilRoom
+	}
+	if room.Grid == nil {
+		return entities.Position{}, entities.Position{}, entities.ErrNoGrid
+	}
+	if !IsPositionValid(room, from) || !IsPositionValid(room, to) {
+		return entities.Position{}, entities.Position{}, entities.ErrInvalidPosition
+	}
+
+	minPos := entities.Position{X: min(from.X, to.X), Y: min(from.Y, to.Y)}
+	maxPos := entities.Position{X: max(from.X, to.X), Y: max(from.Y, to.Y)}
+	return minPos, maxPos, nil
+}
+
+// findWeightedPath runs A* from one position to another, moving one square at a time in any of eight directions
+// Each step costs 1 plus extraCost of the cell being entered (extraCost may be nil).
+// Cells holding blocking obstacles, doors, or walls cannot be entered. The returned path starts at from and ends at to
+func findWeightedPath(room *entities.Room, from, to entities.Position, extraCost func(entities.Position) float64) ([]entities.Position, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+	return findPathAround(room, from, to, blockingObstaclePositions(room), extraCost)
+}
+
+// findPathAround runs the A* search of findWeightedPath, treating the cells in blocked as impassable
+func findPathAround(room *entities.Room, from, to entities.Position, blocked map[entities.Position]bool, extraCost func(entities.Position) float64) ([]entities.Position, error) {
+	if !IsPositionValid(room, from) || !IsPositionValid(room, to) {
+		return nil, entities.ErrInvalidPosition
+	}
+
+	if blocked[to] {
+		return nil, ErrNoPath
+	}
+
+	cost := map[entities.Position]float64{from: 0}
+	cameFrom := map[entities.Position]entities.Position{}
+	closed := map[entities.Position]bool{}
+	open := &pathQueue{}
+	heap.Push(open, &pathNode{position: from, priority: CalculateDistance(from, to)})
+
+	for open.Len() > 0 {
+		current := heap.Pop(open).(*pathNode).position
+		if current == to {
+			path := []entities.Position{to}
+			for current != from {
+				current = cameFrom[current]
+				path = append(path, current)
+			}
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return path, nil
+		}
+		if closed[current] {
+			continue
+		}
+		closed[current] = true
+
+		for _, neighbor := range current.Neighbors(true) {
+			if !IsPositionValid(room, neighbor) || blocked[neighbor] || closed[neighbor] {
+				continue
+			}
+
+			stepCost := 1.0
+			if extraCost != nil {
+				stepCost += extraCost(neighbor)
+			}
+			newCost := cost[current] + stepCost
+			if known, ok := cost[neighbor]; ok && newCost >= known {
+				continue
+			}
+
+			cost[neighbor] = newCost
+			cameFrom[neighbor] = current
+			heap.Push(open, &pathNode{position: neighbor, priority: newCost + CalculateDistance(neighbor, to)})
+		}
+	}
+
+	return nil, ErrNoPath
+}
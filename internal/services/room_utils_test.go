@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
 )
@@ -200,6 +201,225 @@ func TestCalculateDistance(t *testing.T) {
 	}
 }
 
+func TestCalculateDistanceFt(t *testing.T) {
+	room := NewRoom(10, 10, entities.LightLevelBright)
+	from := entities.Position{X: 1, Y: 1}
+	to := entities.Position{X: 4, Y: 3}
+
+	room.CellSizeFt = 5
+	assert.Equal(t, 15.0, CalculateDistanceFt(room, from, to))
+	room.CellSizeFt = 10
+	assert.Equal(t, 30.0, CalculateDistanceFt(room, from, to))
+
+	// Rooms without a cell size use the standard 5 ft square
+	room.CellSizeFt = 0
+	assert.Equal(t, 15.0, CalculateDistanceFt(room, from, to))
+	assert.Equal(t, 15.0, CalculateDistanceFt(nil, from, to))
+	assert.Zero(t, CalculateDistanceFt(room, from, from))
+}
+
+func TestFindPath(t *testing.T) {
+	wall := func(positions ...entities.Position) []entities.Obstacle {
+		obstacles := []entities.Obstacle{}
+		for i, pos := range positions {
+			obstacles = append(obstacles, entities.Obstacle{ID: fmt.Sprintf("wall%d", i), Blocking: true, Position: pos})
+		}
+		return obstacles
+	}
+
+	testCases := []struct {
+		name        string
+		from        entities.Position
+		to          entities.Position
+		obstacles   []entities.Obstacle
+		expectedLen int
+		expectedErr error
+	}{
+		{
+			name:        "Open room matches CalculateDistance",
+			from:        entities.Position{X: 0, Y: 0},
+			to:          entities.Position{X: 4, Y: 2},
+			expectedLen: 5,
+		},
+		{
+			name:        "Same position",
+			from:        entities.Position{X: 2, Y: 2},
+			to:          entities.Position{X: 2, Y: 2},
+			expectedLen: 1,
+		},
+		{
+			name:        "Non-blocking obstacle is walked through",
+			from:        entities.Position{X: 0, Y: 0},
+			to:          entities.Position{X: 4, Y: 0},
+			obstacles:   []entities.Obstacle{{ID: "rubble", Position: entities.Position{X: 2, Y: 0}}},
+			expectedLen: 5,
+		},
+		{
+			name:        "Detour around a wall",
+			from:        entities.Position{X: 0, Y: 0},
+			to:          entities.Position{X: 4, Y: 0},
+			obstacles:   wall(entities.Position{X: 2, Y: 0}, entities.Position{X: 2, Y: 1}, entities.Position{X: 2, Y: 2}, entities.Position{X: 2, Y: 3}),
+			expectedLen: 9,
+		},
+		{
+			name:        "Wall with no gap",
+			from:        entities.Position{X: 0, Y: 0},
+			to:          entities.Position{X: 4, Y: 0},
+			obstacles:   wall(entities.Position{X: 2, Y: 0}, entities.Position{X: 2, Y: 1}, entities.Position{X: 2, Y: 2}, entities.Position{X: 2, Y: 3}, entities.Position{X: 2, Y: 4}),
+			expectedErr: ErrNoPath,
+		},
+		{
+			name:        "Goal is a blocking obstacle",
+			from:        entities.Position{X: 0, Y: 0},
+			to:          entities.Position{X: 2, Y: 2},
+			obstacles:   wall(entities.Position{X: 2, Y: 2}),
+			expectedErr: ErrNoPath,
+		},
+		{
+			name:        "Goal outside the room",
+			from:        entities.Position{X: 0, Y: 0},
+			to:          entities.Position{X: 5, Y: 0},
+			expectedErr: entities.ErrInvalidPosition,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			room := createTestRoom()
+			for i := range tc.obstacles {
+				require.NoError(t, PlaceEntity(room, &tc.obstacles[i]))
+			}
+
+			path, err := FindPath(room, tc.from, tc.to)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, path, tc.expectedLen)
+			assert.Equal(t, tc.from, path[0])
+			assert.Equal(t, tc.to, path[len(path)-1])
+
+			blocked := blockingObstaclePositions(room)
+			for i, pos := range path {
+				assert.False(t, blocked[pos], "path enters a blocking obstacle at %v", pos)
+				if i > 0 {
+					assert.Equal(t, 1.0, CalculateDistance(path[i-1], pos), "each step moves one square")
+				}
+			}
+		})
+	}
+
+	_, err := FindPath(nil, entities.Position{}, entities.Position{})
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
+
+func TestLineOfSight(t *testing.T) {
+	room := createTestRoom()
+	require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "pillar", Blocking: true, Position: entities.Position{X: 2, Y: 2}}))
+	require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "table", Position: entities.Position{X: 2, Y: 0}}))
+
+	testCases := []struct {
+		name     string
+		from     entities.Position
+		to       entities.Position
+		expected bool
+	}{
+		{"Open line", entities.Position{X: 0, Y: 4}, entities.Position{X: 4, Y: 4}, true},
+		{"Same position", entities.Position{X: 1, Y: 1}, entities.Position{X: 1, Y: 1}, true},
+		{"Non-blocking obstacle", entities.Position{X: 0, Y: 0}, entities.Position{X: 4, Y: 0}, true},
+		{"Blocked diagonal", entities.Position{X: 0, Y: 0}, entities.Position{X: 4, Y: 4}, false},
+		{"Blocked orthogonal", entities.Position{X: 2, Y: 0}, entities.Position{X: 2, Y: 4}, false},
+		{"Blocking obstacle itself is visible", entities.Position{X: 0, Y: 0}, entities.Position{X: 2, Y: 2}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			visible, err := LineOfSight(room, tc.from, tc.to)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, visible)
+
+			visible, err = LineOfSight(room, tc.to, tc.from)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, visible, "line of sight is symmetric")
+		})
+	}
+
+	_, err := LineOfSight(room, entities.Position{X: -1, Y: 0}, entities.Position{X: 0, Y: 0})
+	assert.ErrorIs(t, err, entities.ErrInvalidPosition)
+	_, err = LineOfSight(room, entities.Position{X: 0, Y: 0}, entities.Position{X: 0, Y: 5})
+	assert.ErrorIs(t, err, entities.ErrInvalidPosition)
+	_, err = LineOfSight(nil, entities.Position{}, entities.Position{})
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
+
+func TestUpdateVisibility(t *testing.T) {
+	t.Run("Obstacles block visibility", func(t *testing.T) {
+		room := createTestRoom()
+		player := createTestPlayer("hero", 1, 0, 2)
+		require.NoError(t, PlaceEntity(room, &player))
+		require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "wall", Blocking: true, Position: entities.Position{X: 2, Y: 1},
+			Size: entities.Size{Width: 1, Height: 3}}))
+		require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "table", Position: entities.Position{X: 1, Y: 0}}))
+
+		require.NoError(t, UpdateVisibility(room, "hero"))
+		assert.Equal(t, entities.VisibilityVisible, room.VisibilityGrid[2][0], "own cell")
+		assert.Equal(t, entities.VisibilityVisible, room.VisibilityGrid[2][2], "the wall itself")
+		assert.Equal(t, entities.VisibilityVisible, room.VisibilityGrid[0][2], "past the non-blocking table")
+		assert.Equal(t, entities.VisibilityUnseen, room.VisibilityGrid[2][3], "behind the wall")
+		assert.Equal(t, entities.VisibilityUnseen, room.VisibilityGrid[2][4], "behind the wall")
+	})
+
+	t.Run("Cells out of view become seen", func(t *testing.T) {
+		room := createTestRoom()
+		player := createTestPlayer("hero", 1, 0, 2)
+		require.NoError(t, PlaceEntity(room, &player))
+		require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "wall", Blocking: true, Position: entities.Position{X: 2, Y: 0},
+			Size: entities.Size{Width: 1, Height: 5}}))
+
+		require.NoError(t, UpdateVisibility(room, "hero"))
+		require.NoError(t, MovePlaceable(room, &room.Players[0], entities.Position{X: 4, Y: 2}))
+		require.NoError(t, UpdateVisibility(room, "hero"))
+
+		assert.Equal(t, entities.VisibilitySeen, room.VisibilityGrid[2][0])
+		assert.Equal(t, entities.VisibilityVisible, room.VisibilityGrid[2][2])
+		assert.Equal(t, entities.VisibilityVisible, room.VisibilityGrid[2][4])
+	})
+
+	t.Run("Visibility range", func(t *testing.T) {
+		room := NewRoom(20, 1, entities.LightLevelBright)
+		InitializeGrid(room)
+		player := createTestPlayer("hero", 1, 0, 0)
+		require.NoError(t, PlaceEntity(room, &player))
+
+		require.NoError(t, UpdateVisibility(room, "hero"))
+		assert.Equal(t, entities.VisibilityVisible, room.VisibilityGrid[0][12])
+		assert.Equal(t, entities.VisibilityUnseen, room.VisibilityGrid[0][13])
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		assert.ErrorIs(t, UpdateVisibility(nil, "hero"), entities.ErrNilRoom)
+		assert.ErrorIs(t, UpdateVisibility(createTestRoomNoGrid(), "hero"), entities.ErrNoGrid)
+		assert.Error(t, UpdateVisibility(createTestRoom(), "missing"))
+	})
+}
+
+func TestRevealAllCells(t *testing.T) {
+	room := createTestRoom()
+	room.VisibilityGrid = nil
+	require.NoError(t, RevealAllCells(room))
+	require.Len(t, room.VisibilityGrid, room.Height)
+	for _, row := range room.VisibilityGrid {
+		require.Len(t, row, room.Width)
+		for _, state := range row {
+			assert.Equal(t, entities.VisibilityVisible, state)
+		}
+	}
+
+	assert.ErrorIs(t, RevealAllCells(nil), entities.ErrNilRoom)
+	assert.ErrorIs(t, RevealAllCells(createTestRoomNoGrid()), entities.ErrNoGrid)
+}
+
 func TestMovePlaceable(t *testing.T) {
 	// Create a room with a grid
 	room := createTestRoom()
@@ -307,6 +527,19 @@ func TestMovePlaceable(t *testing.T) {
 	err = MovePlaceable(roomNoGrid, &monsterNoGrid, newPos)
 	assert.NoError(t, err)
 	assert.Equal(t, newPos, monsterNoGrid.Position)
+
+	// Every entity type can be moved on a grid
+	npc := entities.NPC{ID: "npc1", Position: entities.Position{X: 0, Y: 4}}
+	require.NoError(t, PlaceEntity(room, &npc))
+	require.NoError(t, MovePlaceable(room, &npc, entities.Position{X: 1, Y: 4}))
+	assert.Equal(t, entities.Position{X: 1, Y: 4}, room.NPCs[0].Position)
+	assert.Equal(t, entities.Cell{Type: entities.CellNPC, EntityID: "npc1"}, room.Grid[4][1])
+	assert.Equal(t, entities.CellTypeEmpty, room.Grid[4][0].Type)
+
+	// An entity of another type with the same ID is not moved
+	err = MovePlaceable(room, &entities.Player{ID: "monster1"}, entities.Position{X: 0, Y: 0})
+	assert.ErrorIs(t, err, ErrEntityNotFound)
+	assert.Equal(t, entities.Position{X: 2, Y: 2}, room.Monsters[0].Position)
 }
 
 func TestRemovePlaceable(t *testing.T) {
@@ -438,3 +671,580 @@ func TestRemovePlaceable(t *testing.T) {
 	assert.True(t, removed)
 	assert.Empty(t, roomNoGrid.Monsters)
 }
+
+func TestFindByID(t *testing.T) {
+	room := createTestRoom()
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "goblin-1", Position: entities.Position{X: 0, Y: 0}}))
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "goblin-2", Position: entities.Position{X: 1, Y: 0}}))
+	require.NoError(t, PlaceEntity(room, &entities.Player{ID: "fighter", Position: entities.Position{X: 2, Y: 0}}))
+	require.NoError(t, PlaceEntity(room, &entities.Item{ID: "sword", Position: entities.Position{X: 3, Y: 0}}))
+	require.NoError(t, PlaceEntity(room, &entities.NPC{ID: "merchant", Position: entities.Position{X: 4, Y: 0}}))
+	require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "boulder", Position: entities.Position{X: 0, Y: 1}}))
+
+	t.Run("Hits", func(t *testing.T) {
+		monster, i := FindMonsterByID(room, "goblin-2")
+		require.NotNil(t, monster)
+		assert.Equal(t, 1, i)
+		assert.Same(t, &room.Monsters[1], monster)
+
+		player, i := FindPlayerByID(room, "fighter")
+		require.NotNil(t, player)
+		assert.Equal(t, 0, i)
+
+		item, i := FindItemByID(room, "sword")
+		require.NotNil(t, item)
+		assert.Equal(t, 0, i)
+
+		npc, i := FindNPCByID(room, "merchant")
+		require.NotNil(t, npc)
+		assert.Equal(t, 0, i)
+
+		obstacle, i := FindObstacleByID(room, "boulder")
+		require.NotNil(t, obstacle)
+		assert.Equal(t, 0, i)
+
+		entityTests := []struct {
+			id       string
+			cellType entities.CellType
+			expected entities.Placeable
+		}{
+			{id: "goblin-2", cellType: entities.CellMonster, expected: &room.Monsters[1]},
+			{id: "fighter", cellType: entities.CellPlayer, expected: &room.Players[0]},
+			{id: "sword", cellType: entities.CellItem, expected: &room.Items[0]},
+			{id: "merchant", cellType: entities.CellNPC, expected: &room.NPCs[0]},
+			{id: "boulder", cellType: entities.CellObstacle, expected: &room.Obstacles[0]},
+		}
+		for _, tc := range entityTests {
+			entity, err := FindEntityByID(room, tc.id)
+			require.NoError(t, err, tc.id)
+			assert.Equal(t, tc.cellType, entity.GetCellType())
+			assert.Same(t, tc.expected, entity)
+		}
+	})
+
+	t.Run("Misses", func(t *testing.T) {
+		monster, i := FindMonsterByID(room, "fighter")
+		assert.Nil(t, monster)
+		assert.Equal(t, -1, i)
+
+		player, i := FindPlayerByID(room, "missing")
+		assert.Nil(t, player)
+		assert.Equal(t, -1, i)
+
+		item, i := FindItemByID(room, "missing")
+		assert.Nil(t, item)
+		assert.Equal(t, -1, i)
+
+		npc, i := FindNPCByID(nil, "merchant")
+		assert.Nil(t, npc)
+		assert.Equal(t, -1, i)
+
+		obstacle, i := FindObstacleByID(room, "missing")
+		assert.Nil(t, obstacle)
+		assert.Equal(t, -1, i)
+
+		entity, err := FindEntityByID(room, "missing")
+		assert.ErrorIs(t, err, ErrEntityNotFound)
+		assert.Nil(t, entity)
+
+		_, err = FindEntityByID(nil, "merchant")
+		assert.ErrorIs(t, err, entities.ErrNilRoom)
+	})
+}
+
+func TestDistanceBetween(t *testing.T) {
+	origin := entities.Position{X: 0, Y: 0}
+	target := entities.Position{X: 3, Y: 4}
+
+	assert.Equal(t, 4.0, DistanceBetween(origin, target, DistanceChebyshev))
+	assert.Equal(t, 5.0, DistanceBetween(origin, target, DistanceEuclidean))
+	assert.Equal(t, 7.0, DistanceBetween(origin, target, DistanceManhattan))
+	assert.Equal(t, CalculateDistance(origin, target), DistanceBetween(origin, target, "unknown"))
+}
+
+func TestGetCellsInRadius(t *testing.T) {
+	room := NewRoom(7, 7, entities.LightLevelBright)
+	center := entities.Position{X: 3, Y: 3}
+
+	testCases := []struct {
+		metric   DistanceMetric
+		expected int
+	}{
+		{DistanceChebyshev, 24},
+		{DistanceEuclidean, 12},
+		{DistanceManhattan, 12},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.metric), func(t *testing.T) {
+			cells, err := GetCellsInRadius(room, center, 2, tc.metric)
+			assert.NoError(t, err)
+			assert.Len(t, cells, tc.expected)
+			assert.NotContains(t, cells, center)
+		})
+	}
+
+	// Cells are clipped to room bounds
+	cells, err := GetCellsInRadius(room, entities.Position{X: 0, Y: 0}, 2, DistanceChebyshev)
+	assert.NoError(t, err)
+	assert.Len(t, cells, 8)
+
+	_, err = GetCellsInRadius(room, center, 2, "hexagonal")
+	assert.Error(t, err)
+	_, err = GetCellsInRadius(nil, center, 2, DistanceChebyshev)
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
+
+func TestGetEntitiesNear(t *testing.T) {
+	service := &RoomService{}
+	center := entities.Position{X: 3, Y: 3}
+
+	// Fill a 7x7 room with a monster in every cell except the center
+	room := NewRoom(7, 7, entities.LightLevelBright)
+	InitializeGrid(room)
+	for y := 0; y < room.Height; y++ {
+		for x := 0; x < room.Width; x++ {
+			if x == center.X && y == center.Y {
+				continue
+			}
+			monster := createTestMonster(fmt.Sprintf("%d-%d", x, y), x, y)
+			assert.NoError(t, PlaceEntity(room, &monster))
+		}
+	}
+
+	testCases := []struct {
+		metric   DistanceMetric
+		expected int
+		included []string // Entity IDs that must be in range
+		excluded []string // Entity IDs that must be out of range
+	}{
+		{
+			metric:   DistanceChebyshev,
+			expected: 24,
+			included: []string{"5-5", "5-4", "1-1"},
+			excluded: []string{"6-3", "0-0"},
+		},
+		{
+			metric:   DistanceEuclidean,
+			expected: 12,
+			included: []string{"4-4", "5-3", "3-1"},
+			excluded: []string{"5-4", "5-5"},
+		},
+		{
+			metric:   DistanceManhattan,
+			expected: 12,
+			included: []string{"4-4", "2-2", "5-3", "3-5"},
+			excluded: []string{"5-4", "4-5", "5-5"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.metric), func(t *testing.T) {
+			nearby, err := service.GetEntitiesNear(room, center, 2, tc.metric)
+			assert.NoError(t, err)
+			assert.Len(t, nearby, tc.expected)
+
+			ids := map[string]bool{}
+			for _, entity := range nearby {
+				ids[entity.GetID()] = true
+				assert.LessOrEqual(t, DistanceBetween(center, entity.GetPosition(), tc.metric), 2.0)
+			}
+			for _, id := range tc.included {
+				assert.True(t, ids[id], "expected %s in range", id)
+			}
+			for _, id := range tc.excluded {
+				assert.False(t, ids[id], "expected %s out of range", id)
+			}
+		})
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := service.GetEntitiesNear(nil, center, 2, DistanceChebyshev)
+		assert.ErrorIs(t, err, entities.ErrNilRoom)
+		_, err = service.GetEntitiesNear(room, center, 2, "hexagonal")
+		assert.Error(t, err)
+	})
+}
+
+func TestGetEntitiesInRange(t *testing.T) {
+	center := entities.Position{X: 5, Y: 5}
+
+	t.Run("Empty room", func(t *testing.T) {
+		room := NewRoom(10, 10, entities.LightLevelBright)
+		InitializeGrid(room)
+
+		assert.Empty(t, GetEntitiesInRange(room, center, 3))
+		assert.Empty(t, GetMonstersInRange(room, center, 3))
+		assert.Empty(t, GetPlayersInRange(room, center, 3))
+		assert.Empty(t, GetNPCsInRange(room, center, 3))
+		assert.Nil(t, GetEntitiesInRange(nil, center, 3))
+	})
+
+	room := NewRoom(10, 10, entities.LightLevelBright)
+	InitializeGrid(room)
+	boundary := createTestMonster("boundary", 7, 3)
+	inside := createTestMonster("inside", 4, 5)
+	outside := createTestMonster("outside", 8, 5)
+	player := createTestPlayer("player", 1, 3, 7)
+	farPlayer := createTestPlayer("far-player", 1, 0, 0)
+	npc := entities.NPC{ID: "npc", Position: entities.Position{X: 6, Y: 6}}
+	item := entities.Item{ID: "item", Position: entities.Position{X: 5, Y: 5}}
+	obstacle := entities.Obstacle{ID: "obstacle", Position: entities.Position{X: 5, Y: 9}}
+	for _, p := range []entities.Placeable{&boundary, &inside, &outside, &player, &farPlayer, &npc, &item, &obstacle} {
+		require.NoError(t, PlaceEntity(room, p))
+	}
+
+	ids := func(placeables []entities.Placeable) []string {
+		result := []string{}
+		for _, p := range placeables {
+			result = append(result, p.GetID())
+		}
+		return result
+	}
+
+	testCases := []struct {
+		name     string
+		rangeSq  float64
+		expected []string
+	}{
+		{name: "Center only", rangeSq: 0, expected: []string{"item"}},
+		{name: "Adjacent", rangeSq: 1, expected: []string{"inside", "item", "npc"}},
+		{name: "Boundary is included", rangeSq: 2, expected: []string{"boundary", "inside", "player", "item", "npc"}},
+		{name: "Partial overlap", rangeSq: 3.5, expected: []string{"boundary", "inside", "outside", "player", "item", "npc"}},
+		{name: "Whole room", rangeSq: 10, expected: []string{"boundary", "inside", "outside", "player", "far-player", "item", "npc", "obstacle"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ElementsMatch(t, tc.expected, ids(GetEntitiesInRange(room, center, tc.rangeSq)))
+		})
+	}
+
+	t.Run("Typed helpers", func(t *testing.T) {
+		monsters := GetMonstersInRange(room, center, 2)
+		require.Len(t, monsters, 2)
+		assert.ElementsMatch(t, []string{"boundary", "inside"}, []string{monsters[0].ID, monsters[1].ID})
+		assert.Same(t, &room.Monsters[0], monsters[0], "helpers return pointers into the room")
+
+		players := GetPlayersInRange(room, center, 2)
+		require.Len(t, players, 1)
+		assert.Equal(t, "player", players[0].ID)
+
+		npcs := GetNPCsInRange(room, center, 0.5)
+		assert.Empty(t, npcs)
+		npcs = GetNPCsInRange(room, center, 1)
+		require.Len(t, npcs, 1)
+		assert.Equal(t, "npc", npcs[0].ID)
+	})
+}
+
+func TestAddWallRegion(t *testing.T) {
+	t.Run("Fills the region", func(t *testing.T) {
+		room := createTestRoom()
+		require.NoError(t, AddWallRegion(room, entities.Position{X: 3, Y: 1}, entities.Position{X: 1, Y: 0}))
+
+		for y := 0; y < room.Height; y++ {
+			for x := 0; x < room.Width; x++ {
+				expected := entities.CellTypeEmpty
+				if x >= 1 && x <= 3 && y <= 1 {
+					expected = entities.CellWall
+				}
+				assert.Equal(t, expected, room.Grid[y][x].Type, "cell (%d,%d)", x, y)
+			}
+		}
+		assert.True(t, room.IsEmpty(), "walls are not entities")
+		assert.Empty(t, ValidateRoomGrid(room))
+	})
+
+	t.Run("Entities cannot be placed on walls", func(t *testing.T) {
+		room := createTestRoom()
+		wall := entities.Position{X: 2, Y: 2}
+		require.NoError(t, AddWallRegion(room, wall, wall))
+
+		err := PlaceEntity(room, &entities.Monster{ID: "goblin", Position: wall})
+		assert.ErrorIs(t, err, entities.ErrCellOccupied)
+		assert.Empty(t, room.Monsters)
+	})
+
+	t.Run("FindEmptyPosition skips walls", func(t *testing.T) {
+		room := createTestRoom()
+		require.NoError(t, AddWallRegion(room, entities.Position{X: 0, Y: 0}, entities.Position{X: 4, Y: 3}))
+
+		for i := 0; i < 20; i++ {
+			pos, err := FindEmptyPosition(room)
+			require.NoError(t, err)
+			assert.Equal(t, 4, pos.Y)
+		}
+	})
+
+	t.Run("Paths go around walls", func(t *testing.T) {
+		room := createTestRoom()
+		require.NoError(t, AddWallRegion(room, entities.Position{X: 2, Y: 0}, entities.Position{X: 2, Y: 3}))
+
+		path, err := FindPath(room, entities.Position{X: 0, Y: 0}, entities.Position{X: 4, Y: 0})
+		require.NoError(t, err)
+		assert.Contains(t, path, entities.Position{X: 2, Y: 4})
+
+		require.NoError(t, AddWallRegion(room, entities.Position{X: 2, Y: 4}, entities.Position{X: 2, Y: 4}))
+		_, err = FindPath(room, entities.Position{X: 0, Y: 0}, entities.Position{X: 4, Y: 0})
+		assert.ErrorIs(t, err, ErrNoPath)
+	})
+
+	t.Run("Occupied region is left unchanged", func(t *testing.T) {
+		room := createTestRoom()
+		require.NoError(t, PlaceEntity(room, &entities.Item{ID: "sword", Position: entities.Position{X: 1, Y: 1}}))
+
+		err := AddWallRegion(room, entities.Position{X: 0, Y: 0}, entities.Position{X: 2, Y: 2})
+		assert.ErrorIs(t, err, entities.ErrCellOccupied)
+		assert.Equal(t, entities.CellTypeEmpty, room.Grid[0][0].Type)
+		assert.Equal(t, entities.CellItem, room.Grid[1][1].Type)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		origin := entities.Position{}
+		assert.ErrorIs(t, AddWallRegion(nil, origin, origin), entities.ErrNilRoom)
+		assert.ErrorIs(t, AddWallRegion(createTestRoomNoGrid(), origin, origin), entities.ErrNoGrid)
+		assert.ErrorIs(t, AddWallRegion(createTestRoom(), origin, entities.Position{X: 5, Y: 0}), entities.ErrInvalidPosition)
+	})
+}
+
+func TestClearWallRegion(t *testing.T) {
+	room := createTestRoom()
+	require.NoError(t, AddWallRegion(room, entities.Position{X: 0, Y: 0}, entities.Position{X: 4, Y: 0}))
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "goblin", Position: entities.Position{X: 2, Y: 1}}))
+
+	require.NoError(t, ClearWallRegion(room, entities.Position{X: 1, Y: 0}, entities.Position{X: 3, Y: 1}))
+	assert.Equal(t, entities.CellWall, room.Grid[0][0].Type)
+	assert.Equal(t, entities.CellTypeEmpty, room.Grid[0][2].Type)
+	assert.Equal(t, entities.CellWall, room.Grid[0][4].Type)
+	assert.Equal(t, entities.CellMonster, room.Grid[1][2].Type, "entities in the region are kept")
+
+	assert.ErrorIs(t, ClearWallRegion(createTestRoomNoGrid(), entities.Position{}, entities.Position{}), entities.ErrNoGrid)
+}
+
+func TestClone(t *testing.T) {
+	room := createTestRoom()
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "goblin", Conditions: []entities.Condition{entities.ConditionPoisoned}, Position: entities.Position{X: 0, Y: 0}}))
+	require.NoError(t, PlaceEntity(room, &entities.Player{ID: "fighter", Position: entities.Position{X: 1, Y: 0}}))
+	require.NoError(t, PlaceEntity(room, &entities.Item{ID: "sword", Properties: []string{"finesse"}, Position: entities.Position{X: 2, Y: 0}}))
+	require.NoError(t, PlaceEntity(room, &entities.NPC{ID: "merchant", Inventory: []entities.Item{{ID: "potion"}}, Position: entities.Position{X: 3, Y: 0}}))
+	require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "boulder", Blocking: true, Position: entities.Position{X: 4, Y: 0}}))
+	require.NoError(t, PlaceEntity(room, &entities.Trap{ID: "pit", Position: entities.Position{X: 0, Y: 1}}))
+	require.NoError(t, PlaceEntity(room, &entities.Door{ID: "door", Locked: true, Position: entities.Position{X: 1, Y: 1}}))
+	room.SetDifficultTerrain(entities.Position{X: 2, Y: 2}, true)
+	room.SetTag("boss", "lich")
+	room.RoomType = &entities.AmbushRoomType{AmbusherIDs: []string{"goblin"}}
+	room.EventLog = []entities.RoomEvent{{EventType: entities.RoomEventMoved, EntityID: "goblin",
+		OldPosition: &entities.Position{X: 1, Y: 1}, NewPosition: &entities.Position{X: 0, Y: 0}}}
+
+	clone := Clone(room)
+	require.NotNil(t, clone)
+	assert.Equal(t, room, clone)
+	assert.NotSame(t, room, clone)
+
+	require.NoError(t, PlaceEntity(clone, &entities.Monster{ID: "orc", Position: entities.Position{X: 4, Y: 4}}))
+	clone.Grid[3][3] = entities.Cell{Type: entities.CellObstacle, EntityID: "rubble"}
+	clone.Monsters[0].Conditions[0] = entities.ConditionStunned
+	clone.Items[0].Properties[0] = "heavy"
+	clone.NPCs[0].Inventory[0].ID = "poison"
+	clone.Obstacles[0].Blocking = false
+	clone.Traps[0].Triggered = true
+	clone.Doors[0].Locked = false
+	clone.SetDifficultTerrain(entities.Position{X: 2, Y: 2}, false)
+	clone.SetTag("boss", "dragon")
+	clone.RoomType.(*entities.AmbushRoomType).Triggered = true
+	clone.RoomType.(*entities.AmbushRoomType).AmbusherIDs[0] = "orc"
+	clone.EventLog[0].OldPosition.X = 3
+	clone.EventLog[0].NewPosition.Y = 3
+
+	assert.Len(t, room.Monsters, 1)
+	assert.Equal(t, entities.CellTypeEmpty, room.Grid[4][4].Type)
+	assert.Equal(t, entities.CellTypeEmpty, room.Grid[3][3].Type)
+	assert.Equal(t, entities.ConditionPoisoned, room.Monsters[0].Conditions[0])
+	assert.Equal(t, "finesse", room.Items[0].Properties[0])
+	assert.Equal(t, "potion", room.NPCs[0].Inventory[0].ID)
+	assert.True(t, room.Obstacles[0].Blocking)
+	assert.False(t, room.Traps[0].Triggered)
+	assert.True(t, room.Doors[0].Locked)
+	assert.True(t, room.IsDifficultTerrain(entities.Position{X: 2, Y: 2}))
+	boss, _ := room.GetTag("boss")
+	assert.Equal(t, "lich", boss)
+	assert.Equal(t, &entities.AmbushRoomType{AmbusherIDs: []string{"goblin"}}, room.RoomType)
+	assert.Equal(t, entities.Position{X: 1, Y: 1}, *room.EventLog[0].OldPosition)
+	assert.Equal(t, entities.Position{X: 0, Y: 0}, *room.EventLog[0].NewPosition)
+
+	assert.Nil(t, Clone(nil))
+}
+
+func TestFilterRoomsByTag(t *testing.T) {
+	boss := NewRoom(5, 5, entities.LightLevelBright)
+	boss.SetTag("type", "boss")
+	boss.SetTag("explored", "false")
+	safe := NewRoom(5, 5, entities.LightLevelBright)
+	safe.SetTag("type", "safe")
+	explored := NewRoom(5, 5, entities.LightLevelBright)
+	explored.SetTag("explored", "true")
+	untagged := NewRoom(5, 5, entities.LightLevelBright)
+	rooms := []*entities.Room{boss, safe, nil, explored, untagged}
+
+	assert.Equal(t, []*entities.Room{boss}, FilterRoomsByTag(rooms, "type", "boss"))
+	assert.Equal(t, []*entities.Room{explored}, FilterRoomsByTag(rooms, "explored", "true"))
+	assert.Empty(t, FilterRoomsByTag(rooms, "type", "shop"))
+	assert.Empty(t, FilterRoomsByTag(nil, "type", "boss"))
+
+	// An empty value only matches rooms that have the tag
+	untagged.SetTag("type", "")
+	assert.Equal(t, []*entities.Room{untagged}, FilterRoomsByTag(rooms, "type", ""))
+}
+
+func TestValidateRoom(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupRoom     func() *entities.Room
+		expectedCodes []string
+		expectedID    string
+	}{
+		{
+			name: "Valid room",
+			setupRoom: func() *entities.Room {
+				room := createTestRoom()
+				monster := createTestMonster("goblin", 1, 1)
+				require.NoError(t, PlaceEntity(room, &monster))
+				require.NoError(t, AddWallRegion(room, entities.Position{X: 4, Y: 0}, entities.Position{X: 4, Y: 4}))
+				return room
+			},
+		},
+		{
+			name: "Valid gridless room",
+			setupRoom: func() *entities.Room {
+				room := createTestRoomNoGrid()
+				room.Monsters = append(room.Monsters, createTestMonster("goblin", 1, 1), createTestMonster("orc", 1, 1))
+				return room
+			},
+		},
+		{
+			name:          "Nil room",
+			setupRoom:     func() *entities.Room { return nil },
+			expectedCodes: []string{ValidationNilRoom},
+		},
+		{
+			name: "Partial grid",
+			setupRoom: func() *entities.Room {
+				room := createTestRoom()
+				room.Grid = room.Grid[:3]
+				room.Monsters = append(room.Monsters, createTestMonster("goblin", 1, 4))
+				return room
+			},
+			expectedCodes: []string{ValidationGridSize},
+		},
+		{
+			name: "Entity out of bounds",
+			setupRoom: func() *entities.Room {
+				room := createTestRoomNoGrid()
+				room.Players = append(room.Players, createTestPlayer("fighter", 3, 10, 2))
+				return room
+			},
+			expectedCodes: []string{ValidationOutOfBounds},
+			expectedID:    "fighter",
+		},
+		{
+			name: "Duplicate IDs across types",
+			setupRoom: func() *entities.Room {
+				room := createTestRoom()
+				require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "shared", Position: entities.Position{X: 0, Y: 0}}))
+				require.NoError(t, PlaceEntity(room, &entities.Item{ID: "shared", Position: entities.Position{X: 1, Y: 0}}))
+				return room
+			},
+			expectedCodes: []string{ValidationDuplicateID},
+			expectedID:    "shared",
+		},
+		{
+			name: "Entity missing from grid",
+			setupRoom: func() *entities.Room {
+				room := createTestRoom()
+				room.Monsters = append(room.Monsters, createTestMonster("goblin", 2, 2))
+				return room
+			},
+			expectedCodes: []string{ValidationGridMismatch},
+			expectedID:    "goblin",
+		},
+		{
+			name: "Grid cell without entity",
+			setupRoom: func() *entities.Room {
+				room := createTestRoom()
+				room.Grid[3][1] = entities.Cell{Type: entities.CellNPC, EntityID: "ghost"}
+				return room
+			},
+			expectedCodes: []string{ValidationGridMismatch},
+			expectedID:    "ghost",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateRoom(tc.setupRoom())
+
+			codes := []string{}
+			for _, err := range errs {
+				codes = append(codes, err.Code)
+				assert.NotEmpty(t, err.Message)
+				assert.Contains(t, err.Error(), err.Code)
+			}
+			if tc.expectedCodes == nil {
+				assert.Empty(t, codes)
+				return
+			}
+			assert.Equal(t, tc.expectedCodes, codes)
+			assert.Equal(t, tc.expectedID, errs[0].EntityID)
+		})
+	}
+}
+
+func TestCanReachInOneTurn(t *testing.T) {
+	room := NewRoom(30, 30, entities.LightLevelBright)
+	target := &entities.Player{ID: "fighter", Position: entities.Position{X: 0, Y: 0}}
+
+	testCases := []struct {
+		name       string
+		entity     entities.Placeable
+		cellSizeFt int
+		reach      bool
+		dash       bool
+	}{
+		{"Default speed at 30 ft", &entities.Monster{ID: "goblin", Position: entities.Position{X: 6, Y: 2}}, 0, true, true},
+		{"Default speed at 35 ft", &entities.Monster{ID: "goblin", Position: entities.Position{X: 7, Y: 0}}, 0, false, true},
+		{"Slow NPC", &entities.NPC{ID: "dwarf", Speed: 25, Position: entities.Position{X: 6, Y: 6}}, 0, false, true},
+		{"Fast player", &entities.Player{ID: "monk", Speed: 45, Position: entities.Position{X: 9, Y: 9}}, 0, true, true},
+		{"Out of dash range", &entities.Monster{ID: "zombie", Speed: 20, Position: entities.Position{X: 9, Y: 0}}, 0, false, false},
+		{"Exactly dash range", &entities.Monster{ID: "zombie", Speed: 20, Position: entities.Position{X: 8, Y: 0}}, 0, false, true},
+		{"Large cells", &entities.Monster{ID: "wolf", Speed: 40, Position: entities.Position{X: 4, Y: 0}}, 10, true, true},
+		{"Large cells out of range", &entities.Monster{ID: "wolf", Speed: 40, Position: entities.Position{X: 5, Y: 0}}, 10, false, true},
+		{"Items cannot move", &entities.Item{ID: "sword", Position: entities.Position{X: 1, Y: 0}}, 0, false, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			room.CellSizeFt = tc.cellSizeFt
+			assert.Equal(t, tc.reach, CanReachInOneTurn(room, tc.entity, target))
+			assert.Equal(t, tc.dash, CanReachWithDash(room, tc.entity, target))
+		})
+	}
+
+	monster := &entities.Monster{ID: "goblin"}
+	assert.False(t, CanReachInOneTurn(nil, monster, target))
+	assert.False(t, CanReachInOneTurn(room, nil, target))
+	assert.False(t, CanReachWithDash(room, monster, nil))
+}
+
+func TestPlayerAndNPCConfigSpeed(t *testing.T) {
+	service, err := NewRoomService()
+	require.NoError(t, err)
+
+	player, err := PlayerConfig{Name: "Monk", Level: 3, Speed: 40}.CreatePlaceable(service)
+	require.NoError(t, err)
+	assert.Equal(t, 40, player.(*entities.Player).Speed)
+
+	npc, err := NPCConfig{Name: "Courier", Speed: 35}.CreatePlaceable(service)
+	require.NoError(t, err)
+	assert.Equal(t, 35, npc.(*entities.NPC).Speed)
+}
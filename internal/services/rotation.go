@@ -0,0 +1,261 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// RotateRoom returns a copy of the room rotated clockwise by 90, 180, or 270 degrees
+// The copy gets a new ID as with CloneRoom, entity IDs are kept, and positions, grid cells, difficult terrain,
+// and pack formation centers are remapped. The copy has no encounter start, since the original's snapshot
+// describes the unrotated room. The original room is not modified
+func (s *RoomService) RotateRoom(room *entities.Room, degrees int) (*entities.Room, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+	if degrees != 90 && degrees != 180 && degrees != 270 {
+		return nil, fmt.Errorf("rotation must be 90, 180, or 270 degrees, got %d", degrees)
+	}
+
+	// The copy has no random source, so its ID does not advance the original's
+	rotated := copyRoom(room)
+	rotated.ID = newEntityID(rotated)
+	rotated.EncounterReset = nil
+	for i := 0; i < degrees/90; i++ {
+		rotateRoomClockwise(rotated)
+	}
+
+	return rotated, nil
+}
+
+// rotatePositionClockwise maps a position in a width x height room to its position
+// after a 90 degree clockwise rotation, in a room that is height x width
+func rotatePositionClockwise(pos entities.Position, height int) entities.Position {
+	return entities.Position{X: height - 1 - pos.Y, Y: pos.X}
+}
+
+// rotateRoomClockwise rotates the room 90 degrees clockwise in place
+func rotateRoomClockwise(room *entities.Room) {
+	height := room.Height
+
+	for i := range room.Monsters {
+		room.Monsters[i].Position = rotatePositionClockwise(room.Monsters[i].Position, height)
+	}
+	for i := range room.Players {
+		room.Players[i].Position = rotatePositionClockwise(room.Players[i].Position, height)
+	}
+	for i := range room.Items {
+		room.Items[i].Position = rotatePositionClockwise(room.Items[i].Position, height)
+	}
+	for i := range room.NPCs {
+		room.NPCs[i].Position = rotatePositionClockwise(room.NPCs[i].Position, height)
+	}
+	for i := range room.Obstacles {
+		// The bottom-left cell of a multi-cell obstacle becomes its top-left cell
+		obstacle := &room.Obstacles[i]
+		bottomLeft := obstacle.Position.Add(entities.Position{Y: obstacle.Footprint().Height - 1})
+		obstacle.Position = rotatePositionClockwise(bottomLeft, height)
+		obstacle.Size.Width, obstacle.Size.Height = obstacle.Size.Height, obstacle.Size.Width
+	}
+	for i := range room.Traps {
+		room.Traps[i].Position = rotatePositionClockwise(room.Traps[i].Position, height)
+	}
+	for i := range room.Doors {
+		room.Doors[i].Position = rotatePositionClockwise(room.Doors[i].Position, height)
+	}
+	for i := range room.MonsterPacks {
+		room.MonsterPacks[i].FormationCenter = rotatePositionClockwise(room.MonsterPacks[i].FormationCenter, height)
+	}
+	for i := range room.Connections {
+		room.Connections[i].Position = rotatePositionClockwise(room.Connections[i].Position, height)
+	}
+	for i := range room.SpellZones {
+		zone := &room.SpellZones[i]
+		zone.Origin = rotatePositionClockwise(zone.Origin, height)
+		zone.Position = rotatePositionClockwise(zone.Position, height)
+		for j := range zone.AffectedPositions {
+			zone.AffectedPositions[j] = rotatePositionClockwise(zone.AffectedPositions[j], height)
+		}
+	}
+
+	if room.DifficultTerrain != nil {
+		terrain := make(map[entities.Position]bool, len(room.DifficultTerrain))
+		for pos, difficult := range room.DifficultTerrain {
+			terrain[rotatePositionClockwise(pos, height)] = difficult
+		}
+		room.DifficultTerrain = terrain
+	}
+
+	if room.Grid != nil {
+		room.Grid = rotateGridClockwise(room.Grid, room.Width, height)
+	}
+	if room.VisibilityGrid != nil {
+		room.VisibilityGrid = rotateGridClockwise(room.VisibilityGrid, room.Width, height)
+	}
+
+	room.Width, room.Height = room.Height, room.Width
+}
+
+// rotateGridClockwise returns a copy of a width by height grid turned 90 degrees clockwise
+func rotateGridClockwise[T any](grid [][]T, width, height int) [][]T {
+	rotated := make([][]T, width)
+	for y := range rotated {
+		rotated[y] = make([]T, height)
+	}
+	for y := range grid {
+		for x := range grid[y] {
+			newPos := rotatePositionClockwise(entities.Position{X: x, Y: y}, height)
+			rotated[newPos.Y][newPos.X] = grid[y][x]
+		}
+	}
+	return rotated
+}
+
+// CloneRoom returns a deep copy of the room with a newly generated ID
+// Entity IDs are kept, so the clone holds the same entities as the original. The clone has no encounter
+// start, since resetting it to the original's snapshot would bring back the original room
+func (s *RoomService) CloneRoom(room *entities.Room) (*entities.Room, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+
+	// The clone has no random source, so its ID does not advance the original's
+	clone := Clone(room)
+	clone.ID = newEntityID(clone)
+	clone.EncounterReset = nil
+	return clone, nil
+}
+
+// copyRoom returns a deep copy of the room so it can be modified independently
+func copyRoom(room *entities.Room) *entities.Room {
+	clone := *room
+	// A random source cannot be copied, so copies place entities with the shared source
+	clone.Rand = nil
+	clone.RoomType = cloneRoomType(room.RoomType)
+
+	clone.Monsters = cloneSlice(room.Monsters)
+	for i := range clone.Monsters {
+		clone.Monsters[i].Conditions = cloneSlice(room.Monsters[i].Conditions)
+		clone.Monsters[i].ConditionImmunities = cloneSlice(room.Monsters[i].ConditionImmunities)
+	}
+	clone.Players = cloneSlice(room.Players)
+	for i := range clone.Players {
+		clone.Players[i].Conditions = cloneSlice(room.Players[i].Conditions)
+	}
+	clone.Items = cloneItems(room.Items)
+	clone.Obstacles = cloneSlice(room.Obstacles)
+	clone.Traps = cloneSlice(room.Traps)
+	clone.Doors = cloneSlice(room.Doors)
+
+	clone.NPCs = cloneSlice(room.NPCs)
+	for i := range clone.NPCs {
+		clone.NPCs[i].Inventory = cloneItems(room.NPCs[i].Inventory)
+		clone.NPCs[i].Conditions = cloneSlice(room.NPCs[i].Conditions)
+	}
+
+	clone.MonsterPacks = cloneSlice(room.MonsterPacks)
+	for i := range clone.MonsterPacks {
+		clone.MonsterPacks[i].MonsterIDs = cloneSlice(room.MonsterPacks[i].MonsterIDs)
+	}
+
+	clone.Atmosphere.Sounds = cloneSlice(room.Atmosphere.Sounds)
+	clone.Atmosphere.Smells = cloneSlice(room.Atmosphere.Smells)
+	clone.Connections = cloneSlice(room.Connections)
+	clone.BattleLog = cloneSlice(room.BattleLog)
+	clone.EventLog = cloneSlice(room.EventLog)
+	for i := range clone.EventLog {
+		clone.EventLog[i].OldPosition = clonePosition(room.EventLog[i].OldPosition)
+		clone.EventLog[i].NewPosition = clonePosition(room.EventLog[i].NewPosition)
+	}
+	clone.InitiativeOrder = cloneSlice(room.InitiativeOrder)
+	clone.SpellZones = cloneSlice(room.SpellZones)
+	for i := range clone.SpellZones {
+		clone.SpellZones[i].AffectedPositions = cloneSlice(room.SpellZones[i].AffectedPositions)
+	}
+	if room.EncounterReset != nil {
+		reset := *room.EncounterReset
+		if reset.StartSnapshot != nil {
+			reset.StartSnapshot = copyRoom(reset.StartSnapshot)
+		}
+		clone.EncounterReset = &reset
+	}
+
+	if room.DifficultTerrain != nil {
+		clone.DifficultTerrain = make(map[entities.Position]bool, len(room.DifficultTerrain))
+		for pos, difficult := range room.DifficultTerrain {
+			clone.DifficultTerrain[pos] = difficult
+		}
+	}
+
+	if room.Tags != nil {
+		clone.Tags = make(map[string]string, len(room.Tags))
+		for key, value := range room.Tags {
+			clone.Tags[key] = value
+		}
+	}
+
+	clone.Grid = cloneGrid(room.Grid)
+	clone.VisibilityGrid = cloneGrid(room.VisibilityGrid)
+
+	return &clone
+}
+
+// cloneRoomType returns a copy of the room type so state such as a triggered ambush is not shared
+// The other room types carry no state and are returned as they are
+func cloneRoomType(roomType entities.RoomType) entities.RoomType {
+	switch t := roomType.(type) {
+	case *entities.AmbushRoomType:
+		if t == nil {
+			return t
+		}
+		clone := *t
+		clone.AmbusherIDs = cloneSlice(t.AmbusherIDs)
+		return &clone
+	case *entities.PuzzleRoomType:
+		if t == nil {
+			return t
+		}
+		clone := *t
+		return &clone
+	}
+	return roomType
+}
+
+// clonePosition returns a copy of the position, or nil for nil
+func clonePosition(pos *entities.Position) *entities.Position {
+	if pos == nil {
+		return nil
+	}
+	clone := *pos
+	return &clone
+}
+
+// cloneSlice returns a shallow copy of the slice, preserving nil versus empty
+func cloneSlice[T any](s []T) []T {
+	if s == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(s)), s...)
+}
+
+// cloneGrid returns a copy of the grid that shares no rows with the original, preserving nil
+func cloneGrid[T any](grid [][]T) [][]T {
+	if grid == nil {
+		return nil
+	}
+	clone := make([][]T, len(grid))
+	for y := range grid {
+		clone[y] = cloneSlice(grid[y])
+	}
+	return clone
+}
+
+// cloneItems returns a copy of the items that shares no slices with the original
+func cloneItems(items []entities.Item) []entities.Item {
+	clone := cloneSlice(items)
+	for i := range clone {
+		clone[i].Properties = cloneSlice(items[i].Properties)
+	}
+	return clone
+}
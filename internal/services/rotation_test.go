@@ -0,0 +1,172 @@
+package services
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// createRotationTestRoom creates a 3x5 gridded room with a monster, player, and blocking obstacle
+func createRotationTestRoom(t *testing.T) *entities.Room {
+	room := NewRoom(3, 5, entities.LightLevelBright)
+	InitializeGrid(room)
+
+	monster := createTestMonster("m1", 0, 0)
+	player := createTestPlayer("p1", 1, 2, 4)
+	obstacle := entities.Obstacle{ID: "o1", Name: "Pillar", Key: "pillar", Position: entities.Position{X: 1, Y: 3}, Blocking: true}
+	require.NoError(t, PlaceEntity(room, &monster))
+	require.NoError(t, PlaceEntity(room, &player))
+	require.NoError(t, PlaceEntity(room, &obstacle))
+	room.SetDifficultTerrain(entities.Position{X: 2, Y: 1}, true)
+
+	return room
+}
+
+func TestRotateRoom(t *testing.T) {
+	testCases := []struct {
+		name              string
+		degrees           int
+		expectedWidth     int
+		expectedHeight    int
+		expectedMonster   entities.Position
+		expectedPlayer    entities.Position
+		expectedObstacle  entities.Position
+		expectedDifficult entities.Position
+	}{
+		{
+			// (x,y) -> (H-1-y, x) with H=5
+			name:              "90 degrees",
+			degrees:           90,
+			expectedWidth:     5,
+			expectedHeight:    3,
+			expectedMonster:   entities.Position{X: 4, Y: 0},
+			expectedPlayer:    entities.Position{X: 0, Y: 2},
+			expectedObstacle:  entities.Position{X: 1, Y: 1},
+			expectedDifficult: entities.Position{X: 3, Y: 2},
+		},
+		{
+			// (x,y) -> (W-1-x, H-1-y)
+			name:              "180 degrees",
+			degrees:           180,
+			expectedWidth:     3,
+			expectedHeight:    5,
+			expectedMonster:   entities.Position{X: 2, Y: 4},
+			expectedPlayer:    entities.Position{X: 0, Y: 0},
+			expectedObstacle:  entities.Position{X: 1, Y: 1},
+			expectedDifficult: entities.Position{X: 0, Y: 3},
+		},
+		{
+			// (x,y) -> (y, W-1-x)
+			name:              "270 degrees",
+			degrees:           270,
+			expectedWidth:     5,
+			expectedHeight:    3,
+			expectedMonster:   entities.Position{X: 0, Y: 2},
+			expectedPlayer:    entities.Position{X: 4, Y: 0},
+			expectedObstacle:  entities.Position{X: 3, Y: 1},
+			expectedDifficult: entities.Position{X: 1, Y: 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			room := createRotationTestRoom(t)
+			service := &RoomService{}
+
+			rotated, err := service.RotateRoom(room, tc.degrees)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedWidth, rotated.Width)
+			assert.Equal(t, tc.expectedHeight, rotated.Height)
+			require.Len(t, rotated.Grid, tc.expectedHeight)
+			assert.Len(t, rotated.Grid[0], tc.expectedWidth)
+
+			assert.NotEqual(t, room.ID, rotated.ID, "the rotated room gets its own ID")
+			assert.True(t, rotated.IsValidID())
+			assert.Equal(t, "m1", rotated.Monsters[0].ID)
+			assert.Equal(t, tc.expectedMonster, rotated.Monsters[0].Position)
+			assert.Equal(t, tc.expectedPlayer, rotated.Players[0].Position)
+			assert.Equal(t, tc.expectedObstacle, rotated.Obstacles[0].Position)
+			assert.True(t, rotated.Obstacles[0].Blocking)
+			assert.True(t, rotated.IsDifficultTerrain(tc.expectedDifficult))
+
+			assert.Equal(t, entities.Cell{Type: entities.CellMonster, EntityID: "m1"}, rotated.Grid[tc.expectedMonster.Y][tc.expectedMonster.X])
+			assert.Equal(t, entities.Cell{Type: entities.CellObstacle, EntityID: "o1"}, rotated.Grid[tc.expectedObstacle.Y][tc.expectedObstacle.X])
+			assert.Empty(t, ValidateRoomGrid(rotated))
+
+			// The original room is untouched
+			assert.Equal(t, 3, room.Width)
+			assert.Equal(t, entities.Position{X: 0, Y: 0}, room.Monsters[0].Position)
+		})
+	}
+}
+
+func TestRotateRoomFullTurn(t *testing.T) {
+	room := createRotationTestRoom(t)
+	service := &RoomService{}
+
+	rotated := room
+	for i := 0; i < 4; i++ {
+		var err error
+		rotated, err = service.RotateRoom(rotated, 90)
+		require.NoError(t, err)
+	}
+	rotated.ID = room.ID
+	assert.Equal(t, room, rotated)
+
+	halfway, err := service.RotateRoom(room, 90)
+	require.NoError(t, err)
+	back, err := service.RotateRoom(halfway, 270)
+	require.NoError(t, err)
+	back.ID = room.ID
+	assert.Equal(t, room, back)
+}
+
+func TestDerivedRoomsKeepRandomState(t *testing.T) {
+	service := &RoomService{}
+	room := createRotationTestRoom(t)
+	room.Rand = rand.New(rand.NewSource(3))
+	expected := rand.New(rand.NewSource(3))
+
+	clone, err := service.CloneRoom(room)
+	require.NoError(t, err)
+	rotated, err := service.RotateRoom(room, 90)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, room.ID, clone.ID)
+	assert.NotEqual(t, clone.ID, rotated.ID)
+	assert.Equal(t, expected.Int63(), room.Rand.Int63(), "deriving rooms must not draw from the original's random source")
+}
+
+func TestRotateRoomInvalid(t *testing.T) {
+	service := &RoomService{}
+
+	_, err := service.RotateRoom(nil, 90)
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+
+	for _, degrees := range []int{0, 45, 360, -90} {
+		_, err := service.RotateRoom(createTestRoom(), degrees)
+		assert.ErrorContains(t, err, "must be 90, 180, or 270")
+	}
+}
+
+func TestRotateRoomMultiCellObstacle(t *testing.T) {
+	service := &RoomService{}
+	room := NewRoom(4, 3, entities.LightLevelBright)
+	InitializeGrid(room)
+	wall := entities.Obstacle{ID: "o1", Position: entities.Position{X: 1, Y: 0}, Size: entities.Size{Width: 3, Height: 1}, Blocking: true}
+	require.NoError(t, PlaceEntity(room, &wall))
+
+	rotated, err := service.RotateRoom(room, 90)
+	require.NoError(t, err)
+
+	// The top row of the 4x3 room becomes the right column of the 3x4 room
+	require.Len(t, rotated.Obstacles, 1)
+	assert.Equal(t, entities.Position{X: 2, Y: 1}, rotated.Obstacles[0].Position)
+	assert.Equal(t, entities.Size{Width: 1, Height: 3}, rotated.Obstacles[0].Size)
+	assert.Empty(t, ValidateRoomGrid(rotated))
+}
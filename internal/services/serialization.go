@@ -0,0 +1,92 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// ExportRoomToJSON encodes the room, its entities, and its grid as JSON so it can be stored between sessions
+func ExportRoomToJSON(room *entities.Room) ([]byte, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+
+	data, err := json.Marshal(room)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export room: %w", err)
+	}
+	return data, nil
+}
+
+// ImportRoomFromJSON decodes a room written by ExportRoomToJSON
+// Returns an error if the data is not a valid room or its grid does not match its dimensions
+func ImportRoomFromJSON(data []byte) (*entities.Room, error) {
+	room := &entities.Room{}
+	if err := json.Unmarshal(data, room); err != nil {
+		return nil, fmt.Errorf("failed to import room: %w", err)
+	}
+	if err := validateGridSize(room); err != nil {
+		return nil, fmt.Errorf("failed to import room: %w", err)
+	}
+
+	return room, nil
+}
+
+// validateGridSize checks that a room's grid, if it has one, matches the room's dimensions
+func validateGridSize(room *entities.Room) error {
+	if room.Grid == nil {
+		return nil
+	}
+	if len(room.Grid) != room.Height {
+		return fmt.Errorf("grid has %d rows, expected %d", len(room.Grid), room.Height)
+	}
+	for y, row := range room.Grid {
+		if len(row) != room.Width {
+			return fmt.Errorf("grid row %d has %d cells, expected %d", y, len(row), room.Width)
+		}
+	}
+	return nil
+}
+
+// ExportDungeonToJSON encodes the dungeon's rooms and connections as JSON
+func ExportDungeonToJSON(dungeon *entities.DungeonMap) ([]byte, error) {
+	if dungeon == nil {
+		return nil, fmt.Errorf("dungeon cannot be nil")
+	}
+
+	data, err := json.Marshal(dungeon)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export dungeon: %w", err)
+	}
+	return data, nil
+}
+
+// ImportDungeonFromJSON decodes a dungeon written by ExportDungeonToJSON
+// Returns an error if a room is invalid (see ImportRoomFromJSON), is stored under a key other than its ID,
+// or a connection refers to a room the dungeon does not have
+func ImportDungeonFromJSON(data []byte) (*entities.DungeonMap, error) {
+	dungeon := entities.NewDungeonMap()
+	if err := json.Unmarshal(data, dungeon); err != nil {
+		return nil, fmt.Errorf("failed to import dungeon: %w", err)
+	}
+
+	for id, room := range dungeon.Rooms {
+		if room == nil || room.ID != id {
+			return nil, fmt.Errorf("failed to import dungeon: room stored under %s has a different ID", id)
+		}
+		if err := validateGridSize(room); err != nil {
+			return nil, fmt.Errorf("failed to import dungeon: room %s: %w", id, err)
+		}
+	}
+	for _, conn := range dungeon.Connections {
+		for _, id := range []string{conn.FromRoomID, conn.ToRoomID} {
+			if _, err := dungeon.GetRoom(id); err != nil {
+				return nil, fmt.Errorf("failed to import dungeon: connection %s to %s: %w", conn.FromRoomID, conn.ToRoomID, err)
+			}
+		}
+	}
+
+	return dungeon, nil
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// createPopulatedRoom creates a gridded room holding every entity type and most optional room state
+func createPopulatedRoom(t *testing.T) *entities.Room {
+	room := NewRoom(6, 4, entities.LightLevelDim)
+	InitializeGrid(room)
+	room.Description = "A flooded crypt"
+	room.RoomType = &entities.PuzzleRoomType{Puzzle: "Three levers", SolutionKey: "middle-lever"}
+
+	monster := entities.Monster{ID: "m1", Key: "ghoul", Name: "Ghoul 1", CR: 1, XP: 200, MaxHP: 22, CurrentHP: 9, Position: entities.Position{X: 1, Y: 1},
+		Conditions: []entities.Condition{entities.ConditionProne}, ConditionImmunities: []entities.Condition{entities.ConditionPoisoned}, Size: entities.SizeMedium, Darkvision: 60}
+	player := entities.Player{ID: "p1", Name: "Vex", Level: 5, Position: entities.Position{X: 0, Y: 0}, Darkvision: 60}
+	npc := entities.NPC{ID: "n1", Name: "Gravekeeper", Position: entities.Position{X: 5, Y: 3},
+		Inventory: []entities.Item{{ID: "key", Key: "iron-key", Name: "Iron Key", Value: 1, ValueUnit: "sp"}}}
+	item := entities.Item{ID: "i1", Key: "longsword", Name: "Longsword", Type: "weapon", Value: 15, ValueUnit: "gp", Position: entities.Position{X: 3, Y: 2},
+		Properties: []string{"versatile"}, DamageDice: "1d8", DamageType: "slashing", Cursed: true, CurseDescription: "It will not let go"}
+	obstacle := entities.Obstacle{ID: "o1", Key: "pillar", Name: "Pillar", Blocking: true, Position: entities.Position{X: 2, Y: 2}}
+
+	require.NoError(t, PlaceEntity(room, &monster))
+	require.NoError(t, PlaceEntity(room, &player))
+	require.NoError(t, PlaceEntity(room, &npc))
+	require.NoError(t, PlaceEntity(room, &item))
+	require.NoError(t, PlaceEntity(room, &obstacle))
+
+	room.SetDifficultTerrain(entities.Position{X: 4, Y: 0}, true)
+	room.SetDifficultTerrain(entities.Position{X: 4, Y: 1}, true)
+	room.Atmosphere = entities.Atmosphere{Temperature: entities.TemperatureCold, Sounds: []string{"dripping water"}}
+	room.InitiativeOrder = []entities.InitiativeEntry{{EntityID: "p1", CellType: entities.CellPlayer, Roll: 17, DexModifier: 3}}
+	return room
+}
+
+func TestExportImportRoomRoundTrip(t *testing.T) {
+	room := createPopulatedRoom(t)
+	room.EncounterReset = &entities.EncounterResetData{StartSnapshot: copyRoom(room), TimesReset: 2}
+
+	data, err := ExportRoomToJSON(room)
+	require.NoError(t, err)
+
+	imported, err := ImportRoomFromJSON(data)
+	require.NoError(t, err)
+	assert.Equal(t, room, imported)
+
+	// Spot check the fields most likely to be lost
+	assert.Equal(t, room.ID, imported.ID)
+	assert.Equal(t, entities.LightLevelDim, imported.LightLevel)
+	assert.Equal(t, "Iron Key", imported.NPCs[0].Inventory[0].Name)
+	assert.Equal(t, entities.Cell{Type: entities.CellObstacle, EntityID: "o1"}, imported.Grid[2][2])
+	assert.True(t, imported.IsDifficultTerrain(entities.Position{X: 4, Y: 1}))
+	assert.Equal(t, &entities.PuzzleRoomType{Puzzle: "Three levers", SolutionKey: "middle-lever"}, imported.RoomType)
+	assert.Equal(t, room.ID, imported.EncounterReset.StartSnapshot.ID)
+}
+
+func TestExportImportRoomTypes(t *testing.T) {
+	roomTypes := []entities.RoomType{
+		nil,
+		&entities.CombatRoomType{},
+		&entities.TreasureRoomType{},
+		&entities.TrapRoomType{},
+		&entities.PuzzleRoomType{Puzzle: "Riddle"},
+		&entities.AmbushRoomType{AmbusherIDs: []string{"m1"}, Triggered: true},
+	}
+
+	for _, roomType := range roomTypes {
+		room := createTestRoomNoGrid()
+		room.RoomType = roomType
+
+		data, err := ExportRoomToJSON(room)
+		require.NoError(t, err)
+		imported, err := ImportRoomFromJSON(data)
+		require.NoError(t, err)
+		assert.Equal(t, room, imported)
+		assert.Nil(t, imported.Grid, "gridless rooms stay gridless")
+	}
+}
+
+func TestImportRoomFromJSONErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{"invalid JSON", `{"Width": 5`},
+		{"unknown room type", `{"Width": 1, "Height": 1, "RoomType": {"Type": "dragon-lair"}}`},
+		{"grid height mismatch", `{"Width": 1, "Height": 2, "Grid": [[{"Type": 0, "EntityID": ""}]]}`},
+		{"grid width mismatch", `{"Width": 2, "Height": 1, "Grid": [[{"Type": 0, "EntityID": ""}]]}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ImportRoomFromJSON([]byte(tc.data))
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := ExportRoomToJSON(nil)
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
+
+func TestExportImportDungeonRoundTrip(t *testing.T) {
+	dungeon := entities.NewDungeonMap()
+	crypt := createPopulatedRoom(t)
+	hall := NewRoom(4, 4, entities.LightLevelBright)
+	hall.Doors = []entities.Door{{ID: "hall-door", Position: entities.Position{X: 0, Y: 3}}}
+	vault := NewRoom(2, 2, entities.LightLevelDark)
+	vault.SetTag("boss", "lich")
+	for _, room := range []*entities.Room{crypt, hall, vault} {
+		dungeon.AddRoom(room)
+	}
+	require.NoError(t, dungeon.ConnectRooms(hall.ID, crypt.ID, "hall-door", "", "north"))
+	require.NoError(t, dungeon.ConnectRooms(crypt.ID, vault.ID, "", "", "down"))
+
+	data, err := ExportDungeonToJSON(dungeon)
+	require.NoError(t, err)
+	imported, err := ImportDungeonFromJSON(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, dungeon, imported)
+	assert.Len(t, imported.GetNeighbors(crypt.ID), 2)
+
+	_, err = ExportDungeonToJSON(nil)
+	assert.Error(t, err)
+}
+
+func TestImportDungeonFromJSONErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{"invalid JSON", `{"Rooms": {`},
+		{"room key mismatch", `{"Rooms": {"a": {"ID": "b", "Width": 1, "Height": 1}}}`},
+		{"null room", `{"Rooms": {"a": null}}`},
+		{"invalid room grid", `{"Rooms": {"a": {"ID": "a", "Width": 2, "Height": 1, "Grid": [[{"Type": 0, "EntityID": ""}]]}}}`},
+		{"missing connected room", `{"Rooms": {"a": {"ID": "a", "Width": 1, "Height": 1}}, "Connections": [{"FromRoomID": "a", "ToRoomID": "b"}]}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ImportDungeonFromJSON([]byte(tc.data))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// DimLightSightRangeFt is how far a creature without darkvision can see in dim light
+const DimLightSightRangeFt = 30
+
+// DefaultVisibilityRangeFt is how far UpdateVisibility reveals the room around a player
+const DefaultVisibilityRangeFt = 60
+
+// SightlineReport holds what a single entity can see
+type SightlineReport struct {
+	EntityID         string
+	CellType         entities.CellType
+	VisiblePositions []entities.Position  // Cells the entity can see, in row-major order
+	VisibleEntities  []entities.Placeable // Other entities standing in visible cells
+}
+
+// HasLineOfSight reports whether a straight line between the two positions is not blocked
+// The line is traced with Bresenham's algorithm, and only blocking obstacles and locked or barred doors on the
+// cells between the endpoints block it, so an entity standing behind a wall is hidden but the wall itself is visible
+func HasLineOfSight(room *entities.Room, from, to entities.Position) bool {
+	return lineOfSight(blockingObstaclePositions(room), from, to)
+}
+
+// lineOfSight traces the line between the positions against a precomputed set of blocked cells
+func lineOfSight(blocked map[entities.Position]bool, from, to entities.Position) bool {
+	for _, pos := range lineBetween(from, to) {
+		if blocked[pos] {
+			return false
+		}
+	}
+	return true
+}
+
+// lineBetween returns the cells on the Bresenham line between the positions, excluding both endpoints
+func lineBetween(from, to entities.Position) []entities.Position {
+	dx := to.X - from.X
+	if dx < 0 {
+		dx = -dx
+	}
+	dy := to.Y - from.Y
+	if dy > 0 {
+		dy = -dy
+	}
+	stepX, stepY := 1, 1
+	if from.X > to.X {
+		stepX = -1
+	}
+	if from.Y > to.Y {
+		stepY = -1
+	}
+
+	cells := []entities.Position{}
+	current := from
+	err := dx + dy
+	for current != to {
+		if current != from {
+			cells = append(cells, current)
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			current.X += stepX
+		}
+		if e2 <= dx {
+			err += dx
+			current.Y += stepY
+		}
+	}
+
+	return cells
+}
+
+// ComputeSightlines returns every cell the entity can see and the other entities standing in them
+// Cells must be in line of sight (see HasLineOfSight) and within sight range for the room's light:
+// unlimited in bright light, DimLightSightRangeFt in dim light, and nothing in darkness. Monsters,
+// players, and NPCs with darkvision see at least as far as their darkvision in dim light and darkness
+func (s *RoomService) ComputeSightlines(room *entities.Room, entityID string, cellType entities.CellType) ([]entities.Position, []entities.Placeable, error) {
+	if room == nil {
+		return nil, nil, entities.ErrNilRoom
+	}
+
+	viewer := findPlaceable(room, entityID, cellType)
+	if viewer == nil {
+		return nil, nil, fmt.Errorf("entity with ID %s not found in room", entityID)
+	}
+
+	report := computeSightline(room, viewer)
+	return report.VisiblePositions, report.VisibleEntities, nil
+}
+
+// GetSightlineReports computes the sightlines of every monster, player, and NPC in the room
+func (s *RoomService) GetSightlineReports(room *entities.Room) ([]SightlineReport, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+
+	reports := []SightlineReport{}
+	for _, p := range collectPlaceables(room) {
+		switch p.GetCellType() {
+		case entities.CellMonster, entities.CellPlayer, entities.CellNPC:
+			reports = append(reports, computeSightline(room, p))
+		}
+	}
+
+	return reports, nil
+}
+
+// CanSeeEachOther reports whether each of the two entities can see the other
+// Sight ranges can differ, for example when only one of them has darkvision
+func (s *RoomService) CanSeeEachOther(room *entities.Room, id1 string, type1 entities.CellType, id2 string, type2 entities.CellType) (bool, error) {
+	if room == nil {
+		return false, entities.ErrNilRoom
+	}
+
+	first := findPlaceable(room, id1, type1)
+	if first == nil {
+		return false, fmt.Errorf("entity with ID %s not found in room", id1)
+	}
+	second := findPlaceable(room, id2, type2)
+	if second == nil {
+		return false, fmt.Errorf("entity with ID %s not found in room", id2)
+	}
+
+	blocked := blockingObstaclePositions(room)
+	return canSee(room, blocked, first, second.GetPosition()) && canSee(room, blocked, second, first.GetPosition()), nil
+}
+
+// computeSightline builds the sightline report for a single entity
+func computeSightline(room *entities.Room, viewer entities.Placeable) SightlineReport {
+	report := SightlineReport{
+		EntityID:         viewer.GetID(),
+		CellType:         viewer.GetCellType(),
+		VisiblePositions: []entities.Position{},
+		VisibleEntities:  []entities.Placeable{},
+	}
+	blocked := blockingObstaclePositions(room)
+
+	for y := 0; y < room.Height; y++ {
+		for x := 0; x < room.Width; x++ {
+			pos := entities.Position{X: x, Y: y}
+			if pos != viewer.GetPosition() && canSee(room, blocked, viewer, pos) {
+				report.VisiblePositions = append(report.VisiblePositions, pos)
+			}
+		}
+	}
+
+	for _, p := range collectPlaceables(room) {
+		if p != viewer && canSee(room, blocked, viewer, p.GetPosition()) {
+			report.VisibleEntities = append(report.VisibleEntities, p)
+		}
+	}
+
+	return report
+}
+
+// canSee reports whether the viewer can see the position given the room's light and its darkvision
+func canSee(room *entities.Room, blocked map[entities.Position]bool, viewer entities.Placeable, pos entities.Position) bool {
+	from := viewer.GetPosition()
+	if pos == from {
+		return true
+	}
+
+	if rangeFt := sightRangeFt(room.LightLevel, darkvisionFt(viewer)); rangeFt >= 0 {
+		if DistanceBetween(from, pos, DistanceChebyshev)*float64(roomCellSizeFt(room)) > float64(rangeFt) {
+			return false
+		}
+	}
+
+	return lineOfSight(blocked, from, pos)
+}
+
+// sightRangeFt returns how far a creature can see in the light level, or -1 if its sight is unlimited
+func sightRangeFt(light entities.LightLevel, darkvision int) int {
+	switch light {
+	case entities.LightLevelDim:
+		return max(DimLightSightRangeFt, darkvision)
+	case entities.LightLevelDark:
+		return darkvision
+	}
+	return -1
+}
+
+// darkvisionFt returns the entity's darkvision range in feet, or 0 for entities that have none
+func darkvisionFt(p entities.Placeable) int {
+	switch e := p.(type) {
+	case *entities.Monster:
+		return e.Darkvision
+	case *entities.Player:
+		return e.Darkvision
+	case *entities.NPC:
+		return e.Darkvision
+	}
+	return 0
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestHasLineOfSight(t *testing.T) {
+	room := createTacticalRoom()
+	require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "pillar", Blocking: true, Position: entities.Position{X: 5, Y: 5}}))
+	require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "rubble", Position: entities.Position{X: 5, Y: 2}}))
+
+	testCases := []struct {
+		name     string
+		from, to entities.Position
+		expected bool
+	}{
+		{"open row", entities.Position{X: 0, Y: 0}, entities.Position{X: 9, Y: 0}, true},
+		{"behind pillar", entities.Position{X: 2, Y: 5}, entities.Position{X: 8, Y: 5}, false},
+		{"diagonal through pillar", entities.Position{X: 3, Y: 3}, entities.Position{X: 7, Y: 7}, false},
+		{"pillar itself", entities.Position{X: 2, Y: 5}, entities.Position{X: 5, Y: 5}, true},
+		{"non-blocking obstacle", entities.Position{X: 2, Y: 2}, entities.Position{X: 8, Y: 2}, true},
+		{"same cell", entities.Position{X: 1, Y: 1}, entities.Position{X: 1, Y: 1}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, HasLineOfSight(room, tc.from, tc.to))
+			assert.Equal(t, tc.expected, HasLineOfSight(room, tc.to, tc.from), "sight should be symmetric")
+		})
+	}
+}
+
+func TestComputeSightlinesObstacleBlocked(t *testing.T) {
+	service := &RoomService{}
+	room := createTacticalRoom()
+	require.NoError(t, PlaceEntity(room, &entities.Player{ID: "rogue", Position: entities.Position{X: 2, Y: 5}}))
+	require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "pillar", Blocking: true, Position: entities.Position{X: 5, Y: 5}}))
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "hidden", Position: entities.Position{X: 8, Y: 5}}))
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "exposed", Position: entities.Position{X: 8, Y: 0}}))
+
+	positions, visible, err := service.ComputeSightlines(room, "rogue", entities.CellPlayer)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"pillar", "exposed"}, placeableIDs(visible))
+	assert.Contains(t, positions, entities.Position{X: 5, Y: 5})
+	assert.NotContains(t, positions, entities.Position{X: 8, Y: 5})
+	assert.NotContains(t, positions, entities.Position{X: 2, Y: 5}, "the entity's own cell is not listed")
+
+	canSee, err := service.CanSeeEachOther(room, "rogue", entities.CellPlayer, "hidden", entities.CellMonster)
+	require.NoError(t, err)
+	assert.False(t, canSee)
+	canSee, err = service.CanSeeEachOther(room, "rogue", entities.CellPlayer, "exposed", entities.CellMonster)
+	require.NoError(t, err)
+	assert.True(t, canSee)
+
+	_, _, err = service.ComputeSightlines(room, "rogue", entities.CellMonster)
+	assert.Error(t, err)
+	_, _, err = service.ComputeSightlines(nil, "rogue", entities.CellPlayer)
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
+
+func TestComputeSightlinesLight(t *testing.T) {
+	service := &RoomService{}
+
+	// The orc has 60 ft of darkvision, the human has none, and they stand 45 ft apart
+	setup := func(light entities.LightLevel) *entities.Room {
+		room := NewRoom(14, 3, light)
+		InitializeGrid(room)
+		require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "orc", Darkvision: 60, Position: entities.Position{X: 0, Y: 1}}))
+		require.NoError(t, PlaceEntity(room, &entities.NPC{ID: "human", Position: entities.Position{X: 9, Y: 1}}))
+		return room
+	}
+	sees := func(room *entities.Room, id string, cellType entities.CellType) []string {
+		_, visible, err := service.ComputeSightlines(room, id, cellType)
+		require.NoError(t, err)
+		return placeableIDs(visible)
+	}
+
+	dark := setup(entities.LightLevelDark)
+	assert.Equal(t, []string{"human"}, sees(dark, "orc", entities.CellMonster), "darkvision sees in darkness")
+	assert.Empty(t, sees(dark, "human", entities.CellNPC))
+	positions, _, err := service.ComputeSightlines(dark, "orc", entities.CellMonster)
+	require.NoError(t, err)
+	assert.NotContains(t, positions, entities.Position{X: 13, Y: 1}, "65 ft is beyond darkvision")
+	assert.Contains(t, positions, entities.Position{X: 12, Y: 1})
+
+	dim := setup(entities.LightLevelDim)
+	assert.Equal(t, []string{"human"}, sees(dim, "orc", entities.CellMonster))
+	assert.Empty(t, sees(dim, "human", entities.CellNPC), "45 ft is beyond dim light sight")
+	canSee, err := service.CanSeeEachOther(dim, "orc", entities.CellMonster, "human", entities.CellNPC)
+	require.NoError(t, err)
+	assert.False(t, canSee)
+
+	// Bright light lets everyone see the whole room, beyond the reach of darkvision
+	bright := setup(entities.LightLevelBright)
+	require.NoError(t, PlaceEntity(bright, &entities.Item{ID: "torch", Position: entities.Position{X: 13, Y: 0}}))
+	assert.ElementsMatch(t, []string{"human", "torch"}, sees(bright, "orc", entities.CellMonster))
+	canSee, err = service.CanSeeEachOther(bright, "orc", entities.CellMonster, "human", entities.CellNPC)
+	require.NoError(t, err)
+	assert.True(t, canSee)
+	positions, _, err = service.ComputeSightlines(bright, "orc", entities.CellMonster)
+	require.NoError(t, err)
+	assert.Len(t, positions, 14*3-1)
+}
+
+func TestComputeSightlinesCellSize(t *testing.T) {
+	service := &RoomService{}
+	room := NewRoom(10, 1, entities.LightLevelDim)
+	room.CellSizeFt = 10
+	InitializeGrid(room)
+	require.NoError(t, PlaceEntity(room, &entities.NPC{ID: "human", Position: entities.Position{X: 0, Y: 0}}))
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "near", Position: entities.Position{X: 3, Y: 0}}))
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "far", Position: entities.Position{X: 4, Y: 0}}))
+
+	// Three 10 ft squares are within dim light sight, four are not
+	_, visible, err := service.ComputeSightlines(room, "human", entities.CellNPC)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"near"}, placeableIDs(visible))
+}
+
+func TestGetSightlineReports(t *testing.T) {
+	service := &RoomService{}
+	room := createTacticalRoom()
+	require.NoError(t, PlaceEntity(room, &entities.Player{ID: "fighter", Position: entities.Position{X: 0, Y: 0}}))
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "goblin", Position: entities.Position{X: 9, Y: 9}}))
+	require.NoError(t, PlaceEntity(room, &entities.Item{ID: "sword", Position: entities.Position{X: 5, Y: 0}}))
+
+	reports, err := service.GetSightlineReports(room)
+	require.NoError(t, err)
+	require.Len(t, reports, 2, "items do not get a report")
+	for _, report := range reports {
+		assert.Len(t, report.VisibleEntities, 2)
+	}
+}
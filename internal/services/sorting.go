@@ -0,0 +1,74 @@
+package services
+
+import (
+	"sort"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// SortMonstersByXP returns a copy of the monsters sorted by XP, highest first
+// Monsters with the same XP keep their relative order, and the original slice is not modified
+func SortMonstersByXP(monsters []entities.Monster) []entities.Monster {
+	sorted := cloneSlice(monsters)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].XP > sorted[j].XP
+	})
+	return sorted
+}
+
+// SortMonstersByCR returns a copy of the monsters sorted by CR, highest first
+// Monsters with the same CR keep their relative order, and the original slice is not modified
+func SortMonstersByCR(monsters []entities.Monster) []entities.Monster {
+	sorted := cloneSlice(monsters)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CR > sorted[j].CR
+	})
+	return sorted
+}
+
+// SortPlayersByLevel returns a copy of the players sorted by level, highest first
+// Players with the same level keep their relative order, and the original slice is not modified
+func SortPlayersByLevel(players []entities.Player) []entities.Player {
+	sorted := cloneSlice(players)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Level > sorted[j].Level
+	})
+	return sorted
+}
+
+// SortEntitiesByDistance returns a copy of the entities sorted by distance (see CalculateDistance) from a position, nearest first
+// Entities at the same distance keep their relative order, and the original slice is not modified
+func SortEntitiesByDistance(placeables []entities.Placeable, from entities.Position) []entities.Placeable {
+	sorted := cloneSlice(placeables)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return CalculateDistance(from, sorted[i].GetPosition()) < CalculateDistance(from, sorted[j].GetPosition())
+	})
+	return sorted
+}
+
+// SortInitiativeOrder sorts the room's initiative order by roll, highest first, breaking ties by Dex modifier
+// Entries with the same roll and modifier are left in any order unless the service was created WithStableSort,
+// in which case they keep their existing order
+func (s *RoomService) SortInitiativeOrder(room *entities.Room) error {
+	if room == nil {
+		return entities.ErrNilRoom
+	}
+
+	sortInitiative(room.InitiativeOrder, s.stableSort)
+	return nil
+}
+
+// sortInitiative sorts initiative entries by roll and then Dex modifier, highest first
+func sortInitiative(order []entities.InitiativeEntry, stable bool) {
+	less := func(i, j int) bool {
+		if order[i].Roll != order[j].Roll {
+			return order[i].Roll > order[j].Roll
+		}
+		return order[i].DexModifier > order[j].DexModifier
+	}
+	if stable {
+		sort.SliceStable(order, less)
+	} else {
+		sort.Slice(order, less)
+	}
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestSortMonsters(t *testing.T) {
+	monsters := []entities.Monster{
+		{ID: "goblin", CR: 0.25, XP: 50},
+		{ID: "ogre", CR: 2, XP: 450},
+		{ID: "wolf", CR: 0.25, XP: 50},
+		{ID: "bugbear", CR: 1, XP: 200},
+	}
+	original := cloneSlice(monsters)
+
+	byXP := SortMonstersByXP(monsters)
+	assert.Equal(t, []string{"ogre", "bugbear", "goblin", "wolf"}, monsterIDs(byXP))
+
+	byCR := SortMonstersByCR(monsters)
+	assert.Equal(t, []string{"ogre", "bugbear", "goblin", "wolf"}, monsterIDs(byCR))
+
+	assert.Equal(t, original, monsters, "the original slice is not modified")
+	assert.Nil(t, SortMonstersByXP(nil))
+}
+
+func TestSortPlayersByLevel(t *testing.T) {
+	players := []entities.Player{{ID: "rogue", Level: 3}, {ID: "wizard", Level: 5}, {ID: "cleric", Level: 4}}
+	original := cloneSlice(players)
+
+	sorted := SortPlayersByLevel(players)
+	require.Len(t, sorted, 3)
+	assert.Equal(t, "wizard", sorted[0].ID)
+	assert.Equal(t, "cleric", sorted[1].ID)
+	assert.Equal(t, "rogue", sorted[2].ID)
+	assert.Equal(t, original, players)
+}
+
+func TestSortEntitiesByDistance(t *testing.T) {
+	far := &entities.Monster{ID: "far", Position: entities.Position{X: 5, Y: 5}}
+	near := &entities.Player{ID: "near", Position: entities.Position{X: 1, Y: 0}}
+	middle := &entities.Item{ID: "middle", Position: entities.Position{X: 2, Y: 2}}
+	placeables := []entities.Placeable{far, near, middle}
+
+	sorted := SortEntitiesByDistance(placeables, entities.Position{})
+	assert.Equal(t, []string{"near", "middle", "far"}, placeableIDs(sorted))
+	assert.Equal(t, []string{"far", "near", "middle"}, placeableIDs(placeables), "the original slice is not modified")
+}
+
+func TestSortInitiativeOrder(t *testing.T) {
+	for _, stable := range []bool{false, true} {
+		service, err := NewRoomService(WithStableSort(stable))
+		require.NoError(t, err)
+
+		room := createTestRoom()
+		room.InitiativeOrder = []entities.InitiativeEntry{
+			{EntityID: "goblin", Roll: 12, DexModifier: 2},
+			{EntityID: "fighter", Roll: 15, DexModifier: 1},
+			{EntityID: "rogue", Roll: 12, DexModifier: 4},
+			{EntityID: "ogre", Roll: 8, DexModifier: -1},
+		}
+		require.NoError(t, service.SortInitiativeOrder(room))
+
+		ids := []string{}
+		for _, entry := range room.InitiativeOrder {
+			ids = append(ids, entry.EntityID)
+		}
+		assert.Equal(t, []string{"fighter", "rogue", "goblin", "ogre"}, ids)
+	}
+
+	assert.ErrorIs(t, (&RoomService{}).SortInitiativeOrder(nil), entities.ErrNilRoom)
+}
+
+func TestSortInitiativeOrderStableTies(t *testing.T) {
+	service, err := NewRoomService(WithStableSort(true))
+	require.NoError(t, err)
+
+	room := createTestRoom()
+	for _, id := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l", "m", "n"} {
+		room.InitiativeOrder = append(room.InitiativeOrder, entities.InitiativeEntry{EntityID: id, Roll: 10})
+	}
+	room.InitiativeOrder = append(room.InitiativeOrder, entities.InitiativeEntry{EntityID: "first", Roll: 20})
+	require.NoError(t, service.SortInitiativeOrder(room))
+
+	ids := []string{}
+	for _, entry := range room.InitiativeOrder {
+		ids = append(ids, entry.EntityID)
+	}
+	assert.Equal(t, []string{"first", "a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l", "m", "n"}, ids)
+}
+
+// monsterIDs returns the IDs of the monsters in order
+func monsterIDs(monsters []entities.Monster) []string {
+	ids := make([]string, len(monsters))
+	for i, monster := range monsters {
+		ids[i] = monster.ID
+	}
+	return ids
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"math"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// coneHalfAngle is half the opening angle of a D&D 5e cone, whose width equals its distance from the origin
+var coneHalfAngle = math.Atan(0.5)
+
+// areaEpsilon absorbs floating point error at the edges of areas
+const areaEpsilon = 1e-9
+
+// SphereArea returns the cells within radiusFt of center, including center
+// Cylinders cover the same cells on a flat grid
+func SphereArea(center entities.Position, radiusFt int) []entities.Position {
+	radius := float64(radiusFt) / entities.MovementStepFt
+	return cellsAround(center, int(radius), func(pos entities.Position) bool {
+		return DistanceBetween(center, pos, DistanceEuclidean) <= radius+areaEpsilon
+	})
+}
+
+// CubeArea returns the cells of a cube with sides of sideFt centered on center
+// Cubes with an even number of squares per side extend one square further up and left
+func CubeArea(center entities.Position, sideFt int) []entities.Position {
+	side := sideFt / entities.MovementStepFt
+	if side <= 0 {
+		return []entities.Position{}
+	}
+	minX, minY := center.X-side/2, center.Y-side/2
+	return cellsAround(center, side, func(pos entities.Position) bool {
+		return pos.X >= minX && pos.X < minX+side && pos.Y >= minY && pos.Y < minY+side
+	})
+}
+
+// ConeArea returns the cells of a cone of lengthFt extending from origin toward target, excluding origin
+// A cell is covered if its center is within the cone's length and opening angle
+func ConeArea(origin, target entities.Position, lengthFt int) []entities.Position {
+	length := float64(lengthFt) / entities.MovementStepFt
+	dirX, dirY := float64(target.X-origin.X), float64(target.Y-origin.Y)
+	dirLength := math.Hypot(dirX, dirY)
+
+	return cellsAround(origin, int(length), func(pos entities.Position) bool {
+		dx, dy := float64(pos.X-origin.X), float64(pos.Y-origin.Y)
+		distance := math.Hypot(dx, dy)
+		if distance == 0 || distance > length+areaEpsilon || dirLength == 0 {
+			return false
+		}
+		cos := (dx*dirX + dy*dirY) / (distance * dirLength)
+		return math.Acos(math.Min(cos, 1)) <= coneHalfAngle+areaEpsilon
+	})
+}
+
+// LineArea returns the cells of a 5 ft wide line of lengthFt extending from origin toward target, excluding origin
+func LineArea(origin, target entities.Position, lengthFt int) []entities.Position {
+	length := float64(lengthFt) / entities.MovementStepFt
+	dirX, dirY := float64(target.X-origin.X), float64(target.Y-origin.Y)
+	dirLength := math.Hypot(dirX, dirY)
+
+	return cellsAround(origin, int(length), func(pos entities.Position) bool {
+		if dirLength == 0 {
+			return false
+		}
+		dx, dy := float64(pos.X-origin.X), float64(pos.Y-origin.Y)
+		along := (dx*dirX + dy*dirY) / dirLength
+		across := math.Abs(dx*dirY-dy*dirX) / dirLength
+		return along > 0 && along <= length+areaEpsilon && across <= 0.5+areaEpsilon
+	})
+}
+
+// cellsAround returns the cells within radius squares of center that satisfy covered, in row-major order
+func cellsAround(center entities.Position, radius int, covered func(entities.Position) bool) []entities.Position {
+	cells := []entities.Position{}
+	for y := center.Y - radius; y <= center.Y+radius; y++ {
+		for x := center.X - radius; x <= center.X+radius; x++ {
+			if pos := (entities.Position{X: x, Y: y}); covered(pos) {
+				cells = append(cells, pos)
+			}
+		}
+	}
+	return cells
+}
+
+// GetCellsInCone returns the room's cells in a cone of lengthCells from origin, excluding origin
+// direction is in radians from the positive X axis toward positive Y, so 0 points right and π/2 points down the
+// grid. The cone is halfWidthCells wide on each side at its far end, and a cell is covered if its center is within
+// the cone's length and opening angle
+func GetCellsInCone(room *entities.Room, origin entities.Position, direction float64, lengthCells, halfWidthCells int) []entities.Position {
+	if room == nil {
+		return nil
+	}
+	if lengthCells <= 0 || halfWidthCells < 0 {
+		return []entities.Position{}
+	}
+
+	length := float64(lengthCells)
+	halfAngle := math.Atan2(float64(halfWidthCells), length)
+	dirX, dirY := math.Cos(direction), math.Sin(direction)
+	return cellsInRoom(room, origin, lengthCells, func(pos entities.Position) bool {
+		dx, dy := float64(pos.X-origin.X), float64(pos.Y-origin.Y)
+		distance := math.Hypot(dx, dy)
+		if distance == 0 || distance > length+areaEpsilon {
+			return false
+		}
+		cos := (dx*dirX + dy*dirY) / distance
+		return math.Acos(math.Max(math.Min(cos, 1), -1)) <= halfAngle+areaEpsilon
+	})
+}
+
+// GetCellsInLine returns the room's cells in a line widthCells wide from one position to another, excluding from
+// A cell is covered if its center is between the ends of the line and within half the width of it.
+// Widths below 1 count as 1, and a line from a position to itself covers nothing
+func GetCellsInLine(room *entities.Room, from, to entities.Position, widthCells int) []entities.Position {
+	if room == nil {
+		return nil
+	}
+
+	dirX, dirY := float64(to.X-from.X), float64(to.Y-from.Y)
+	length := math.Hypot(dirX, dirY)
+	if length == 0 {
+		return []entities.Position{}
+	}
+	halfWidth := float64(max(widthCells, 1)) / 2
+
+	return cellsInRoom(room, from, int(math.Ceil(length)), func(pos entities.Position) bool {
+		dx, dy := float64(pos.X-from.X), float64(pos.Y-from.Y)
+		along := (dx*dirX + dy*dirY) / length
+		across := math.Abs(dx*dirY-dy*dirX) / length
+		return along > 0 && along <= length+areaEpsilon && across <= halfWidth+areaEpsilon
+	})
+}
+
+// GetCellsInCircle returns the room's cells whose centers are within radiusCells of center, including center
+func GetCellsInCircle(room *entities.Room, center entities.Position, radiusCells int) []entities.Position {
+	if room == nil {
+		return nil
+	}
+	if radiusCells < 0 {
+		return []entities.Position{}
+	}
+
+	radius := float64(radiusCells)
+	return cellsInRoom(room, center, radiusCells, func(pos entities.Position) bool {
+		return DistanceBetween(center, pos, DistanceEuclidean) <= radius+areaEpsilon
+	})
+}
+
+// GetCellsInSquare returns the room's cells in the square reaching halfSide cells from center in each direction
+// The square is 2 x halfSide + 1 cells wide
+func GetCellsInSquare(room *entities.Room, center entities.Position, halfSide int) []entities.Position {
+	if room == nil {
+		return nil
+	}
+	if halfSide < 0 {
+		return []entities.Position{}
+	}
+
+	return cellsInRoom(room, center, halfSide, func(entities.Position) bool { return true })
+}
+
+// GetEntitiesInArea returns the room's entities that occupy any of the cells, in the order of collectPlaceables
+// Obstacles are included if any cell of their footprint is in the area. The returned pointers reference the
+// room's slices, so they must not be held across removals
+func GetEntitiesInArea(room *entities.Room, cells []entities.Position) []entities.Placeable {
+	if room == nil {
+		return nil
+	}
+
+	area := make(map[entities.Position]bool, len(cells))
+	for _, pos := range cells {
+		area[pos] = true
+	}
+
+	found := []entities.Placeable{}
+	for _, p := range collectPlaceables(room) {
+		for _, pos := range occupiedCells(p) {
+			if area[pos] {
+				found = append(found, p)
+				break
+			}
+		}
+	}
+	return found
+}
+
+// cellsInRoom returns the cells around center (see cellsAround) that are inside the room and satisfy covered
+func cellsInRoom(room *entities.Room, center entities.Position, radius int, covered func(entities.Position) bool) []entities.Position {
+	return cellsAround(center, radius, func(pos entities.Position) bool {
+		return IsPositionValid(room, pos) && covered(pos)
+	})
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestCubeArea(t *testing.T) {
+	assert.Len(t, CubeArea(entities.Position{X: 5, Y: 5}, 15), 9)
+	assert.Equal(t, []entities.Position{{X: 4, Y: 4}, {X: 5, Y: 4}, {X: 4, Y: 5}, {X: 5, Y: 5}}, CubeArea(entities.Position{X: 5, Y: 5}, 10))
+	assert.Empty(t, CubeArea(entities.Position{}, 0))
+}
+
+func TestLineArea(t *testing.T) {
+	line := LineArea(entities.Position{X: 0, Y: 0}, entities.Position{X: 1, Y: 0}, 20)
+	assert.Equal(t, []entities.Position{{X: 1, Y: 0}, {X: 2, Y: 0}, {X: 3, Y: 0}, {X: 4, Y: 0}}, line)
+
+	diagonal := LineArea(entities.Position{X: 0, Y: 0}, entities.Position{X: 3, Y: 3}, 15)
+	assert.Contains(t, diagonal, entities.Position{X: 2, Y: 2})
+	assert.NotContains(t, diagonal, entities.Position{X: 0, Y: 0})
+	assert.Empty(t, LineArea(entities.Position{}, entities.Position{}, 15))
+}
+
+func TestGetCellsInCone(t *testing.T) {
+	room := createTestRoom()
+
+	right := GetCellsInCone(room, entities.Position{X: 0, Y: 2}, 0, 4, 2)
+	assert.ElementsMatch(t, []entities.Position{
+		{X: 1, Y: 2}, {X: 2, Y: 2}, {X: 3, Y: 2}, {X: 4, Y: 2},
+		{X: 2, Y: 1}, {X: 3, Y: 1}, {X: 2, Y: 3}, {X: 3, Y: 3},
+	}, right)
+
+	// π/2 points down the grid, and cells past the edge of the room are dropped
+	down := GetCellsInCone(room, entities.Position{X: 2, Y: 3}, math.Pi/2, 3, 0)
+	assert.Equal(t, []entities.Position{{X: 2, Y: 4}}, down)
+
+	assert.Empty(t, GetCellsInCone(room, entities.Position{X: 2, Y: 2}, 0, 0, 1))
+	assert.Nil(t, GetCellsInCone(nil, entities.Position{}, 0, 3, 1))
+}
+
+func TestGetCellsInLine(t *testing.T) {
+	room := createTestRoom()
+
+	straight := GetCellsInLine(room, entities.Position{X: 0, Y: 0}, entities.Position{X: 4, Y: 0}, 1)
+	assert.Equal(t, []entities.Position{{X: 1, Y: 0}, {X: 2, Y: 0}, {X: 3, Y: 0}, {X: 4, Y: 0}}, straight)
+
+	wide := GetCellsInLine(room, entities.Position{X: 0, Y: 2}, entities.Position{X: 4, Y: 2}, 3)
+	assert.Len(t, wide, 12)
+	assert.NotContains(t, wide, entities.Position{X: 0, Y: 1})
+
+	diagonal := GetCellsInLine(room, entities.Position{X: 0, Y: 0}, entities.Position{X: 4, Y: 4}, 0)
+	assert.Equal(t, []entities.Position{{X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 3}, {X: 4, Y: 4}}, diagonal)
+
+	assert.Empty(t, GetCellsInLine(room, entities.Position{X: 2, Y: 2}, entities.Position{X: 2, Y: 2}, 1))
+	assert.Nil(t, GetCellsInLine(nil, entities.Position{}, entities.Position{X: 1}, 1))
+}
+
+func TestGetCellsInCircle(t *testing.T) {
+	room := createTestRoom()
+
+	assert.Len(t, GetCellsInCircle(room, entities.Position{X: 2, Y: 2}, 1), 5)
+	assert.Len(t, GetCellsInCircle(room, entities.Position{X: 2, Y: 2}, 2), 13)
+	assert.ElementsMatch(t, []entities.Position{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}},
+		GetCellsInCircle(room, entities.Position{X: 0, Y: 0}, 1))
+	assert.Equal(t, []entities.Position{{X: 2, Y: 2}}, GetCellsInCircle(room, entities.Position{X: 2, Y: 2}, 0))
+	assert.Empty(t, GetCellsInCircle(room, entities.Position{X: 2, Y: 2}, -1))
+}
+
+func TestGetCellsInSquare(t *testing.T) {
+	room := createTestRoom()
+
+	assert.Len(t, GetCellsInSquare(room, entities.Position{X: 2, Y: 2}, 1), 9)
+	assert.Len(t, GetCellsInSquare(room, entities.Position{X: 2, Y: 2}, 5), 25)
+	assert.ElementsMatch(t, []entities.Position{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}},
+		GetCellsInSquare(room, entities.Position{X: 0, Y: 0}, 1))
+	assert.Empty(t, GetCellsInSquare(room, entities.Position{X: 2, Y: 2}, -1))
+}
+
+func TestGetEntitiesInArea(t *testing.T) {
+	room := createTestRoom()
+	monster := createTestMonster("goblin-1", 1, 1)
+	player := createTestPlayer("player-1", 3, 4, 4)
+	require.NoError(t, PlaceEntity(room, &monster))
+	require.NoError(t, PlaceEntity(room, &player))
+
+	inArea := GetEntitiesInArea(room, GetCellsInCircle(room, entities.Position{X: 0, Y: 0}, 2))
+	require.Len(t, inArea, 1)
+	assert.Equal(t, "goblin-1", inArea[0].GetID())
+
+	assert.Len(t, GetEntitiesInArea(room, GetCellsInSquare(room, entities.Position{X: 2, Y: 2}, 2)), 2)
+	assert.Empty(t, GetEntitiesInArea(room, nil))
+	assert.Nil(t, GetEntitiesInArea(nil, []entities.Position{{X: 1, Y: 1}}))
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// Split axes supported by SplitRoom
+const (
+	SplitHorizontal = "horizontal"
+	SplitVertical   = "vertical"
+)
+
+// SplitRoomConfig describes where SplitRoom divides a room
+type SplitRoomConfig struct {
+	Axis          string // SplitVertical divides columns, SplitHorizontal divides rows
+	SplitLine     int    // First column (vertical) or row (horizontal) of the second room
+	DoorPositions []int  // Rows (vertical) or columns (horizontal) where a door crosses the wall
+}
+
+// SplitRoom divides a room into two new rooms separated by a wall along the split line
+// For a vertical split at column C the first room holds columns 0 to C-1 and the second holds the
+// remaining Width-C columns; horizontal splits divide rows the same way. Entities keep their IDs and
+// move to the room holding their cell, and each door position adds a connection to both rooms between
+// the cells on either side of the wall. Returns an error if a door cell holds a blocking obstacle or a
+// multi-cell obstacle straddles the split line. Neither new room has an encounter start.
+// The original room is not modified
+func (s *RoomService) SplitRoom(room *entities.Room, config SplitRoomConfig) (*entities.Room, *entities.Room, error) {
+	if room == nil {
+		return nil, nil, entities.ErrNilRoom
+	}
+
+	var length, lineLength int
+	var offset entities.Position
+	var doorCells func(door int) (entities.Position, entities.Position)
+	switch config.Axis {
+	case SplitVertical:
+		length, lineLength = room.Width, room.Height
+		offset = entities.Position{X: config.SplitLine}
+		doorCells = func(door int) (entities.Position, entities.Position) {
+			return entities.Position{X: config.SplitLine - 1, Y: door}, entities.Position{X: config.SplitLine, Y: door}
+		}
+	case SplitHorizontal:
+		length, lineLength = room.Height, room.Width
+		offset = entities.Position{Y: config.SplitLine}
+		doorCells = func(door int) (entities.Position, entities.Position) {
+			return entities.Position{X: door, Y: config.SplitLine - 1}, entities.Position{X: door, Y: config.SplitLine}
+		}
+	default:
+		return nil, nil, fmt.Errorf("invalid split axis: %s", config.Axis)
+	}
+	if config.SplitLine <= 0 || config.SplitLine >= length {
+		return nil, nil, fmt.Errorf("split line %d must be between 1 and %d", config.SplitLine, length-1)
+	}
+
+	blocked := blockingObstaclePositions(room)
+	for _, door := range config.DoorPositions {
+		if door < 0 || door >= lineLength {
+			return nil, nil, fmt.Errorf("door position %d is outside the split line (0-%d)", door, lineLength-1)
+		}
+		firstCell, secondCell := doorCells(door)
+		if blocked[firstCell] || blocked[secondCell] {
+			return nil, nil, fmt.Errorf("door position %d is blocked by an obstacle", door)
+		}
+	}
+
+	inFirst := func(pos entities.Position) bool {
+		return pos.X < offset.X || pos.Y < offset.Y
+	}
+	for _, obstacle := range room.Obstacles {
+		cells := obstacle.Cells()
+		if inFirst(cells[0]) != inFirst(cells[len(cells)-1]) {
+			return nil, nil, fmt.Errorf("obstacle %s straddles the split line", obstacle.ID)
+		}
+	}
+	first, second := splitRoomAt(room, inFirst, offset)
+	if config.Axis == SplitVertical {
+		first.Width, second.Width = config.SplitLine, room.Width-config.SplitLine
+	} else {
+		first.Height, second.Height = config.SplitLine, room.Height-config.SplitLine
+	}
+	clipSpellZones(first)
+	clipSpellZones(second)
+
+	for _, door := range config.DoorPositions {
+		firstCell, secondCell := doorCells(door)
+		secondCell = secondCell.Sub(offset)
+		first.Connections = append(first.Connections, entities.RoomConnection{Position: firstCell, ToRoomID: second.ID, ToPosition: secondCell})
+		second.Connections = append(second.Connections, entities.RoomConnection{Position: secondCell, ToRoomID: first.ID, ToPosition: firstCell})
+	}
+
+	return first, second, nil
+}
+
+// splitRoomAt copies the room twice, keeping the contents where inFirst is true in the first copy and the
+// rest in the second, whose positions are shifted back by offset. Room sizes are left for the caller to set
+func splitRoomAt(room *entities.Room, inFirst func(entities.Position) bool, offset entities.Position) (*entities.Room, *entities.Room) {
+	first, second := copyRoom(room), copyRoom(room)
+	// The copies have no random source, so their IDs do not advance the original's
+	first.ID, second.ID = newEntityID(first), newEntityID(second)
+	first.EncounterReset, second.EncounterReset = nil, nil
+
+	first.Monsters, second.Monsters = partitionByPosition(first.Monsters, func(m *entities.Monster) *entities.Position { return &m.Position }, inFirst, offset)
+	first.Players, second.Players = partitionByPosition(first.Players, func(p *entities.Player) *entities.Position { return &p.Position }, inFirst, offset)
+	first.Items, second.Items = partitionByPosition(first.Items, func(i *entities.Item) *entities.Position { return &i.Position }, inFirst, offset)
+	first.NPCs, second.NPCs = partitionByPosition(first.NPCs, func(n *entities.NPC) *entities.Position { return &n.Position }, inFirst, offset)
+	first.Obstacles, second.Obstacles = partitionByPosition(first.Obstacles, func(o *entities.Obstacle) *entities.Position { return &o.Position }, inFirst, offset)
+	first.Traps, second.Traps = partitionByPosition(first.Traps, func(t *entities.Trap) *entities.Position { return &t.Position }, inFirst, offset)
+	first.Doors, second.Doors = partitionByPosition(first.Doors, func(d *entities.Door) *entities.Position { return &d.Position }, inFirst, offset)
+	first.Connections, second.Connections = partitionByPosition(first.Connections, func(c *entities.RoomConnection) *entities.Position { return &c.Position }, inFirst, offset)
+	first.SpellZones, second.SpellZones = partitionByPosition(first.SpellZones, func(z *entities.SpellZone) *entities.Position { return &z.Position }, inFirst, offset)
+	for i := range second.SpellZones {
+		zone := &second.SpellZones[i]
+		zone.Origin = zone.Origin.Sub(offset)
+		for j := range zone.AffectedPositions {
+			zone.AffectedPositions[j] = zone.AffectedPositions[j].Sub(offset)
+		}
+	}
+
+	first.MonsterPacks, second.MonsterPacks = splitMonsterPacks(first.MonsterPacks, first.Monsters, entities.Position{}), splitMonsterPacks(second.MonsterPacks, second.Monsters, offset)
+
+	first.DifficultTerrain, second.DifficultTerrain = nil, nil
+	for pos := range room.DifficultTerrain {
+		if inFirst(pos) {
+			first.SetDifficultTerrain(pos, true)
+		} else {
+			second.SetDifficultTerrain(pos.Sub(offset), true)
+		}
+	}
+
+	if room.Grid != nil {
+		first.Grid, second.Grid = splitGrid(room.Grid, inFirst)
+	}
+	if room.VisibilityGrid != nil {
+		first.VisibilityGrid, second.VisibilityGrid = splitGrid(room.VisibilityGrid, inFirst)
+	}
+
+	return first, second
+}
+
+// splitGrid splits the cells of a grid by whether their position is in the first half
+// Rows left empty on either side are dropped
+func splitGrid[T any](grid [][]T, inFirst func(entities.Position) bool) ([][]T, [][]T) {
+	first, second := [][]T{}, [][]T{}
+	for y, row := range grid {
+		firstRow, secondRow := []T{}, []T{}
+		for x, cell := range row {
+			if inFirst(entities.Position{X: x, Y: y}) {
+				firstRow = append(firstRow, cell)
+			} else {
+				secondRow = append(secondRow, cell)
+			}
+		}
+		if len(firstRow) > 0 {
+			first = append(first, firstRow)
+		}
+		if len(secondRow) > 0 {
+			second = append(second, secondRow)
+		}
+	}
+	return first, second
+}
+
+// clipSpellZones drops the affected positions of the room's spell zones that lie outside the room
+// Each zone stays with the room holding its target point, so the part covering the other room is lost
+func clipSpellZones(room *entities.Room) {
+	for i := range room.SpellZones {
+		zone := &room.SpellZones[i]
+		kept := []entities.Position{}
+		for _, pos := range zone.AffectedPositions {
+			if IsPositionValid(room, pos) {
+				kept = append(kept, pos)
+			}
+		}
+		zone.AffectedPositions = kept
+	}
+}
+
+// partitionByPosition splits items by whether their position is in the first half, shifting the
+// positions of the second half back by offset. Both results are non-nil if items is non-nil
+func partitionByPosition[T any](items []T, position func(*T) *entities.Position, inFirst func(entities.Position) bool, offset entities.Position) ([]T, []T) {
+	if items == nil {
+		return nil, nil
+	}
+
+	first, second := []T{}, []T{}
+	for _, item := range items {
+		pos := position(&item)
+		if inFirst(*pos) {
+			first = append(first, item)
+		} else {
+			*pos = pos.Sub(offset)
+			second = append(second, item)
+		}
+	}
+	return first, second
+}
+
+// splitMonsterPacks keeps the packs with members among the monsters, dropping the members that are not,
+// and shifts their formation centers back by offset
+func splitMonsterPacks(packs []entities.MonsterPack, monsters []entities.Monster, offset entities.Position) []entities.MonsterPack {
+	if packs == nil {
+		return nil
+	}
+
+	present := map[string]bool{}
+	for _, monster := range monsters {
+		present[monster.ID] = true
+	}
+
+	kept := []entities.MonsterPack{}
+	for _, pack := range packs {
+		members := []string{}
+		for _, id := range pack.MonsterIDs {
+			if present[id] {
+				members = append(members, id)
+			}
+		}
+		if len(members) == 0 {
+			continue
+		}
+
+		pack.MonsterIDs = members
+		if !present[pack.LeaderID] {
+			pack.LeaderID = ""
+		}
+		pack.FormationCenter = pack.FormationCenter.Sub(offset)
+		kept = append(kept, pack)
+	}
+	return kept
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// createSplitTestRoom creates a 10x6 room with entities on both sides of column 4
+func createSplitTestRoom(t *testing.T) *entities.Room {
+	room := NewRoom(10, 6, entities.LightLevelDim)
+	InitializeGrid(room)
+	placeables := []entities.Placeable{
+		&entities.Player{ID: "fighter", Position: entities.Position{X: 0, Y: 0}},
+		&entities.Monster{ID: "goblin", Position: entities.Position{X: 3, Y: 5}},
+		&entities.Item{ID: "sword", Position: entities.Position{X: 2, Y: 2}},
+		&entities.Monster{ID: "ogre", Position: entities.Position{X: 4, Y: 1}},
+		&entities.NPC{ID: "prisoner", Position: entities.Position{X: 9, Y: 5}},
+		&entities.Obstacle{ID: "crate", Blocking: true, Position: entities.Position{X: 4, Y: 3}},
+	}
+	for _, p := range placeables {
+		require.NoError(t, PlaceEntity(room, p))
+	}
+	room.SetDifficultTerrain(entities.Position{X: 6, Y: 4}, true)
+	return room
+}
+
+func TestSplitRoomVertical(t *testing.T) {
+	service := &RoomService{}
+	room := createSplitTestRoom(t)
+
+	first, second, err := service.SplitRoom(room, SplitRoomConfig{Axis: SplitVertical, SplitLine: 4, DoorPositions: []int{0, 4}})
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, first.Width)
+	assert.Equal(t, 6, second.Width)
+	assert.Equal(t, 6, first.Height)
+	assert.Equal(t, 6, second.Height)
+	assert.NotEqual(t, first.ID, second.ID)
+	assert.NotEqual(t, room.ID, first.ID)
+
+	firstIDs := placeableIDs(collectPlaceables(first))
+	secondIDs := placeableIDs(collectPlaceables(second))
+	assert.Equal(t, len(collectPlaceables(room)), len(firstIDs)+len(secondIDs), "total entity count is preserved")
+	assert.ElementsMatch(t, []string{"fighter", "goblin", "sword"}, firstIDs)
+	assert.ElementsMatch(t, []string{"ogre", "prisoner", "crate"}, secondIDs)
+	for _, id := range firstIDs {
+		assert.NotContains(t, secondIDs, id)
+	}
+
+	ogre, _ := FindMonsterByID(second, "ogre")
+	require.NotNil(t, ogre)
+	assert.Equal(t, entities.Position{X: 0, Y: 1}, ogre.Position)
+	assert.Equal(t, entities.CellMonster, second.Grid[1][0].Type)
+	assert.Len(t, first.Grid[0], 4)
+	assert.Len(t, second.Grid[0], 6)
+	assert.True(t, second.IsDifficultTerrain(entities.Position{X: 2, Y: 4}))
+	assert.Empty(t, first.DifficultTerrain)
+
+	// Doors connect the cells on either side of the wall, and neither side is blocked
+	require.Len(t, first.Connections, 2)
+	require.Len(t, second.Connections, 2)
+	assert.Equal(t, entities.RoomConnection{Position: entities.Position{X: 3, Y: 4}, ToRoomID: second.ID, ToPosition: entities.Position{X: 0, Y: 4}}, first.Connections[1])
+	assert.Equal(t, entities.RoomConnection{Position: entities.Position{X: 0, Y: 4}, ToRoomID: first.ID, ToPosition: entities.Position{X: 3, Y: 4}}, second.Connections[1])
+	for _, connection := range second.Connections {
+		assert.False(t, blockingObstaclePositions(second)[connection.Position])
+	}
+
+	assert.Len(t, room.Monsters, 2, "original room should be unchanged")
+	assert.Equal(t, 10, room.Width)
+}
+
+func TestSplitRoomHorizontal(t *testing.T) {
+	service := &RoomService{}
+	room := createSplitTestRoom(t)
+
+	first, second, err := service.SplitRoom(room, SplitRoomConfig{Axis: SplitHorizontal, SplitLine: 2, DoorPositions: []int{7}})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, first.Height)
+	assert.Equal(t, 4, second.Height)
+	assert.Len(t, first.Grid, 2)
+	assert.Len(t, second.Grid, 4)
+	assert.ElementsMatch(t, []string{"fighter", "ogre"}, placeableIDs(collectPlaceables(first)))
+
+	sword := second.Items[0]
+	assert.Equal(t, entities.Position{X: 2, Y: 0}, sword.Position)
+	assert.Equal(t, entities.Position{X: 7, Y: 1}, first.Connections[0].Position)
+	assert.Equal(t, entities.Position{X: 7, Y: 0}, second.Connections[0].Position)
+}
+
+func TestSplitRoomSharesNoSlices(t *testing.T) {
+	service := &RoomService{}
+	room := createSplitTestRoom(t)
+	room.Monsters[0].Conditions = []entities.Condition{entities.ConditionPoisoned}
+	room.Monsters[0].ConditionImmunities = []entities.Condition{entities.ConditionCharmed}
+	room.Monsters[1].Conditions = []entities.Condition{entities.ConditionProne}
+	room.Players[0].Conditions = []entities.Condition{entities.ConditionBlinded}
+	room.Items[0].Properties = []string{"finesse"}
+
+	first, second, err := service.SplitRoom(room, SplitRoomConfig{Axis: SplitVertical, SplitLine: 4})
+	require.NoError(t, err)
+
+	goblin, _ := FindMonsterByID(first, "goblin")
+	goblin.Conditions[0] = entities.ConditionStunned
+	goblin.ConditionImmunities[0] = entities.ConditionFrightened
+	ogre, _ := FindMonsterByID(second, "ogre")
+	ogre.Conditions[0] = entities.ConditionStunned
+	first.Players[0].Conditions[0] = entities.ConditionStunned
+	first.Items[0].Properties[0] = "heavy"
+
+	assert.Equal(t, []entities.Condition{entities.ConditionPoisoned}, room.Monsters[0].Conditions)
+	assert.Equal(t, []entities.Condition{entities.ConditionCharmed}, room.Monsters[0].ConditionImmunities)
+	assert.Equal(t, []entities.Condition{entities.ConditionProne}, room.Monsters[1].Conditions)
+	assert.Equal(t, []entities.Condition{entities.ConditionBlinded}, room.Players[0].Conditions)
+	assert.Equal(t, []string{"finesse"}, room.Items[0].Properties)
+}
+
+func TestSplitRoomKeepsRandomState(t *testing.T) {
+	service := &RoomService{}
+	room := createSplitTestRoom(t)
+	room.Rand = rand.New(rand.NewSource(3))
+	expected := rand.New(rand.NewSource(3))
+
+	first, second, err := service.SplitRoom(room, SplitRoomConfig{Axis: SplitVertical, SplitLine: 4})
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first.ID, second.ID)
+	assert.Equal(t, expected.Int63(), room.Rand.Int63(), "splitting must not draw from the original's random source")
+}
+
+func TestSplitRoomErrors(t *testing.T) {
+	service := &RoomService{}
+	room := createSplitTestRoom(t)
+
+	testCases := []struct {
+		name   string
+		config SplitRoomConfig
+	}{
+		{"unknown axis", SplitRoomConfig{Axis: "diagonal", SplitLine: 4}},
+		{"split at edge", SplitRoomConfig{Axis: SplitVertical, SplitLine: 0}},
+		{"split past edge", SplitRoomConfig{Axis: SplitHorizontal, SplitLine: 6}},
+		{"door outside wall", SplitRoomConfig{Axis: SplitVertical, SplitLine: 4, DoorPositions: []int{6}}},
+		{"door blocked by crate", SplitRoomConfig{Axis: SplitVertical, SplitLine: 4, DoorPositions: []int{3}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := service.SplitRoom(room, tc.config)
+			assert.Error(t, err)
+		})
+	}
+
+	_, _, err := service.SplitRoom(nil, SplitRoomConfig{Axis: SplitVertical, SplitLine: 1})
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// PlacementResult describes what AddPlaceablesStrict placed and what it could not place
+type PlacementResult struct {
+	Placed []PlacedEntity
+	Failed []FailedPlacement
+}
+
+// PlacedEntity is an entity placed by AddPlaceablesStrict
+type PlacedEntity struct {
+	ID       string
+	Name     string
+	Position entities.Position
+}
+
+// FailedPlacement is an entity AddPlaceablesStrict could not place
+type FailedPlacement struct {
+	Name     string
+	Reason   error
+	Required bool // Whether the entity's config was marked Required
+}
+
+// AddPlaceablesStrict adds entities to a room like AddPlaceablesToRoom, but all or nothing
+// If a config marked Required cannot be placed, or placement fails with an error, every entity placed by the
+// call is removed again and the room is left as it was; the result still describes the failed attempt.
+// Entities whose configs are not required are skipped when they cannot be placed and listed in Failed
+func (s *RoomService) AddPlaceablesStrict(room *entities.Room, configs []PlaceableConfig) (*PlacementResult, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+
+	snapshot := copyRoom(room)
+	snapshot.Rand = room.Rand
+	rollback := func() { *room = *snapshot }
+
+	added, err := s.AddPlaceablesToRoomWithResult(room, configs)
+	if err != nil {
+		rollback()
+		return nil, err
+	}
+
+	result := &PlacementResult{Placed: added.placed, Failed: added.failed}
+	if result.Placed == nil {
+		result.Placed = []PlacedEntity{}
+	}
+	if result.Failed == nil {
+		result.Failed = []FailedPlacement{}
+	}
+
+	for _, failure := range result.Failed {
+		if failure.Required {
+			rollback()
+			return result, fmt.Errorf("required entity %s could not be placed, no entities were added: %w", failure.Name, failure.Reason)
+		}
+	}
+
+	return result, nil
+}
+
+// isRequired reports whether a built-in config is marked Required
+// Configs of other types are never required
+func isRequired(config PlaceableConfig) bool {
+	switch c := config.(type) {
+	case MonsterConfig:
+		return c.Required
+	case PlayerConfig:
+		return c.Required
+	case ItemConfig:
+		return c.Required
+	case WeaponItemConfig:
+		return c.Required
+	case ArmorItemConfig:
+		return c.Required
+	case NPCConfig:
+		return c.Required
+	case ObstacleConfig:
+		return c.Required
+	case TrapConfig:
+		return c.Required
+	case DoorConfig:
+		return c.Required
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestAddPlaceablesStrict(t *testing.T) {
+	service, err := NewRoomService()
+	require.NoError(t, err)
+
+	t.Run("All entities placed", func(t *testing.T) {
+		room := createTestRoom()
+		configs := []PlaceableConfig{
+			createTestMonsterConfig("Goblin", "goblin", 0.25, 1, false, &entities.Position{X: 1, Y: 1}),
+			createTestObstacleConfig("Pillar", "pillar", true, 1, false, &entities.Position{X: 2, Y: 2}),
+		}
+
+		result, err := service.AddPlaceablesStrict(room, configs)
+		require.NoError(t, err)
+		require.Len(t, result.Placed, 2)
+		assert.Empty(t, result.Failed)
+		assert.Equal(t, "Goblin", result.Placed[0].Name)
+		assert.Equal(t, room.Monsters[0].ID, result.Placed[0].ID)
+		assert.Equal(t, entities.Position{X: 1, Y: 1}, result.Placed[0].Position)
+		assert.Equal(t, room.Obstacles[0].ID, result.Placed[1].ID)
+	})
+
+	t.Run("Optional failures are skipped", func(t *testing.T) {
+		room := createTestRoom()
+		configs := []PlaceableConfig{
+			createTestMonsterConfig("Goblin", "goblin", 0.25, 1, false, &entities.Position{X: 1, Y: 1}),
+			createTestItemConfig("Dagger", "dagger", false, &entities.Position{X: 1, Y: 1}),
+		}
+
+		result, err := service.AddPlaceablesStrict(room, configs)
+		require.NoError(t, err)
+		assert.Len(t, result.Placed, 1)
+		require.Len(t, result.Failed, 1)
+		assert.Equal(t, "Dagger", result.Failed[0].Name)
+		assert.ErrorIs(t, result.Failed[0].Reason, entities.ErrCellOccupied)
+		assert.False(t, result.Failed[0].Required)
+		assert.Len(t, room.Monsters, 1)
+	})
+
+	t.Run("Required failures roll back the room", func(t *testing.T) {
+		room := createTestRoom()
+		existing := createTestMonster("existing", 4, 4)
+		require.NoError(t, PlaceEntity(room, &existing))
+		before := copyRoom(room)
+
+		item := createTestItemConfig("Crown", "crown", false, &entities.Position{X: 1, Y: 1})
+		item.Required = true
+		configs := []PlaceableConfig{
+			createTestMonsterConfig("Goblin", "goblin", 0.25, 1, false, &entities.Position{X: 1, Y: 1}),
+			createTestObstacleConfig("Pillar", "pillar", true, 1, false, &entities.Position{X: 2, Y: 2}),
+			item,
+		}
+
+		result, err := service.AddPlaceablesStrict(room, configs)
+		assert.ErrorIs(t, err, entities.ErrCellOccupied)
+		require.NotNil(t, result)
+		require.Len(t, result.Failed, 1)
+		assert.True(t, result.Failed[0].Required)
+		assert.Equal(t, before, room)
+	})
+
+	t.Run("Placement errors roll back the room", func(t *testing.T) {
+		room := createTestRoom()
+		before := copyRoom(room)
+		configs := []PlaceableConfig{
+			createTestMonsterConfig("Goblin", "goblin", 0.25, 1, false, &entities.Position{X: 1, Y: 1}),
+			ItemConfig{Name: "Dagger", Key: "dagger", RandomPlace: true, Zone: &PlacementZone{MinX: 3, MaxX: 1}},
+		}
+
+		_, err := service.AddPlaceablesStrict(room, configs)
+		assert.Error(t, err)
+		assert.Equal(t, before, room)
+	})
+
+	t.Run("Nil room", func(t *testing.T) {
+		_, err := service.AddPlaceablesStrict(nil, []PlaceableConfig{})
+		assert.ErrorIs(t, err, entities.ErrNilRoom)
+	})
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// Default values used by ExportRoomToSVG when the config leaves them unset
+const (
+	DefaultSVGCellSizePixels = 32
+	DefaultSVGFontSize       = 16
+)
+
+// SVGColorScheme maps cell types to hex fill colors
+type SVGColorScheme map[entities.CellType]string
+
+// SVGExportConfig contains parameters for rendering a room as SVG
+type SVGExportConfig struct {
+	CellSizePixels int            // Size of each grid cell in pixels (default 32)
+	FontSize       int            // Font size for entity labels (default 16)
+	ColorScheme    SVGColorScheme // Fill color per cell type, merged over the default scheme
+	ShowAxisLabels bool           // Whether to label column and row indices along the edges
+}
+
+// DefaultSVGColorScheme returns the color scheme used for cell types missing from a custom scheme
+func DefaultSVGColorScheme() SVGColorScheme {
+	return SVGColorScheme{
+		entities.CellTypeEmpty: "#f5f0e1",
+		entities.CellMonster:   "#c0392b",
+		entities.CellPlayer:    "#2980b9",
+		entities.CellItem:      "#f1c40f",
+		entities.CellNPC:       "#27ae60",
+		entities.CellObstacle:  "#7f8c8d",
+		entities.CellTrap:      "#8e44ad",
+		entities.CellDoor:      "#a0522d",
+		entities.CellWall:      "#2c3e50",
+	}
+}
+
+// svgDocument is the root SVG element
+type svgDocument struct {
+	XMLName xml.Name   `xml:"svg"`
+	Xmlns   string     `xml:"xmlns,attr"`
+	Width   int        `xml:"width,attr"`
+	Height  int        `xml:"height,attr"`
+	ViewBox string     `xml:"viewBox,attr"`
+	Rects   []svgRect  `xml:"rect"`
+	Border  svgPolygon `xml:"polygon"`
+	Texts   []svgText  `xml:"text"`
+}
+
+// svgRect is a single grid cell
+type svgRect struct {
+	X      int    `xml:"x,attr"`
+	Y      int    `xml:"y,attr"`
+	Width  int    `xml:"width,attr"`
+	Height int    `xml:"height,attr"`
+	Fill   string `xml:"fill,attr"`
+	Stroke string `xml:"stroke,attr"`
+}
+
+// svgPolygon is the room border
+type svgPolygon struct {
+	Points      string `xml:"points,attr"`
+	Fill        string `xml:"fill,attr"`
+	Stroke      string `xml:"stroke,attr"`
+	StrokeWidth int    `xml:"stroke-width,attr"`
+}
+
+// svgText is an entity or axis label
+type svgText struct {
+	X          int    `xml:"x,attr"`
+	Y          int    `xml:"y,attr"`
+	FontSize   int    `xml:"font-size,attr"`
+	TextAnchor string `xml:"text-anchor,attr"`
+	Baseline   string `xml:"dominant-baseline,attr"`
+	Class      string `xml:"class,attr,omitempty"`
+	Content    string `xml:",chardata"`
+}
+
+// ExportRoomToSVG renders the room as an SVG image
+// Each cell is drawn as a rect colored by its cell type, each entity is labelled with the
+// first character of its ID, and the room is outlined with a border
+// Gridless rooms are drawn with empty cells and entity labels at their positions
+func (s *RoomService) ExportRoomToSVG(room *entities.Room, config SVGExportConfig) ([]byte, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+	if room.Width <= 0 || room.Height <= 0 {
+		return nil, fmt.Errorf("room dimensions must be positive")
+	}
+
+	cellSize := config.CellSizePixels
+	if cellSize <= 0 {
+		cellSize = DefaultSVGCellSizePixels
+	}
+	fontSize := config.FontSize
+	if fontSize <= 0 {
+		fontSize = DefaultSVGFontSize
+	}
+	colors := DefaultSVGColorScheme()
+	for cellType, color := range config.ColorScheme {
+		colors[cellType] = color
+	}
+
+	width := room.Width * cellSize
+	height := room.Height * cellSize
+
+	doc := svgDocument{
+		Xmlns:   "http://www.w3.org/2000/svg",
+		Width:   width,
+		Height:  height,
+		ViewBox: fmt.Sprintf("0 0 %d %d", width, height),
+		Rects:   make([]svgRect, 0, room.Width*room.Height),
+		Border: svgPolygon{
+			Points:      fmt.Sprintf("0,0 %d,0 %d,%d 0,%d", width, width, height, height),
+			Fill:        "none",
+			Stroke:      "#000000",
+			StrokeWidth: 2,
+		},
+	}
+
+	for y := 0; y < room.Height; y++ {
+		for x := 0; x < room.Width; x++ {
+			cellType := entities.CellTypeEmpty
+			if room.Grid != nil {
+				cellType = room.Grid[y][x].Type
+			}
+			doc.Rects = append(doc.Rects, svgRect{
+				X:      x * cellSize,
+				Y:      y * cellSize,
+				Width:  cellSize,
+				Height: cellSize,
+				Fill:   colors[cellType],
+				Stroke: "#333333",
+			})
+		}
+	}
+
+	for _, entity := range collectPlaceables(room) {
+		label := "?"
+		if id := entity.GetID(); id != "" {
+			label = string([]rune(id)[0])
+		}
+		pos := entity.GetPosition()
+		doc.Texts = append(doc.Texts, svgText{
+			X:          pos.X*cellSize + cellSize/2,
+			Y:          pos.Y*cellSize + cellSize/2,
+			FontSize:   fontSize,
+			TextAnchor: "middle",
+			Baseline:   "central",
+			Class:      "entity",
+			Content:    label,
+		})
+	}
+
+	if config.ShowAxisLabels {
+		axisFont := fontSize / 2
+		if axisFont < 1 {
+			axisFont = 1
+		}
+		for x := 0; x < room.Width; x++ {
+			doc.Texts = append(doc.Texts, svgText{
+				X: x*cellSize + 2, Y: axisFont, FontSize: axisFont,
+				TextAnchor: "start", Baseline: "auto", Class: "axis", Content: strconv.Itoa(x),
+			})
+		}
+		for y := 1; y < room.Height; y++ {
+			doc.Texts = append(doc.Texts, svgText{
+				X: 2, Y: y*cellSize + axisFont, FontSize: axisFont,
+				TextAnchor: "start", Baseline: "auto", Class: "axis", Content: strconv.Itoa(y),
+			})
+		}
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode SVG: %w", err)
+	}
+
+	return append([]byte(xml.Header), out...), nil
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"encoding/xml"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// parsedSVG captures the parts of an exported SVG the tests inspect
+type parsedSVG struct {
+	ViewBox string `xml:"viewBox,attr"`
+	Rects   []struct {
+		Fill string `xml:"fill,attr"`
+	} `xml:"rect"`
+	Texts []struct {
+		Class   string `xml:"class,attr"`
+		Content string `xml:",chardata"`
+	} `xml:"text"`
+}
+
+func TestExportRoomToSVG(t *testing.T) {
+	service := &RoomService{}
+
+	room := NewRoom(6, 4, entities.LightLevelBright)
+	InitializeGrid(room)
+	monster := createTestMonster("goblin1", 1, 1)
+	player := createTestPlayer("hero1", 3, 4, 2)
+	obstacle := entities.Obstacle{ID: "wall1", Blocking: true, Position: entities.Position{X: 5, Y: 3}}
+	require.NoError(t, PlaceEntity(room, &monster))
+	require.NoError(t, PlaceEntity(room, &player))
+	require.NoError(t, PlaceEntity(room, &obstacle))
+
+	testCases := []struct {
+		name           string
+		config         SVGExportConfig
+		expectedCell   int
+		expectedLabels int
+	}{
+		{name: "Default config", config: SVGExportConfig{}, expectedCell: DefaultSVGCellSizePixels},
+		{name: "Custom cell size", config: SVGExportConfig{CellSizePixels: 20, FontSize: 10}, expectedCell: 20},
+		{name: "Axis labels", config: SVGExportConfig{CellSizePixels: 10, ShowAxisLabels: true}, expectedCell: 10, expectedLabels: 6 + 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := service.ExportRoomToSVG(room, tc.config)
+			require.NoError(t, err)
+
+			var svg parsedSVG
+			require.NoError(t, xml.Unmarshal(data, &svg))
+
+			assert.Len(t, svg.Rects, room.Width*room.Height)
+			assert.Equal(t, fmt.Sprintf("0 0 %d %d", tc.expectedCell*room.Width, tc.expectedCell*room.Height), svg.ViewBox)
+
+			entityLabels := []string{}
+			axisLabels := 0
+			for _, text := range svg.Texts {
+				if text.Class == "axis" {
+					axisLabels++
+				} else {
+					entityLabels = append(entityLabels, text.Content)
+				}
+			}
+			assert.ElementsMatch(t, []string{"g", "h", "w"}, entityLabels)
+			assert.Equal(t, tc.expectedLabels, axisLabels)
+		})
+	}
+
+	t.Run("Cells are colored by type", func(t *testing.T) {
+		scheme := SVGColorScheme{entities.CellMonster: "#000001"}
+		data, err := service.ExportRoomToSVG(room, SVGExportConfig{ColorScheme: scheme})
+		require.NoError(t, err)
+
+		var svg parsedSVG
+		require.NoError(t, xml.Unmarshal(data, &svg))
+
+		defaults := DefaultSVGColorScheme()
+		assert.Equal(t, "#000001", svg.Rects[1*room.Width+1].Fill)
+		assert.Equal(t, defaults[entities.CellPlayer], svg.Rects[2*room.Width+4].Fill)
+		assert.Equal(t, defaults[entities.CellObstacle], svg.Rects[3*room.Width+5].Fill)
+		assert.Equal(t, defaults[entities.CellTypeEmpty], svg.Rects[0].Fill)
+	})
+
+	t.Run("Gridless room", func(t *testing.T) {
+		gridless := createTestRoomNoGrid()
+		require.NoError(t, PlaceEntity(gridless, &monster))
+
+		data, err := service.ExportRoomToSVG(gridless, SVGExportConfig{})
+		require.NoError(t, err)
+
+		var svg parsedSVG
+		require.NoError(t, xml.Unmarshal(data, &svg))
+		assert.Len(t, svg.Rects, 25)
+		assert.Len(t, svg.Texts, 1)
+	})
+
+	t.Run("Nil room", func(t *testing.T) {
+		_, err := service.ExportRoomToSVG(nil, SVGExportConfig{})
+		assert.ErrorIs(t, err, entities.ErrNilRoom)
+	})
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"math"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// Tactics recommended by ScoreRoomTactically
+const (
+	TacticSpreadOut         = "spread out"
+	TacticHoldTheLine       = "hold the line"
+	TacticRush              = "rush"
+	TacticDefendChokepoints = "defend chokepoints"
+)
+
+// tacticalDangerRadius is the radius in squares used for danger scores, one 30 ft move
+const tacticalDangerRadius = 6
+
+// monsterAdvantageThreshold is the MonsterAdvantage at which the party should fight defensively
+const monsterAdvantageThreshold = 0.5
+
+// TacticalScore summarizes how a room's layout favors the monsters or the players
+type TacticalScore struct {
+	MonsterAdvantage       float64 // Average danger at player positions relative to party level (0-1)
+	PlayerAdvantage        float64 // 1 - MonsterAdvantage
+	ObstacleCoverage       float64 // Fraction of cells holding blocking obstacles
+	FlankingOpportunities  int     // Pairs of creatures on opposite sides of an adjacent enemy
+	Chokepoints            int     // Open cells walled in on two opposite sides
+	DangerZoneArea         int     // Cells within tacticalDangerRadius of at least one monster
+	RecommendedPartyTactic string  // One of the Tactic constants
+}
+
+// ScoreRoomTactically scores the room's layout for the party
+// The danger at each player position (see GetRoomDangerZones) is divided by the party's average level and capped at 1,
+// and MonsterAdvantage is the average over all players. If party is nil, the levels of the players in the room are used
+func (s *RoomService) ScoreRoomTactically(room *entities.Room, party *entities.Party) (TacticalScore, error) {
+	if room == nil {
+		return TacticalScore{}, entities.ErrNilRoom
+	}
+
+	score := TacticalScore{
+		FlankingOpportunities: countFlankingPairs(room),
+		Chokepoints:           len(findChokepoints(room)),
+	}
+
+	avgLevel := 0.0
+	if party != nil {
+		avgLevel = party.AverageLevel()
+	} else if len(room.Players) > 0 {
+		for _, player := range room.Players {
+			avgLevel += float64(player.Level)
+		}
+		avgLevel /= float64(len(room.Players))
+	}
+	avgLevel = math.Max(avgLevel, 1)
+
+	if len(room.Players) > 0 {
+		total := 0.0
+		for _, player := range room.Players {
+			danger := dangerAt(room, player.Position, tacticalDangerRadius).DangerScore
+			total += math.Min(danger/avgLevel, 1)
+		}
+		score.MonsterAdvantage = total / float64(len(room.Players))
+	}
+	score.PlayerAdvantage = 1 - score.MonsterAdvantage
+
+	if cells := room.Width * room.Height; cells > 0 {
+		score.ObstacleCoverage = float64(len(blockingObstaclePositions(room))) / float64(cells)
+	}
+
+	for y := 0; y < room.Height; y++ {
+		for x := 0; x < room.Width; x++ {
+			if dangerAt(room, entities.Position{X: x, Y: y}, tacticalDangerRadius).DangerScore > 0 {
+				score.DangerZoneArea++
+			}
+		}
+	}
+
+	score.RecommendedPartyTactic = recommendTactic(score)
+	return score, nil
+}
+
+// recommendTactic picks a party tactic from the other components of the score
+// Outmatched parties fall back to chokepoints if there are any and hold the line otherwise;
+// parties with the upper hand spread out when they can be flanked and rush when they cannot
+func recommendTactic(score TacticalScore) string {
+	if score.MonsterAdvantage >= monsterAdvantageThreshold {
+		if score.Chokepoints > 0 {
+			return TacticDefendChokepoints
+		}
+		return TacticHoldTheLine
+	}
+	if score.FlankingOpportunities > 0 {
+		return TacticSpreadOut
+	}
+	return TacticRush
+}
+
+// countFlankingPairs counts pairs of allies standing on directly opposite sides of an adjacent enemy
+// Monsters flank players and players flank monsters
+func countFlankingPairs(room *entities.Room) int {
+	monsters := map[entities.Position]bool{}
+	for _, monster := range room.Monsters {
+		monsters[monster.Position] = true
+	}
+	players := map[entities.Position]bool{}
+	for _, player := range room.Players {
+		players[player.Position] = true
+	}
+
+	count := 0
+	countAround := func(target entities.Position, allies map[entities.Position]bool) {
+		// Each opposite pair is found from one half of the neighbors to avoid counting it twice
+		for _, offset := range []entities.Position{{X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: -1}} {
+			if allies[target.Add(offset)] && allies[target.Sub(offset)] {
+				count++
+			}
+		}
+	}
+	for _, player := range room.Players {
+		countAround(player.Position, monsters)
+	}
+	for _, monster := range room.Monsters {
+		countAround(monster.Position, players)
+	}
+
+	return count
+}
+
+// findChokepoints returns the open cells whose left and right, or top and bottom, neighbors are both
+// blocked by blocking obstacles or the room's edge while the other two are open
+func findChokepoints(room *entities.Room) []entities.Position {
+	blocked := blockingObstaclePositions(room)
+	isBlocked := func(pos entities.Position) bool {
+		return !IsPositionValid(room, pos) || blocked[pos]
+	}
+
+	chokepoints := []entities.Position{}
+	for y := 0; y < room.Height; y++ {
+		for x := 0; x < room.Width; x++ {
+			pos := entities.Position{X: x, Y: y}
+			if blocked[pos] {
+				continue
+			}
+
+			left, right := pos.Sub(entities.Position{X: 1}), pos.Add(entities.Position{X: 1})
+			up, down := pos.Sub(entities.Position{Y: 1}), pos.Add(entities.Position{Y: 1})
+			horizontalPass := isBlocked(up) && isBlocked(down) && !isBlocked(left) && !isBlocked(right)
+			verticalPass := isBlocked(left) && isBlocked(right) && !isBlocked(up) && !isBlocked(down)
+			if horizontalPass || verticalPass {
+				chokepoints = append(chokepoints, pos)
+			}
+		}
+	}
+
+	return chokepoints
+}
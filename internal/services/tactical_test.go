@@ -0,0 +1,116 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// createTacticalRoom creates an empty 10x10 gridded room
+func createTacticalRoom() *entities.Room {
+	room := NewRoom(10, 10, entities.LightLevelBright)
+	InitializeGrid(room)
+	return room
+}
+
+func TestScoreRoomTacticallyAdvantage(t *testing.T) {
+	service := &RoomService{}
+	room := createTacticalRoom()
+	require.NoError(t, PlaceEntity(room, &entities.Player{ID: "fighter", Level: 4, Position: entities.Position{X: 0, Y: 0}}))
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "ogre", CR: 2, Position: entities.Position{X: 3, Y: 0}}))
+
+	// CR 2 of danger against a level 4 player
+	score, err := service.ScoreRoomTactically(room, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, score.MonsterAdvantage)
+	assert.Equal(t, 0.5, score.PlayerAdvantage)
+
+	party := createTestParty(4, 8)
+	score, err = service.ScoreRoomTactically(room, &party)
+	require.NoError(t, err)
+	assert.Equal(t, 0.25, score.MonsterAdvantage)
+	assert.Equal(t, 0.75, score.PlayerAdvantage)
+
+	_, err = service.ScoreRoomTactically(nil, nil)
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
+
+func TestScoreRoomTacticallyObstacleCoverage(t *testing.T) {
+	service := &RoomService{}
+	room := createTacticalRoom()
+	for x := 0; x < 5; x++ {
+		require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: fmt.Sprintf("pillar-%d", x), Blocking: true, Position: entities.Position{X: x * 2, Y: 9}}))
+	}
+	require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: "rubble", Position: entities.Position{X: 5, Y: 5}}))
+
+	score, err := service.ScoreRoomTactically(room, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0.05, score.ObstacleCoverage, "only blocking obstacles count")
+}
+
+func TestScoreRoomTacticallyFlanking(t *testing.T) {
+	service := &RoomService{}
+	room := createTacticalRoom()
+	require.NoError(t, PlaceEntity(room, &entities.Player{ID: "fighter", Position: entities.Position{X: 5, Y: 5}}))
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "west", Position: entities.Position{X: 4, Y: 5}}))
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "east", Position: entities.Position{X: 6, Y: 5}}))
+
+	score, err := service.ScoreRoomTactically(room, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, score.FlankingOpportunities)
+
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "northwest", Position: entities.Position{X: 4, Y: 4}}))
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "southeast", Position: entities.Position{X: 6, Y: 6}}))
+	score, err = service.ScoreRoomTactically(room, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, score.FlankingOpportunities)
+}
+
+func TestScoreRoomTacticallyChokepoints(t *testing.T) {
+	service := &RoomService{}
+	room := createTestRoom() // 5x5
+
+	// A wall down column 2 with a single gap in the middle
+	for _, y := range []int{0, 1, 3, 4} {
+		require.NoError(t, PlaceEntity(room, &entities.Obstacle{ID: fmt.Sprintf("wall-%d", y), Blocking: true, Position: entities.Position{X: 2, Y: y}}))
+	}
+
+	score, err := service.ScoreRoomTactically(room, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, score.Chokepoints)
+	assert.Equal(t, []entities.Position{{X: 2, Y: 2}}, findChokepoints(room))
+}
+
+func TestScoreRoomTacticallyDangerZoneArea(t *testing.T) {
+	service := &RoomService{}
+	room := createTacticalRoom()
+
+	score, err := service.ScoreRoomTactically(room, nil)
+	require.NoError(t, err)
+	assert.Zero(t, score.DangerZoneArea)
+
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "wolf", CR: 0.25, Position: entities.Position{X: 0, Y: 0}}))
+	score, err = service.ScoreRoomTactically(room, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 7*7, score.DangerZoneArea)
+}
+
+func TestRecommendTactic(t *testing.T) {
+	testCases := []struct {
+		score    TacticalScore
+		expected string
+	}{
+		{TacticalScore{MonsterAdvantage: 0.8, Chokepoints: 2}, TacticDefendChokepoints},
+		{TacticalScore{MonsterAdvantage: 0.8}, TacticHoldTheLine},
+		{TacticalScore{MonsterAdvantage: 0.2, FlankingOpportunities: 1}, TacticSpreadOut},
+		{TacticalScore{MonsterAdvantage: 0.2, Chokepoints: 3}, TacticRush},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, recommendTactic(tc.score))
+	}
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// GetThreatRadius returns every cell a monster can attack this turn
+// The monster may move up to Speed / the room's cell size squares (difficult terrain costs double and occupied cells
+// block movement) and then attack any cell within its melee reach. Monsters without a Speed use
+// DefaultMonsterSpeedFt, and monsters without a MeleeReach use the default for their size.
+// The monster's own cell is not included, and positions are in row-major order
+func (s *RoomService) GetThreatRadius(room *entities.Room, monsterID string) ([]entities.Position, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+
+	monster, _ := FindMonsterByID(room, monsterID)
+	if monster == nil {
+		return nil, fmt.Errorf("monster with ID %s not found in room", monsterID)
+	}
+
+	return threatenedCells(room, monster), nil
+}
+
+// GetThreatMap maps every threatened cell in the room to the IDs of the monsters threatening it
+// See GetThreatRadius for how each monster's threatened cells are found
+func (s *RoomService) GetThreatMap(room *entities.Room) (map[entities.Position][]string, error) {
+	if room == nil {
+		return nil, entities.ErrNilRoom
+	}
+
+	threats := map[entities.Position][]string{}
+	for i := range room.Monsters {
+		for _, pos := range threatenedCells(room, &room.Monsters[i]) {
+			threats[pos] = append(threats[pos], room.Monsters[i].ID)
+		}
+	}
+
+	return threats, nil
+}
+
+// threatenedCells returns the cells within melee reach of anywhere the monster can move this turn
+func threatenedCells(room *entities.Room, monster *entities.Monster) []entities.Position {
+	speedFt := monster.Speed
+	if speedFt <= 0 {
+		speedFt = DefaultMonsterSpeedFt
+	}
+	reach := monster.MeleeReach
+	if reach <= 0 {
+		reach = monster.Size.DefaultMeleeReach()
+	}
+	cellSizeFt := roomCellSizeFt(room)
+	budget := speedFt / cellSizeFt * cellSizeFt
+
+	// Find the cheapest cost in feet to reach every cell, revisiting a cell whenever a cheaper route is found
+	start := monster.Position
+	cost := map[entities.Position]int{start: 0}
+	queue := []entities.Position{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, neighbor := range current.Neighbors(true) {
+			if !isCellEnterable(room, neighbor) {
+				continue
+			}
+			newCost := cost[current] + stepCostFt(room, neighbor)
+			if newCost > budget {
+				continue
+			}
+			if known, ok := cost[neighbor]; ok && newCost >= known {
+				continue
+			}
+			cost[neighbor] = newCost
+			queue = append(queue, neighbor)
+		}
+	}
+
+	threatened := map[entities.Position]bool{}
+	for reachable := range cost {
+		for y := reachable.Y - reach; y <= reachable.Y+reach; y++ {
+			for x := reachable.X - reach; x <= reachable.X+reach; x++ {
+				pos := entities.Position{X: x, Y: y}
+				if pos != start && IsPositionValid(room, pos) {
+					threatened[pos] = true
+				}
+			}
+		}
+	}
+
+	positions := make([]entities.Position, 0, len(threatened))
+	for pos := range threatened {
+		positions = append(positions, pos)
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].Y != positions[j].Y {
+			return positions[i].Y < positions[j].Y
+		}
+		return positions[i].X < positions[j].X
+	})
+
+	return positions
+}
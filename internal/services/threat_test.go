@@ -0,0 +1,94 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// createThreatRoom creates a 25x25 room with a single monster from config placed in the center
+func createThreatRoom(t *testing.T, config MonsterConfig) *entities.Room {
+	room := NewRoom(25, 25, entities.LightLevelBright)
+	InitializeGrid(room)
+
+	config.Position = &entities.Position{X: 12, Y: 12}
+	service := &RoomService{}
+	require.NoError(t, service.AddPlaceablesToRoom(room, []PlaceableConfig{config}))
+	return room
+}
+
+func TestGetThreatRadius(t *testing.T) {
+	service := &RoomService{}
+
+	goblinRoom := createThreatRoom(t, MonsterConfig{Name: "Goblin", Key: "goblin", CR: 0.25, Size: entities.SizeSmall, Speed: 30})
+	giantRoom := createThreatRoom(t, MonsterConfig{Name: "Hill Giant", Key: "hill-giant", CR: 5, Size: entities.SizeHuge, Speed: 40})
+	assert.Equal(t, 1, goblinRoom.Monsters[0].MeleeReach)
+	assert.Equal(t, 2, giantRoom.Monsters[0].MeleeReach, "Large and bigger monsters default to reach 2")
+
+	goblinThreats, err := service.GetThreatRadius(goblinRoom, goblinRoom.Monsters[0].ID)
+	require.NoError(t, err)
+	giantThreats, err := service.GetThreatRadius(giantRoom, giantRoom.Monsters[0].ID)
+	require.NoError(t, err)
+
+	// Six squares of movement plus reach 1 versus eight squares plus reach 2, excluding the monster's own cell
+	assert.Len(t, goblinThreats, 15*15-1)
+	assert.Len(t, giantThreats, 21*21-1)
+	assert.Greater(t, len(giantThreats), len(goblinThreats))
+	assert.NotContains(t, goblinThreats, entities.Position{X: 12, Y: 12})
+	assert.Contains(t, goblinThreats, entities.Position{X: 19, Y: 19})
+	assert.NotContains(t, goblinThreats, entities.Position{X: 20, Y: 12})
+
+	_, err = service.GetThreatRadius(goblinRoom, "missing")
+	assert.Error(t, err)
+	_, err = service.GetThreatRadius(nil, "goblin")
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
+
+func TestGetThreatRadiusDifficultTerrain(t *testing.T) {
+	service := &RoomService{}
+	room := createThreatRoom(t, MonsterConfig{Name: "Goblin", Key: "goblin", CR: 0.25, Speed: 10, MeleeReach: 1})
+
+	open, err := service.GetThreatRadius(room, room.Monsters[0].ID)
+	require.NoError(t, err)
+	assert.Len(t, open, 7*7-1, "two squares of movement plus reach 1")
+
+	// Surrounding the goblin with difficult terrain leaves it a single step of movement
+	for _, pos := range room.Monsters[0].Position.Neighbors(true) {
+		room.SetDifficultTerrain(pos, true)
+	}
+	slowed, err := service.GetThreatRadius(room, room.Monsters[0].ID)
+	require.NoError(t, err)
+	assert.Len(t, slowed, 5*5-1)
+}
+
+func TestGetThreatRadiusCellSize(t *testing.T) {
+	service := &RoomService{}
+	room := createThreatRoom(t, MonsterConfig{Name: "Goblin", Key: "goblin", CR: 0.25, Speed: 20, MeleeReach: 1})
+	room.CellSizeFt = 10
+
+	threats, err := service.GetThreatRadius(room, room.Monsters[0].ID)
+	require.NoError(t, err)
+	assert.Len(t, threats, 7*7-1, "20 ft is two 10 ft squares of movement plus reach 1")
+}
+
+func TestGetThreatMap(t *testing.T) {
+	service := &RoomService{}
+	room := NewRoom(10, 10, entities.LightLevelBright)
+	InitializeGrid(room)
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "left", Speed: 5, MeleeReach: 1, Position: entities.Position{X: 2, Y: 5}}))
+	require.NoError(t, PlaceEntity(room, &entities.Monster{ID: "right", Speed: 5, MeleeReach: 1, Position: entities.Position{X: 6, Y: 5}}))
+
+	threats, err := service.GetThreatMap(room)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"left", "right"}, threats[entities.Position{X: 4, Y: 5}])
+	assert.Equal(t, []string{"left"}, threats[entities.Position{X: 0, Y: 5}])
+	assert.Equal(t, []string{"right"}, threats[entities.Position{X: 8, Y: 5}])
+	assert.NotContains(t, threats, entities.Position{X: 9, Y: 5})
+
+	_, err = service.GetThreatMap(nil)
+	assert.ErrorIs(t, err, entities.ErrNilRoom)
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// DefaultTrapKey is the key given to traps placed without one, including those placed by GenerateTrapRoom
+const DefaultTrapKey = "trap"
+
+// TrapRoomConfig contains parameters for generating a trap room
+type TrapRoomConfig struct {
+	TrapCount          int             // Number of traps to place
+	TrapZone           SpawnZone       // Region near the entrance where traps are placed
+	GuardMonsterConfig []MonsterConfig // Monsters guarding the loot
+	LootConfigs        []ItemConfig    // Loot placed adjacent to the guards
+	GuardZone          SpawnZone       // Region near the back where guards are placed
+}
+
+// GenerateTrapRoom creates a room laid out as a classic dungeon trap room
+// Traps are placed randomly in TrapZone, guards in GuardZone, and loot in cells adjacent to the guards
+// Returns an error if the zones overlap or any entity cannot be placed
+func (s *RoomService) GenerateTrapRoom(roomConfig RoomConfig, trapConfig TrapRoomConfig) (*entities.Room, error) {
+	if trapConfig.TrapZone.Overlaps(trapConfig.GuardZone) {
+		return nil, fmt.Errorf("trap zone and guard zone must not overlap")
+	}
+
+	room, err := s.GenerateRoom(roomConfig)
+	if err != nil {
+		return nil, err
+	}
+	room.RoomType = &entities.TrapRoomType{}
+
+	if err := trapConfig.TrapZone.Validate(room); err != nil {
+		return nil, fmt.Errorf("invalid trap zone: %w", err)
+	}
+	if err := trapConfig.GuardZone.Validate(room); err != nil {
+		return nil, fmt.Errorf("invalid guard zone: %w", err)
+	}
+
+	// Place traps near the entrance
+	for i := 0; i < trapConfig.TrapCount; i++ {
+		position, err := FindEmptyPositionInZone(room, trapConfig.TrapZone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to place trap: %w", err)
+		}
+
+		trap := &entities.Trap{
+			ID:   newEntityID(room),
+			Name: "Trap",
+			Key:  DefaultTrapKey,
+		}
+		trap.SetPosition(position)
+		if err := PlaceEntity(room, trap); err != nil {
+			return nil, fmt.Errorf("failed to place trap: %w", err)
+		}
+	}
+
+	// Place guards at the back of the room
+	guardPositions := []entities.Position{}
+	for _, config := range trapConfig.GuardMonsterConfig {
+		for i := 0; i < config.Count; i++ {
+			position, err := FindEmptyPositionInZone(room, trapConfig.GuardZone)
+			if err != nil {
+				return nil, fmt.Errorf("failed to place %s (monster): %w", config.GetName(), err)
+			}
+			if err := s.placeConfigAt(room, config, position); err != nil {
+				return nil, err
+			}
+			guardPositions = append(guardPositions, position)
+		}
+	}
+
+	// Place loot next to the guards
+	for _, config := range trapConfig.LootConfigs {
+		for i := 0; i < config.Count; i++ {
+			if len(guardPositions) == 0 {
+				return nil, fmt.Errorf("loot requires at least one guard monster")
+			}
+
+			position, err := s.findEmptyPositionAdjacentTo(room, guardPositions)
+			if err != nil {
+				return nil, fmt.Errorf("failed to place %s (item): %w", config.GetName(), err)
+			}
+			if err := s.placeConfigAt(room, config, position); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return room, nil
+}
+
+// placeConfigAt creates the entity described by config, with a seeded ID in seeded rooms, and places it at position
+func (s *RoomService) placeConfigAt(room *entities.Room, config PlaceableConfig, position entities.Position) error {
+	entity, err := config.CreatePlaceable(s)
+	if err != nil {
+		return err
+	}
+	assignSeededID(room, entity)
+
+	entity.SetPosition(position)
+	if err := PlaceEntity(room, entity); err != nil {
+		return fmt.Errorf("failed to add %s: %w", config.GetName(), err)
+	}
+	return nil
+}
+
+// findEmptyPositionAdjacentTo picks a random empty cell adjacent to any of the anchor positions
+func (s *RoomService) findEmptyPositionAdjacentTo(room *entities.Room, anchors []entities.Position) (entities.Position, error) {
+	candidates := []entities.Position{}
+	seen := map[entities.Position]bool{}
+
+	for _, anchor := range anchors {
+		cells, err := s.GetAdjacentCells(room, anchor, true)
+		if err != nil {
+			return entities.Position{}, err
+		}
+		for _, cell := range cells {
+			if cell.Cell.Type == entities.CellTypeEmpty && !seen[cell.Position] {
+				seen[cell.Position] = true
+				candidates = append(candidates, cell.Position)
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return entities.Position{}, ErrNoEmptyPositions
+	}
+
+	return candidates[roomIntn(room)(len(candidates))], nil
+}
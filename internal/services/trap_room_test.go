@@ -0,0 +1,105 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestGenerateTrapRoom(t *testing.T) {
+	service, err := NewRoomService()
+	assert.NoError(t, err)
+
+	roomConfig := createTestRoomConfig(10, 10, entities.LightLevelDim, true)
+	trapZone := SpawnZone{MinX: 0, MinY: 0, MaxX: 9, MaxY: 2}
+	guardZone := SpawnZone{MinX: 2, MinY: 7, MaxX: 7, MaxY: 8}
+
+	t.Run("Entities are placed in their zones", func(t *testing.T) {
+		config := TrapRoomConfig{
+			TrapCount: 4,
+			TrapZone:  trapZone,
+			GuardMonsterConfig: []MonsterConfig{
+				createTestMonsterConfig("Goblin", "goblin", 0.25, 2, true, nil),
+				createTestMonsterConfig("Hobgoblin", "hobgoblin", 0.5, 1, true, nil),
+			},
+			LootConfigs: []ItemConfig{
+				{Name: "Gold Idol", Key: "gold-idol", Count: 2},
+			},
+			GuardZone: guardZone,
+		}
+
+		room, err := service.GenerateTrapRoom(roomConfig, config)
+		assert.NoError(t, err)
+		assert.Equal(t, "trap", room.RoomType.Type())
+
+		assert.Empty(t, room.Obstacles)
+		assert.Len(t, room.Traps, 4)
+		for _, trap := range room.Traps {
+			assert.Equal(t, DefaultTrapKey, trap.Key)
+			assert.True(t, trapZone.Contains(trap.Position))
+			assert.Equal(t, trap.ID, room.Grid[trap.Position.Y][trap.Position.X].EntityID)
+		}
+
+		assert.Len(t, room.Monsters, 3)
+		for _, monster := range room.Monsters {
+			assert.True(t, guardZone.Contains(monster.Position))
+		}
+
+		assert.Len(t, room.Items, 2)
+		for _, item := range room.Items {
+			adjacent := false
+			for _, monster := range room.Monsters {
+				if CalculateDistance(item.Position, monster.Position) == 1 {
+					adjacent = true
+				}
+			}
+			assert.True(t, adjacent, "item %s should be adjacent to a guard", item.ID)
+		}
+	})
+
+	t.Run("Overlapping zones", func(t *testing.T) {
+		config := TrapRoomConfig{
+			TrapCount: 1,
+			TrapZone:  SpawnZone{MinX: 0, MinY: 0, MaxX: 5, MaxY: 5},
+			GuardZone: SpawnZone{MinX: 5, MinY: 5, MaxX: 9, MaxY: 9},
+		}
+
+		room, err := service.GenerateTrapRoom(roomConfig, config)
+		assert.Error(t, err)
+		assert.Nil(t, room)
+	})
+
+	t.Run("Zone outside room", func(t *testing.T) {
+		config := TrapRoomConfig{
+			TrapZone:  trapZone,
+			GuardZone: SpawnZone{MinX: 0, MinY: 8, MaxX: 9, MaxY: 12},
+		}
+
+		_, err := service.GenerateTrapRoom(roomConfig, config)
+		assert.Error(t, err)
+	})
+
+	t.Run("Loot without guards", func(t *testing.T) {
+		config := TrapRoomConfig{
+			TrapZone:    trapZone,
+			GuardZone:   guardZone,
+			LootConfigs: []ItemConfig{{Name: "Gold Idol", Count: 1}},
+		}
+
+		_, err := service.GenerateTrapRoom(roomConfig, config)
+		assert.Error(t, err)
+	})
+
+	t.Run("Trap zone too small", func(t *testing.T) {
+		config := TrapRoomConfig{
+			TrapCount: 3,
+			TrapZone:  SpawnZone{MinX: 0, MinY: 0, MaxX: 1, MaxY: 0},
+			GuardZone: guardZone,
+		}
+
+		_, err := service.GenerateTrapRoom(roomConfig, config)
+		assert.ErrorIs(t, err, ErrNoEmptyPositions)
+	})
+}
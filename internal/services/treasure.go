@@ -0,0 +1,98 @@
+package services
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// treasureBatchSize is the number of candidate items requested from the item repository at a time
+const treasureBatchSize = 20
+
+// maxTreasureRequests bounds the number of repository requests made by PlaceTreasureByGoldBudget
+const maxTreasureRequests = 100
+
+// PlaceTreasureByGoldBudget places randomly positioned items from the item repository (see WithItemRepository)
+// whose total gold value lies within budget x (1 ± tolerance)
+// Each step first asks for a single item that completes the budget and otherwise adds the most valuable
+// item that still fits. If the budget cannot be reached, or the room cannot hold the items, the room is unchanged
+func (s *RoomService) PlaceTreasureByGoldBudget(room *entities.Room, budget int, tolerance float64) error {
+	if room == nil {
+		return entities.ErrNilRoom
+	}
+	if budget <= 0 {
+		return fmt.Errorf("budget must be positive, got %d", budget)
+	}
+	if tolerance < 0 || tolerance > 1 {
+		return fmt.Errorf("tolerance must be between 0 and 1, got %.2f", tolerance)
+	}
+	if s.itemRepo == nil {
+		return fmt.Errorf("no item repository configured")
+	}
+
+	lower := float64(budget) * (1 - tolerance)
+	upper := float64(budget) * (1 + tolerance)
+
+	selected := []*entities.Item{}
+	total := 0.0
+	for requests := 0; total < lower && requests < maxTreasureRequests; requests++ {
+		maxGold := int(math.Floor(upper - total))
+		if maxGold < 1 {
+			break
+		}
+
+		// Finish with one item if the repository has one that closes the gap
+		if minGold := max(int(math.Ceil(lower-total)), 0); minGold <= maxGold {
+			items, err := s.itemRepo.GetItemsByValueRange(minGold, maxGold, 1)
+			if err != nil {
+				return fmt.Errorf("failed to get items: %w", err)
+			}
+			if len(items) > 0 {
+				selected = append(selected, items[0])
+				total += items[0].GoldValue()
+				break
+			}
+		}
+
+		items, err := s.itemRepo.GetItemsByValueRange(0, maxGold, treasureBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to get items: %w", err)
+		}
+		var best *entities.Item
+		for _, item := range items {
+			if item.GoldValue() > 0 && (best == nil || item.GoldValue() > best.GoldValue()) {
+				best = item
+			}
+		}
+		if best == nil {
+			break
+		}
+		selected = append(selected, best)
+		total += best.GoldValue()
+	}
+
+	if total < lower {
+		return fmt.Errorf("could not find items worth %.0f-%.0f gold, best total was %.2f", lower, upper, total)
+	}
+	if room.Grid != nil {
+		if free := room.CapacityRemaining(); free < len(selected) {
+			return fmt.Errorf("room has %d empty cells but %d items were chosen", free, len(selected))
+		}
+	}
+
+	for _, template := range selected {
+		item := *template
+		item.ID = newEntityID(room)
+		pos, err := FindEmptyPosition(room)
+		if err != nil {
+			return err
+		}
+		item.Position = pos
+		if err := PlaceEntity(room, &item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+	"github.com/fadedpez/dnd5e-roomgen/internal/repositories"
+)
+
+// mockItemRepository wraps a TestItemRepository and can fail every request
+type mockItemRepository struct {
+	repositories.TestItemRepository
+	err error
+}
+
+func (m *mockItemRepository) GetItemsByValueRange(minGold, maxGold int, count int) ([]*entities.Item, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.TestItemRepository.GetItemsByValueRange(minGold, maxGold, count)
+}
+
+func createTestTreasureRepository() *mockItemRepository {
+	return &mockItemRepository{TestItemRepository: repositories.TestItemRepository{Items: []*entities.Item{
+		{Key: "copper-ring", Name: "Copper Ring", Value: 50, ValueUnit: "cp"},
+		{Key: "dagger", Name: "Dagger", Value: 2, ValueUnit: "gp"},
+		{Key: "shortsword", Name: "Shortsword", Value: 10, ValueUnit: "gp"},
+		{Key: "chain-mail", Name: "Chain Mail", Value: 75, ValueUnit: "gp"},
+		{Key: "platinum-crown", Name: "Platinum Crown", Value: 30, ValueUnit: "pp"},
+	}}}
+}
+
+func totalGold(room *entities.Room) float64 {
+	total := 0.0
+	for i := range room.Items {
+		total += room.Items[i].GoldValue()
+	}
+	return total
+}
+
+func TestPlaceTreasureByGoldBudget(t *testing.T) {
+	testCases := []struct {
+		name      string
+		budget    int
+		tolerance float64
+	}{
+		{"single item", 300, 0},
+		{"several items", 100, 0.05},
+		{"small budget", 13, 0.1},
+		{"loose tolerance", 500, 0.5},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := createTestTreasureRepository()
+			service, err := NewRoomService(WithItemRepository(repo))
+			require.NoError(t, err)
+			room := NewRoom(10, 10, entities.LightLevelBright)
+			InitializeGrid(room)
+
+			require.NoError(t, service.PlaceTreasureByGoldBudget(room, tc.budget, tc.tolerance))
+
+			total := totalGold(room)
+			assert.GreaterOrEqual(t, total, float64(tc.budget)*(1-tc.tolerance))
+			assert.LessOrEqual(t, total, float64(tc.budget)*(1+tc.tolerance))
+			for _, item := range room.Items {
+				assert.NotEmpty(t, item.ID)
+				assert.Equal(t, entities.CellItem, room.Grid[item.Position.Y][item.Position.X].Type)
+			}
+		})
+	}
+}
+
+func TestPlaceTreasureByGoldBudgetFailures(t *testing.T) {
+	room := createTestRoom()
+
+	service, err := NewRoomService()
+	require.NoError(t, err)
+	assert.Error(t, service.PlaceTreasureByGoldBudget(room, 100, 0.1), "no item repository")
+
+	repo := createTestTreasureRepository()
+	service, err = NewRoomService(WithItemRepository(repo))
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, service.PlaceTreasureByGoldBudget(nil, 100, 0.1), entities.ErrNilRoom)
+	assert.Error(t, service.PlaceTreasureByGoldBudget(room, 0, 0.1))
+	assert.Error(t, service.PlaceTreasureByGoldBudget(room, 100, -0.1))
+
+	// Nothing is worth less than a gold piece except the copper ring
+	assert.Error(t, service.PlaceTreasureByGoldBudget(room, 1, 0))
+	assert.Empty(t, room.Items, "room should be unchanged")
+
+	// A 5x5 room cannot hold the 200 copper rings needed for a 100 gp budget
+	repo.Items = repo.Items[:1]
+	assert.Error(t, service.PlaceTreasureByGoldBudget(room, 100, 0))
+	assert.Empty(t, room.Items, "room should be unchanged")
+
+	repo.err = errors.New("api unavailable")
+	assert.Error(t, service.PlaceTreasureByGoldBudget(room, 100, 0.1))
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+// SpawnZone is an inclusive rectangular region of a room used to restrict placement
+type SpawnZone struct {
+	MinX int
+	MinY int
+	MaxX int
+	MaxY int
+}
+
+// PlacementZone restricts where a config's entities are placed when RandomPlace is true
+type PlacementZone = SpawnZone
+
+// Contains reports whether the position lies within the zone
+func (z SpawnZone) Contains(pos entities.Position) bool {
+	return pos.X >= z.MinX && pos.X <= z.MaxX && pos.Y >= z.MinY && pos.Y <= z.MaxY
+}
+
+// Overlaps reports whether the two zones share at least one cell
+func (z SpawnZone) Overlaps(other SpawnZone) bool {
+	return z.MinX <= other.MaxX && other.MinX <= z.MaxX &&
+		z.MinY <= other.MaxY && other.MinY <= z.MaxY
+}
+
+// Validate checks that the zone is well-formed and lies within the room
+func (z SpawnZone) Validate(room *entities.Room) error {
+	if room == nil {
+		return entities.ErrNilRoom
+	}
+	if z.MinX > z.MaxX || z.MinY > z.MaxY {
+		return fmt.Errorf("zone (%d,%d)-(%d,%d) has inverted bounds", z.MinX, z.MinY, z.MaxX, z.MaxY)
+	}
+	if z.MinX < 0 || z.MinY < 0 || z.MaxX >= room.Width || z.MaxY >= room.Height {
+		return fmt.Errorf("zone (%d,%d)-(%d,%d) is outside room bounds (%d, %d)",
+			z.MinX, z.MinY, z.MaxX, z.MaxY, room.Width, room.Height)
+	}
+	return nil
+}
+
+// FindEmptyPositionInZone finds a random empty position inside the zone
+// For gridless rooms any position in the zone is returned. Returns an error if the zone is not within
+// the room, or ErrNoEmptyPositions if every cell in the zone is occupied
+func FindEmptyPositionInZone(room *entities.Room, zone PlacementZone) (entities.Position, error) {
+	if err := zone.Validate(room); err != nil {
+		return entities.Position{}, err
+	}
+
+	intn := roomIntn(room)
+	if room.Grid == nil {
+		return entities.Position{
+			X: zone.MinX + intn(zone.MaxX-zone.MinX+1),
+			Y: zone.MinY + intn(zone.MaxY-zone.MinY+1),
+		}, nil
+	}
+
+	emptyCells := []entities.Position{}
+	for y := zone.MinY; y <= zone.MaxY; y++ {
+		for x := zone.MinX; x <= zone.MaxX; x++ {
+			if room.Grid[y][x].Type == entities.CellTypeEmpty {
+				emptyCells = append(emptyCells, entities.Position{X: x, Y: y})
+			}
+		}
+	}
+
+	if len(emptyCells) == 0 {
+		return entities.Position{}, ErrNoEmptyPositions
+	}
+
+	return emptyCells[intn(len(emptyCells))], nil
+}
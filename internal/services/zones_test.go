@@ -0,0 +1,51 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fadedpez/dnd5e-roomgen/internal/entities"
+)
+
+func TestSpawnZone(t *testing.T) {
+	zone := SpawnZone{MinX: 1, MinY: 1, MaxX: 2, MaxY: 3}
+
+	assert.True(t, zone.Contains(entities.Position{X: 1, Y: 1}))
+	assert.True(t, zone.Contains(entities.Position{X: 2, Y: 3}))
+	assert.False(t, zone.Contains(entities.Position{X: 0, Y: 1}))
+	assert.False(t, zone.Contains(entities.Position{X: 2, Y: 4}))
+
+	assert.True(t, zone.Overlaps(SpawnZone{MinX: 2, MinY: 3, MaxX: 4, MaxY: 4}))
+	assert.False(t, zone.Overlaps(SpawnZone{MinX: 3, MinY: 0, MaxX: 4, MaxY: 4}))
+
+	room := createTestRoom()
+	assert.NoError(t, zone.Validate(room))
+	assert.Error(t, SpawnZone{MinX: 3, MinY: 0, MaxX: 1, MaxY: 0}.Validate(room))
+	assert.Error(t, SpawnZone{MinX: 0, MinY: 0, MaxX: 5, MaxY: 0}.Validate(room))
+	assert.ErrorIs(t, zone.Validate(nil), entities.ErrNilRoom)
+}
+
+func TestFindEmptyPositionInZone(t *testing.T) {
+	room := createTestRoom()
+	zone := SpawnZone{MinX: 0, MinY: 0, MaxX: 1, MaxY: 0}
+
+	// Fill the zone one cell at a time
+	for i := 0; i < 2; i++ {
+		pos, err := FindEmptyPositionInZone(room, zone)
+		assert.NoError(t, err)
+		assert.True(t, zone.Contains(pos))
+
+		monster := createTestMonster(string(rune('a'+i)), pos.X, pos.Y)
+		assert.NoError(t, PlaceEntity(room, &monster))
+	}
+
+	_, err := FindEmptyPositionInZone(room, zone)
+	assert.ErrorIs(t, err, ErrNoEmptyPositions)
+
+	// Gridless rooms always return a position within the zone
+	gridless := createTestRoomNoGrid()
+	pos, err := FindEmptyPositionInZone(gridless, zone)
+	assert.NoError(t, err)
+	assert.True(t, zone.Contains(pos))
+}